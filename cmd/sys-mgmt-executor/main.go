@@ -14,18 +14,63 @@
 package main
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 
+	"github.com/edgexfoundry/edgex-go/internal/system"
 	"github.com/edgexfoundry/edgex-go/internal/system/executor"
 )
 
+// commandExecutor returns an executor.CommandExecutor which runs the named program.
+func commandExecutor(name string) executor.CommandExecutor {
+	return func(arg ...string) ([]byte, error) {
+		return exec.Command(name, arg...).CombinedOutput()
+	}
+}
+
+// streamingExecutor returns an executor.StreamingCommandExecutor which runs the named program, passing each line of
+// its combined output to emit as it is written.
+func streamingExecutor(name string) executor.StreamingCommandExecutor {
+	return func(emit func(line string) error, arg ...string) error {
+		reader, writer := io.Pipe()
+		cmd := exec.Command(name, arg...)
+		cmd.Stdout = writer
+		cmd.Stderr = writer
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		go func() { _ = writer.CloseWithError(cmd.Wait()) }()
+
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			if err := emit(scanner.Text()); err != nil {
+				_ = cmd.Process.Kill()
+				return err
+			}
+		}
+		return scanner.Err()
+	}
+}
+
 func main() {
-	result, err := json.Marshal(executor.Execute(os.Args, func(arg ...string) ([]byte, error) {
-		return exec.Command("docker", arg...).CombinedOutput()
-	}))
+	var executeResult system.Result
+	backendType := os.Getenv(executor.BackendEnvName)
+	backend, err := executor.NewBackend(backendType, commandExecutor, streamingExecutor)
+	if err != nil {
+		var service, operation string
+		if len(os.Args) > 2 {
+			service, operation = os.Args[1], os.Args[2]
+		}
+		executeResult = system.Failure(service, operation, backendType, err.Error())
+	} else {
+		executeResult = executor.ExecuteWith(os.Args, backend, os.Stdout)
+	}
+
+	result, err := json.Marshal(executeResult)
 	switch {
 	case err != nil:
 		fmt.Printf("json.Marshal error: %s", err.Error())
@@ -76,6 +76,7 @@ func (d Database) newDBClient(
 		conf := db.Configuration{
 			Host:     databaseInfo.Host,
 			Port:     databaseInfo.Port,
+			Username: credentials.Username,
 			Password: credentials.Password,
 		}
 
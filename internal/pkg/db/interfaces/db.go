@@ -16,6 +16,7 @@ package interfaces
 
 import (
 	correlation "github.com/edgexfoundry/edgex-go/internal/pkg/correlation/models"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
 
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
@@ -178,6 +179,7 @@ type DBClient interface {
 	GetNotificationsByEnd(end int64, limit int) ([]contract.Notification, error)
 	GetNewNotifications(limit int) ([]contract.Notification, error)
 	GetNewNormalNotifications(limit int) ([]contract.Notification, error)
+	GetNotificationsBySeverity(severity string, limit int) ([]contract.Notification, error)
 	AddNotification(n contract.Notification) (string, error)
 	UpdateNotification(n contract.Notification) error
 	MarkNotificationProcessed(n contract.Notification) error
@@ -185,6 +187,22 @@ type DBClient interface {
 	DeleteNotificationBySlug(slug string) error
 	DeleteNotificationsOld(age int) error
 
+	/*
+		Notification lifecycles
+	*/
+	GetNotificationLifecycle(slug string) (notificationsModels.NotificationLifecycle, error)
+	GetNotificationLifecycles(slugs []string) ([]notificationsModels.NotificationLifecycle, error)
+	UpdateNotificationLifecycle(
+		slug string,
+		update func(l *notificationsModels.NotificationLifecycle) error) (notificationsModels.NotificationLifecycle, error)
+	GetUnacknowledgedCriticalNotifications(offset int, limit int) ([]contract.Notification, error)
+	AddDelayedNotification(d notificationsModels.DelayedNotification, due int64) error
+	GetDelayedNotifications(end int64, limit int) ([]notificationsModels.DelayedNotification, error)
+	DeleteDelayedNotification(d notificationsModels.DelayedNotification) (bool, error)
+	AddDigestNotification(subscription string, notification string, due int64) error
+	GetDigests(end int64, limit int) ([]string, error)
+	DeleteDigest(subscription string) ([]string, error)
+
 	/*
 		Subscriptions
 	*/
@@ -199,6 +217,8 @@ type DBClient interface {
 	UpdateSubscription(sub contract.Subscription) error
 	DeleteSubscriptionBySlug(slug string) error
 	GetSubscriptions() ([]contract.Subscription, error)
+	GetSubscriptionSettings(id string) (notificationsModels.SubscriptionSettings, error)
+	UpdateSubscriptionSettings(id string, settings notificationsModels.SubscriptionSettings) error
 
 	/*
 		Transmissions
@@ -213,6 +233,9 @@ type DBClient interface {
 	GetTransmissionsByStart(start int64, limit int) ([]contract.Transmission, error)
 	GetTransmissionsByEnd(end int64, limit int) ([]contract.Transmission, error)
 	GetTransmissionsByStatus(limit int, status contract.TransmissionStatus) ([]contract.Transmission, error)
+	AddTransmissionRetry(id string, due int64) error
+	GetTransmissionRetries(end int64, limit int) ([]string, error)
+	DeleteTransmissionRetry(id string) (bool, error)
 
 	Cleanup() error
 	CleanupOld(age int) error
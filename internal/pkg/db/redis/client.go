@@ -66,7 +66,9 @@ func NewClient(config db.Configuration, lc logger.LoggingClient) (*Client, error
 		opts := []redis.DialOption{
 			redis.DialConnectTimeout(time.Duration(config.Timeout) * time.Millisecond),
 		}
-		if os.Getenv("EDGEX_SECURITY_SECRET_STORE") != "false" {
+		secure := os.Getenv("EDGEX_SECURITY_SECRET_STORE") != "false"
+		// DialPassword can only authenticate the default user, so ACL users are authenticated once connected
+		if secure && config.Username == "" {
 			opts = append(opts, redis.DialPassword(config.Password))
 		}
 
@@ -77,6 +79,12 @@ func NewClient(config db.Configuration, lc logger.LoggingClient) (*Client, error
 			if err != nil {
 				return nil, fmt.Errorf("Could not dial Redis: %s", err)
 			}
+			if secure && config.Username != "" {
+				if _, err := conn.Do("AUTH", config.Username, config.Password); err != nil {
+					_ = conn.Close()
+					return nil, fmt.Errorf("Could not authenticate Redis user %s: %s", config.Username, err)
+				}
+			}
 			return conn, nil
 		}
 		// Default the batch size to 1,000 if not set
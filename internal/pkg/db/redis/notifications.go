@@ -16,12 +16,16 @@ package redis
 import (
 	"fmt"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/gomodule/redigo/redis"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 )
 
+// lifecycleUpdateAttempts limits how often a lifecycle update is applied again because of concurrent updates.
+const lifecycleUpdateAttempts = 10
+
 // ******************************* NOTIFICATIONS **********************************
 func (c Client) AddNotification(n contract.Notification) (string, error) {
 	conn := c.Pool.Get()
@@ -218,6 +222,19 @@ func (c Client) GetNewNormalNotifications(limit int) ([]contract.Notification, e
 
 }
 
+// GetNotificationsBySeverity returns the notifications with the severity, most recent first.
+func (c Client) GetNotificationsBySeverity(severity string, limit int) ([]contract.Notification, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	objects, err := getObjectsByValuesSorted(conn, limit, db.Notification+":severity:"+severity, db.Notification+":created")
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalNotifications(objects)
+}
+
 func (c Client) MarkNotificationProcessed(n contract.Notification) error {
 	conn := c.Pool.Get()
 	defer conn.Close()
@@ -307,14 +324,25 @@ func (c Client) UpdateSubscription(s contract.Subscription) error {
 	conn := c.Pool.Get()
 	defer conn.Close()
 
+	// the settings are deleted with the subscription, keep them for the updated one
+	settings, err := redis.Bytes(conn.Do("HGET", db.Subscription+":settings", s.ID))
+	if err != nil && err != redis.ErrNil {
+		return err
+	}
+
 	// update modified, delete and add subscription
-	err := deleteSubscription(conn, s.ID)
+	err = deleteSubscription(conn, s.ID)
 	if err != nil {
 		return nil
 	}
 
 	s.Modified = db.MakeTimestamp()
-	return addSubscription(conn, &s)
+	err = addSubscription(conn, &s)
+	if err != nil || settings == nil {
+		return err
+	}
+	_, err = conn.Do("HSET", db.Subscription+":settings", s.ID, settings)
+	return err
 }
 
 func (c Client) GetSubscriptions() ([]contract.Subscription, error) {
@@ -463,6 +491,226 @@ func (c Client) DeleteSubscriptionBySlug(slug string) error {
 
 }
 
+// GetSubscriptionSettings returns the delivery settings stored with the subscription with the id.
+func (c Client) GetSubscriptionSettings(id string) (notificationsModels.SubscriptionSettings, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	var settings notificationsModels.SubscriptionSettings
+	object, err := redis.Bytes(conn.Do("HGET", db.Subscription+":settings", id))
+	if err != nil {
+		if err == redis.ErrNil {
+			return settings, db.ErrNotFound
+		}
+		return settings, err
+	}
+
+	err = unmarshalObject(object, &settings)
+	return settings, err
+}
+
+// UpdateSubscriptionSettings replaces the delivery settings stored with the subscription with the id.
+func (c Client) UpdateSubscriptionSettings(id string, settings notificationsModels.SubscriptionSettings) error {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	m, err := marshalObject(settings)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Do("HSET", db.Subscription+":settings", id, m)
+	return err
+}
+
+// ************************** NOTIFICATION LIFECYCLES ******************************
+func (c Client) GetNotificationLifecycle(slug string) (notificationsModels.NotificationLifecycle, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	var l notificationsModels.NotificationLifecycle
+	object, err := redis.Bytes(conn.Do("HGET", db.Notification+":lifecycle", slug))
+	if err != nil {
+		if err == redis.ErrNil {
+			return l, db.ErrNotFound
+		}
+		return l, err
+	}
+
+	err = unmarshalObject(object, &l)
+	return l, err
+}
+
+// GetNotificationLifecycles returns the lifecycles of those notifications with the slugs which have one.
+func (c Client) GetNotificationLifecycles(slugs []string) ([]notificationsModels.NotificationLifecycle, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	args := []interface{}{db.Notification + ":lifecycle"}
+	for _, slug := range slugs {
+		args = append(args, slug)
+	}
+	objects, err := redis.ByteSlices(conn.Do("HMGET", args...))
+	if err != nil {
+		return nil, err
+	}
+
+	var lifecycles []notificationsModels.NotificationLifecycle
+	for _, object := range objects {
+		if len(object) == 0 {
+			continue
+		}
+		var l notificationsModels.NotificationLifecycle
+		if err = unmarshalObject(object, &l); err != nil {
+			return nil, err
+		}
+		lifecycles = append(lifecycles, l)
+	}
+	return lifecycles, nil
+}
+
+// UpdateNotificationLifecycle applies the update to the lifecycle of the notification with the slug, an open one when
+// it has none, and stores the result. The lifecycles are watched while the update is applied, it is applied again to
+// the stored lifecycle when another one changes meanwhile. Errors of the update are returned as they are.
+func (c Client) UpdateNotificationLifecycle(
+	slug string,
+	update func(l *notificationsModels.NotificationLifecycle) error) (notificationsModels.NotificationLifecycle, error) {
+
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	for attempt := 0; attempt < lifecycleUpdateAttempts; attempt++ {
+		l, updated, err := updateNotificationLifecycle(conn, slug, update)
+		if err != nil || updated {
+			return l, err
+		}
+	}
+	return notificationsModels.NotificationLifecycle{},
+		errors.Errorf("lifecycle of notification %s changed concurrently %d times", slug, lifecycleUpdateAttempts)
+}
+
+// GetUnacknowledgedCriticalNotifications returns the CRITICAL notifications which are neither acknowledged nor
+// resolved, most recent first. Snoozed notifications are included, escalated copies of notifications are not.
+func (c Client) GetUnacknowledgedCriticalNotifications(offset int, limit int) ([]contract.Notification, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	objects, err := getObjectsByRevRange(conn, db.Notification+":unacknowledged", offset, offset+limit-1)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalNotifications(objects)
+}
+
+// ************************** DELAYED NOTIFICATIONS ******************************
+
+// AddDelayedNotification schedules the delivery of the notification to the subscription at the due time, replacing
+// any earlier schedule of the same delivery.
+func (c Client) AddDelayedNotification(d notificationsModels.DelayedNotification, due int64) error {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	m, err := marshalObject(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Do("ZADD", db.Notification+":delayed", due, m)
+	return err
+}
+
+// GetDelayedNotifications returns the delayed deliveries which are due by the end time.
+func (c Client) GetDelayedNotifications(end int64, limit int) ([]notificationsModels.DelayedNotification, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	args := []interface{}{db.Notification + ":delayed", 0, end}
+	if limit > 0 {
+		args = append(args, "LIMIT", 0, limit)
+	}
+	objects, err := redis.ByteSlices(conn.Do("ZRANGEBYSCORE", args...))
+	if err != nil && err != redis.ErrNil {
+		return nil, err
+	}
+
+	delayed := make([]notificationsModels.DelayedNotification, 0, len(objects))
+	for _, object := range objects {
+		var d notificationsModels.DelayedNotification
+		if err = unmarshalObject(object, &d); err != nil {
+			return nil, err
+		}
+		delayed = append(delayed, d)
+	}
+	return delayed, nil
+}
+
+// DeleteDelayedNotification removes the delayed delivery. The result reports whether the delivery was still
+// scheduled, which lets only one of several concurrent callers claim a due delivery.
+func (c Client) DeleteDelayedNotification(d notificationsModels.DelayedNotification) (bool, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	m, err := marshalObject(d)
+	if err != nil {
+		return false, err
+	}
+
+	return redis.Bool(conn.Do("ZREM", db.Notification+":delayed", m))
+}
+
+// ******************************* DIGESTS ******************************
+
+// AddDigestNotification adds the notification to the digest of the subscription. A new digest becomes due at the due
+// time, a pending digest keeps its due time.
+func (c Client) AddDigestNotification(subscription string, notification string, due int64) error {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("ZADD", db.Notification+":digest", "NX", due, subscription)
+	_ = conn.Send("RPUSH", db.Notification+":digest:"+subscription, notification)
+	_, err := conn.Do("EXEC")
+	return err
+}
+
+// GetDigests returns the slugs of the subscriptions whose digest is due by the end time.
+func (c Client) GetDigests(end int64, limit int) ([]string, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	args := []interface{}{db.Notification + ":digest", 0, end}
+	if limit > 0 {
+		args = append(args, "LIMIT", 0, limit)
+	}
+	subscriptions, err := redis.Strings(conn.Do("ZRANGEBYSCORE", args...))
+	if err != nil && err != redis.ErrNil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// DeleteDigest removes the digest of the subscription and returns the slugs of its notifications. Only one of several
+// concurrent callers gets the notifications of a digest.
+func (c Client) DeleteDigest(subscription string) ([]string, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("ZREM", db.Notification+":digest", subscription)
+	_ = conn.Send("LRANGE", db.Notification+":digest:"+subscription, 0, -1)
+	_ = conn.Send("DEL", db.Notification+":digest:"+subscription)
+	res, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return nil, err
+	}
+	return redis.Strings(res[1], nil)
+}
+
 // ******************************* TRANSMISSIONS **********************************
 func (c Client) AddTransmission(t contract.Transmission) (string, error) {
 	conn := c.Pool.Get()
@@ -608,6 +856,41 @@ func (c Client) DeleteTransmission(age int64, status contract.TransmissionStatus
 	return err
 }
 
+// AddTransmissionRetry schedules the next retry or escalation of the transmission at the due time, replacing any
+// earlier schedule of the transmission.
+func (c Client) AddTransmissionRetry(id string, due int64) error {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	_, err := conn.Do("ZADD", db.Transmission+":retry", due, id)
+	return err
+}
+
+// GetTransmissionRetries returns the ids of the transmissions whose retry or escalation is due by the end time.
+func (c Client) GetTransmissionRetries(end int64, limit int) ([]string, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	args := []interface{}{db.Transmission + ":retry", 0, end}
+	if limit > 0 {
+		args = append(args, "LIMIT", 0, limit)
+	}
+	ids, err := redis.Strings(conn.Do("ZRANGEBYSCORE", args...))
+	if err != nil && err != redis.ErrNil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteTransmissionRetry removes the scheduled retry of the transmission. The result reports whether the retry was
+// still scheduled, which lets only one of several concurrent callers claim a due retry.
+func (c Client) DeleteTransmissionRetry(id string) (bool, error) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("ZREM", db.Transmission+":retry", id))
+}
+
 // Cleanup delete all notifications and associated transmissions
 func (c Client) Cleanup() error {
 	//conn := c.Pool.Get()
@@ -676,6 +959,11 @@ func addNotification(conn redis.Conn, n *contract.Notification) error {
 		return errors.Errorf("%v, slug=%v", db.ErrNotUnique, n.Slug)
 	}
 
+	l, err := notificationLifecycle(conn, n.Slug)
+	if err != nil {
+		return err
+	}
+
 	if n.Created == 0 {
 		n.Created = db.MakeTimestamp()
 		n.Modified = n.Created
@@ -703,6 +991,9 @@ func addNotification(conn redis.Conn, n *contract.Notification) error {
 	for _, label := range n.Labels {
 		_ = conn.Send("ZADD", db.Notification+":label:"+label, 0, id)
 	}
+	if isUnacknowledgedCritical(*n, l) {
+		_ = conn.Send("ZADD", db.Notification+":unacknowledged", n.Created, id)
+	}
 	_, err = conn.Do("EXEC")
 	if err != nil {
 		return err
@@ -722,6 +1013,8 @@ func deleteNotification(conn redis.Conn, id string) error {
 	_ = conn.Send("DEL", id)
 	_ = conn.Send("ZREM", db.Notification, id)
 	_ = conn.Send("HDEL", db.Notification+":slug", n.Slug)
+	_ = conn.Send("HDEL", db.Notification+":lifecycle", n.Slug)
+	_ = conn.Send("ZREM", db.Notification+":unacknowledged", id)
 	_ = conn.Send("ZREM", db.Notification+":sender:"+n.Sender, id)
 	_ = conn.Send("ZREM", db.Notification+":status:"+n.Status, id)
 	_ = conn.Send("ZREM", db.Notification+":severity:"+n.Severity, id)
@@ -736,6 +1029,76 @@ func deleteNotification(conn redis.Conn, id string) error {
 
 }
 
+// notificationLifecycle returns the stored lifecycle of the notification with the slug, an open one when it has none.
+func notificationLifecycle(conn redis.Conn, slug string) (notificationsModels.NotificationLifecycle, error) {
+	object, err := redis.Bytes(conn.Do("HGET", db.Notification+":lifecycle", slug))
+	if err == redis.ErrNil {
+		return notificationsModels.NewNotificationLifecycle(slug), nil
+	} else if err != nil {
+		return notificationsModels.NotificationLifecycle{}, err
+	}
+
+	var l notificationsModels.NotificationLifecycle
+	err = unmarshalObject(object, &l)
+	return l, err
+}
+
+// updateNotificationLifecycle applies the update to the stored lifecycle of the notification with the slug and stores
+// the result along with the index of the unacknowledged CRITICAL notifications. It reports whether the result is
+// stored, which it is not when a lifecycle changed after it was read.
+func updateNotificationLifecycle(
+	conn redis.Conn,
+	slug string,
+	update func(l *notificationsModels.NotificationLifecycle) error) (notificationsModels.NotificationLifecycle, bool, error) {
+
+	if _, err := conn.Do("WATCH", db.Notification+":lifecycle"); err != nil {
+		return notificationsModels.NotificationLifecycle{}, false, err
+	}
+	l, err := notificationLifecycle(conn, slug)
+	if err != nil {
+		return l, false, err
+	}
+
+	// Notifications of the V2 API have their lifecycles stored here too, but they are not indexed
+	var n contract.Notification
+	id, err := redis.String(conn.Do("HGET", db.Notification+":slug", slug))
+	if err == nil {
+		err = getObjectById(conn, id, unmarshalObject, &n)
+	}
+	if err != nil && err != redis.ErrNil {
+		return l, false, err
+	}
+
+	if err = update(&l); err != nil {
+		return l, false, err
+	}
+	m, err := marshalObject(l)
+	if err != nil {
+		return l, false, err
+	}
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("HSET", db.Notification+":lifecycle", slug, m)
+	if id != "" {
+		if isUnacknowledgedCritical(n, l) {
+			_ = conn.Send("ZADD", db.Notification+":unacknowledged", n.Created, id)
+		} else {
+			_ = conn.Send("ZREM", db.Notification+":unacknowledged", id)
+		}
+	}
+	_, err = redis.Values(conn.Do("EXEC"))
+	if err == redis.ErrNil {
+		return l, false, nil
+	}
+	return l, err == nil, err
+}
+
+// isUnacknowledgedCritical reports whether the notification belongs to the index of unacknowledged CRITICAL
+// notifications.
+func isUnacknowledgedCritical(n contract.Notification, l notificationsModels.NotificationLifecycle) bool {
+	return n.Severity == contract.Critical && n.Status != contract.Escalated && !l.Closed()
+}
+
 func addSubscription(conn redis.Conn, s *contract.Subscription) error {
 	exist, err := redis.Bool(conn.Do("HEXISTS", db.Subscription+":slug", s.Slug))
 	if err != nil {
@@ -788,6 +1151,7 @@ func deleteSubscription(conn redis.Conn, id string) error {
 	_ = conn.Send("ZREM", db.Subscription, id)
 	_ = conn.Send("HDEL", db.Subscription+":slug", s.Slug)
 	_ = conn.Send("ZREM", db.Subscription+":receiver:"+s.Receiver, id)
+	_ = conn.Send("HDEL", db.Subscription+":settings", id)
 	for _, label := range s.SubscribedLabels {
 		_ = conn.Send("SREM", db.Subscription+":label:"+label, id)
 	}
@@ -298,6 +298,11 @@ func getObjectsByValuesSorted(conn redis.Conn, limit int, vals ...string) ([][]b
 		return nil, err
 	}
 
+	if len(ids) == 0 {
+		// ZINTERSTORE does not create the cache set for an empty intersection
+		return nil, nil
+	}
+
 	if limit < 0 || limit > len(ids) {
 		limit = len(ids)
 	}
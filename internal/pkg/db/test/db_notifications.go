@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	dbp "github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
@@ -176,6 +177,95 @@ func testDBNotification(t *testing.T, db interfaces.DBClient) {
 		t.Fatalf("Fail to delete old notifications, '%v'", err)
 	}
 
+	// Test delayed notifications
+	due := notificationsModels.DelayedNotification{Notification: "delayed-due", Subscription: "night-shift"}
+	later := notificationsModels.DelayedNotification{Notification: "delayed-later", Subscription: "night-shift"}
+	err = db.AddDelayedNotification(due, 100)
+	if err != nil {
+		t.Fatalf("Error adding delayed notification: %v", err)
+	}
+	err = db.AddDelayedNotification(later, 300)
+	if err != nil {
+		t.Fatalf("Error adding delayed notification: %v", err)
+	}
+	delayed, err := db.GetDelayedNotifications(200, 10)
+	if err != nil {
+		t.Fatalf("Error getting delayed notifications: %v", err)
+	}
+	if len(delayed) != 1 || delayed[0] != due {
+		t.Fatalf("Unexpect result. The due delayed notifications should be [%v], but actually are %v", due, delayed)
+	}
+	claimed, err := db.DeleteDelayedNotification(due)
+	if err != nil || !claimed {
+		t.Fatalf("Fail to delete delayed notification, %v", err)
+	}
+	claimed, err = db.DeleteDelayedNotification(due)
+	if err != nil || claimed {
+		t.Fatalf("Delayed notification should only be deleted once, %v", err)
+	}
+	_, _ = db.DeleteDelayedNotification(later)
+
+	// Test digests
+	err = db.AddDigestNotification("digest-shift", "digest-first", 100)
+	if err != nil {
+		t.Fatalf("Error adding digest notification: %v", err)
+	}
+	err = db.AddDigestNotification("digest-shift", "digest-second", 300)
+	if err != nil {
+		t.Fatalf("Error adding digest notification: %v", err)
+	}
+	digests, err := db.GetDigests(200, 10)
+	if err != nil {
+		t.Fatalf("Error getting digests: %v", err)
+	}
+	if len(digests) != 1 || digests[0] != "digest-shift" {
+		t.Fatalf("Unexpect result. The due digests should be [digest-shift], but actually are %v", digests)
+	}
+	slugs, err := db.DeleteDigest("digest-shift")
+	if err != nil || len(slugs) != 2 || slugs[0] != "digest-first" || slugs[1] != "digest-second" {
+		t.Fatalf("Fail to delete digest, %v %v", slugs, err)
+	}
+	slugs, err = db.DeleteDigest("digest-shift")
+	if err != nil || len(slugs) != 0 {
+		t.Fatalf("Digest should only be deleted once, %v %v", slugs, err)
+	}
+
+	// Test lifecycles and the unacknowledged CRITICAL notifications
+	for _, slug := range []string{"critical-first", "critical-second"} {
+		n := getNotification(slug, contract.New)
+		n.Severity = contract.Critical
+		if _, err = db.AddNotification(n); err != nil {
+			t.Fatalf("Error adding notification: %v", err)
+		}
+	}
+	unacknowledged, err := db.GetUnacknowledgedCriticalNotifications(0, 10)
+	if err != nil || len(unacknowledged) != 2 || unacknowledged[0].Slug != "critical-second" {
+		t.Fatalf("Unexpect result. The unacknowledged notifications should be the 2 critical ones, but actually are %v %v", unacknowledged, err)
+	}
+	l, err := db.UpdateNotificationLifecycle("critical-first", func(l *notificationsModels.NotificationLifecycle) error {
+		return l.Transition(notificationsModels.Acknowledged, "alice", 100, 0, "")
+	})
+	if err != nil || l.State != notificationsModels.Acknowledged {
+		t.Fatalf("Fail to acknowledge notification, %v %v", l, err)
+	}
+	_, err = db.UpdateNotificationLifecycle("critical-first", func(l *notificationsModels.NotificationLifecycle) error {
+		return l.Transition(notificationsModels.Snoozed, "alice", 200, 300, "")
+	})
+	if _, ok := err.(notificationsModels.ErrInvalidTransition); !ok {
+		t.Fatalf("Acknowledged notification should not be snoozed, %v", err)
+	}
+	unacknowledged, err = db.GetUnacknowledgedCriticalNotifications(0, 10)
+	if err != nil || len(unacknowledged) != 1 || unacknowledged[0].Slug != "critical-second" {
+		t.Fatalf("Unexpect result. The unacknowledged notifications should be [critical-second], but actually are %v %v", unacknowledged, err)
+	}
+	if err = db.DeleteNotificationBySlug("critical-second"); err != nil {
+		t.Fatalf("Error deleting notification: %v", err)
+	}
+	unacknowledged, err = db.GetUnacknowledgedCriticalNotifications(0, 10)
+	if err != nil || len(unacknowledged) != 0 {
+		t.Fatalf("Deleted notification should not be unacknowledged, %v %v", unacknowledged, err)
+	}
+	_ = db.DeleteNotificationBySlug("critical-first")
 }
 
 func testDBSubscription(t *testing.T, db interfaces.DBClient) {
@@ -331,6 +421,32 @@ func testDBTransmission(t *testing.T, db interfaces.DBClient) {
 	if err != nil {
 		t.Fatalf("Fail to delete old transmission, %v", err)
 	}
+
+	// Test transmission retries
+	err = db.AddTransmissionRetry("retry-due", 100)
+	if err != nil {
+		t.Fatalf("Error adding transmission retry: %v", err)
+	}
+	err = db.AddTransmissionRetry("retry-later", 300)
+	if err != nil {
+		t.Fatalf("Error adding transmission retry: %v", err)
+	}
+	ids, err := db.GetTransmissionRetries(200, 10)
+	if err != nil {
+		t.Fatalf("Error getting transmission retries: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "retry-due" {
+		t.Fatalf("Unexpect result. The due transmission retries should be [retry-due], but actually are %v", ids)
+	}
+	claimed, err := db.DeleteTransmissionRetry("retry-due")
+	if err != nil || !claimed {
+		t.Fatalf("Fail to delete transmission retry, %v", err)
+	}
+	claimed, err = db.DeleteTransmissionRetry("retry-due")
+	if err != nil || claimed {
+		t.Fatalf("Transmission retry should only be deleted once, %v", err)
+	}
+	_, _ = db.DeleteTransmissionRetry("retry-later")
 }
 
 func getNotification(slug string, status contract.NotificationsStatus) contract.Notification {
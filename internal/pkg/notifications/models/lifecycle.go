@@ -0,0 +1,146 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+// Package models contains the support-notifications models which are not part of the core contracts.
+package models
+
+import (
+	"fmt"
+)
+
+// LifecycleState is the state of a notification in its acknowledgement lifecycle.
+type LifecycleState string
+
+const (
+	// Open notifications have not been picked up by anyone
+	Open LifecycleState = "OPEN"
+	// Acknowledged notifications have been picked up, they are no longer retried or escalated
+	Acknowledged LifecycleState = "ACKNOWLEDGED"
+	// Snoozed notifications are not retried or escalated until the snooze ends
+	Snoozed LifecycleState = "SNOOZED"
+	// Resolved notifications are closed, they are no longer retried or escalated
+	Resolved LifecycleState = "RESOLVED"
+)
+
+// allowedTransitions lists the states each state can change to.
+var allowedTransitions = map[LifecycleState][]LifecycleState{
+	Open:         {Acknowledged, Snoozed, Resolved},
+	Acknowledged: {Resolved, Open},
+	Snoozed:      {Acknowledged, Snoozed, Resolved, Open},
+	Resolved:     {Open},
+}
+
+// ErrInvalidTransition reports a lifecycle change which is not allowed from the current state of the notification.
+type ErrInvalidTransition struct {
+	Slug string
+	From LifecycleState
+	To   LifecycleState
+}
+
+func (e ErrInvalidTransition) Error() string {
+	return fmt.Sprintf("notification %s cannot change from %s to %s", e.Slug, e.From, e.To)
+}
+
+// ErrInvalidSnooze reports a snooze which does not end in the future.
+type ErrInvalidSnooze struct {
+	Slug string
+}
+
+func (e ErrInvalidSnooze) Error() string {
+	return fmt.Sprintf("notification %s must be snoozed until a future time", e.Slug)
+}
+
+// LifecycleEvent records a change of a notification's lifecycle state: who changed it and when (in milliseconds).
+type LifecycleEvent struct {
+	State   LifecycleState `json:"state"`
+	By      string         `json:"by"`
+	At      int64          `json:"at"`
+	Until   int64          `json:"until,omitempty"`
+	Comment string         `json:"comment,omitempty"`
+}
+
+// NotificationLifecycle is the acknowledgement lifecycle of the notification with the slug. Notifications without a
+// lifecycle are open.
+type NotificationLifecycle struct {
+	Slug           string           `json:"slug"`
+	State          LifecycleState   `json:"state"`
+	AcknowledgedBy string           `json:"acknowledgedBy,omitempty"`
+	Acknowledged   int64            `json:"acknowledged,omitempty"`
+	ResolvedBy     string           `json:"resolvedBy,omitempty"`
+	Resolved       int64            `json:"resolved,omitempty"`
+	SnoozedUntil   int64            `json:"snoozedUntil,omitempty"`
+	History        []LifecycleEvent `json:"history,omitempty"`
+}
+
+// NewNotificationLifecycle returns the lifecycle of a notification nobody acted on yet.
+func NewNotificationLifecycle(slug string) NotificationLifecycle {
+	return NotificationLifecycle{Slug: slug, State: Open}
+}
+
+// StateAt returns the state at the given time in milliseconds, a snoozed notification is open again once the snooze
+// has ended.
+func (l NotificationLifecycle) StateAt(now int64) LifecycleState {
+	switch {
+	case l.State == "":
+		return Open
+	case l.State == Snoozed && now >= l.SnoozedUntil:
+		return Open
+	default:
+		return l.State
+	}
+}
+
+// Closed reports whether the notification is acknowledged or resolved, snoozed notifications open again on their own.
+func (l NotificationLifecycle) Closed() bool {
+	return l.State == Acknowledged || l.State == Resolved
+}
+
+// Transition changes the state at the given time and records who changed it. The until time is required when
+// snoozing and ignored otherwise.
+func (l *NotificationLifecycle) Transition(state LifecycleState, by string, at int64, until int64, comment string) error {
+	current := l.StateAt(at)
+	if !isAllowedTransition(current, state) {
+		return ErrInvalidTransition{Slug: l.Slug, From: current, To: state}
+	}
+	if state == Snoozed && until <= at {
+		return ErrInvalidSnooze{Slug: l.Slug}
+	}
+
+	event := LifecycleEvent{State: state, By: by, At: at, Comment: comment}
+	l.State = state
+	l.SnoozedUntil = 0
+	switch state {
+	case Acknowledged:
+		l.AcknowledgedBy, l.Acknowledged = by, at
+	case Resolved:
+		l.ResolvedBy, l.Resolved = by, at
+	case Snoozed:
+		l.SnoozedUntil = until
+		event.Until = until
+	case Open:
+		l.AcknowledgedBy, l.Acknowledged = "", 0
+		l.ResolvedBy, l.Resolved = "", 0
+	}
+	l.History = append(l.History, event)
+	return nil
+}
+
+func isAllowedTransition(from LifecycleState, to LifecycleState) bool {
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
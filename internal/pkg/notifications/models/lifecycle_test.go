@@ -0,0 +1,57 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleTransitions(t *testing.T) {
+	l := NewNotificationLifecycle("n1")
+
+	require.NoError(t, l.Transition(Acknowledged, "alice", 100, 0, "on it"))
+	assert.Equal(t, Acknowledged, l.StateAt(100))
+	assert.Equal(t, "alice", l.AcknowledgedBy)
+	assert.Equal(t, int64(100), l.Acknowledged)
+
+	assert.IsType(t, ErrInvalidTransition{}, l.Transition(Snoozed, "bob", 200, 300, ""), "acknowledged notifications cannot be snoozed")
+
+	require.NoError(t, l.Transition(Resolved, "bob", 300, 0, ""))
+	assert.Equal(t, Resolved, l.StateAt(300))
+	assert.Error(t, l.Transition(Acknowledged, "bob", 400, 0, ""))
+
+	require.NoError(t, l.Transition(Open, "carol", 500, 0, "happened again"))
+	assert.Equal(t, Open, l.StateAt(500))
+	assert.Empty(t, l.AcknowledgedBy)
+	assert.Len(t, l.History, 3)
+}
+
+func TestLifecycleSnoozeEnds(t *testing.T) {
+	l := NewNotificationLifecycle("n1")
+
+	assert.IsType(t, ErrInvalidSnooze{}, l.Transition(Snoozed, "alice", 100, 100, ""), "snoozing requires a future time")
+	require.NoError(t, l.Transition(Snoozed, "alice", 100, 200, ""))
+	assert.Equal(t, Snoozed, l.StateAt(199))
+	assert.Equal(t, Open, l.StateAt(200))
+
+	// a snooze which ended behaves like an open notification
+	require.NoError(t, l.Transition(Acknowledged, "alice", 250, 0, ""))
+	assert.Equal(t, int64(0), l.SnoozedUntil)
+	assert.Equal(t, LifecycleState(""), NotificationLifecycle{}.State)
+	assert.Equal(t, Open, NotificationLifecycle{}.StateAt(0))
+}
@@ -0,0 +1,63 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package models
+
+// SubscriptionSettings are the delivery settings stored with a subscription. Subscriptions without settings use the
+// defaults of every field.
+type SubscriptionSettings struct {
+	// Template is the name of the template in Writable.Templates used to render the subscription's notifications
+	Template string `json:"template,omitempty"`
+	// EmailSender is the sender address of the subscription's emails, Smtp.Sender when empty
+	EmailSender string `json:"emailSender,omitempty"`
+	// EmailSubject is the subject of the subscription's emails unless its template has one, Smtp.Subject when empty
+	EmailSubject string `json:"emailSubject,omitempty"`
+	// MinSeverity is the lowest notification severity delivered to the subscription, NORMAL or CRITICAL. All
+	// severities are delivered when empty
+	MinSeverity string `json:"minSeverity,omitempty"`
+	// TimeZone is the IANA time zone of the delivery windows, e.g. "Europe/Berlin", UTC when empty
+	TimeZone string `json:"timeZone,omitempty"`
+	// DeliveryWindows are the times notifications are delivered to the subscription, any time when empty
+	DeliveryWindows []DeliveryWindow `json:"deliveryWindows,omitempty"`
+	// QuietHours handles the notifications arriving outside the delivery windows: "delay" delivers them once the
+	// next window opens, "drop" discards them and "critical" delivers CRITICAL notifications right away and discards
+	// the others. "delay" when empty
+	QuietHours string `json:"quietHours,omitempty"`
+	// RetryPolicy is the name of the policy in Writable.RetryPolicies applied to failed transmissions, "default"
+	// when empty
+	RetryPolicy string `json:"retryPolicy,omitempty"`
+	// EscalationPolicy is the name of the policy in Writable.EscalationPolicies applied to failed transmissions,
+	// "default" when empty
+	EscalationPolicy string `json:"escalationPolicy,omitempty"`
+	// RateLimit is the number of notifications delivered to the subscription per RateLimitPeriod, 0 for no limit.
+	// Notifications beyond the limit are delivered in a digest at the end of the period
+	RateLimit int `json:"rateLimit,omitempty"`
+	// RateLimitPeriod is the period of the rate limit, "1m" when empty
+	RateLimitPeriod string `json:"rateLimitPeriod,omitempty"`
+	// DigestInterval batches the subscription's notifications into a digest delivered every interval, e.g. "15m".
+	// Notifications are delivered individually when empty
+	DigestInterval string `json:"digestInterval,omitempty"`
+}
+
+// DeliveryWindow is a daily time range in which notifications are delivered. A window ending at or before its start
+// ends on the following day.
+type DeliveryWindow struct {
+	// Days are the week days the window starts on, e.g. ["Mon", "Tue"], every day when empty
+	Days []string `json:"days,omitempty"`
+	// Start is the time of day the window opens, e.g. "08:00", midnight when empty
+	Start string `json:"start,omitempty"`
+	// End is the time of day the window closes, e.g. "18:00", the end of the day when empty
+	End string `json:"end,omitempty"`
+}
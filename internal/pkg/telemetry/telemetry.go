@@ -28,6 +28,7 @@ import (
 type SystemUsage struct {
 	Memory     memoryUsage
 	CpuBusyAvg float64
+	Goroutines int
 }
 
 type memoryUsage struct {
@@ -67,6 +68,7 @@ func NewSystemUsage() (s SystemUsage) {
 	s.Memory.LiveObjects = s.Memory.Mallocs - s.Memory.Frees
 
 	s.CpuBusyAvg = usageAvg
+	s.Goroutines = runtime.NumGoroutine()
 
 	return s
 }
@@ -18,6 +18,14 @@ func TestNewSystemUsageMemory(t *testing.T) {
 	}
 }
 
+func TestNewSystemUsageGoroutines(t *testing.T) {
+	usageUnderTest := NewSystemUsage()
+
+	if usageUnderTest.Goroutines < 1 {
+		t.Error("Goroutine count was not taken")
+	}
+}
+
 func TestNewSystemUsageAvg(t *testing.T) {
 	var testValue = 13.37
 	usageAvg = testValue
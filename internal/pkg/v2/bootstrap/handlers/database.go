@@ -54,8 +54,10 @@ func (d Database) newDBClient(
 	case "redisdb":
 		return redis.NewClient(
 			db.Configuration{
-				Host: databaseInfo.Host,
-				Port: databaseInfo.Port,
+				Host:     databaseInfo.Host,
+				Port:     databaseInfo.Port,
+				Username: credentials.Username,
+				Password: credentials.Password,
 			},
 			lc)
 	default:
@@ -11,6 +11,8 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
 	redisClient "github.com/edgexfoundry/edgex-go/internal/pkg/db/redis"
+	notificationsModel "github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	schedulerModel "github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
@@ -658,3 +660,543 @@ func (c *Client) DeleteProvisionWatcherByName(name string) errors.EdgeX {
 
 	return nil
 }
+
+// AddNotification adds a new notification
+func (c *Client) AddNotification(n notificationsModel.Notification) (notificationsModel.Notification, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(n.Id) == 0 {
+		n.Id = uuid.New().String()
+	}
+
+	return addNotification(conn, n)
+}
+
+// NotificationBySlug gets a notification by slug
+func (c *Client) NotificationBySlug(slug string) (notification notificationsModel.Notification, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	notification, edgeXerr = notificationBySlug(conn, slug)
+	if edgeXerr != nil {
+		return notification, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// NotificationsByCategory query notifications by offset, limit and category
+func (c *Client) NotificationsByCategory(offset int, limit int, category string) (notifications []notificationsModel.Notification, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	notifications, edgeXerr = notificationsByKey(conn, offset, limit, CreateKey(NotificationCollectionCategory, category))
+	if edgeXerr != nil {
+		return notifications, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query notifications by offset %d, limit %d and category %s", offset, limit, category), edgeXerr)
+	}
+	return notifications, nil
+}
+
+// NotificationsByLabel query notifications by offset, limit and label
+func (c *Client) NotificationsByLabel(offset int, limit int, label string) (notifications []notificationsModel.Notification, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	notifications, edgeXerr = notificationsByKey(conn, offset, limit, CreateKey(NotificationCollectionLabel, label))
+	if edgeXerr != nil {
+		return notifications, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query notifications by offset %d, limit %d and label %s", offset, limit, label), edgeXerr)
+	}
+	return notifications, nil
+}
+
+// NotificationsByStatus query notifications by offset, limit and status
+func (c *Client) NotificationsByStatus(offset int, limit int, status string) (notifications []notificationsModel.Notification, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	notifications, edgeXerr = notificationsByKey(conn, offset, limit, CreateKey(NotificationCollectionStatus, status))
+	if edgeXerr != nil {
+		return notifications, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query notifications by offset %d, limit %d and status %s", offset, limit, status), edgeXerr)
+	}
+	return notifications, nil
+}
+
+// NotificationsByTimeRange query notifications by time range, offset, and limit
+func (c *Client) NotificationsByTimeRange(start int, end int, offset int, limit int) (notifications []notificationsModel.Notification, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	notifications, edgeXerr = notificationsByTimeRange(conn, start, end, offset, limit)
+	if edgeXerr != nil {
+		return notifications, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query notifications by time range %v ~ %v, offset %d, and limit %d", start, end, offset, limit), edgeXerr)
+	}
+	return notifications, nil
+}
+
+// DeleteNotificationBySlug deletes a notification and its transmissions by slug
+func (c *Client) DeleteNotificationBySlug(slug string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteNotificationBySlug(conn, slug)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the notification with slug %s", slug), edgeXerr)
+	}
+
+	return nil
+}
+
+// DeleteProcessedNotificationsByAge deletes the processed notifications and their transmissions that are older than age.
+// Age is supposed in milliseconds since the last modification.
+func (c *Client) DeleteProcessedNotificationsByAge(age int64) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteNotificationsByAge(conn, CreateKey(NotificationCollectionStatus, string(notificationsModel.Processed)), age)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete processed notifications by age %d", age), edgeXerr)
+	}
+
+	return nil
+}
+
+// CleanupNotificationsByAge deletes all notifications and their transmissions that are older than age, regardless of
+// their status. Age is supposed in milliseconds since the last modification.
+func (c *Client) CleanupNotificationsByAge(age int64) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteNotificationsByAge(conn, NotificationCollection, age)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to cleanup notifications by age %d", age), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddSubscription adds a new subscription
+func (c *Client) AddSubscription(s notificationsModel.Subscription) (notificationsModel.Subscription, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(s.Id) == 0 {
+		s.Id = uuid.New().String()
+	}
+
+	return addSubscription(conn, s)
+}
+
+// SubscriptionById gets a subscription by id
+func (c *Client) SubscriptionById(id string) (subscription notificationsModel.Subscription, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	subscription, edgeXerr = subscriptionById(conn, id)
+	if edgeXerr != nil {
+		return subscription, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// SubscriptionBySlug gets a subscription by slug
+func (c *Client) SubscriptionBySlug(slug string) (subscription notificationsModel.Subscription, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	subscription, edgeXerr = subscriptionBySlug(conn, slug)
+	if edgeXerr != nil {
+		return subscription, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// AllSubscriptions query subscriptions with offset and limit
+func (c *Client) AllSubscriptions(offset int, limit int) (subscriptions []notificationsModel.Subscription, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	subscriptions, edgeXerr = subscriptionsByKey(conn, offset, limit, SubscriptionCollection)
+	if edgeXerr != nil {
+		return subscriptions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return subscriptions, nil
+}
+
+// SubscriptionsByCategory query subscriptions by offset, limit and category
+func (c *Client) SubscriptionsByCategory(offset int, limit int, category string) (subscriptions []notificationsModel.Subscription, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	subscriptions, edgeXerr = subscriptionsByKey(conn, offset, limit, CreateKey(SubscriptionCollectionCategory, category))
+	if edgeXerr != nil {
+		return subscriptions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query subscriptions by offset %d, limit %d and category %s", offset, limit, category), edgeXerr)
+	}
+	return subscriptions, nil
+}
+
+// SubscriptionsByLabel query subscriptions by offset, limit and label
+func (c *Client) SubscriptionsByLabel(offset int, limit int, label string) (subscriptions []notificationsModel.Subscription, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	subscriptions, edgeXerr = subscriptionsByKey(conn, offset, limit, CreateKey(SubscriptionCollectionLabel, label))
+	if edgeXerr != nil {
+		return subscriptions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query subscriptions by offset %d, limit %d and label %s", offset, limit, label), edgeXerr)
+	}
+	return subscriptions, nil
+}
+
+// SubscriptionsByReceiver query subscriptions by offset, limit and receiver
+func (c *Client) SubscriptionsByReceiver(offset int, limit int, receiver string) (subscriptions []notificationsModel.Subscription, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	subscriptions, edgeXerr = subscriptionsByKey(conn, offset, limit, CreateKey(SubscriptionCollectionReceiver, receiver))
+	if edgeXerr != nil {
+		return subscriptions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query subscriptions by offset %d, limit %d and receiver %s", offset, limit, receiver), edgeXerr)
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscriptionById deletes a subscription by id
+func (c *Client) DeleteSubscriptionById(id string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteSubscriptionById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the subscription with id %s", id), edgeXerr)
+	}
+
+	return nil
+}
+
+// DeleteSubscriptionBySlug deletes a subscription by slug
+func (c *Client) DeleteSubscriptionBySlug(slug string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteSubscriptionBySlug(conn, slug)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the subscription with slug %s", slug), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddTransmission adds a new transmission
+func (c *Client) AddTransmission(t notificationsModel.Transmission) (notificationsModel.Transmission, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(t.Id) == 0 {
+		t.Id = uuid.New().String()
+	}
+
+	return addTransmission(conn, t)
+}
+
+// TransmissionById gets a transmission by id
+func (c *Client) TransmissionById(id string) (transmission notificationsModel.Transmission, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	transmission, edgeXerr = transmissionById(conn, id)
+	if edgeXerr != nil {
+		return transmission, errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to query transmission by id %s", id), edgeXerr)
+	}
+
+	return
+}
+
+// UpdateTransmission updates a transmission
+func (c *Client) UpdateTransmission(t notificationsModel.Transmission) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := updateTransmission(conn, t)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to update the transmission with id %s", t.Id), edgeXerr)
+	}
+
+	return nil
+}
+
+// AllTransmissions query transmissions with offset and limit
+func (c *Client) AllTransmissions(offset int, limit int) (transmissions []notificationsModel.Transmission, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	transmissions, edgeXerr = transmissionsByKey(conn, offset, limit, TransmissionCollection)
+	if edgeXerr != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return transmissions, nil
+}
+
+// TransmissionsByNotificationSlug query transmissions by offset, limit and the slug of the originating notification
+func (c *Client) TransmissionsByNotificationSlug(offset int, limit int, slug string) (transmissions []notificationsModel.Transmission, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	transmissions, edgeXerr = transmissionsByKey(conn, offset, limit, CreateKey(TransmissionCollectionNotificationSlug, slug))
+	if edgeXerr != nil {
+		return transmissions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query transmissions by offset %d, limit %d and notification slug %s", offset, limit, slug), edgeXerr)
+	}
+	return transmissions, nil
+}
+
+// TransmissionsByStatus query transmissions by offset, limit and status
+func (c *Client) TransmissionsByStatus(offset int, limit int, status string) (transmissions []notificationsModel.Transmission, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	transmissions, edgeXerr = transmissionsByKey(conn, offset, limit, CreateKey(TransmissionCollectionStatus, status))
+	if edgeXerr != nil {
+		return transmissions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query transmissions by offset %d, limit %d and status %s", offset, limit, status), edgeXerr)
+	}
+	return transmissions, nil
+}
+
+// TransmissionsByTimeRange query transmissions by time range, offset, and limit
+func (c *Client) TransmissionsByTimeRange(start int, end int, offset int, limit int) (transmissions []notificationsModel.Transmission, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	transmissions, edgeXerr = transmissionsByTimeRange(conn, start, end, offset, limit)
+	if edgeXerr != nil {
+		return transmissions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query transmissions by time range %v ~ %v, offset %d, and limit %d", start, end, offset, limit), edgeXerr)
+	}
+	return transmissions, nil
+}
+
+// DeleteProcessedTransmissionsByAge deletes the processed transmissions that are older than age.
+// Age is supposed in milliseconds since the last modification.
+func (c *Client) DeleteProcessedTransmissionsByAge(age int64) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteProcessedTransmissionsByAge(conn, age)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete processed transmissions by age %d", age), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddInterval adds a new interval
+func (c *Client) AddInterval(i schedulerModel.Interval) (schedulerModel.Interval, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(i.Id) == 0 {
+		i.Id = uuid.New().String()
+	}
+
+	return addInterval(conn, i)
+}
+
+// IntervalById gets an interval by id
+func (c *Client) IntervalById(id string) (interval schedulerModel.Interval, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	interval, edgeXerr = intervalById(conn, id)
+	if edgeXerr != nil {
+		return interval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// IntervalByName gets an interval by name
+func (c *Client) IntervalByName(name string) (interval schedulerModel.Interval, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	interval, edgeXerr = intervalByName(conn, name)
+	if edgeXerr != nil {
+		return interval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// AllIntervals query intervals with offset and limit
+func (c *Client) AllIntervals(offset int, limit int) (intervals []schedulerModel.Interval, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	intervals, edgeXerr = allIntervals(conn, offset, limit)
+	if edgeXerr != nil {
+		return intervals, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return intervals, nil
+}
+
+// UpdateInterval updates an interval
+func (c *Client) UpdateInterval(i schedulerModel.Interval) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := updateInterval(conn, i)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to update the interval with id %s", i.Id), edgeXerr)
+	}
+
+	return nil
+}
+
+// DeleteIntervalById deletes an interval and its interval actions by id
+func (c *Client) DeleteIntervalById(id string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteIntervalById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the interval with id %s", id), edgeXerr)
+	}
+
+	return nil
+}
+
+// DeleteIntervalByName deletes an interval and its interval actions by name
+func (c *Client) DeleteIntervalByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteIntervalByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the interval with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
+
+// AddIntervalAction adds a new interval action
+func (c *Client) AddIntervalAction(a schedulerModel.IntervalAction) (schedulerModel.IntervalAction, errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	if len(a.Id) == 0 {
+		a.Id = uuid.New().String()
+	}
+
+	return addIntervalAction(conn, a)
+}
+
+// IntervalActionById gets an interval action by id
+func (c *Client) IntervalActionById(id string) (action schedulerModel.IntervalAction, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	action, edgeXerr = intervalActionById(conn, id)
+	if edgeXerr != nil {
+		return action, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// IntervalActionByName gets an interval action by name
+func (c *Client) IntervalActionByName(name string) (action schedulerModel.IntervalAction, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	action, edgeXerr = intervalActionByName(conn, name)
+	if edgeXerr != nil {
+		return action, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	return
+}
+
+// AllIntervalActions query interval actions with offset and limit
+func (c *Client) AllIntervalActions(offset int, limit int) (actions []schedulerModel.IntervalAction, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	actions, edgeXerr = intervalActionsByKey(conn, offset, limit, IntervalActionCollection)
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return actions, nil
+}
+
+// IntervalActionsByIntervalName query interval actions by offset, limit and interval name
+func (c *Client) IntervalActionsByIntervalName(offset int, limit int, intervalName string) (actions []schedulerModel.IntervalAction, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	actions, edgeXerr = intervalActionsByKey(conn, offset, limit, CreateKey(IntervalActionCollectionIntervalName, intervalName))
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query interval actions by offset %d, limit %d and interval name %s", offset, limit, intervalName), edgeXerr)
+	}
+	return actions, nil
+}
+
+// IntervalActionsByTarget query interval actions by offset, limit and target
+func (c *Client) IntervalActionsByTarget(offset int, limit int, target string) (actions []schedulerModel.IntervalAction, edgeXerr errors.EdgeX) {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	actions, edgeXerr = intervalActionsByKey(conn, offset, limit, CreateKey(IntervalActionCollectionTarget, target))
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeX(errors.Kind(edgeXerr),
+			fmt.Sprintf("fail to query interval actions by offset %d, limit %d and target %s", offset, limit, target), edgeXerr)
+	}
+	return actions, nil
+}
+
+// UpdateIntervalAction updates an interval action
+func (c *Client) UpdateIntervalAction(a schedulerModel.IntervalAction) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := updateIntervalAction(conn, a)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to update the interval action with id %s", a.Id), edgeXerr)
+	}
+
+	return nil
+}
+
+// DeleteIntervalActionById deletes an interval action by id
+func (c *Client) DeleteIntervalActionById(id string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteIntervalActionById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the interval action with id %s", id), edgeXerr)
+	}
+
+	return nil
+}
+
+// DeleteIntervalActionByName deletes an interval action by name
+func (c *Client) DeleteIntervalActionByName(name string) errors.EdgeX {
+	conn := c.Pool.Get()
+	defer conn.Close()
+
+	edgeXerr := deleteIntervalActionByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.Kind(edgeXerr), fmt.Sprintf("fail to delete the interval action with name %s", name), edgeXerr)
+	}
+
+	return nil
+}
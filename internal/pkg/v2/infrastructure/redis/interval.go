@@ -0,0 +1,196 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	IntervalCollection     = "ss|intvl"
+	IntervalCollectionName = IntervalCollection + DBKeySeparator + v2.Name
+)
+
+// intervalStoredKey return the interval's stored key which combines the collection name and object id
+func intervalStoredKey(id string) string {
+	return CreateKey(IntervalCollection, id)
+}
+
+// addInterval adds a new interval into DB
+func addInterval(conn redis.Conn, i models.Interval) (addedInterval models.Interval, edgeXerr errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, intervalStoredKey(i.Id))
+	if edgeXerr != nil {
+		return addedInterval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedInterval, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval id %s already exists", i.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, IntervalCollectionName, i.Name)
+	if edgeXerr != nil {
+		return addedInterval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedInterval, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval name %s already exists", i.Name), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	// For Redis DB, the PATCH operation will removes the old object and add the modified one,
+	// so the Created is not zero value and we shouldn't set the timestamp again.
+	if i.Created == 0 {
+		i.Created = ts
+	}
+	i.Modified = ts
+
+	m, err := json.Marshal(i)
+	if err != nil {
+		return addedInterval, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal interval for Redis persistence", err)
+	}
+
+	storedKey := intervalStoredKey(i.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, IntervalCollection, i.Modified, storedKey)
+	_ = conn.Send(HSET, IntervalCollectionName, i.Name, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "interval creation failed", err)
+	}
+
+	return i, edgeXerr
+}
+
+// intervalById query interval by id from DB
+func intervalById(conn redis.Conn, id string) (interval models.Interval, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, intervalStoredKey(id), &interval)
+	if edgeXerr != nil {
+		return interval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// intervalByName query interval by name from DB
+func intervalByName(conn redis.Conn, name string) (interval models.Interval, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, IntervalCollectionName, name, &interval)
+	if edgeXerr != nil {
+		return interval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// allIntervals queries intervals by offset and limit
+func allIntervals(conn redis.Conn, offset int, limit int) (intervals []models.Interval, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, IntervalCollection, offset, end)
+	if edgeXerr != nil {
+		return intervals, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	intervals = make([]models.Interval, len(objects))
+	for i, in := range objects {
+		interval := models.Interval{}
+		err := json.Unmarshal(in, &interval)
+		if err != nil {
+			return []models.Interval{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "interval format parsing failed from the database", err)
+		}
+		intervals[i] = interval
+	}
+	return intervals, nil
+}
+
+// updateInterval replaces the stored interval, keeping its id and created timestamp. The interval actions
+// associated with the interval are left untouched.
+func updateInterval(conn redis.Conn, interval models.Interval) errors.EdgeX {
+	oldInterval, edgeXerr := intervalById(conn, interval.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if interval.Name != oldInterval.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("interval name '%s' not match the exsting '%s' ", interval.Name, oldInterval.Name), nil)
+	}
+
+	edgeXerr = removeInterval(conn, oldInterval)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	interval.Created = oldInterval.Created
+	_, edgeXerr = addInterval(conn, interval)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "interval updating failed", edgeXerr)
+	}
+	return nil
+}
+
+// removeInterval removes the interval and its indexes
+func removeInterval(conn redis.Conn, interval models.Interval) errors.EdgeX {
+	storedKey := intervalStoredKey(interval.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, IntervalCollection, storedKey)
+	_ = conn.Send(HDEL, IntervalCollectionName, interval.Name)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "interval deletion failed", err)
+	}
+	return nil
+}
+
+// deleteInterval deletes the interval, its indexes and the interval actions associated with it
+func deleteInterval(conn redis.Conn, interval models.Interval) errors.EdgeX {
+	actions, edgeXerr := intervalActionsByKey(conn, 0, -1, CreateKey(IntervalActionCollectionIntervalName, interval.Name))
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	for _, action := range actions {
+		edgeXerr = deleteIntervalAction(conn, action)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+
+	edgeXerr = removeInterval(conn, interval)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// deleteIntervalById deletes the interval by id
+func deleteIntervalById(conn redis.Conn, id string) errors.EdgeX {
+	interval, edgeXerr := intervalById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteInterval(conn, interval)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// deleteIntervalByName deletes the interval by name
+func deleteIntervalByName(conn redis.Conn, name string) errors.EdgeX {
+	interval, edgeXerr := intervalByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteInterval(conn, interval)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
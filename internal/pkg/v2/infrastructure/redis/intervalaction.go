@@ -0,0 +1,196 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/constants"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	IntervalActionCollection             = "ss|intvlact"
+	IntervalActionCollectionName         = IntervalActionCollection + DBKeySeparator + v2.Name
+	IntervalActionCollectionTarget       = IntervalActionCollection + DBKeySeparator + constants.Target
+	IntervalActionCollectionIntervalName = IntervalActionCollection + DBKeySeparator + constants.Interval + DBKeySeparator + v2.Name
+)
+
+// intervalActionStoredKey return the interval action's stored key which combines the collection name and object id
+func intervalActionStoredKey(id string) string {
+	return CreateKey(IntervalActionCollection, id)
+}
+
+// addIntervalAction adds a new interval action into DB
+func addIntervalAction(conn redis.Conn, a models.IntervalAction) (addedAction models.IntervalAction, edgeXerr errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, intervalActionStoredKey(a.Id))
+	if edgeXerr != nil {
+		return addedAction, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedAction, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval action id %s already exists", a.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, IntervalActionCollectionName, a.Name)
+	if edgeXerr != nil {
+		return addedAction, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedAction, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("interval action name %s already exists", a.Name), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, IntervalCollectionName, a.IntervalName)
+	if edgeXerr != nil {
+		return addedAction, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if !exists {
+		return addedAction, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("interval %s does not exist", a.IntervalName), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	// For Redis DB, the PATCH operation will removes the old object and add the modified one,
+	// so the Created is not zero value and we shouldn't set the timestamp again.
+	if a.Created == 0 {
+		a.Created = ts
+	}
+	a.Modified = ts
+
+	m, err := json.Marshal(a)
+	if err != nil {
+		return addedAction, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal interval action for Redis persistence", err)
+	}
+
+	storedKey := intervalActionStoredKey(a.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, IntervalActionCollection, a.Modified, storedKey)
+	_ = conn.Send(HSET, IntervalActionCollectionName, a.Name, storedKey)
+	_ = conn.Send(ZADD, CreateKey(IntervalActionCollectionTarget, a.Target), a.Modified, storedKey)
+	_ = conn.Send(ZADD, CreateKey(IntervalActionCollectionIntervalName, a.IntervalName), a.Modified, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "interval action creation failed", err)
+	}
+
+	return a, edgeXerr
+}
+
+// intervalActionById query interval action by id from DB
+func intervalActionById(conn redis.Conn, id string) (action models.IntervalAction, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, intervalActionStoredKey(id), &action)
+	if edgeXerr != nil {
+		return action, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// intervalActionByName query interval action by name from DB
+func intervalActionByName(conn redis.Conn, name string) (action models.IntervalAction, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, IntervalActionCollectionName, name, &action)
+	if edgeXerr != nil {
+		return action, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// intervalActionsByKey query interval actions from the specified sorted set by offset and limit
+func intervalActionsByKey(conn redis.Conn, offset int, limit int, key string) (actions []models.IntervalAction, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, end)
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	actions = make([]models.IntervalAction, len(objects))
+	for i, in := range objects {
+		a := models.IntervalAction{}
+		err := json.Unmarshal(in, &a)
+		if err != nil {
+			return []models.IntervalAction{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "interval action format parsing failed from the database", err)
+		}
+		actions[i] = a
+	}
+	return actions, nil
+}
+
+// updateIntervalAction replaces the stored interval action, keeping its id and created timestamp
+func updateIntervalAction(conn redis.Conn, action models.IntervalAction) errors.EdgeX {
+	oldAction, edgeXerr := intervalActionById(conn, action.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	if action.Name != oldAction.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("interval action name '%s' not match the exsting '%s' ", action.Name, oldAction.Name), nil)
+	}
+	// check the interval before removing the old action, so a missing interval doesn't lose the action
+	exists, edgeXerr := objectNameExists(conn, IntervalCollectionName, action.IntervalName)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if !exists {
+		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("interval %s does not exist", action.IntervalName), nil)
+	}
+
+	edgeXerr = deleteIntervalAction(conn, oldAction)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	action.Created = oldAction.Created
+	_, edgeXerr = addIntervalAction(conn, action)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "interval action updating failed", edgeXerr)
+	}
+	return nil
+}
+
+// deleteIntervalAction deletes the interval action and all of its indexes
+func deleteIntervalAction(conn redis.Conn, action models.IntervalAction) errors.EdgeX {
+	storedKey := intervalActionStoredKey(action.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, IntervalActionCollection, storedKey)
+	_ = conn.Send(HDEL, IntervalActionCollectionName, action.Name)
+	_ = conn.Send(ZREM, CreateKey(IntervalActionCollectionTarget, action.Target), storedKey)
+	_ = conn.Send(ZREM, CreateKey(IntervalActionCollectionIntervalName, action.IntervalName), storedKey)
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "interval action deletion failed", err)
+	}
+	return nil
+}
+
+// deleteIntervalActionById deletes the interval action by id
+func deleteIntervalActionById(conn redis.Conn, id string) errors.EdgeX {
+	action, edgeXerr := intervalActionById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteIntervalAction(conn, action)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// deleteIntervalActionByName deletes the interval action by name
+func deleteIntervalActionByName(conn redis.Conn, name string) errors.EdgeX {
+	action, edgeXerr := intervalActionByName(conn, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteIntervalAction(conn, action)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	NotificationCollection         = "sn|notif"
+	NotificationCollectionSlug     = NotificationCollection + DBKeySeparator + constants.Slug
+	NotificationCollectionCategory = NotificationCollection + DBKeySeparator + constants.Category
+	NotificationCollectionLabel    = NotificationCollection + DBKeySeparator + v2.Label
+	NotificationCollectionStatus   = NotificationCollection + DBKeySeparator + constants.Status
+	NotificationCollectionCreated  = NotificationCollection + DBKeySeparator + v2.Created
+)
+
+// notificationStoredKey return the notification's stored key which combines the collection name and object id
+func notificationStoredKey(id string) string {
+	return CreateKey(NotificationCollection, id)
+}
+
+// addNotification adds a new notification into DB
+func addNotification(conn redis.Conn, n models.Notification) (addedNotification models.Notification, edgeXerr errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, notificationStoredKey(n.Id))
+	if edgeXerr != nil {
+		return addedNotification, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedNotification, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("notification id %s already exists", n.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, NotificationCollectionSlug, n.Slug)
+	if edgeXerr != nil {
+		return addedNotification, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedNotification, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("notification slug %s already exists", n.Slug), edgeXerr)
+	}
+
+	if n.Created == 0 {
+		n.Created = common.MakeTimestamp()
+	}
+	// query API will sort the result based on Modified, so even newly created notification shall specify Modified as Created
+	n.Modified = n.Created
+
+	m, err := json.Marshal(n)
+	if err != nil {
+		return addedNotification, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal notification for Redis persistence", err)
+	}
+
+	storedKey := notificationStoredKey(n.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, NotificationCollection, n.Modified, storedKey)
+	_ = conn.Send(ZADD, NotificationCollectionCreated, n.Created, storedKey)
+	_ = conn.Send(HSET, NotificationCollectionSlug, n.Slug, storedKey)
+	_ = conn.Send(ZADD, CreateKey(NotificationCollectionCategory, string(n.Category)), n.Modified, storedKey)
+	_ = conn.Send(ZADD, CreateKey(NotificationCollectionStatus, string(n.Status)), n.Modified, storedKey)
+	for _, label := range n.Labels {
+		_ = conn.Send(ZADD, CreateKey(NotificationCollectionLabel, label), n.Modified, storedKey)
+	}
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "notification creation failed", err)
+	}
+
+	return n, edgeXerr
+}
+
+// notificationBySlug query notification by slug from DB
+func notificationBySlug(conn redis.Conn, slug string) (notification models.Notification, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, NotificationCollectionSlug, slug, &notification)
+	if edgeXerr != nil {
+		return notification, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// notificationsByKey query notifications from the specified sorted set by offset and limit
+func notificationsByKey(conn redis.Conn, offset int, limit int, key string) (notifications []models.Notification, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, end)
+	if edgeXerr != nil {
+		return notifications, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToNotifications(objects)
+}
+
+// notificationsByTimeRange query notifications by created time range, offset, and limit
+func notificationsByTimeRange(conn redis.Conn, start int, end int, offset int, limit int) (notifications []models.Notification, edgeXerr errors.EdgeX) {
+	objects, edgeXerr := getObjectsByScoreRange(conn, NotificationCollectionCreated, start, end, offset, limit)
+	if edgeXerr != nil {
+		return notifications, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToNotifications(objects)
+}
+
+func convertObjectsToNotifications(objects [][]byte) (notifications []models.Notification, edgeXerr errors.EdgeX) {
+	notifications = make([]models.Notification, len(objects))
+	for i, in := range objects {
+		n := models.Notification{}
+		err := json.Unmarshal(in, &n)
+		if err != nil {
+			return []models.Notification{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "notification format parsing failed from the database", err)
+		}
+		notifications[i] = n
+	}
+	return notifications, nil
+}
+
+// deleteNotification deletes the notification and all of its transmissions
+func deleteNotification(conn redis.Conn, notification models.Notification) errors.EdgeX {
+	edgeXerr := deleteTransmissionsByNotificationSlug(conn, notification.Slug)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	storedKey := notificationStoredKey(notification.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, NotificationCollection, storedKey)
+	_ = conn.Send(ZREM, NotificationCollectionCreated, storedKey)
+	_ = conn.Send(HDEL, NotificationCollectionSlug, notification.Slug)
+	_ = conn.Send(ZREM, CreateKey(NotificationCollectionCategory, string(notification.Category)), storedKey)
+	_ = conn.Send(ZREM, CreateKey(NotificationCollectionStatus, string(notification.Status)), storedKey)
+	for _, label := range notification.Labels {
+		_ = conn.Send(ZREM, CreateKey(NotificationCollectionLabel, label), storedKey)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "notification deletion failed", err)
+	}
+	return nil
+}
+
+// deleteNotificationBySlug deletes the notification by slug
+func deleteNotificationBySlug(conn redis.Conn, slug string) errors.EdgeX {
+	notification, edgeXerr := notificationBySlug(conn, slug)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteNotification(conn, notification)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// deleteNotificationsByAge deletes the notifications under the specified sorted set whose last modification is older
+// than age, along with their transmissions
+func deleteNotificationsByAge(conn redis.Conn, key string, age int64) errors.EdgeX {
+	expireTimestamp := utils.MakeTimestamp() - age
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, key, 0, strconv.FormatInt(expireTimestamp, 10)))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("retrieve notification ids by key %s failed", key), err)
+	}
+	objects, edgeXerr := getObjectsByIds(conn, common.ConvertStringsToInterfaces(storedKeys))
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	notifications, edgeXerr := convertObjectsToNotifications(objects)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	for _, n := range notifications {
+		edgeXerr = deleteNotification(conn, n)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+	return nil
+}
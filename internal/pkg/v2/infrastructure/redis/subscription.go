@@ -0,0 +1,170 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	SubscriptionCollection         = "sn|sub"
+	SubscriptionCollectionSlug     = SubscriptionCollection + DBKeySeparator + constants.Slug
+	SubscriptionCollectionCategory = SubscriptionCollection + DBKeySeparator + constants.Category
+	SubscriptionCollectionLabel    = SubscriptionCollection + DBKeySeparator + v2.Label
+	SubscriptionCollectionReceiver = SubscriptionCollection + DBKeySeparator + constants.Receiver
+)
+
+// subscriptionStoredKey return the subscription's stored key which combines the collection name and object id
+func subscriptionStoredKey(id string) string {
+	return CreateKey(SubscriptionCollection, id)
+}
+
+// addSubscription adds a new subscription into DB
+func addSubscription(conn redis.Conn, s models.Subscription) (addedSubscription models.Subscription, edgeXerr errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, subscriptionStoredKey(s.Id))
+	if edgeXerr != nil {
+		return addedSubscription, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedSubscription, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("subscription id %s already exists", s.Id), edgeXerr)
+	}
+
+	exists, edgeXerr = objectNameExists(conn, SubscriptionCollectionSlug, s.Slug)
+	if edgeXerr != nil {
+		return addedSubscription, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedSubscription, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("subscription slug %s already exists", s.Slug), edgeXerr)
+	}
+
+	ts := common.MakeTimestamp()
+	// For Redis DB, the PATCH operation will removes the old object and add the modified one,
+	// so the Created is not zero value and we shouldn't set the timestamp again.
+	if s.Created == 0 {
+		s.Created = ts
+	}
+	s.Modified = ts
+
+	m, err := json.Marshal(s)
+	if err != nil {
+		return addedSubscription, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal subscription for Redis persistence", err)
+	}
+
+	storedKey := subscriptionStoredKey(s.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, SubscriptionCollection, s.Modified, storedKey)
+	_ = conn.Send(HSET, SubscriptionCollectionSlug, s.Slug, storedKey)
+	_ = conn.Send(ZADD, CreateKey(SubscriptionCollectionReceiver, s.Receiver), s.Modified, storedKey)
+	for _, category := range s.Categories {
+		_ = conn.Send(ZADD, CreateKey(SubscriptionCollectionCategory, string(category)), s.Modified, storedKey)
+	}
+	for _, label := range s.Labels {
+		_ = conn.Send(ZADD, CreateKey(SubscriptionCollectionLabel, label), s.Modified, storedKey)
+	}
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "subscription creation failed", err)
+	}
+
+	return s, edgeXerr
+}
+
+// subscriptionById query subscription by id from DB
+func subscriptionById(conn redis.Conn, id string) (subscription models.Subscription, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, subscriptionStoredKey(id), &subscription)
+	if edgeXerr != nil {
+		return subscription, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// subscriptionBySlug query subscription by slug from DB
+func subscriptionBySlug(conn redis.Conn, slug string) (subscription models.Subscription, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectByHash(conn, SubscriptionCollectionSlug, slug, &subscription)
+	if edgeXerr != nil {
+		return subscription, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// subscriptionsByKey query subscriptions from the specified sorted set by offset and limit
+func subscriptionsByKey(conn redis.Conn, offset int, limit int, key string) (subscriptions []models.Subscription, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, end)
+	if edgeXerr != nil {
+		return subscriptions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	subscriptions = make([]models.Subscription, len(objects))
+	for i, in := range objects {
+		s := models.Subscription{}
+		err := json.Unmarshal(in, &s)
+		if err != nil {
+			return []models.Subscription{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "subscription format parsing failed from the database", err)
+		}
+		subscriptions[i] = s
+	}
+	return subscriptions, nil
+}
+
+// deleteSubscription deletes the subscription and all of its indexes
+func deleteSubscription(conn redis.Conn, subscription models.Subscription) errors.EdgeX {
+	storedKey := subscriptionStoredKey(subscription.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(DEL, storedKey)
+	_ = conn.Send(ZREM, SubscriptionCollection, storedKey)
+	_ = conn.Send(HDEL, SubscriptionCollectionSlug, subscription.Slug)
+	_ = conn.Send(ZREM, CreateKey(SubscriptionCollectionReceiver, subscription.Receiver), storedKey)
+	for _, category := range subscription.Categories {
+		_ = conn.Send(ZREM, CreateKey(SubscriptionCollectionCategory, string(category)), storedKey)
+	}
+	for _, label := range subscription.Labels {
+		_ = conn.Send(ZREM, CreateKey(SubscriptionCollectionLabel, label), storedKey)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "subscription deletion failed", err)
+	}
+	return nil
+}
+
+// deleteSubscriptionById deletes the subscription by id
+func deleteSubscriptionById(conn redis.Conn, id string) errors.EdgeX {
+	subscription, edgeXerr := subscriptionById(conn, id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteSubscription(conn, subscription)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
+
+// deleteSubscriptionBySlug deletes the subscription by slug
+func deleteSubscriptionBySlug(conn redis.Conn, slug string) errors.EdgeX {
+	subscription, edgeXerr := subscriptionBySlug(conn, slug)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteSubscription(conn, subscription)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/common"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	TransmissionCollection                 = "sn|trans"
+	TransmissionCollectionStatus           = TransmissionCollection + DBKeySeparator + constants.Status
+	TransmissionCollectionCreated          = TransmissionCollection + DBKeySeparator + v2.Created
+	TransmissionCollectionNotificationSlug = TransmissionCollection + DBKeySeparator + constants.Notification + DBKeySeparator + constants.Slug
+)
+
+// processedTransmissionStatuses are the transmission statuses which need no further delivery attempt
+var processedTransmissionStatuses = []models.TransmissionStatus{models.Sent, models.Acknowledged}
+
+// transmissionStoredKey return the transmission's stored key which combines the collection name and object id
+func transmissionStoredKey(id string) string {
+	return CreateKey(TransmissionCollection, id)
+}
+
+// addTransmission adds a new transmission into DB
+func addTransmission(conn redis.Conn, t models.Transmission) (addedTransmission models.Transmission, edgeXerr errors.EdgeX) {
+	exists, edgeXerr := objectIdExists(conn, transmissionStoredKey(t.Id))
+	if edgeXerr != nil {
+		return addedTransmission, errors.NewCommonEdgeXWrapper(edgeXerr)
+	} else if exists {
+		return addedTransmission, errors.NewCommonEdgeX(errors.KindDuplicateName, fmt.Sprintf("transmission id %s already exists", t.Id), edgeXerr)
+	}
+
+	if t.Created == 0 {
+		t.Created = common.MakeTimestamp()
+	}
+	t.Modified = t.Created
+
+	m, err := json.Marshal(t)
+	if err != nil {
+		return addedTransmission, errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal transmission for Redis persistence", err)
+	}
+
+	storedKey := transmissionStoredKey(t.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, TransmissionCollection, t.Modified, storedKey)
+	_ = conn.Send(ZADD, TransmissionCollectionCreated, t.Created, storedKey)
+	_ = conn.Send(ZADD, CreateKey(TransmissionCollectionStatus, string(t.Status)), t.Modified, storedKey)
+	_ = conn.Send(ZADD, CreateKey(TransmissionCollectionNotificationSlug, t.Notification.Slug), t.Modified, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		edgeXerr = errors.NewCommonEdgeX(errors.KindDatabaseError, "transmission creation failed", err)
+	}
+
+	return t, edgeXerr
+}
+
+// transmissionById query transmission by id from DB
+func transmissionById(conn redis.Conn, id string) (transmission models.Transmission, edgeXerr errors.EdgeX) {
+	edgeXerr = getObjectById(conn, transmissionStoredKey(id), &transmission)
+	if edgeXerr != nil {
+		return transmission, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return
+}
+
+// updateTransmission replaces the stored transmission and moves it to the index of its new status
+func updateTransmission(conn redis.Conn, t models.Transmission) errors.EdgeX {
+	oldTransmission, edgeXerr := transmissionById(conn, t.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	t.Created = oldTransmission.Created
+	t.Modified = common.MakeTimestamp()
+	m, err := json.Marshal(t)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "unable to JSON marshal transmission for Redis persistence", err)
+	}
+
+	storedKey := transmissionStoredKey(t.Id)
+	_ = conn.Send(MULTI)
+	_ = conn.Send(ZREM, CreateKey(TransmissionCollectionStatus, string(oldTransmission.Status)), storedKey)
+	_ = conn.Send(SET, storedKey, m)
+	_ = conn.Send(ZADD, TransmissionCollection, t.Modified, storedKey)
+	_ = conn.Send(ZADD, CreateKey(TransmissionCollectionStatus, string(t.Status)), t.Modified, storedKey)
+	_ = conn.Send(ZADD, CreateKey(TransmissionCollectionNotificationSlug, t.Notification.Slug), t.Modified, storedKey)
+	_, err = conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "transmission updating failed", err)
+	}
+	return nil
+}
+
+// transmissionsByKey query transmissions from the specified sorted set by offset and limit
+func transmissionsByKey(conn redis.Conn, offset int, limit int, key string) (transmissions []models.Transmission, edgeXerr errors.EdgeX) {
+	end := offset + limit - 1
+	if limit == -1 { //-1 limit means that clients want to retrieve all remaining records after offset from DB, so specifying -1 for end
+		end = limit
+	}
+	objects, edgeXerr := getObjectsByRevRange(conn, key, offset, end)
+	if edgeXerr != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToTransmissions(objects)
+}
+
+// transmissionsByTimeRange query transmissions by created time range, offset, and limit
+func transmissionsByTimeRange(conn redis.Conn, start int, end int, offset int, limit int) (transmissions []models.Transmission, edgeXerr errors.EdgeX) {
+	objects, edgeXerr := getObjectsByScoreRange(conn, TransmissionCollectionCreated, start, end, offset, limit)
+	if edgeXerr != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToTransmissions(objects)
+}
+
+func convertObjectsToTransmissions(objects [][]byte) (transmissions []models.Transmission, edgeXerr errors.EdgeX) {
+	transmissions = make([]models.Transmission, len(objects))
+	for i, in := range objects {
+		t := models.Transmission{}
+		err := json.Unmarshal(in, &t)
+		if err != nil {
+			return []models.Transmission{}, errors.NewCommonEdgeX(errors.KindDatabaseError, "transmission format parsing failed from the database", err)
+		}
+		transmissions[i] = t
+	}
+	return transmissions, nil
+}
+
+// transmissionsByScoreRange query all transmissions under the specified sorted set whose score is at most max
+func transmissionsByScoreRange(conn redis.Conn, key string, max string) (transmissions []models.Transmission, edgeXerr errors.EdgeX) {
+	storedKeys, err := redis.Strings(conn.Do(ZRANGEBYSCORE, key, 0, max))
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindDatabaseError, fmt.Sprintf("retrieve transmission ids by key %s failed", key), err)
+	}
+	objects, edgeXerr := getObjectsByIds(conn, common.ConvertStringsToInterfaces(storedKeys))
+	if edgeXerr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return convertObjectsToTransmissions(objects)
+}
+
+// deleteTransmissions deletes the given transmissions and their indexes in a single transaction
+func deleteTransmissions(conn redis.Conn, transmissions []models.Transmission) errors.EdgeX {
+	if len(transmissions) == 0 {
+		return nil
+	}
+	_ = conn.Send(MULTI)
+	for _, t := range transmissions {
+		storedKey := transmissionStoredKey(t.Id)
+		_ = conn.Send(DEL, storedKey)
+		_ = conn.Send(ZREM, TransmissionCollection, storedKey)
+		_ = conn.Send(ZREM, TransmissionCollectionCreated, storedKey)
+		_ = conn.Send(ZREM, CreateKey(TransmissionCollectionStatus, string(t.Status)), storedKey)
+		_ = conn.Send(ZREM, CreateKey(TransmissionCollectionNotificationSlug, t.Notification.Slug), storedKey)
+	}
+	_, err := conn.Do(EXEC)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindDatabaseError, "transmission deletion failed", err)
+	}
+	return nil
+}
+
+// deleteTransmissionsByNotificationSlug deletes all transmissions that originated with the specified notification
+func deleteTransmissionsByNotificationSlug(conn redis.Conn, slug string) errors.EdgeX {
+	transmissions, edgeXerr := transmissionsByScoreRange(conn, CreateKey(TransmissionCollectionNotificationSlug, slug), InfiniteMax)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return deleteTransmissions(conn, transmissions)
+}
+
+// deleteProcessedTransmissionsByAge deletes the processed transmissions whose last modification is older than age
+func deleteProcessedTransmissionsByAge(conn redis.Conn, age int64) errors.EdgeX {
+	expireTimestamp := strconv.FormatInt(utils.MakeTimestamp()-age, 10)
+	for _, status := range processedTransmissionStatuses {
+		transmissions, edgeXerr := transmissionsByScoreRange(conn, CreateKey(TransmissionCollectionStatus, string(status)), expireTimestamp)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+		edgeXerr = deleteTransmissions(conn, transmissions)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+	return nil
+}
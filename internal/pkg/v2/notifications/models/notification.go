@@ -0,0 +1,47 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+// Notification and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Notification
+// Model fields are same as the DTOs documented by this swagger. Exceptions, if any, are noted below.
+type Notification struct {
+	models.Timestamps
+	Id          string
+	Slug        string
+	Sender      string
+	Category    NotificationCategory
+	Severity    NotificationSeverity
+	Content     string
+	ContentType string
+	Description string
+	Status      NotificationStatus
+	Labels      []string
+}
+
+// NotificationCategory indicates the category of a notification
+type NotificationCategory string
+
+// NotificationSeverity indicates the level of severity of a notification
+type NotificationSeverity string
+
+// NotificationStatus indicates the processing status of a notification
+type NotificationStatus string
+
+const (
+	Security NotificationCategory = "SECURITY"
+	HwHealth NotificationCategory = "HW_HEALTH"
+	SwHealth NotificationCategory = "SW_HEALTH"
+
+	Normal   NotificationSeverity = "NORMAL"
+	Critical NotificationSeverity = "CRITICAL"
+
+	New       NotificationStatus = "NEW"
+	Processed NotificationStatus = "PROCESSED"
+	Escalated NotificationStatus = "ESCALATED"
+)
@@ -0,0 +1,63 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+// Subscription and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Subscription
+// Model fields are same as the DTOs documented by this swagger. Exceptions, if any, are noted below.
+type Subscription struct {
+	models.Timestamps
+	Id               string
+	Slug             string
+	Receiver         string
+	Description      string
+	Channels         []Channel
+	Categories       []NotificationCategory
+	Labels           []string
+	Template         string
+	EmailSender      string
+	EmailSubject     string
+	MinSeverity      NotificationSeverity
+	TimeZone         string
+	DeliveryWindows  []DeliveryWindow
+	QuietHours       string
+	RetryPolicy      string
+	EscalationPolicy string
+	RateLimit        int
+	RateLimitPeriod  string
+	DigestInterval   string
+}
+
+// DeliveryWindow is a daily time range in which notifications are delivered to a subscription
+type DeliveryWindow struct {
+	Days  []string
+	Start string
+	End   string
+}
+
+// Channel and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Channel
+type Channel struct {
+	Type           ChannelType
+	EmailAddresses []string
+	Url            string
+	Webhook        string
+	SmsGateway     string
+	PhoneNumbers   []string
+}
+
+// ChannelType indicates the transport used to deliver a notification
+type ChannelType string
+
+const (
+	Rest    ChannelType = "REST"
+	Email   ChannelType = "EMAIL"
+	Mqtt    ChannelType = "MQTT"
+	Webhook ChannelType = "WEBHOOK"
+	Sms     ChannelType = "SMS"
+)
@@ -0,0 +1,40 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+// Transmission and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Transmission
+// Model fields are same as the DTOs documented by this swagger. Exceptions, if any, are noted below.
+type Transmission struct {
+	models.Timestamps
+	Id           string
+	Notification Notification
+	Receiver     string
+	Channel      Channel
+	Status       TransmissionStatus
+	ResendCount  int
+	Records      []TransmissionRecord
+}
+
+// TransmissionRecord and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/TransmissionRecord
+type TransmissionRecord struct {
+	Status   TransmissionStatus
+	Response string
+	Sent     int64
+}
+
+// TransmissionStatus indicates the most recent success/failure of a transmission
+type TransmissionStatus string
+
+const (
+	Acknowledged TransmissionStatus = "ACKNOWLEDGED"
+	Failed       TransmissionStatus = "FAILED"
+	Sent         TransmissionStatus = "SENT"
+	Trxescalated TransmissionStatus = "TRXESCALATED"
+)
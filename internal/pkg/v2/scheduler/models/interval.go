@@ -0,0 +1,21 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+// Interval and its properties are defined in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/Interval
+// Model fields are same as the DTOs documented by this swagger. Exceptions, if any, are noted below.
+type Interval struct {
+	models.Timestamps
+	Id        string
+	Name      string
+	Start     string
+	End       string
+	Frequency string
+	RunOnce   bool
+}
@@ -0,0 +1,29 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/models"
+
+// IntervalAction and its properties are defined in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/IntervalAction
+// Model fields are same as the DTOs documented by this swagger. Exceptions, if any, are noted below.
+type IntervalAction struct {
+	models.Timestamps
+	Id           string
+	Name         string
+	IntervalName string
+	Target       string
+	Protocol     string
+	Host         string
+	Port         int
+	Path         string
+	HTTPMethod   string
+	Parameters   string
+	User         string
+	Password     string
+	Publisher    string
+	Topic        string
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/help"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/container"
 
@@ -59,6 +60,8 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 		command, err = help.NewCommand(lc, configuration, subcommandArgs)
 	case proxy.CommandName:
 		command, err = proxy.NewCommand(lc, configuration, subcommandArgs)
+	case secretstore.CommandName:
+		command, err = secretstore.NewCommand(lc, configuration, subcommandArgs)
 	default:
 		lc.Error(fmt.Sprintf("unsupported command %s", commandName))
 		b.exitStatusCode = interfaces.StatusCodeNoOptionSelected
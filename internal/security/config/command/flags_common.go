@@ -99,6 +99,7 @@ func HelpCallback() {
 			"\n"+
 			"Commands:\n"+
 			"    help          Show available commands (this text)\n"+
-			"    proxy         Configure security settings for EdgeX proxy\n",
+			"    proxy         Configure security settings for EdgeX proxy\n"+
+			"    secretstore   Inspect, unseal, rekey and back up the EdgeX secret store\n",
 		os.Args[0])
 }
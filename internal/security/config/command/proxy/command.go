@@ -12,7 +12,11 @@ import (
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/adduser"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/deluser"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/jwt"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/listusers"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/oauth2"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/rotatekey"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/setexpiry"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/setgroups"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/tls"
 	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
@@ -33,7 +37,7 @@ func NewCommand(
 	var err error
 
 	if len(args) < 1 {
-		return nil, fmt.Errorf("subcommand required (adduser, deluser, jwt, oauth2, tls)")
+		return nil, fmt.Errorf("subcommand required (adduser, deluser, jwt, listusers, oauth2, rotatekey, setexpiry, setgroups, tls)")
 	}
 
 	commandName := args[0]
@@ -49,6 +53,14 @@ func NewCommand(
 		command, err = jwt.NewCommand(lc, configuration, args[1:])
 	case oauth2.CommandName:
 		command, err = oauth2.NewCommand(lc, configuration, args[1:])
+	case listusers.CommandName:
+		command, err = listusers.NewCommand(lc, configuration, args[1:])
+	case rotatekey.CommandName:
+		command, err = rotatekey.NewCommand(lc, configuration, args[1:])
+	case setgroups.CommandName:
+		command, err = setgroups.NewCommand(lc, configuration, args[1:])
+	case setexpiry.CommandName:
+		command, err = setexpiry.NewCommand(lc, configuration, args[1:])
 	default:
 		command = nil
 		err = fmt.Errorf("unsupported command %s", commandName)
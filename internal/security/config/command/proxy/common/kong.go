@@ -0,0 +1,183 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+)
+
+const (
+	// ExpiryTagPrefix prefixes the consumer tag holding the Unix time at which the user expires.  security-proxy-setup
+	// --reconcile deletes the JWT and OAuth2 credentials of expired users.
+	ExpiryTagPrefix = proxy.ConsumerExpiryTagPrefix
+)
+
+// KongError is returned for a Kong admin API response with an unexpected status code
+type KongError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *KongError) Error() string {
+	return fmt.Sprintf("%s %s returned status %d: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns whether err is a Kong admin API response for a missing object
+func IsNotFound(err error) bool {
+	var kongErr *KongError
+	return errors.As(err, &kongErr) && kongErr.StatusCode == http.StatusNotFound
+}
+
+// KongAdmin sends requests to the Kong admin API
+type KongAdmin struct {
+	client  internal.HttpCaller
+	baseURL string
+}
+
+// NewKongAdmin returns a KongAdmin for the Kong admin API in configuration
+func NewKongAdmin(client internal.HttpCaller, configuration *config.ConfigurationStruct) KongAdmin {
+	return KongAdmin{client: client, baseURL: configuration.KongURL.GetProxyBaseURL()}
+}
+
+// Request sends a request with a JSON body to the Kong admin API at path and decodes the response into response
+// when it is not nil.  A response other than 200, 201 or 204 is returned as a *KongError.
+func (k KongAdmin) Request(method string, path string, body interface{}, response interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = strings.NewReader(string(data))
+	}
+
+	req, err := http.NewRequest(method, k.baseURL+"/"+path, reader)
+	if err != nil {
+		return fmt.Errorf("Failed to prepare request: %w", err)
+	}
+	if body != nil {
+		req.Header.Add(clients.ContentType, clients.ContentTypeJSON)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	default:
+		b, _ := ioutil.ReadAll(resp.Body)
+		return &KongError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+	}
+
+	if response != nil {
+		return json.NewDecoder(resp.Body).Decode(response)
+	}
+	return nil
+}
+
+// List reads every page of the Kong admin API list at path into objects, a pointer to a slice
+func (k KongAdmin) List(path string, objects interface{}) error {
+	var all []json.RawMessage
+	next := "/" + path
+	for next != "" {
+		var page struct {
+			Data []json.RawMessage `json:"data"`
+			Next string            `json:"next"`
+		}
+		if err := k.Request(http.MethodGet, strings.TrimPrefix(next, "/"), nil, &page); err != nil {
+			return fmt.Errorf("Failed to list Kong %s: %w", path, err)
+		}
+		all = append(all, page.Data...)
+		next = page.Next
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, objects)
+}
+
+// ConsumerPath returns the path of the Kong admin API for username, followed by elements
+func ConsumerPath(username string, elements ...string) string {
+	return strings.Join(append([]string{"consumers", url.PathEscape(username)}, elements...), "/")
+}
+
+// KongConsumer is a consumer (user) of the Kong admin API
+type KongConsumer struct {
+	ID       string   `json:"id"`
+	Username string   `json:"username"`
+	CustomID string   `json:"custom_id,omitempty"`
+	Tags     []string `json:"tags"`
+}
+
+// Expiry returns the time at which the consumer expires, or the zero time if it doesn't
+func (c KongConsumer) Expiry() time.Time {
+	return proxy.ConsumerExpiry(c.Tags)
+}
+
+// Expired returns whether the consumer's expiry has passed at now
+func (c KongConsumer) Expired(now time.Time) bool {
+	expiry := c.Expiry()
+	return !expiry.IsZero() && !expiry.After(now)
+}
+
+// TagsWithExpiry returns the consumer tags with the expiry replaced by expiry, or removed when it is the zero time
+func (c KongConsumer) TagsWithExpiry(expiry time.Time) []string {
+	tags := []string{}
+	for _, tag := range c.Tags {
+		if !strings.HasPrefix(tag, ExpiryTagPrefix) {
+			tags = append(tags, tag)
+		}
+	}
+	if !expiry.IsZero() {
+		tags = append(tags, fmt.Sprintf("%s%d", ExpiryTagPrefix, expiry.Unix()))
+	}
+	return tags
+}
+
+// KongACLGroup is an ACL group association of a consumer
+type KongACLGroup struct {
+	ID    string `json:"id"`
+	Group string `json:"group"`
+}
+
+// KongJWTCredential is a JWT credential of a consumer
+type KongJWTCredential struct {
+	ID           string `json:"id,omitempty"`
+	Key          string `json:"key,omitempty"`
+	Algorithm    string `json:"algorithm"`
+	RSAPublicKey string `json:"rsa_public_key,omitempty"`
+	Secret       string `json:"secret,omitempty"`
+}
+
+// PrintJSON writes value to standard output as JSON, for automation
+func PrintJSON(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKongAdminList(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/consumers":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []interface{}{map[string]string{"id": "1", "username": "alice"}},
+				"next": "/consumers?offset=abc",
+			})
+		case "/consumers?offset=abc":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []interface{}{map[string]string{"id": "2", "username": "bob"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	configuration.KongURL.Server = tsURL.Hostname()
+	configuration.KongURL.AdminPort, _ = strconv.Atoi(tsURL.Port())
+	kong := NewKongAdmin(secretstoreclient.NewRequestor(logger.MockLogger{}).Insecure(), configuration)
+
+	var consumers []KongConsumer
+	err = kong.List("consumers", &consumers)
+
+	require.NoError(t, err)
+	assert.Equal(t, []KongConsumer{{ID: "1", Username: "alice"}, {ID: "2", Username: "bob"}}, consumers)
+
+	err = kong.List(ConsumerPath("carol", "acls"), &consumers)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestKongConsumerExpiry(t *testing.T) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	consumer := KongConsumer{Tags: []string{"team", ExpiryTagPrefix + "1"}}
+
+	consumer.Tags = consumer.TagsWithExpiry(expiry)
+
+	assert.Equal(t, expiry, consumer.Expiry())
+	assert.Len(t, consumer.Tags, 2)
+	assert.Equal(t, []string{"team"}, consumer.TagsWithExpiry(time.Time{}))
+	assert.True(t, KongConsumer{}.Expiry().IsZero())
+
+	assert.False(t, consumer.Expired(expiry.Add(-time.Second)))
+	assert.True(t, consumer.Expired(expiry))
+	assert.False(t, KongConsumer{}.Expired(expiry))
+}
@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package listusers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, past time.Time, future time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		var response interface{}
+		switch r.URL.RequestURI() {
+		case "/consumers":
+			response = map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"id": "id-bob", "username": "bob",
+						"tags": []string{"team", fmt.Sprintf("%s%d", common.ExpiryTagPrefix, past.Unix())}},
+				},
+				"next": "/consumers?offset=1",
+			}
+		case "/consumers?offset=1":
+			response = map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"id": "id-alice", "username": "alice",
+						"tags": []string{fmt.Sprintf("%s%d", common.ExpiryTagPrefix, future.Unix())}},
+					map[string]interface{}{"id": "id-carol", "username": "carol"},
+				},
+			}
+		case "/consumers/id-alice/acls":
+			response = map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "acl-1", "group": "rules"},
+				map[string]interface{}{"id": "acl-2", "group": "admin"},
+			}}
+		case "/consumers/id-alice/jwt":
+			response = map[string]interface{}{"data": []interface{}{
+				map[string]interface{}{"id": "jwt-1", "key": "alice-key", "algorithm": "ES256", "rsa_public_key": "..."},
+			}}
+		case "/consumers/id-bob/acls", "/consumers/id-carol/acls", "/consumers/id-bob/jwt":
+			response = map[string]interface{}{"data": []interface{}{}}
+		case "/consumers/id-carol/jwt":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		default:
+			t.Fatalf("Unexpected call to URL %s", r.URL.RequestURI())
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func newTestCommand(t *testing.T, ts *httptest.Server, args ...string) *cmd {
+	tsURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	configuration.KongURL.Server = tsURL.Hostname()
+	configuration.KongURL.AdminPort, _ = strconv.Atoi(tsURL.Port())
+
+	command, err := NewCommand(logger.MockLogger{}, configuration, args)
+	require.NoError(t, err)
+	return command.(*cmd)
+}
+
+func TestListUsersBadArg(t *testing.T) {
+	command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, []string{"-badarg"})
+
+	assert.Error(t, err)
+	assert.Nil(t, command)
+}
+
+func TestListUsers(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	future := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	ts := newTestServer(t, past, future)
+	defer ts.Close()
+
+	users, err := newTestCommand(t, ts).listUsers()
+
+	require.NoError(t, err)
+	assert.Equal(t, []user{
+		{ID: "id-alice", Username: "alice", Groups: []string{"admin", "rules"},
+			JWTCredentials: []jwtCredential{{ID: "jwt-1", Key: "alice-key", Algorithm: "ES256"}}, Expires: &future},
+		{ID: "id-bob", Username: "bob", Groups: []string{}, JWTCredentials: []jwtCredential{},
+			Expires: &past, Expired: true},
+		{ID: "id-carol", Username: "carol", Groups: []string{}, JWTCredentials: []jwtCredential{}},
+	}, users)
+}
+
+func TestListUsersExpired(t *testing.T) {
+	past := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+	ts := newTestServer(t, past, time.Now().Add(time.Hour))
+	defer ts.Close()
+	command := newTestCommand(t, ts, "--expired", "--json")
+
+	users, err := command.listUsers()
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "bob", users[0].Username)
+
+	code, err := command.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+}
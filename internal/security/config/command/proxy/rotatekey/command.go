@@ -0,0 +1,145 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package rotatekey
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	CommandName = "rotatekey"
+)
+
+type cmd struct {
+	loggingClient logger.LoggingClient
+	kong          common.KongAdmin
+	username      string
+	algorithm     string
+	publicKeyPath string
+	jwtID         string
+	keepOld       bool
+	jsonOutput    bool
+}
+
+// rotation is the outcome of a key rotation as output
+type rotation struct {
+	Username    string   `json:"username"`
+	Key         string   `json:"key"`
+	RemovedKeys []string `json:"removed_keys"`
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		kong:          common.NewKongAdmin(secretstoreclient.NewRequestor(lc).Insecure(), configuration),
+	}
+	var dummy string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.username, "user", "", "Username of the user whose JWT signing key is rotated")
+	flagSet.StringVar(&cmd.algorithm, "algorithm", "", "Algorithm used for signing the JWT, RS256 or ES256")
+	flagSet.StringVar(&cmd.publicKeyPath, "public_key", "", "New public key (in PEM format) used to validate the JWT.")
+	flagSet.StringVar(&cmd.jwtID, "id", "", "ID to use for linkage with JWT claim (usually the 'iss' field)")
+	flagSet.BoolVar(&cmd.keepOld, "keep-old", false, "Keep the existing JWT credentials alongside the new one")
+	flagSet.BoolVar(&cmd.jsonOutput, "json", false, "Output the result as JSON")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	if cmd.username == "" {
+		return nil, fmt.Errorf("%s proxy rotatekey: argument --user is required", os.Args[0])
+	}
+	if cmd.algorithm != "RS256" && cmd.algorithm != "ES256" {
+		return nil, fmt.Errorf("%s proxy rotatekey: argument --algorithm must be either 'RS256' or 'ES256'", os.Args[0])
+	}
+	if cmd.publicKeyPath == "" {
+		return nil, fmt.Errorf("%s proxy rotatekey: argument --public_key is required", os.Args[0])
+	}
+
+	return &cmd, nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	publicKey, err := ioutil.ReadFile(c.publicKeyPath)
+	if err != nil {
+		return interfaces.StatusCodeExitWithError,
+			fmt.Errorf("Failed to read public key from file %s: %w", c.publicKeyPath, err)
+	}
+
+	var consumer common.KongConsumer
+	if err := c.kong.Request(http.MethodGet, common.ConsumerPath(c.username), nil, &consumer); err != nil {
+		if common.IsNotFound(err) {
+			return interfaces.StatusCodeExitWithError, fmt.Errorf("User %s not found", c.username)
+		}
+		return interfaces.StatusCodeExitWithError, err
+	}
+	if consumer.Expired(time.Now()) {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("User %s expired at %s; extend it with setexpiry first",
+			c.username, consumer.Expiry().Format(time.RFC3339))
+	}
+
+	var existing []common.KongJWTCredential
+	if err := c.kong.List(common.ConsumerPath(c.username, "jwt"), &existing); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	// The new credential is added before the old ones are deleted so the user is never left without one
+	credential := common.KongJWTCredential{
+		Key:          c.jwtID, // Kong creates a random key if one is not supplied
+		Algorithm:    c.algorithm,
+		RSAPublicKey: string(publicKey),
+		Secret:       "required-but-not-used-see-documentation",
+	}
+	var created common.KongJWTCredential
+	if err := c.kong.Request(http.MethodPost, common.ConsumerPath(c.username, "jwt"), credential, &created); err != nil {
+		return interfaces.StatusCodeExitWithError,
+			fmt.Errorf("Failed to associate new JWT to user %s: %w", c.username, err)
+	}
+	c.loggingClient.Info(fmt.Sprintf("associated new JWT credential %s to user %s", created.Key, c.username))
+
+	result := rotation{Username: c.username, Key: created.Key, RemovedKeys: []string{}}
+	if !c.keepOld {
+		for _, old := range existing {
+			err := c.kong.Request(http.MethodDelete, common.ConsumerPath(c.username, "jwt", old.ID), nil, nil)
+			if err != nil && !common.IsNotFound(err) {
+				return interfaces.StatusCodeExitWithError,
+					fmt.Errorf("Failed to delete JWT credential %s of user %s: %w", old.Key, c.username, err)
+			}
+			c.loggingClient.Info(fmt.Sprintf("deleted JWT credential %s of user %s", old.Key, c.username))
+			result.RemovedKeys = append(result.RemovedKeys, old.Key)
+		}
+	}
+
+	if c.jsonOutput {
+		if err := common.PrintJSON(result); err != nil {
+			return interfaces.StatusCodeExitWithError, err
+		}
+		return interfaces.StatusCodeExitNormal, nil
+	}
+	fmt.Printf("%s\n", result.Key)
+	return interfaces.StatusCodeExitNormal, nil
+}
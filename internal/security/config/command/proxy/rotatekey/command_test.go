@@ -0,0 +1,160 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package rotatekey
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPublicKey = "-----BEGIN PUBLIC KEY-----\ntest\n-----END PUBLIC KEY-----\n"
+
+// fakeKong holds the tags and JWT credentials of the consumer someuser
+type fakeKong struct {
+	tags        []string
+	credentials map[string]map[string]interface{}
+	calls       []string
+}
+
+func (k *fakeKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.calls = append(k.calls, r.Method+" "+r.URL.Path)
+	switch {
+	case r.URL.Path == "/consumers/someuser" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "someuser-id", "username": "someuser", "tags": k.tags})
+	case r.URL.Path == "/consumers/someuser/jwt" && r.Method == http.MethodGet:
+		data := []interface{}{}
+		for _, credential := range k.credentials {
+			data = append(data, credential)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	case r.URL.Path == "/consumers/someuser/jwt" && r.Method == http.MethodPost:
+		var credential map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&credential)
+		if credential["key"] == nil {
+			credential["key"] = "generated-key"
+		}
+		credential["id"] = "jwt-new"
+		k.credentials["jwt-new"] = credential
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(credential)
+	case r.URL.Path == "/consumers/someuser/jwt/jwt-old" && r.Method == http.MethodDelete:
+		delete(k.credentials, "jwt-old")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func runCommand(t *testing.T, kong *fakeKong, args ...string) (int, error) {
+	ts := httptest.NewServer(kong)
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	configuration.KongURL.Server = tsURL.Hostname()
+	configuration.KongURL.AdminPort, _ = strconv.Atoi(tsURL.Port())
+
+	publicKey := filepath.Join(t.TempDir(), "key.pub")
+	require.NoError(t, ioutil.WriteFile(publicKey, []byte(testPublicKey), 0600))
+	args = append(args, "--algorithm", "ES256", "--public_key", publicKey)
+
+	command, err := NewCommand(logger.MockLogger{}, configuration, args)
+	require.NoError(t, err)
+	return command.Execute()
+}
+
+func newFakeKong() *fakeKong {
+	return &fakeKong{credentials: map[string]map[string]interface{}{
+		"jwt-old": {"id": "jwt-old", "key": "old-key", "algorithm": "RS256"},
+	}}
+}
+
+func TestRotateKeyBadArg(t *testing.T) {
+	badArgTestcases := [][]string{
+		{},          // missing --user
+		{"-badarg"}, // invalid arg
+		{"--user", "someuser", "--public_key", "key.pub"},                         // missing --algorithm
+		{"--user", "someuser", "--public_key", "key.pub", "--algorithm", "HS256"}, // invalid algorithm
+		{"--user", "someuser", "--algorithm", "RS256"},                            // missing --public_key
+	}
+
+	for _, args := range badArgTestcases {
+		command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, args)
+
+		assert.Error(t, err, "Args: %v", args)
+		assert.Nil(t, command)
+	}
+}
+
+func TestRotateKey(t *testing.T) {
+	kong := newFakeKong()
+
+	code, err := runCommand(t, kong, "--user", "someuser", "--id", "new-key")
+
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	require.Len(t, kong.credentials, 1)
+	assert.Equal(t, "new-key", kong.credentials["jwt-new"]["key"])
+	assert.Equal(t, testPublicKey, kong.credentials["jwt-new"]["rsa_public_key"])
+	// The new credential is created before the old one is deleted
+	assert.Equal(t, []string{
+		"GET /consumers/someuser",
+		"GET /consumers/someuser/jwt",
+		"POST /consumers/someuser/jwt",
+		"DELETE /consumers/someuser/jwt/jwt-old",
+	}, kong.calls)
+}
+
+func TestRotateKeyKeepOld(t *testing.T) {
+	kong := newFakeKong()
+
+	code, err := runCommand(t, kong, "--user", "someuser", "--keep-old", "--json")
+
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	assert.Len(t, kong.credentials, 2)
+	assert.Equal(t, "generated-key", kong.credentials["jwt-new"]["key"])
+}
+
+func TestRotateKeyUnknownUser(t *testing.T) {
+	kong := newFakeKong()
+
+	code, err := runCommand(t, kong, "--user", "nobody")
+
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+	assert.Equal(t, []string{"GET /consumers/nobody"}, kong.calls)
+}
+
+func TestRotateKeyExpiredUser(t *testing.T) {
+	kong := newFakeKong()
+	kong.tags = []string{fmt.Sprintf("%s%d", common.ExpiryTagPrefix, time.Now().Add(-time.Hour).Unix())}
+
+	code, err := runCommand(t, kong, "--user", "someuser")
+
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+	assert.Equal(t, []string{"GET /consumers/someuser"}, kong.calls)
+	assert.Len(t, kong.credentials, 1)
+}
@@ -0,0 +1,131 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package setexpiry
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	CommandName = "setexpiry"
+
+	neverExpires = "never"
+)
+
+type cmd struct {
+	loggingClient logger.LoggingClient
+	kong          common.KongAdmin
+	username      string
+	expiry        time.Time
+	jsonOutput    bool
+}
+
+// expiry is the outcome of setting the expiry of a user as output
+type expiry struct {
+	Username string     `json:"username"`
+	Expires  *time.Time `json:"expires"`
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		kong:          common.NewKongAdmin(secretstoreclient.NewRequestor(lc).Insecure(), configuration),
+	}
+	var dummy string
+	var expires string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.username, "user", "", "Username of the user whose expiry is set")
+	flagSet.StringVar(&expires, "expires", "",
+		"RFC 3339 time or duration from now (such as 720h) at which the user expires, or 'never'")
+	flagSet.BoolVar(&cmd.jsonOutput, "json", false, "Output the result as JSON")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	if cmd.username == "" {
+		return nil, fmt.Errorf("%s proxy setexpiry: argument --user is required", os.Args[0])
+	}
+	if cmd.expiry, err = parseExpiry(expires, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s proxy setexpiry: argument --expires %w", os.Args[0], err)
+	}
+
+	return &cmd, nil
+}
+
+// parseExpiry parses an RFC 3339 time, a positive duration after now or "never", which is the zero time
+func parseExpiry(expires string, now time.Time) (time.Time, error) {
+	switch {
+	case expires == "":
+		return time.Time{}, fmt.Errorf("is required")
+	case expires == neverExpires:
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, expires); err == nil {
+		return t.UTC(), nil
+	}
+	duration, err := time.ParseDuration(expires)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be an RFC 3339 time, a duration or '%s'", neverExpires)
+	}
+	if duration <= 0 {
+		return time.Time{}, fmt.Errorf("duration must be positive")
+	}
+	return now.Add(duration).UTC().Truncate(time.Second), nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	var consumer common.KongConsumer
+	if err := c.kong.Request(http.MethodGet, common.ConsumerPath(c.username), nil, &consumer); err != nil {
+		if common.IsNotFound(err) {
+			return interfaces.StatusCodeExitWithError, fmt.Errorf("User %s not found", c.username)
+		}
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	body := map[string]interface{}{"tags": consumer.TagsWithExpiry(c.expiry)}
+	if err := c.kong.Request(http.MethodPatch, common.ConsumerPath(c.username), body, nil); err != nil {
+		return interfaces.StatusCodeExitWithError,
+			fmt.Errorf("Failed to set the expiry of user %s: %w", c.username, err)
+	}
+
+	result := expiry{Username: c.username}
+	expires := neverExpires
+	if !c.expiry.IsZero() {
+		result.Expires = &c.expiry
+		expires = c.expiry.Format(time.RFC3339)
+	}
+	c.loggingClient.Info(fmt.Sprintf("set the expiry of user %s to %s", c.username, expires))
+
+	if c.jsonOutput {
+		if err := common.PrintJSON(result); err != nil {
+			return interfaces.StatusCodeExitWithError, err
+		}
+		return interfaces.StatusCodeExitNormal, nil
+	}
+	fmt.Printf("%s\n", expires)
+	return interfaces.StatusCodeExitNormal, nil
+}
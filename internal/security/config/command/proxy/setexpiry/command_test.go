@@ -0,0 +1,121 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package setexpiry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKong holds the tags of the consumer someuser
+type fakeKong struct {
+	tags []interface{}
+}
+
+func (k *fakeKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/consumers/someuser" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "id-1", "username": "someuser", "tags": k.tags})
+	case http.MethodPatch:
+		var body map[string][]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		k.tags = body["tags"]
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func runCommand(t *testing.T, kong *fakeKong, args ...string) (int, error) {
+	ts := httptest.NewServer(kong)
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	configuration.KongURL.Server = tsURL.Hostname()
+	configuration.KongURL.AdminPort, _ = strconv.Atoi(tsURL.Port())
+
+	command, err := NewCommand(logger.MockLogger{}, configuration, args)
+	require.NoError(t, err)
+	return command.Execute()
+}
+
+func TestSetExpiryBadArg(t *testing.T) {
+	badArgTestcases := [][]string{
+		{},                     // missing --user
+		{"-badarg"},            // invalid arg
+		{"--user", "someuser"}, // missing --expires
+		{"--user", "someuser", "--expires", "soon"}, // invalid expiry
+		{"--user", "someuser", "--expires", "-24h"}, // negative duration
+	}
+
+	for _, args := range badArgTestcases {
+		command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, args)
+
+		assert.Error(t, err, "Args: %v", args)
+		assert.Nil(t, command)
+	}
+}
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	expiry, err := parseExpiry("2021-06-30T00:00:00+02:00", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 6, 29, 22, 0, 0, 0, time.UTC), expiry)
+
+	expiry, err = parseExpiry("720h", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 3, 31, 12, 0, 0, 0, time.UTC), expiry)
+
+	expiry, err = parseExpiry("never", now)
+	require.NoError(t, err)
+	assert.True(t, expiry.IsZero())
+}
+
+func TestSetExpiry(t *testing.T) {
+	kong := &fakeKong{tags: []interface{}{"team", common.ExpiryTagPrefix + "1"}}
+
+	code, err := runCommand(t, kong, "--user", "someuser", "--expires", "2030-01-01T00:00:00Z", "--json")
+
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	expected := fmt.Sprintf("%s%d", common.ExpiryTagPrefix, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC).Unix())
+	assert.Equal(t, []interface{}{"team", expected}, kong.tags)
+
+	code, err = runCommand(t, kong, "--user", "someuser", "--expires", "never")
+
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	assert.Equal(t, []interface{}{"team"}, kong.tags)
+}
+
+func TestSetExpiryUnknownUser(t *testing.T) {
+	code, err := runCommand(t, &fakeKong{}, "--user", "nobody", "--expires", "24h")
+
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+}
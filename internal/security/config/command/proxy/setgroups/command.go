@@ -0,0 +1,136 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package setgroups
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/proxy/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	CommandName = "setgroups"
+)
+
+type cmd struct {
+	loggingClient logger.LoggingClient
+	kong          common.KongAdmin
+	username      string
+	groups        []string
+	jsonOutput    bool
+}
+
+// membership is the outcome of setting the groups of a user as output
+type membership struct {
+	Username string   `json:"username"`
+	Groups   []string `json:"groups"`
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		kong:          common.NewKongAdmin(secretstoreclient.NewRequestor(lc).Insecure(), configuration),
+	}
+	var dummy string
+	var groups string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.username, "user", "", "Username of the user whose groups are set")
+	flagSet.StringVar(&groups, "groups", "", "Comma-separated groups the user belongs to, replacing its current groups")
+	flagSet.BoolVar(&cmd.jsonOutput, "json", false, "Output the result as JSON")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	if cmd.username == "" {
+		return nil, fmt.Errorf("%s proxy setgroups: argument --user is required", os.Args[0])
+	}
+	unique := make(map[string]bool)
+	for _, group := range strings.Split(groups, ",") {
+		if group = strings.TrimSpace(group); group != "" && !unique[group] {
+			unique[group] = true
+			cmd.groups = append(cmd.groups, group)
+		}
+	}
+	if len(cmd.groups) == 0 {
+		return nil, fmt.Errorf("%s proxy setgroups: argument --groups requires at least one group", os.Args[0])
+	}
+	sort.Strings(cmd.groups)
+
+	return &cmd, nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	var existing []common.KongACLGroup
+	if err := c.kong.List(common.ConsumerPath(c.username, "acls"), &existing); err != nil {
+		if common.IsNotFound(err) {
+			return interfaces.StatusCodeExitWithError, fmt.Errorf("User %s not found", c.username)
+		}
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	result := membership{Username: c.username, Groups: c.groups, Added: []string{}, Removed: []string{}}
+	wanted := make(map[string]bool)
+	for _, group := range c.groups {
+		wanted[group] = true
+	}
+	have := make(map[string]bool)
+	for _, group := range existing {
+		have[group.Group] = true
+		if wanted[group.Group] {
+			continue
+		}
+		err := c.kong.Request(http.MethodDelete, common.ConsumerPath(c.username, "acls", group.ID), nil, nil)
+		if err != nil && !common.IsNotFound(err) {
+			return interfaces.StatusCodeExitWithError,
+				fmt.Errorf("Failed to remove user %s from group %s: %w", c.username, group.Group, err)
+		}
+		c.loggingClient.Info(fmt.Sprintf("removed user %s from group %s", c.username, group.Group))
+		result.Removed = append(result.Removed, group.Group)
+	}
+	for _, group := range c.groups {
+		if have[group] {
+			continue
+		}
+		body := map[string]string{"group": group}
+		if err := c.kong.Request(http.MethodPost, common.ConsumerPath(c.username, "acls"), body, nil); err != nil {
+			return interfaces.StatusCodeExitWithError,
+				fmt.Errorf("Failed to associate user %s to group %s: %w", c.username, group, err)
+		}
+		c.loggingClient.Info(fmt.Sprintf("associated user %s to group %s", c.username, group))
+		result.Added = append(result.Added, group)
+	}
+	sort.Strings(result.Removed)
+
+	if c.jsonOutput {
+		if err := common.PrintJSON(result); err != nil {
+			return interfaces.StatusCodeExitWithError, err
+		}
+		return interfaces.StatusCodeExitNormal, nil
+	}
+	fmt.Printf("%s\n", strings.Join(result.Groups, ","))
+	return interfaces.StatusCodeExitNormal, nil
+}
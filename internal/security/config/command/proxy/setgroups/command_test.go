@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package setgroups
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKong holds the ACL groups of the consumer someuser, keyed by ID
+type fakeKong struct {
+	groups map[string]string
+}
+
+func (k *fakeKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const aclsPath = "/consumers/someuser/acls"
+	switch {
+	case r.URL.Path == aclsPath && r.Method == http.MethodGet:
+		data := []interface{}{}
+		for id, group := range k.groups {
+			data = append(data, map[string]string{"id": id, "group": group})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	case r.URL.Path == aclsPath && r.Method == http.MethodPost:
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		k.groups["acl-"+body["group"]] = body["group"]
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(r.URL.Path, aclsPath+"/") && r.Method == http.MethodDelete:
+		delete(k.groups, strings.TrimPrefix(r.URL.Path, aclsPath+"/"))
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (k *fakeKong) groupNames() []string {
+	var names []string
+	for _, group := range k.groups {
+		names = append(names, group)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runCommand(t *testing.T, kong *fakeKong, args ...string) (int, error) {
+	ts := httptest.NewServer(kong)
+	defer ts.Close()
+	tsURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	configuration.KongURL.Server = tsURL.Hostname()
+	configuration.KongURL.AdminPort, _ = strconv.Atoi(tsURL.Port())
+
+	command, err := NewCommand(logger.MockLogger{}, configuration, args)
+	require.NoError(t, err)
+	return command.Execute()
+}
+
+func TestSetGroupsBadArg(t *testing.T) {
+	badArgTestcases := [][]string{
+		{},                     // missing --user
+		{"-badarg"},            // invalid arg
+		{"--user", "someuser"}, // missing --groups
+		{"--user", "someuser", "--groups", " , "}, // no groups
+	}
+
+	for _, args := range badArgTestcases {
+		command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, args)
+
+		assert.Error(t, err, "Args: %v", args)
+		assert.Nil(t, command)
+	}
+}
+
+func TestSetGroups(t *testing.T) {
+	kong := &fakeKong{groups: map[string]string{"acl-1": "admin", "acl-2": "legacy"}}
+
+	code, err := runCommand(t, kong, "--user", "someuser", "--groups", "rules, admin,rules", "--json")
+
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	assert.Equal(t, []string{"admin", "rules"}, kong.groupNames())
+	assert.Contains(t, kong.groups, "acl-1", "unchanged groups are kept")
+}
+
+func TestSetGroupsUnknownUser(t *testing.T) {
+	kong := &fakeKong{groups: map[string]string{}}
+
+	code, err := runCommand(t, kong, "--user", "nobody", "--groups", "admin")
+
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+}
@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package backup
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+const (
+	CommandName string = "backup"
+)
+
+type cmd struct {
+	loggingClient  logger.LoggingClient
+	fileOpener     fileioperformer.FileIoPerformer
+	client         secretstoreclient.SecretStoreClient
+	tokenPath      string
+	secretPath     string
+	outputFile     string
+	passphraseFile string
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		fileOpener:    fileioperformer.NewDefaultFileIoPerformer(),
+	}
+	var dummy string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.tokenPath, "keyfile", configuration.SecretService.TokenPath,
+		"Path of the master key shares file written by security-secretstore-setup")
+	flagSet.StringVar(&cmd.secretPath, "path", "secret/", "Secret store path to back up, recursively")
+	flagSet.StringVar(&cmd.outputFile, "out", "", "File to write the snapshot to")
+	flagSet.StringVar(&cmd.passphraseFile, "passphrase-file", "",
+		"File holding the passphrase the snapshot is encrypted with")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	if cmd.outputFile == "" {
+		return nil, fmt.Errorf("secretstore backup: argument --out is required")
+	}
+	if cmd.passphraseFile == "" {
+		return nil, fmt.Errorf("secretstore backup: argument --passphrase-file is required")
+	}
+	if cmd.tokenPath == "" {
+		return nil, fmt.Errorf("secretstore backup: argument --keyfile is required")
+	}
+	cmd.secretPath = strings.Trim(cmd.secretPath, "/") + "/"
+
+	cmd.client, err = common.NewSecretStoreClient(lc, cmd.fileOpener, configuration.SecretService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmd, nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	passphrase, err := common.ReadPassphrase(c.passphraseFile)
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+	defer copy(passphrase, make([]byte, len(passphrase)))
+
+	var initResponse secretstoreclient.InitResponse
+	if _, err := common.LoadInitResponse(c.fileOpener, c.tokenPath, &initResponse); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	snapshot := common.Snapshot{
+		Created: time.Now().UTC().Format(time.RFC3339),
+		Secrets: make(map[string]map[string]interface{}),
+	}
+	err = common.WithRootToken(c.loggingClient, c.client, &initResponse, func(token string) error {
+		return c.walk(token, c.secretPath, snapshot.Secrets)
+	})
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	data, err := common.MarshalSnapshot(snapshot, passphrase)
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Failed to create the snapshot: %w", err)
+	}
+	if err := ioutil.WriteFile(c.outputFile, data, 0600); err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Failed to write the snapshot: %w", err)
+	}
+
+	fmt.Printf("%d secret(s) under %s written to %s\n", len(snapshot.Secrets), c.secretPath, c.outputFile)
+	return interfaces.StatusCodeExitNormal, nil
+}
+
+// walk reads every secret under folder, which ends in "/", into secrets
+func (c *cmd) walk(token string, folder string, secrets map[string]map[string]interface{}) error {
+	var keys []string
+	code, err := c.client.ListSecrets(token, folder, &keys)
+	if code == http.StatusNotFound {
+		// Vault answers 404 for an empty folder
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Failed to list secrets under %s: %w", folder, err)
+	}
+
+	for _, key := range keys {
+		path := folder + key
+		if strings.HasSuffix(key, "/") {
+			if err := c.walk(token, path, secrets); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var data map[string]interface{}
+		if _, err := c.client.ReadSecret(token, path, &data); err != nil {
+			return fmt.Errorf("Failed to read secret %s: %w", path, err)
+		}
+		secrets[path] = data
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package backup
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupBadArg(t *testing.T) {
+	// Arrange
+	lc := logger.MockLogger{}
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = "res/resp-init.json"
+	badArgTestcases := [][]string{
+		{},                         // missing output file
+		{"-badarg"},                // invalid arg
+		{"--out", "snapshot.json"}, // missing passphrase file
+	}
+
+	for _, args := range badArgTestcases {
+		// Act
+		command, err := NewCommand(lc, config, args)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, command)
+	}
+}
+
+// mockSecretStore answers the listing and reading of secrets from secrets, keyed by path
+func mockSecretStore(mockClient *MockSecretStoreClient, secrets map[string]map[string]interface{}) {
+	mockClient.On("RegenRootToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*string) = "root-token"
+		}).
+		Return(nil)
+	mockClient.On("RevokeSelf", "root-token").Return(http.StatusNoContent, nil)
+
+	folders := map[string][]string{
+		"secret/":                {"edgex/", "empty/"},
+		"secret/edgex/":          {"coredata/", "security-bootstrap-redis"},
+		"secret/edgex/coredata/": {"redisdb"},
+	}
+	for path, keys := range folders {
+		keys := keys
+		mockClient.On("ListSecrets", "root-token", path, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(2).(*[]string) = keys
+			}).
+			Return(http.StatusOK, nil)
+	}
+	// Vault answers 404 for an empty folder
+	mockClient.On("ListSecrets", "root-token", "secret/empty/", mock.Anything).
+		Return(http.StatusNotFound, errors.New("not found"))
+	for path, data := range secrets {
+		data := data
+		mockClient.On("ReadSecret", "root-token", path, mock.Anything).
+			Run(func(args mock.Arguments) {
+				*args.Get(2).(*map[string]interface{}) = data
+			}).
+			Return(http.StatusOK, nil)
+	}
+}
+
+func TestBackup(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte(`{"keys":["6b6579"],"keys_base64":["a2V5"]}`), 0600))
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("passphrase\n"), 0600))
+	outputFile := filepath.Join(dir, "snapshot.json")
+
+	secrets := map[string]map[string]interface{}{
+		"secret/edgex/coredata/redisdb":         {"username": "core", "password": "hunter2"},
+		"secret/edgex/security-bootstrap-redis": {"password": "admin"},
+	}
+	mockClient := &MockSecretStoreClient{}
+	mockSecretStore(mockClient, secrets)
+
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = tokenPath
+	command, err := NewCommand(logger.MockLogger{}, config,
+		[]string{"--out", outputFile, "--passphrase-file", passphraseFile})
+	require.NoError(t, err)
+	command.(*cmd).client = mockClient
+
+	// Act
+	code, err := command.Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	mockClient.AssertExpectations(t)
+
+	data, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	var snapshot common.Snapshot
+	require.NoError(t, common.UnmarshalSnapshot(data, []byte("passphrase"), false, &snapshot))
+	assert.Equal(t, secrets, snapshot.Secrets)
+}
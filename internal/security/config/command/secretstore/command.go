@@ -0,0 +1,58 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstore
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/backup"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/rekey"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/restore"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/status"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/unseal"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	CommandName = "secretstore"
+)
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	var command interfaces.Command
+	var err error
+
+	if len(args) < 1 {
+		return nil, fmt.Errorf("subcommand required (status, unseal, rekey, backup, restore)")
+	}
+
+	commandName := args[0]
+
+	switch commandName {
+	case status.CommandName:
+		command, err = status.NewCommand(lc, configuration, args[1:])
+	case unseal.CommandName:
+		command, err = unseal.NewCommand(lc, configuration, args[1:])
+	case rekey.CommandName:
+		command, err = rekey.NewCommand(lc, configuration, args[1:])
+	case backup.CommandName:
+		command, err = backup.NewCommand(lc, configuration, args[1:])
+	case restore.CommandName:
+		command, err = restore.NewCommand(lc, configuration, args[1:])
+	default:
+		command = nil
+		err = fmt.Errorf("unsupported command %s", commandName)
+	}
+
+	return command, err
+}
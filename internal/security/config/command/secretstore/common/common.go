@@ -0,0 +1,169 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/ikm"
+	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
+	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+// NewSecretStoreClient returns a client of the secret store described by the SecretService configuration,
+// verifying its certificate when a CA certificate is configured.
+func NewSecretStoreClient(
+	lc logger.LoggingClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	secretService config.SecretServiceInfo) (secretstoreclient.SecretStoreClient, error) {
+
+	var req internal.HttpCaller
+	if secretService.CACertPath != "" {
+		caReader, err := fileOpener.OpenFileReader(secretService.CACertPath, os.O_RDONLY, 0400)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA certificate %s: %w", secretService.CACertPath, err)
+		}
+		req = secretstoreclient.NewRequestor(lc).WithTLS(caReader, secretService.Server)
+	} else {
+		req = secretstoreclient.NewRequestor(lc).Insecure()
+	}
+
+	host := fmt.Sprintf("%s:%d", secretService.Server, secretService.Port)
+	return secretstoreclient.NewSecretStoreClient(lc, req, secretService.Protocol, host), nil
+}
+
+// newVMKEncryption returns the vault master key encryption of the init response at path, with the input key
+// material loaded from the provider selected by IKM_PROVIDER or IKM_HOOK.  The caller must wipe the key material
+// when done.
+func newVMKEncryption(fileOpener fileioperformer.FileIoPerformer, path string) (*secretstore.VMKEncryption, error) {
+	provider, err := ikm.NewProviderFromEnv(fileOpener, filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("the key shares in %s are encrypted but neither %s nor %s is set",
+			path, ikm.ProviderEnvVar, ikm.HookEnvVar)
+	}
+	vmkEncryption := secretstore.NewVMKEncryption(
+		fileOpener,
+		pipedhexreader.NewPipedHexReader(),
+		kdf.NewKdf(fileOpener, filepath.Dir(path), sha256.New))
+	if err := vmkEncryption.LoadIKMFromProvider(provider); err != nil {
+		return nil, err
+	}
+	return vmkEncryption, nil
+}
+
+// LoadInitResponse reads the init response saved by security-secretstore-setup at path, decrypting its key
+// shares when they were saved with vault master key encryption.  It returns whether they were encrypted.
+func LoadInitResponse(
+	fileOpener fileioperformer.FileIoPerformer,
+	path string,
+	initResponse *secretstoreclient.InitResponse) (encrypted bool, err error) {
+
+	reader, err := fileOpener.OpenFileReader(path, os.O_RDONLY, 0400)
+	if err != nil {
+		return false, fmt.Errorf("could not read master key shares file %s: %w", path, err)
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+
+	if err := json.NewDecoder(readCloser).Decode(initResponse); err != nil {
+		return false, fmt.Errorf("unable to parse master key shares file %s: %w", path, err)
+	}
+
+	if len(initResponse.EncryptedKeys) == 0 {
+		if len(initResponse.KeysBase64) == 0 {
+			return false, fmt.Errorf("master key shares file %s holds no key shares; they may be in escrow", path)
+		}
+		return false, nil
+	}
+
+	vmkEncryption, err := newVMKEncryption(fileOpener, path)
+	if err != nil {
+		return true, err
+	}
+	defer vmkEncryption.WipeIKM()
+	if err := vmkEncryption.DecryptInitResponse(initResponse); err != nil {
+		return true, fmt.Errorf("failed to decrypt the key shares in %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// SaveInitResponse replaces the init response at path, encrypting its key shares when encrypt is set.  The file
+// is written next to path first and then renamed over it, so path always holds a complete set of key shares;
+// should the rename fail, the error names the file holding the new key shares.
+func SaveInitResponse(
+	fileOpener fileioperformer.FileIoPerformer,
+	path string,
+	initResponse secretstoreclient.InitResponse,
+	encrypt bool) error {
+
+	if encrypt {
+		vmkEncryption, err := newVMKEncryption(fileOpener, path)
+		if err != nil {
+			return err
+		}
+		defer vmkEncryption.WipeIKM()
+		if err := vmkEncryption.EncryptInitResponse(&initResponse); err != nil {
+			return fmt.Errorf("failed to encrypt the key shares: %w", err)
+		}
+	}
+
+	tempPath := path + ".new"
+	writer, err := fileOpener.OpenFileWriter(tempPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create master key shares file %s: %w", tempPath, err)
+	}
+	if err := json.NewEncoder(writer).Encode(initResponse); err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("unable to write master key shares file %s: %w", tempPath, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("unable to close master key shares file %s: %w", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("unable to replace %s; the new key shares are in %s: %w", path, tempPath, err)
+	}
+	return nil
+}
+
+// WithRootToken generates a root token from the key shares of initResponse, runs action with it and revokes it.
+func WithRootToken(
+	lc logger.LoggingClient,
+	client secretstoreclient.SecretStoreClient,
+	initResponse *secretstoreclient.InitResponse,
+	action func(token string) error) error {
+
+	var rootToken string
+	if err := client.RegenRootToken(initResponse, &rootToken); err != nil {
+		return fmt.Errorf("failed to generate a root token: %w", err)
+	}
+	if rootToken == "" {
+		return errors.New("failed to generate a root token: no token returned")
+	}
+	defer func() {
+		if _, err := client.RevokeSelf(rootToken); err != nil {
+			lc.Warn(fmt.Sprintf("failed to revoke the root token: %s", err.Error()))
+		}
+	}()
+
+	return action(rootToken)
+}
@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package common
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/ikm"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSecretStoreClientMissingCACert(t *testing.T) {
+	_, err := NewSecretStoreClient(logger.MockLogger{}, fileioperformer.NewDefaultFileIoPerformer(),
+		config.SecretServiceInfo{Protocol: "https", Server: "localhost", Port: 8200, CACertPath: "/nonexistent/ca.pem"})
+
+	assert.Error(t, err)
+}
+
+func TestSaveAndLoadInitResponse(t *testing.T) {
+	// Arrange
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+	path := filepath.Join(t.TempDir(), "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"keys":["6f6c64"],"keys_base64":["b2xk"]}`), 0600))
+	saved := secretstoreclient.InitResponse{Keys: []string{"6e6577"}, KeysBase64: []string{"bmV3"}, RootToken: "root"}
+
+	// Act
+	err := SaveInitResponse(fileOpener, path, saved, false)
+	require.NoError(t, err)
+	var loaded secretstoreclient.InitResponse
+	encrypted, err := LoadInitResponse(fileOpener, path, &loaded)
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+	assert.Equal(t, saved, loaded)
+	_, err = os.Stat(path + ".new")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLoadInitResponseErrors(t *testing.T) {
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+	dir := t.TempDir()
+	require.NoError(t, os.Unsetenv(ikm.ProviderEnvVar))
+	require.NoError(t, os.Unsetenv(ikm.HookEnvVar))
+	testcases := map[string]string{
+		"empty.json":     `{"root_token":"root"}`,
+		"invalid.json":   `{`,
+		"encrypted.json": `{"encrypted_keys":["00"],"nonces":["00"]}`,
+	}
+
+	for name, contents := range testcases {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+
+		var initResponse secretstoreclient.InitResponse
+		_, err := LoadInitResponse(fileOpener, path, &initResponse)
+
+		assert.Error(t, err, name)
+	}
+
+	var initResponse secretstoreclient.InitResponse
+	_, err := LoadInitResponse(fileOpener, filepath.Join(dir, "missing.json"), &initResponse)
+	assert.Error(t, err)
+}
+
+func TestWithRootToken(t *testing.T) {
+	// Arrange
+	initResponse := secretstoreclient.InitResponse{KeysBase64: []string{"a2V5"}}
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("RegenRootToken", &initResponse, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*string) = "root-token"
+		}).
+		Return(nil)
+	mockClient.On("RevokeSelf", "root-token").Return(204, nil)
+
+	// Act
+	var used string
+	err := WithRootToken(logger.MockLogger{}, mockClient, &initResponse, func(token string) error {
+		used = token
+		return errors.New("action failed")
+	})
+
+	// Assert
+	assert.EqualError(t, err, "action failed")
+	assert.Equal(t, "root-token", used)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,145 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package common
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	snapshotVersion = 1
+	snapshotKDF     = "scrypt"
+
+	// scrypt parameters recommended for interactive use in 2017
+	scryptN      = 32768
+	scryptR      = 8
+	scryptP      = 1
+	snapshotSalt = 16
+	snapshotKey  = 32
+)
+
+// ErrPlaintextSnapshot is returned when a snapshot that is not encrypted is read without allowing plaintext
+var ErrPlaintextSnapshot = errors.New("snapshot is not encrypted")
+
+// Snapshot holds the secrets of a secret store, keyed by their path
+type Snapshot struct {
+	Created string                            `json:"created"`
+	Secrets map[string]map[string]interface{} `json:"secrets"`
+}
+
+// encryptedSnapshot is the file format of a snapshot encrypted with AES-256-GCM under a key derived from a passphrase
+type encryptedSnapshot struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func snapshotAEAD(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, snapshotKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the snapshot key: %w", err)
+	}
+	defer copy(key, make([]byte, len(key)))
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// MarshalSnapshot returns the file contents of snapshot, encrypted with passphrase
+func MarshalSnapshot(snapshot Snapshot, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("a passphrase is required to encrypt the snapshot")
+	}
+	plaintext, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	defer copy(plaintext, make([]byte, len(plaintext)))
+
+	file := encryptedSnapshot{Version: snapshotVersion, KDF: snapshotKDF, Salt: make([]byte, snapshotSalt)}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := snapshotAEAD(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return nil, err
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext, nil)
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// UnmarshalSnapshot parses the file contents of a snapshot, decrypting it with passphrase.  A plaintext snapshot,
+// as written by earlier releases, is only accepted when allowPlaintext is set.
+func UnmarshalSnapshot(data []byte, passphrase []byte, allowPlaintext bool, snapshot *Snapshot) error {
+	var file encryptedSnapshot
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to parse snapshot: %w", err)
+	}
+
+	if len(file.Ciphertext) > 0 {
+		if file.Version != snapshotVersion || file.KDF != snapshotKDF {
+			return fmt.Errorf("unsupported snapshot version %d with key derivation %s", file.Version, file.KDF)
+		}
+		if len(passphrase) == 0 {
+			return errors.New("snapshot is encrypted and requires a passphrase")
+		}
+		aead, err := snapshotAEAD(passphrase, file.Salt)
+		if err != nil {
+			return err
+		}
+		if len(file.Nonce) != aead.NonceSize() {
+			return errors.New("snapshot nonce is invalid")
+		}
+		plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+		if err != nil {
+			return errors.New("unable to decrypt snapshot: wrong passphrase or corrupted file")
+		}
+		defer copy(plaintext, make([]byte, len(plaintext)))
+		data = plaintext
+	} else if !allowPlaintext {
+		return ErrPlaintextSnapshot
+	}
+
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return fmt.Errorf("unable to parse snapshot: %w", err)
+	}
+	if snapshot.Secrets == nil {
+		return errors.New("snapshot holds no secrets")
+	}
+	return nil
+}
+
+// ReadPassphrase reads the snapshot passphrase from the first line of file
+func ReadPassphrase(file string) ([]byte, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read passphrase file %s: %w", file, err)
+	}
+	passphrase := bytes.TrimRight(bytes.SplitN(data, []byte("\n"), 2)[0], "\r")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSnapshot() Snapshot {
+	return Snapshot{
+		Created: "2021-03-01T00:00:00Z",
+		Secrets: map[string]map[string]interface{}{
+			"secret/edgex/coredata/redisdb": {"username": "core", "password": "hunter2"},
+		},
+	}
+}
+
+func TestSnapshotPlaintext(t *testing.T) {
+	_, err := MarshalSnapshot(testSnapshot(), nil)
+	assert.Error(t, err, "a passphrase is required")
+
+	data, err := json.Marshal(testSnapshot())
+	require.NoError(t, err)
+	assert.Error(t, UnmarshalSnapshot(data, nil, false, &Snapshot{}), "plaintext snapshot not allowed")
+
+	var snapshot Snapshot
+	require.NoError(t, UnmarshalSnapshot(data, nil, true, &snapshot))
+	assert.Equal(t, testSnapshot(), snapshot)
+}
+
+func TestSnapshotEncrypted(t *testing.T) {
+	data, err := MarshalSnapshot(testSnapshot(), []byte("passphrase"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+
+	var snapshot Snapshot
+	require.NoError(t, UnmarshalSnapshot(data, []byte("passphrase"), false, &snapshot))
+	assert.Equal(t, testSnapshot(), snapshot)
+
+	assert.Error(t, UnmarshalSnapshot(data, nil, true, &Snapshot{}))
+	assert.Error(t, UnmarshalSnapshot(data, []byte("wrong"), false, &Snapshot{}))
+	tampered := strings.Replace(string(data), `"version": 1`, `"version": 2`, 1)
+	assert.Error(t, UnmarshalSnapshot([]byte(tampered), []byte("passphrase"), false, &Snapshot{}))
+}
+
+func TestReadPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(file, []byte("correct horse\r\nignored\n"), 0600))
+
+	passphrase, err := ReadPassphrase(file)
+
+	require.NoError(t, err)
+	assert.Equal(t, "correct horse", string(passphrase))
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("\n"), 0600))
+	_, err = ReadPassphrase(file)
+	assert.Error(t, err)
+}
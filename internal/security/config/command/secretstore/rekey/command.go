@@ -0,0 +1,124 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package rekey
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+const (
+	CommandName string = "rekey"
+)
+
+type cmd struct {
+	loggingClient logger.LoggingClient
+	fileOpener    fileioperformer.FileIoPerformer
+	client        secretstoreclient.SecretStoreClient
+	tokenPath     string
+	shares        int
+	threshold     int
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		fileOpener:    fileioperformer.NewDefaultFileIoPerformer(),
+	}
+	var dummy string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.tokenPath, "keyfile", configuration.SecretService.TokenPath,
+		"Path of the master key shares file written by security-secretstore-setup")
+	flagSet.IntVar(&cmd.shares, "shares", 0, "Number of new key shares; defaults to the current number")
+	flagSet.IntVar(&cmd.threshold, "threshold", 0,
+		"Number of new key shares required to unseal; defaults to the current threshold")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	if cmd.tokenPath == "" {
+		return nil, fmt.Errorf("secretstore rekey: argument --keyfile is required")
+	}
+	if cmd.shares < 0 || cmd.threshold < 0 {
+		return nil, fmt.Errorf("secretstore rekey: --shares and --threshold must be positive")
+	}
+
+	cmd.client, err = common.NewSecretStoreClient(lc, cmd.fileOpener, configuration.SecretService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmd, nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	code, err := c.client.HealthCheck()
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Unable to reach the secret store: %w", err)
+	}
+	if code != http.StatusOK {
+		return interfaces.StatusCodeExitWithError,
+			fmt.Errorf("secret store must be unsealed and active to rekey (health check status %d)", code)
+	}
+
+	var sealStatus secretstoreclient.SealStatusResponse
+	if _, err := c.client.SealStatus(&sealStatus); err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Unable to read the seal status: %w", err)
+	}
+	shares, threshold := c.shares, c.threshold
+	if shares == 0 {
+		shares = sealStatus.N
+	}
+	if threshold == 0 {
+		threshold = sealStatus.T
+	}
+	if threshold < 1 || threshold > shares {
+		return interfaces.StatusCodeExitWithError,
+			fmt.Errorf("key threshold %d must be between 1 and the number of key shares %d", threshold, shares)
+	}
+	if shares > 1 && threshold == 1 {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("key threshold must be greater than 1 for multiple shares")
+	}
+
+	var initResponse secretstoreclient.InitResponse
+	encrypted, err := common.LoadInitResponse(c.fileOpener, c.tokenPath, &initResponse)
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	var rekeyResponse secretstoreclient.InitResponse
+	if err := c.client.Rekey(&initResponse, shares, threshold, &rekeyResponse); err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Failed to rekey the secret store: %w", err)
+	}
+
+	// The previous key shares are no longer valid from here on, so the new ones must not be lost
+	if err := common.SaveInitResponse(c.fileOpener, c.tokenPath, rekeyResponse, encrypted); err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("secret store was rekeyed but saving the key shares failed: %w", err)
+	}
+
+	c.loggingClient.Info(fmt.Sprintf("secret store rekeyed with %d key shares and threshold %d", shares, threshold))
+	fmt.Printf("secret store rekeyed: %d key shares, threshold %d, written to %s\n", shares, threshold, c.tokenPath)
+	return interfaces.StatusCodeExitNormal, nil
+}
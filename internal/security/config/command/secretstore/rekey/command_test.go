@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package rekey
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommand(t *testing.T, mockClient *MockSecretStoreClient, tokenPath string, args ...string) *cmd {
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = tokenPath
+	command, err := NewCommand(logger.MockLogger{}, config, args)
+	require.NoError(t, err)
+	command.(*cmd).client = mockClient
+	return command.(*cmd)
+}
+
+func mockSealStatus(mockClient *MockSecretStoreClient, shares int, threshold int) {
+	mockClient.On("HealthCheck").Return(http.StatusOK, nil)
+	mockClient.On("SealStatus", mock.Anything).
+		Run(func(args mock.Arguments) {
+			status := args.Get(0).(*secretstoreclient.SealStatusResponse)
+			status.Initialized, status.N, status.T = true, shares, threshold
+		}).
+		Return(http.StatusOK, nil)
+}
+
+func TestRekeyBadArg(t *testing.T) {
+	// Arrange
+	lc := logger.MockLogger{}
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = "res/resp-init.json"
+	badArgTestcases := [][]string{
+		{"-badarg"},          // invalid arg
+		{"--shares", "-1"},   // negative shares
+		{"--threshold", "x"}, // not a number
+	}
+
+	for _, args := range badArgTestcases {
+		// Act
+		command, err := NewCommand(lc, config, args)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, command)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	// Arrange
+	tokenPath := filepath.Join(t.TempDir(), "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(tokenPath,
+		[]byte(`{"keys":["6f6c64"],"keys_base64":["b2xk"],"root_token":"root"}`), 0600))
+	mockClient := &MockSecretStoreClient{}
+	mockSealStatus(mockClient, 1, 1)
+	rekeyed := secretstoreclient.InitResponse{
+		Keys:       []string{"6e657731", "6e657732", "6e657733"},
+		KeysBase64: []string{"bmV3MQ==", "bmV3Mg==", "bmV3Mw=="},
+		RootToken:  "root",
+	}
+	mockClient.On("Rekey", mock.Anything, 3, 2, mock.Anything).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, []string{"b2xk"}, args.Get(0).(*secretstoreclient.InitResponse).KeysBase64)
+			*args.Get(3).(*secretstoreclient.InitResponse) = rekeyed
+		}).
+		Return(nil)
+
+	// Act
+	code, err := newTestCommand(t, mockClient, tokenPath, "--shares", "3", "--threshold", "2").Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	mockClient.AssertExpectations(t)
+
+	data, err := ioutil.ReadFile(tokenPath)
+	require.NoError(t, err)
+	var saved secretstoreclient.InitResponse
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, rekeyed, saved)
+}
+
+func TestRekeyInvalidThreshold(t *testing.T) {
+	testcases := map[string][]string{
+		"threshold above shares":     {"--shares", "2", "--threshold", "3"},
+		"threshold of 1 with shares": {"--shares", "3", "--threshold", "1"},
+		"default shares":             {"--threshold", "2"},
+	}
+
+	for name, args := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockClient := &MockSecretStoreClient{}
+			mockSealStatus(mockClient, 1, 1)
+
+			// Act
+			code, err := newTestCommand(t, mockClient, "/nonexistent/resp-init.json", args...).Execute()
+
+			// Assert
+			assert.Error(t, err)
+			assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+			mockClient.AssertNotCalled(t, "Rekey", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRekeySealed(t *testing.T) {
+	// Arrange
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("HealthCheck").Return(http.StatusServiceUnavailable, nil)
+
+	// Act
+	code, err := newTestCommand(t, mockClient, "/nonexistent/resp-init.json").Execute()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+}
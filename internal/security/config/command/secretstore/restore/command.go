@@ -0,0 +1,133 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package restore
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+const (
+	CommandName string = "restore"
+)
+
+type cmd struct {
+	loggingClient     logger.LoggingClient
+	fileOpener        fileioperformer.FileIoPerformer
+	client            secretstoreclient.SecretStoreClient
+	tokenPath         string
+	inputFile         string
+	passphraseFile    string
+	insecurePlaintext bool
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		fileOpener:    fileioperformer.NewDefaultFileIoPerformer(),
+	}
+	var dummy string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.tokenPath, "keyfile", configuration.SecretService.TokenPath,
+		"Path of the master key shares file written by security-secretstore-setup")
+	flagSet.StringVar(&cmd.inputFile, "in", "", "Snapshot file written by the backup command")
+	flagSet.StringVar(&cmd.passphraseFile, "passphrase-file", "",
+		"File holding the passphrase the snapshot was encrypted with")
+	flagSet.BoolVar(&cmd.insecurePlaintext, "insecure-plaintext", false,
+		"Accept a snapshot that is not encrypted, as written by earlier releases")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	if cmd.inputFile == "" {
+		return nil, fmt.Errorf("secretstore restore: argument --in is required")
+	}
+	if cmd.passphraseFile == "" && !cmd.insecurePlaintext {
+		return nil, fmt.Errorf("secretstore restore: argument --passphrase-file is required")
+	}
+	if cmd.tokenPath == "" {
+		return nil, fmt.Errorf("secretstore restore: argument --keyfile is required")
+	}
+
+	cmd.client, err = common.NewSecretStoreClient(lc, cmd.fileOpener, configuration.SecretService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmd, nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	var passphrase []byte
+	if c.passphraseFile != "" {
+		var err error
+		if passphrase, err = common.ReadPassphrase(c.passphraseFile); err != nil {
+			return interfaces.StatusCodeExitWithError, err
+		}
+		defer copy(passphrase, make([]byte, len(passphrase)))
+	}
+
+	data, err := ioutil.ReadFile(c.inputFile)
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Unable to read snapshot %s: %w", c.inputFile, err)
+	}
+	var snapshot common.Snapshot
+	err = common.UnmarshalSnapshot(data, passphrase, c.insecurePlaintext, &snapshot)
+	if errors.Is(err, common.ErrPlaintextSnapshot) {
+		return interfaces.StatusCodeExitWithError,
+			fmt.Errorf("%s: %w; use --insecure-plaintext to restore it anyway", c.inputFile, err)
+	} else if err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	var initResponse secretstoreclient.InitResponse
+	if _, err := common.LoadInitResponse(c.fileOpener, c.tokenPath, &initResponse); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	// Restore in a stable order so a failure is reported against the same secret on every attempt
+	var paths []string
+	for path := range snapshot.Secrets {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	err = common.WithRootToken(c.loggingClient, c.client, &initResponse, func(token string) error {
+		for _, path := range paths {
+			if _, err := c.client.WriteSecret(token, path, snapshot.Secrets[path]); err != nil {
+				return fmt.Errorf("Failed to restore secret %s: %w", path, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	fmt.Printf("%d secret(s) restored from %s (snapshot of %s)\n", len(paths), c.inputFile, snapshot.Created)
+	return interfaces.StatusCodeExitNormal, nil
+}
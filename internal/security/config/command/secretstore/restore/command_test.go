@@ -0,0 +1,160 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package restore
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreBadArg(t *testing.T) {
+	// Arrange
+	lc := logger.MockLogger{}
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = "res/resp-init.json"
+	badArgTestcases := [][]string{
+		{},                        // missing input file
+		{"-badarg"},               // invalid arg
+		{"--in", "snapshot.json"}, // missing passphrase file
+	}
+
+	for _, args := range badArgTestcases {
+		// Act
+		command, err := NewCommand(lc, config, args)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, command)
+	}
+}
+
+func restoreWithPassphrase(t *testing.T, passphrase string) (*MockSecretStoreClient, int, error) {
+	// Arrange
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte(`{"keys":["6b6579"],"keys_base64":["a2V5"]}`), 0600))
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte(passphrase), 0600))
+
+	inputFile := filepath.Join(dir, "snapshot.json")
+	data, err := common.MarshalSnapshot(common.Snapshot{
+		Created: "2021-03-01T00:00:00Z",
+		Secrets: map[string]map[string]interface{}{
+			"secret/edgex/coredata/redisdb":         {"username": "core", "password": "hunter2"},
+			"secret/edgex/security-bootstrap-redis": {"password": "admin"},
+		},
+	}, []byte("passphrase"))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(inputFile, data, 0600))
+
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("RegenRootToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*string) = "root-token"
+		}).
+		Return(nil)
+	mockClient.On("RevokeSelf", "root-token").Return(http.StatusNoContent, nil)
+	mockClient.On("WriteSecret", "root-token", "secret/edgex/coredata/redisdb",
+		map[string]interface{}{"username": "core", "password": "hunter2"}).Return(http.StatusNoContent, nil)
+	mockClient.On("WriteSecret", "root-token", "secret/edgex/security-bootstrap-redis",
+		map[string]interface{}{"password": "admin"}).Return(http.StatusNoContent, nil)
+
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = tokenPath
+	command, err := NewCommand(logger.MockLogger{}, config,
+		[]string{"--in", inputFile, "--passphrase-file", passphraseFile})
+	require.NoError(t, err)
+	command.(*cmd).client = mockClient
+
+	// Act
+	code, err := command.Execute()
+	return mockClient, code, err
+}
+
+func TestRestore(t *testing.T) {
+	mockClient, code, err := restoreWithPassphrase(t, "passphrase\n")
+
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestRestoreWrongPassphrase(t *testing.T) {
+	mockClient, code, err := restoreWithPassphrase(t, "wrong\n")
+
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+	mockClient.AssertNotCalled(t, "WriteSecret", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestorePlaintext(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte(`{"keys":["6b6579"],"keys_base64":["a2V5"]}`), 0600))
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("passphrase\n"), 0600))
+	inputFile := filepath.Join(dir, "snapshot.json")
+	require.NoError(t, ioutil.WriteFile(inputFile,
+		[]byte(`{"created":"2021-03-01T00:00:00Z","secrets":{"secret/edgex/coredata/redisdb":{"password":"hunter2"}}}`),
+		0600))
+
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("RegenRootToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*string) = "root-token"
+		}).
+		Return(nil)
+	mockClient.On("RevokeSelf", "root-token").Return(http.StatusNoContent, nil)
+	mockClient.On("WriteSecret", "root-token", "secret/edgex/coredata/redisdb",
+		map[string]interface{}{"password": "hunter2"}).Return(http.StatusNoContent, nil)
+
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = tokenPath
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"rejected by default", []string{"--in", inputFile, "--passphrase-file", passphraseFile}, true},
+		{"accepted when insecure", []string{"--in", inputFile, "--insecure-plaintext"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := NewCommand(logger.MockLogger{}, config, tt.args)
+			require.NoError(t, err)
+			command.(*cmd).client = mockClient
+
+			// Act
+			code, err := command.Execute()
+
+			// Assert
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+				mockClient.AssertNotCalled(t, "WriteSecret", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+				mockClient.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package status
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusBadArg(t *testing.T) {
+	// Arrange
+	lc := logger.MockLogger{}
+	config := &config.ConfigurationStruct{}
+
+	// Act
+	command, err := NewCommand(lc, config, []string{"-badarg"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, command)
+}
+
+func TestStatus(t *testing.T) {
+	testcases := map[string]struct {
+		healthCode int
+		sealed     bool
+		exitCode   int
+	}{
+		"active":  {http.StatusOK, false, interfaces.StatusCodeExitNormal},
+		"standby": {http.StatusTooManyRequests, false, interfaces.StatusCodeExitNormal},
+		"sealed":  {http.StatusServiceUnavailable, true, interfaces.StatusCodeExitWithError},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockClient := &MockSecretStoreClient{}
+			mockClient.On("HealthCheck").Return(tc.healthCode, nil)
+			mockClient.On("SealStatus", mock.Anything).
+				Run(func(args mock.Arguments) {
+					*args.Get(0).(*secretstoreclient.SealStatusResponse) = secretstoreclient.SealStatusResponse{
+						Initialized: true, Sealed: tc.sealed, T: 3, N: 5,
+					}
+				}).
+				Return(http.StatusOK, nil)
+			command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, []string{})
+			require.NoError(t, err)
+			command.(*cmd).client = mockClient
+
+			// Act
+			code, err := command.Execute()
+
+			// Assert
+			assert.Equal(t, tc.exitCode, code)
+			assert.Equal(t, tc.exitCode != interfaces.StatusCodeExitNormal, err != nil)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package unseal
+
+import (
+	"crypto/rsa"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/command/secretstore/common"
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+const (
+	CommandName string = "unseal"
+)
+
+type cmd struct {
+	loggingClient logger.LoggingClient
+	fileOpener    fileioperformer.FileIoPerformer
+	client        secretstoreclient.SecretStoreClient
+	tokenPath     string
+	escrowFiles   []string
+	escrowKeys    []string
+}
+
+func NewCommand(
+	lc logger.LoggingClient,
+	configuration *config.ConfigurationStruct,
+	args []string) (interfaces.Command, error) {
+
+	cmd := cmd{
+		loggingClient: lc,
+		fileOpener:    fileioperformer.NewDefaultFileIoPerformer(),
+	}
+	var dummy string
+
+	flagSet := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flagSet.StringVar(&dummy, "confdir", "", "") // handled by bootstrap; duplicated here to prevent arg parsing errors
+
+	flagSet.StringVar(&cmd.tokenPath, "keyfile", configuration.SecretService.TokenPath,
+		"Path of the master key shares file written by security-secretstore-setup")
+	var escrowFiles, escrowKeys string
+	flagSet.StringVar(&escrowFiles, "escrow-files", "",
+		"Comma-separated paths of key share escrow files to unseal with instead of the key file")
+	flagSet.StringVar(&escrowKeys, "escrow-keys", "",
+		"Comma-separated paths of the PEM-encoded RSA private keys of escrow recipients")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse command: %s: %w", strings.Join(args, " "), err)
+	}
+	cmd.escrowFiles = splitPaths(escrowFiles)
+	cmd.escrowKeys = splitPaths(escrowKeys)
+	if len(cmd.escrowFiles) > 0 && len(cmd.escrowKeys) == 0 {
+		return nil, fmt.Errorf("secretstore unseal: argument --escrow-keys is required with --escrow-files")
+	}
+	if len(cmd.escrowKeys) > 0 && len(cmd.escrowFiles) == 0 {
+		return nil, fmt.Errorf("secretstore unseal: argument --escrow-files is required with --escrow-keys")
+	}
+	if cmd.tokenPath == "" && len(cmd.escrowFiles) == 0 {
+		return nil, fmt.Errorf("secretstore unseal: argument --keyfile is required")
+	}
+
+	cmd.client, err = common.NewSecretStoreClient(lc, cmd.fileOpener, configuration.SecretService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmd, nil
+}
+
+func (c *cmd) Execute() (int, error) {
+	code, err := c.client.HealthCheck()
+	if err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Unable to reach the secret store: %w", err)
+	}
+
+	switch code {
+	case http.StatusOK, http.StatusTooManyRequests:
+		c.loggingClient.Info("secret store is already unsealed")
+		return interfaces.StatusCodeExitNormal, nil
+	case http.StatusNotImplemented:
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("secret store is not initialized")
+	}
+
+	var initResponse secretstoreclient.InitResponse
+	if len(c.escrowFiles) > 0 {
+		if err := c.recoverKeyShares(&initResponse); err != nil {
+			return interfaces.StatusCodeExitWithError, err
+		}
+	} else if _, err := common.LoadInitResponse(c.fileOpener, c.tokenPath, &initResponse); err != nil {
+		return interfaces.StatusCodeExitWithError, err
+	}
+
+	if _, err := c.client.Unseal(&initResponse); err != nil {
+		return interfaces.StatusCodeExitWithError, fmt.Errorf("Failed to unseal the secret store: %w", err)
+	}
+
+	fmt.Println("secret store unsealed")
+	return interfaces.StatusCodeExitNormal, nil
+}
+
+// recoverKeyShares decrypts the key shares of the escrow files whose recipient's private key was given
+func (c *cmd) recoverKeyShares(initResponse *secretstoreclient.InitResponse) error {
+	var files []secretstore.EscrowFile
+	for _, path := range c.escrowFiles {
+		file, err := secretstore.LoadEscrowFile(c.fileOpener, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+
+	var privateKeys []*rsa.PrivateKey
+	for _, path := range c.escrowKeys {
+		privateKey, err := secretstore.LoadRSAPrivateKey(c.fileOpener, path)
+		if err != nil {
+			return err
+		}
+		privateKeys = append(privateKeys, privateKey)
+	}
+
+	return secretstore.RecoverKeyShares(files, privateKeys, initResponse)
+}
+
+// splitPaths splits a comma-separated list of paths, ignoring empty entries
+func splitPaths(list string) []string {
+	var paths []string
+	for _, path := range strings.Split(list, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
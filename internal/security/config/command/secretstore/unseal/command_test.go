@@ -0,0 +1,194 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package unseal
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/config/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommand(t *testing.T, mockClient *MockSecretStoreClient, tokenPath string) *cmd {
+	config := &config.ConfigurationStruct{}
+	config.SecretService.TokenPath = tokenPath
+	command, err := NewCommand(logger.MockLogger{}, config, []string{})
+	require.NoError(t, err)
+	command.(*cmd).client = mockClient
+	return command.(*cmd)
+}
+
+func TestUnsealBadArg(t *testing.T) {
+	// Arrange
+	lc := logger.MockLogger{}
+	config := &config.ConfigurationStruct{}
+	badArgTestcases := [][]string{
+		{},                           // missing key file
+		{"-badarg"},                  // invalid arg
+		{"--escrow-files", "a.json"}, // missing escrow keys
+		{"--keyfile", "k.json", "--escrow-keys", "a.pem"}, // missing escrow files
+	}
+
+	for _, args := range badArgTestcases {
+		// Act
+		command, err := NewCommand(lc, config, args)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, command)
+	}
+}
+
+func TestUnseal(t *testing.T) {
+	// Arrange
+	tokenPath := filepath.Join(t.TempDir(), "resp-init.json")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte(`{"keys":["6b6579"],"keys_base64":["a2V5"]}`), 0600))
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("HealthCheck").Return(http.StatusServiceUnavailable, nil)
+	mockClient.On("Unseal", &secretstoreclient.InitResponse{Keys: []string{"6b6579"}, KeysBase64: []string{"a2V5"}}).
+		Return(http.StatusOK, nil)
+
+	// Act
+	code, err := newTestCommand(t, mockClient, tokenPath).Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUnsealEscrow(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	initResponse := secretstoreclient.InitResponse{
+		Keys:       []string{"6b6531", "6b6532", "6b6533"},
+		KeysBase64: []string{"a2Ux", "a2Uy", "a2Uz"},
+	}
+	var privateKeys []*rsa.PrivateKey
+	var publicKeys []*rsa.PublicKey
+	for i := 0; i < 3; i++ {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		privateKeys = append(privateKeys, key)
+		publicKeys = append(publicKeys, &key.PublicKey)
+	}
+	files, err := secretstore.EscrowKeyShares(initResponse, 2, publicKeys)
+	require.NoError(t, err)
+	var filePaths, keyPaths []string
+	for i := range files {
+		contents, err := json.Marshal(files[i])
+		require.NoError(t, err)
+		filePath := filepath.Join(dir, "escrow"+string(rune('a'+i))+".json")
+		require.NoError(t, ioutil.WriteFile(filePath, contents, 0600))
+		filePaths = append(filePaths, filePath)
+		keyPath := filepath.Join(dir, "recipient"+string(rune('a'+i))+".pem")
+		require.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{
+			Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKeys[i])}), 0600))
+		keyPaths = append(keyPaths, keyPath)
+	}
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("HealthCheck").Return(http.StatusServiceUnavailable, nil)
+	mockClient.On("Unseal", &secretstoreclient.InitResponse{Keys: []string{"6b6531", "6b6533"},
+		KeysBase64: []string{"a2Ux", "a2Uz"}}).Return(http.StatusOK, nil)
+
+	// Only the first and last recipients take part
+	command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, []string{
+		"--escrow-files", filePaths[0] + "," + filePaths[2],
+		"--escrow-keys", keyPaths[0] + "," + keyPaths[2],
+	})
+	require.NoError(t, err)
+	command.(*cmd).client = mockClient
+
+	// Act
+	code, err := command.Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUnsealEscrowBelowThreshold(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	other, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	files, err := secretstore.EscrowKeyShares(secretstoreclient.InitResponse{
+		Keys:       []string{"6b6531", "6b6532", "6b6533"},
+		KeysBase64: []string{"a2Ux", "a2Uy", "a2Uz"},
+	}, 3, []*rsa.PublicKey{&key.PublicKey, &other.PublicKey})
+	require.NoError(t, err)
+	contents, err := json.Marshal(files[1])
+	require.NoError(t, err)
+	filePath := filepath.Join(dir, "escrow.json")
+	require.NoError(t, ioutil.WriteFile(filePath, contents, 0600))
+	keyPath := filepath.Join(dir, "recipient.pem")
+	require.NoError(t, ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(other)}), 0600))
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("HealthCheck").Return(http.StatusServiceUnavailable, nil)
+
+	command, err := NewCommand(logger.MockLogger{}, &config.ConfigurationStruct{}, []string{
+		"--escrow-files", filePath, "--escrow-keys", keyPath,
+	})
+	require.NoError(t, err)
+	command.(*cmd).client = mockClient
+
+	// Act
+	code, err := command.Execute()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+	mockClient.AssertNotCalled(t, "Unseal", mock.Anything)
+}
+
+func TestUnsealAlreadyUnsealed(t *testing.T) {
+	// Arrange
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("HealthCheck").Return(http.StatusOK, nil)
+
+	// Act
+	code, err := newTestCommand(t, mockClient, "/nonexistent/resp-init.json").Execute()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitNormal, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUnsealNotInitialized(t *testing.T) {
+	// Arrange
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("HealthCheck").Return(http.StatusNotImplemented, nil)
+
+	// Act
+	code, err := newTestCommand(t, mockClient, "/nonexistent/resp-init.json").Execute()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, interfaces.StatusCodeExitWithError, code)
+}
@@ -42,6 +42,14 @@ type TokenFileProviderInfo struct {
 	OutputDir string
 	// File name for token file (default: secrets-token.json)
 	OutputFilename string
+	// Keep running after creating tokens and renew them before they expire (default: false)
+	Daemon bool
+	// Interval between token renewal checks in daemon mode (default: 1m)
+	RenewInterval string
+	// Renew a token when its remaining TTL drops below this duration (default: 15m)
+	RenewBefore string
+	// Local address of the token status endpoint in daemon mode, such as 127.0.0.1:59841 (default: disabled)
+	StatusAddress string
 }
 
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
@@ -0,0 +1,322 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package fileprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+)
+
+const (
+	defaultRenewInterval = time.Minute
+	defaultRenewBefore   = 15 * time.Minute
+
+	// StatusPath is the path of the token status endpoint
+	StatusPath = "/status"
+)
+
+// TokenStatus is the renewal status of a service token, as reported by the status endpoint
+type TokenStatus struct {
+	Service     string `json:"service"`
+	Accessor    string `json:"accessor"`
+	ExpireTime  string `json:"expire_time,omitempty"`
+	TTL         int    `json:"ttl"`
+	LastCheck   string `json:"last_check,omitempty"`
+	LastRenewal string `json:"last_renewal,omitempty"`
+	Reissued    int    `json:"reissued"`
+	LastError   string `json:"last_error,omitempty"`
+}
+
+// issuedToken is a service token tracked for renewal along with what is needed to reissue it
+type issuedToken struct {
+	serviceConfig ServiceKey
+	parameters    map[string]interface{}
+	status        TokenStatus
+}
+
+// trackToken records the accessor of a newly created service token for renewal
+func (p *fileTokenProvider) trackToken(serviceName string, serviceConfig ServiceKey,
+	createTokenParameters map[string]interface{}, createTokenResponse interface{}) {
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	token, ok := p.tokens[serviceName]
+	if !ok {
+		token = &issuedToken{status: TokenStatus{Service: serviceName}}
+		p.tokens[serviceName] = token
+	}
+	token.serviceConfig = serviceConfig
+	token.parameters = createTokenParameters
+	token.status.Accessor = responseAccessor(createTokenResponse)
+	token.status.ExpireTime = ""
+	token.status.TTL = 0
+}
+
+// responseAccessor returns the token accessor of a create token response, or "" if there is none
+func responseAccessor(createTokenResponse interface{}) string {
+	response, _ := createTokenResponse.(map[string]interface{})
+	auth, _ := response["auth"].(map[string]interface{})
+	accessor, _ := auth["accessor"].(string)
+	return accessor
+}
+
+// StartRenewal renews the privileged token and every service token created by Run in the background until ctx is
+// done, and serves the token status on the configured status address.  It returns an error, without starting
+// anything, if the renewal configuration is invalid or the status address can't be listened on.
+func (p *fileTokenProvider) StartRenewal(ctx context.Context, wg *sync.WaitGroup) error {
+	interval, err := parseDuration(p.tokenConfig.RenewInterval, defaultRenewInterval)
+	if err != nil {
+		return fmt.Errorf("invalid RenewInterval: %w", err)
+	}
+	renewBefore, err := parseDuration(p.tokenConfig.RenewBefore, defaultRenewBefore)
+	if err != nil {
+		return fmt.Errorf("invalid RenewBefore: %w", err)
+	}
+
+	if p.tokenConfig.StatusAddress != "" {
+		listener, err := net.Listen("tcp", p.tokenConfig.StatusAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on status address %s: %w", p.tokenConfig.StatusAddress, err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc(StatusPath, p.statusHandler)
+		server := &http.Server{Handler: mux}
+
+		p.logger.Info(fmt.Sprintf("serving token status on http://%s%s", listener.Addr().String(), StatusPath))
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+				p.logger.Error(fmt.Sprintf("token status endpoint failed: %s", err.Error()))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			_ = server.Close()
+		}()
+	}
+
+	p.logger.Info(fmt.Sprintf("renewing tokens every %s when they expire within %s", interval, renewBefore))
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				p.logger.Info("token renewal stopped")
+				return
+			case <-ticker.C:
+				p.renewTokens(renewBefore)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// parseDuration parses a Go duration string, returning defaultValue for an empty one
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration %s must be positive", value)
+	}
+	return duration, nil
+}
+
+// renewTokens renews the privileged token and each service token whose remaining TTL is below renewBefore,
+// reissuing service tokens that can't be renewed
+func (p *fileTokenProvider) renewTokens(renewBefore time.Duration) {
+	if _, err := p.vaultClient.RenewSelf(p.privilegedToken); err != nil {
+		p.logger.Warn(fmt.Sprintf("failed to renew privileged token: %s", err.Error()))
+	}
+
+	p.mutex.Lock()
+	serviceNames := make([]string, 0, len(p.tokens))
+	for serviceName := range p.tokens {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	p.mutex.Unlock()
+	sort.Strings(serviceNames)
+
+	for _, serviceName := range serviceNames {
+		p.renewToken(serviceName, renewBefore)
+	}
+}
+
+// renewToken renews the token of serviceName if needed, falling back to reissuing it
+func (p *fileTokenProvider) renewToken(serviceName string, renewBefore time.Duration) {
+	p.mutex.Lock()
+	accessor := p.tokens[serviceName].status.Accessor
+	p.mutex.Unlock()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	var metadata secretstoreclient.TokenMetadata
+	_, err := p.vaultClient.LookupAccessor(p.privilegedToken, accessor, &metadata)
+	switch {
+	case err != nil:
+		p.logger.Warn(fmt.Sprintf("failed to look up token of service %s: %s", serviceName, err.Error()))
+		p.reissueToken(serviceName, now)
+		return
+	case !expiresWithin(metadata, renewBefore):
+		p.updateStatus(serviceName, metadata, now, "", nil)
+		return
+	case !metadata.Renewable:
+		p.logger.Info(fmt.Sprintf("token of service %s is not renewable", serviceName))
+		p.reissueToken(serviceName, now)
+		return
+	}
+
+	p.logger.Debug(fmt.Sprintf("renewing token of service %s", serviceName))
+	if _, err := p.vaultClient.RenewAccessor(p.privilegedToken, accessor, ""); err != nil {
+		p.logger.Warn(fmt.Sprintf("failed to renew token of service %s: %s", serviceName, err.Error()))
+		p.reissueToken(serviceName, now)
+		return
+	}
+
+	// Renewal is capped by the token's maximum TTL, so check it actually bought enough time
+	if _, err := p.vaultClient.LookupAccessor(p.privilegedToken, accessor, &metadata); err != nil {
+		p.logger.Warn(fmt.Sprintf("failed to look up renewed token of service %s: %s", serviceName, err.Error()))
+		p.reissueToken(serviceName, now)
+		return
+	}
+	if expiresWithin(metadata, renewBefore) {
+		p.logger.Info(fmt.Sprintf("token of service %s has reached its maximum TTL", serviceName))
+		p.reissueToken(serviceName, now)
+		return
+	}
+	p.updateStatus(serviceName, metadata, now, now, nil)
+}
+
+// expiresWithin returns whether a token expires within duration; tokens with no TTL never expire
+func expiresWithin(metadata secretstoreclient.TokenMetadata, duration time.Duration) bool {
+	return metadata.TTL > 0 && time.Duration(metadata.TTL)*time.Second < duration
+}
+
+// reissueToken creates a new token for serviceName with its original parameters and atomically replaces its token
+// file, so a service never reads a partially written token
+func (p *fileTokenProvider) reissueToken(serviceName string, now string) {
+	p.mutex.Lock()
+	serviceConfig := p.tokens[serviceName].serviceConfig
+	parameters := p.tokens[serviceName].parameters
+	p.mutex.Unlock()
+
+	p.logger.Info(fmt.Sprintf("reissuing token of service %s", serviceName))
+
+	var createTokenResponse interface{}
+	if _, err := p.vaultClient.CreateToken(p.privilegedToken, parameters, &createTokenResponse); err != nil {
+		p.logger.Error(fmt.Sprintf("failed to reissue vault token for service %s: %s", serviceName, err.Error()))
+		p.updateStatus(serviceName, secretstoreclient.TokenMetadata{}, now, "", err)
+		return
+	}
+
+	outputTokenFilename := filepath.Join(p.tokenConfig.OutputDir, serviceName, p.tokenConfig.OutputFilename)
+	tempFilename := outputTokenFilename + ".tmp"
+	if err := p.writeTokenFile(tempFilename, serviceConfig, createTokenResponse); err != nil {
+		p.updateStatus(serviceName, secretstoreclient.TokenMetadata{}, now, "", err)
+		return
+	}
+	if err := p.rename(tempFilename, outputTokenFilename); err != nil {
+		p.logger.Error(fmt.Sprintf("failed to replace token file %s: %s", outputTokenFilename, err.Error()))
+		p.updateStatus(serviceName, secretstoreclient.TokenMetadata{}, now, "", err)
+		return
+	}
+
+	p.trackToken(serviceName, serviceConfig, parameters, createTokenResponse)
+	p.mutex.Lock()
+	p.tokens[serviceName].status.Reissued++
+	p.mutex.Unlock()
+	p.updateStatus(serviceName, secretstoreclient.TokenMetadata{}, now, now, nil)
+}
+
+// updateStatus records the outcome of a renewal check of the token of serviceName.  Token metadata is only recorded
+// when it was looked up, and lastRenewal only when the token was renewed or reissued.
+func (p *fileTokenProvider) updateStatus(serviceName string, metadata secretstoreclient.TokenMetadata,
+	lastCheck string, lastRenewal string, err error) {
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	status := &p.tokens[serviceName].status
+	if metadata.Accessor != "" {
+		status.ExpireTime = metadata.ExpireTime
+		status.TTL = metadata.TTL
+	}
+	status.LastCheck = lastCheck
+	if lastRenewal != "" {
+		status.LastRenewal = lastRenewal
+	}
+	status.LastError = ""
+	if err != nil {
+		status.LastError = err.Error()
+	}
+}
+
+// Status returns the renewal status of every service token, sorted by service name
+func (p *fileTokenProvider) Status() []TokenStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	statuses := make([]TokenStatus, 0, len(p.tokens))
+	for _, token := range p.tokens {
+		statuses = append(statuses, token.status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Service < statuses[j].Service })
+	return statuses
+}
+
+// statusHandler serves the token status as JSON, with status 503 if the last renewal of any token failed
+func (p *fileTokenProvider) statusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	statuses := p.Status()
+	statusCode := http.StatusOK
+	for _, status := range statuses {
+		if status.LastError != "" {
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set(clients.ContentType, clients.ContentTypeJSON)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(struct {
+		Tokens []TokenStatus `json:"tokens"`
+	}{Tokens: statuses})
+}
@@ -0,0 +1,284 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package fileprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	loaderMock "github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/authtokenloader/mocks"
+	fileMock "github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer/mocks"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const oldAccessor = "old-accessor"
+
+// newRenewingProvider returns a provider tracking the token oldAccessor of myservice, and the file renames it makes
+func newRenewingProvider(mockFileIoPerformer *fileMock.FileIoPerformer,
+	mockSecretStoreClient *MockSecretStoreClient) (*fileTokenProvider, *[][]string) {
+
+	p := NewTokenProvider(logger.MockLogger{}, mockFileIoPerformer, &loaderMock.AuthTokenLoader{},
+		mockSecretStoreClient).(*fileTokenProvider)
+	p.SetConfiguration(secretstoreclient.SecretServiceInfo{}, config.TokenFileProviderInfo{
+		OutputDir:      outputDir,
+		OutputFilename: outputFilename,
+	})
+	p.privilegedToken = "fake-priv-token"
+	renames := &[][]string{}
+	p.rename = func(oldpath string, newpath string) error {
+		*renames = append(*renames, []string{oldpath, newpath})
+		return nil
+	}
+	p.trackToken("myservice", ServiceKey{}, makeMetaServiceName("myservice"),
+		map[string]interface{}{"auth": map[string]interface{}{"accessor": oldAccessor}})
+	return p, renames
+}
+
+func onLookupAccessor(mockSecretStoreClient *MockSecretStoreClient, metadata secretstoreclient.TokenMetadata) {
+	mockSecretStoreClient.On("LookupAccessor", "fake-priv-token", oldAccessor, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*secretstoreclient.TokenMetadata) = metadata
+		}).
+		Return(http.StatusOK, nil).Once()
+}
+
+// expectReissue sets up the mocks for reissuing the token of myservice and returns the new token file contents
+func expectReissue(mockFileIoPerformer *fileMock.FileIoPerformer, mockSecretStoreClient *MockSecretStoreClient) *bytes.Buffer {
+	mockSecretStoreClient.On("CreateToken", "fake-priv-token", makeMetaServiceName("myservice"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			setCreateTokenResponse(args.Get(2).(*interface{}))
+		}).
+		Return(http.StatusOK, nil)
+	buffer := new(bytes.Buffer)
+	tempFilename := filepath.Join(outputDir, "myservice", outputFilename) + ".tmp"
+	mockFileIoPerformer.On("OpenFileWriter", tempFilename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600)).
+		Return(&writeCloserBuffer{buffer}, nil)
+	return buffer
+}
+
+func TestRunTracksTokens(t *testing.T) {
+	mockFileIoPerformer := &fileMock.FileIoPerformer{}
+	mockFileIoPerformer.On("OpenFileReader", configFile, os.O_RDONLY, os.FileMode(0400)).
+		Return(strings.NewReader(`{"myservice":{}}`), nil)
+	mockFileIoPerformer.On("MkdirAll", filepath.Join(outputDir, "myservice"), os.FileMode(0700)).Return(nil)
+	mockFileIoPerformer.On("OpenFileWriter", filepath.Join(outputDir, "myservice", outputFilename),
+		os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600)).Return(&writeCloserBuffer{new(bytes.Buffer)}, nil)
+	mockAuthTokenLoader := &loaderMock.AuthTokenLoader{}
+	mockAuthTokenLoader.On("Load", privilegedTokenPath).Return("fake-priv-token", nil)
+	mockSecretStoreClient := &MockSecretStoreClient{}
+	mockSecretStoreClient.On("InstallPolicy", "fake-priv-token", "edgex-service-myservice", "{}").Return(http.StatusNoContent, nil)
+	mockSecretStoreClient.On("CreateToken", "fake-priv-token", makeMetaServiceName("myservice"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			setCreateTokenResponse(args.Get(2).(*interface{}))
+		}).
+		Return(http.StatusOK, nil)
+
+	p := NewTokenProvider(logger.MockLogger{}, mockFileIoPerformer, mockAuthTokenLoader, mockSecretStoreClient)
+	p.SetConfiguration(secretstoreclient.SecretServiceInfo{}, config.TokenFileProviderInfo{
+		PrivilegedTokenPath: privilegedTokenPath,
+		ConfigFile:          configFile,
+		OutputDir:           outputDir,
+		OutputFilename:      outputFilename,
+	})
+
+	err := p.Run()
+
+	require.NoError(t, err)
+	provider := p.(*fileTokenProvider)
+	assert.Equal(t, "fake-priv-token", provider.privilegedToken)
+	assert.Equal(t, []TokenStatus{{Service: "myservice", Accessor: "B6oixijqmeR4bsLOJH88Ska9"}}, provider.Status())
+	assert.Equal(t, makeMetaServiceName("myservice"), provider.tokens["myservice"].parameters)
+}
+
+func TestRenewTokenNotDue(t *testing.T) {
+	mockFileIoPerformer := &fileMock.FileIoPerformer{}
+	mockSecretStoreClient := &MockSecretStoreClient{}
+	mockSecretStoreClient.On("RenewSelf", "fake-priv-token").Return(http.StatusOK, nil)
+	onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{
+		Accessor: oldAccessor, ExpireTime: "2021-03-01T13:00:00Z", TTL: 3600, Renewable: true})
+	p, renames := newRenewingProvider(mockFileIoPerformer, mockSecretStoreClient)
+
+	p.renewTokens(15 * time.Minute)
+
+	mockSecretStoreClient.AssertExpectations(t)
+	assert.Empty(t, *renames)
+	status := p.Status()
+	require.Len(t, status, 1)
+	assert.Equal(t, "myservice", status[0].Service)
+	assert.Equal(t, oldAccessor, status[0].Accessor)
+	assert.Equal(t, "2021-03-01T13:00:00Z", status[0].ExpireTime)
+	assert.Equal(t, 3600, status[0].TTL)
+	assert.NotEmpty(t, status[0].LastCheck)
+	assert.Empty(t, status[0].LastRenewal)
+}
+
+func TestRenewTokenRenewed(t *testing.T) {
+	mockFileIoPerformer := &fileMock.FileIoPerformer{}
+	mockSecretStoreClient := &MockSecretStoreClient{}
+	mockSecretStoreClient.On("RenewSelf", "fake-priv-token").Return(http.StatusOK, nil)
+	onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{Accessor: oldAccessor, TTL: 60, Renewable: true})
+	mockSecretStoreClient.On("RenewAccessor", "fake-priv-token", oldAccessor, "").Return(http.StatusOK, nil)
+	onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{Accessor: oldAccessor, TTL: 3600, Renewable: true})
+	p, renames := newRenewingProvider(mockFileIoPerformer, mockSecretStoreClient)
+
+	p.renewTokens(15 * time.Minute)
+
+	mockSecretStoreClient.AssertExpectations(t)
+	mockFileIoPerformer.AssertExpectations(t)
+	assert.Empty(t, *renames)
+	status := p.Status()[0]
+	assert.Equal(t, oldAccessor, status.Accessor)
+	assert.Equal(t, 3600, status.TTL)
+	assert.NotEmpty(t, status.LastRenewal)
+	assert.Equal(t, 0, status.Reissued)
+	assert.Empty(t, status.LastError)
+}
+
+func TestRenewTokenReissued(t *testing.T) {
+	reissueTestcases := []struct {
+		name  string
+		setup func(mockSecretStoreClient *MockSecretStoreClient)
+	}{
+		{"expired", func(mockSecretStoreClient *MockSecretStoreClient) {
+			mockSecretStoreClient.On("LookupAccessor", "fake-priv-token", oldAccessor, mock.Anything).
+				Return(http.StatusBadRequest, errors.New("invalid accessor"))
+		}},
+		{"not renewable", func(mockSecretStoreClient *MockSecretStoreClient) {
+			onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{Accessor: oldAccessor, TTL: 60})
+		}},
+		{"renewal failed", func(mockSecretStoreClient *MockSecretStoreClient) {
+			onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{Accessor: oldAccessor, TTL: 60, Renewable: true})
+			mockSecretStoreClient.On("RenewAccessor", "fake-priv-token", oldAccessor, "").
+				Return(http.StatusForbidden, errors.New("permission denied"))
+		}},
+		{"maximum TTL", func(mockSecretStoreClient *MockSecretStoreClient) {
+			onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{Accessor: oldAccessor, TTL: 60, Renewable: true})
+			mockSecretStoreClient.On("RenewAccessor", "fake-priv-token", oldAccessor, "").Return(http.StatusOK, nil)
+			onLookupAccessor(mockSecretStoreClient, secretstoreclient.TokenMetadata{Accessor: oldAccessor, TTL: 120, Renewable: true})
+		}},
+	}
+
+	for _, testcase := range reissueTestcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			mockFileIoPerformer := &fileMock.FileIoPerformer{}
+			mockSecretStoreClient := &MockSecretStoreClient{}
+			mockSecretStoreClient.On("RenewSelf", "fake-priv-token").Return(http.StatusOK, nil)
+			testcase.setup(mockSecretStoreClient)
+			buffer := expectReissue(mockFileIoPerformer, mockSecretStoreClient)
+			p, renames := newRenewingProvider(mockFileIoPerformer, mockSecretStoreClient)
+
+			p.renewTokens(15 * time.Minute)
+
+			mockSecretStoreClient.AssertExpectations(t)
+			mockFileIoPerformer.AssertExpectations(t)
+			outputTokenFilename := filepath.Join(outputDir, "myservice", outputFilename)
+			assert.Equal(t, [][]string{{outputTokenFilename + ".tmp", outputTokenFilename}}, *renames)
+			assert.Equal(t, expectedTokenFile("myservice"), buffer.Bytes())
+			status := p.Status()[0]
+			assert.Equal(t, "B6oixijqmeR4bsLOJH88Ska9", status.Accessor)
+			assert.Equal(t, 1, status.Reissued)
+			assert.NotEmpty(t, status.LastRenewal)
+			assert.Empty(t, status.LastError)
+		})
+	}
+}
+
+func TestRenewTokenReissueFailed(t *testing.T) {
+	mockFileIoPerformer := &fileMock.FileIoPerformer{}
+	mockSecretStoreClient := &MockSecretStoreClient{}
+	mockSecretStoreClient.On("RenewSelf", "fake-priv-token").Return(http.StatusForbidden, errors.New("permission denied"))
+	mockSecretStoreClient.On("LookupAccessor", "fake-priv-token", oldAccessor, mock.Anything).
+		Return(http.StatusBadRequest, errors.New("invalid accessor"))
+	mockSecretStoreClient.On("CreateToken", "fake-priv-token", makeMetaServiceName("myservice"), mock.Anything).
+		Return(http.StatusForbidden, errors.New("permission denied"))
+	p, renames := newRenewingProvider(mockFileIoPerformer, mockSecretStoreClient)
+
+	p.renewTokens(15 * time.Minute)
+
+	mockSecretStoreClient.AssertExpectations(t)
+	assert.Empty(t, *renames)
+	status := p.Status()[0]
+	assert.Equal(t, oldAccessor, status.Accessor)
+	assert.Equal(t, "permission denied", status.LastError)
+
+	// The status endpoint reports the failure
+	recorder := httptest.NewRecorder()
+	p.statusHandler(recorder, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	var body struct {
+		Tokens []TokenStatus `json:"tokens"`
+	}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+	assert.Equal(t, []TokenStatus{status}, body.Tokens)
+}
+
+func TestStatusHandler(t *testing.T) {
+	p, _ := newRenewingProvider(&fileMock.FileIoPerformer{}, &MockSecretStoreClient{})
+
+	recorder := httptest.NewRecorder()
+	p.statusHandler(recorder, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"tokens":[{"service":"myservice","accessor":"old-accessor","ttl":0,"reissued":0}]}`,
+		recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	p.statusHandler(recorder, httptest.NewRequest(http.MethodPost, StatusPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestStartRenewal(t *testing.T) {
+	p, _ := newRenewingProvider(&fileMock.FileIoPerformer{}, &MockSecretStoreClient{})
+
+	badConfigTestcases := []config.TokenFileProviderInfo{
+		{RenewInterval: "often"},
+		{RenewInterval: "-1m"},
+		{RenewBefore: "0s"},
+		{StatusAddress: "127.0.0.1:-1"},
+	}
+	for _, tokenConfig := range badConfigTestcases {
+		p.tokenConfig = tokenConfig
+		err := p.StartRenewal(context.Background(), &sync.WaitGroup{})
+		assert.Error(t, err, "Config: %v", tokenConfig)
+	}
+
+	// Renewal and the status endpoint stop when the context is done
+	p.tokenConfig = config.TokenFileProviderInfo{RenewInterval: "1h", StatusAddress: "127.0.0.1:0"}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	require.NoError(t, p.StartRenewal(ctx, wg))
+	cancel()
+	wg.Wait()
+}
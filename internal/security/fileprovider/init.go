@@ -50,7 +50,7 @@ func (b *Bootstrap) ExitCode() int {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the data service.
-func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	cfg := container.ConfigurationFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
@@ -82,6 +82,16 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 	if err != nil {
 		lc.Error(fmt.Sprintf("error occurred generating tokens: %s", err.Error()))
 		b.exitCode = 1
+		return false
+	}
+
+	if cfg.TokenFileProvider.Daemon {
+		if err := fileProvider.StartRenewal(ctx, wg); err != nil {
+			lc.Error(fmt.Sprintf("error occurred starting token renewal: %s", err.Error()))
+			b.exitCode = 1
+			return false
+		}
+		return true // Keep renewing tokens until the service is stopped
 	}
 
 	return false // Tell bootstrap.Run() to exit wait loop and terminate
@@ -17,6 +17,9 @@
 package fileprovider
 
 import (
+	"context"
+	"sync"
+
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 )
@@ -28,4 +31,6 @@ type TokenProvider interface {
 	SetConfiguration(secretConfig secretstoreclient.SecretServiceInfo, tokenConfig config.TokenFileProviderInfo)
 	// Generate tokens
 	Run() error
+	// Renew generated tokens in the background until ctx is done
+	StartRenewal(ctx context.Context, wg *sync.WaitGroup) error
 }
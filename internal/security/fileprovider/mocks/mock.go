@@ -17,6 +17,9 @@
 package mocks
 
 import (
+	"context"
+	"sync"
+
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 
@@ -34,6 +37,13 @@ func (p *MockTokenProvider) Run() error {
 	return arguments.Error(0)
 }
 
+// StartRenewal see interface.go
+func (p *MockTokenProvider) StartRenewal(ctx context.Context, wg *sync.WaitGroup) error {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := p.Called(ctx, wg)
+	return arguments.Error(0)
+}
+
 func (p *MockTokenProvider) SetConfiguration(secretConfig secretstoreclient.SecretServiceInfo, tokenConfig config.TokenFileProviderInfo) {
 	// Boilerplate that returns whatever Mock.On().Returns() is configured for
 	p.Called(secretConfig, tokenConfig)
@@ -17,6 +17,8 @@
 package mocks
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	. "github.com/edgexfoundry/edgex-go/internal/security/fileprovider"
@@ -42,6 +44,16 @@ func TestMockRun(t *testing.T) {
 	p.AssertExpectations(t)
 }
 
+func TestMockStartRenewal(t *testing.T) {
+	p := &MockTokenProvider{}
+	p.On("StartRenewal", context.Background(), &sync.WaitGroup{}).Return(nil)
+
+	err := p.StartRenewal(context.Background(), &sync.WaitGroup{})
+
+	assert.Nil(t, err)
+	p.AssertExpectations(t)
+}
+
 func TestMockSetConfiguration(t *testing.T) {
 	p := &MockTokenProvider{}
 	p.On("SetConfiguration", secretstoreclient.SecretServiceInfo{}, config.TokenFileProviderInfo{})
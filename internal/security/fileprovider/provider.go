@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"github.com/edgexfoundry/edgex-go/internal/security/fileprovider/config"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
@@ -46,6 +47,11 @@ type fileTokenProvider struct {
 	vaultClient   secretstoreclient.SecretStoreClient
 	secretConfig  secretstoreclient.SecretServiceInfo
 	tokenConfig   config.TokenFileProviderInfo
+	// rename atomically replaces a token file, swappable for testing
+	rename          func(oldpath string, newpath string) error
+	privilegedToken string
+	mutex           sync.Mutex
+	tokens          map[string]*issuedToken
 }
 
 // NewTokenProvider creates a new TokenProvider
@@ -58,6 +64,8 @@ func NewTokenProvider(logger logger.LoggingClient,
 		fileOpener:    fileOpener,
 		tokenProvider: tokenProvider,
 		vaultClient:   vaultClient,
+		rename:        os.Rename,
+		tokens:        make(map[string]*issuedToken),
 	}
 }
 
@@ -159,59 +167,71 @@ func (p *fileTokenProvider) Run() error {
 		}
 
 		p.logger.Info(fmt.Sprintf("creating token file %s", outputTokenFilename))
-		writeCloser, err := p.fileOpener.OpenFileWriter(outputTokenFilename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600))
-		if err != nil {
-			p.logger.Error(fmt.Sprintf("failed open token file for writing %s: %s", outputTokenFilename, err.Error()))
+		if err := p.writeTokenFile(outputTokenFilename, serviceConfig, createTokenResponse); err != nil {
 			return err
 		}
-		// writeCloser is writable file -- explicitly close() to ensure we catch errors writing to it
-
-		permissionable, ok := writeCloser.(permissionable)
-		if ok {
-			if serviceConfig.FilePermissions != nil &&
-				(serviceConfig.FilePermissions).ModeOctal != nil {
-				mode, err := strconv.ParseInt(*(serviceConfig.FilePermissions).ModeOctal, 8, 32)
-				if err != nil {
-					_ = writeCloser.Close()
-					p.logger.Error(fmt.Sprintf("invalid file mode %s: %s", *(serviceConfig.FilePermissions).ModeOctal, err.Error()))
-					return err
-				}
-				if err := permissionable.Chmod(os.FileMode(mode)); err != nil {
-					_ = writeCloser.Close()
-					p.logger.Error(fmt.Sprintf("failed to set file mode on %s: %s", outputTokenFilename, err.Error()))
-					return err
-				}
+
+		p.trackToken(serviceName, serviceConfig, createTokenParameters, createTokenResponse)
+	}
+
+	p.privilegedToken = privilegedToken
+	return nil
+}
+
+// writeTokenFile writes the create token response to filename with the file permissions of serviceConfig
+func (p *fileTokenProvider) writeTokenFile(filename string, serviceConfig ServiceKey, createTokenResponse interface{}) error {
+	writeCloser, err := p.fileOpener.OpenFileWriter(filename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(0600))
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed open token file for writing %s: %s", filename, err.Error()))
+		return err
+	}
+	// writeCloser is writable file -- explicitly close() to ensure we catch errors writing to it
+
+	permissionable, ok := writeCloser.(permissionable)
+	if ok {
+		if serviceConfig.FilePermissions != nil &&
+			(serviceConfig.FilePermissions).ModeOctal != nil {
+			mode, err := strconv.ParseInt(*(serviceConfig.FilePermissions).ModeOctal, 8, 32)
+			if err != nil {
+				_ = writeCloser.Close()
+				p.logger.Error(fmt.Sprintf("invalid file mode %s: %s", *(serviceConfig.FilePermissions).ModeOctal, err.Error()))
+				return err
 			}
-			if serviceConfig.FilePermissions != nil &&
-				(serviceConfig.FilePermissions).Uid != nil &&
-				(serviceConfig.FilePermissions).Gid != nil {
-				err := permissionable.Chown(*(serviceConfig.FilePermissions).Uid, *(serviceConfig.FilePermissions).Gid)
-				if err != nil {
-					_ = writeCloser.Close()
-					p.logger.Error(fmt.Sprintf("failed to set file user/group on %s: %s", outputTokenFilename, err.Error()))
-					return err
-				}
+			if err := permissionable.Chmod(os.FileMode(mode)); err != nil {
+				_ = writeCloser.Close()
+				p.logger.Error(fmt.Sprintf("failed to set file mode on %s: %s", filename, err.Error()))
+				return err
 			}
 		}
-
-		encoder := json.NewEncoder(writeCloser)
-		if encoder == nil {
-			_ = writeCloser.Close()
-			err = fmt.Errorf("unable to create JSON output encoder")
-			return err
+		if serviceConfig.FilePermissions != nil &&
+			(serviceConfig.FilePermissions).Uid != nil &&
+			(serviceConfig.FilePermissions).Gid != nil {
+			err := permissionable.Chown(*(serviceConfig.FilePermissions).Uid, *(serviceConfig.FilePermissions).Gid)
+			if err != nil {
+				_ = writeCloser.Close()
+				p.logger.Error(fmt.Sprintf("failed to set file user/group on %s: %s", filename, err.Error()))
+				return err
+			}
 		}
+	}
 
-		// Write resulting token
-		if err := encoder.Encode(createTokenResponse); err != nil {
-			_ = writeCloser.Close()
-			p.logger.Error(fmt.Sprintf("failed to write token file: %s", err.Error()))
-			return err
-		}
+	encoder := json.NewEncoder(writeCloser)
+	if encoder == nil {
+		_ = writeCloser.Close()
+		err = fmt.Errorf("unable to create JSON output encoder")
+		return err
+	}
 
-		if err := writeCloser.Close(); err != nil {
-			p.logger.Error(fmt.Sprintf("failed to close %s: %s", outputTokenFilename, err.Error()))
-			return err
-		}
+	// Write resulting token
+	if err := encoder.Encode(createTokenResponse); err != nil {
+		_ = writeCloser.Close()
+		p.logger.Error(fmt.Sprintf("failed to write token file: %s", err.Error()))
+		return err
+	}
+
+	if err := writeCloser.Close(); err != nil {
+		p.logger.Error(fmt.Sprintf("failed to close %s: %s", filename, err.Error()))
+		return err
 	}
 
 	return nil
@@ -0,0 +1,74 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+// Environment variables selecting and configuring the input key material provider
+const (
+	ProviderEnvVar       = "IKM_PROVIDER"
+	HookEnvVar           = "IKM_HOOK"
+	PassphraseFileEnvVar = "IKM_PASSPHRASE_FILE"
+	KMSSocketEnvVar      = "IKM_KMS_SOCKET"
+	KMSKeyIDEnvVar       = "IKM_KMS_KEY_ID"
+)
+
+// Values of IKM_PROVIDER
+const (
+	HookProvider       = "hook"
+	PassphraseProvider = "passphrase"
+	KMSProvider        = "kms"
+
+	// DefaultKMSKeyID is requested from the KMS when IKM_KMS_KEY_ID is not set
+	DefaultKMSKeyID = "edgex-vault"
+)
+
+// NewProviderFromEnv creates the Provider selected by IKM_PROVIDER, or nil if none is configured.  IKM_PROVIDER
+// defaults to hook when IKM_HOOK is set, for compatibility.  The passphrase salt is kept in persistencePath.
+func NewProviderFromEnv(fileOpener fileioperformer.FileIoPerformer, persistencePath string) (Provider, error) {
+	providerType := os.Getenv(ProviderEnvVar)
+	if providerType == "" && os.Getenv(HookEnvVar) != "" {
+		providerType = HookProvider
+	}
+
+	switch providerType {
+	case "":
+		return nil, nil
+	case HookProvider:
+		hook := os.Getenv(HookEnvVar)
+		if hook == "" {
+			return nil, fmt.Errorf("%s is %s but %s is not set", ProviderEnvVar, HookProvider, HookEnvVar)
+		}
+		return NewHookProvider(pipedhexreader.NewPipedHexReader(), hook), nil
+	case PassphraseProvider:
+		passphraseFile := os.Getenv(PassphraseFileEnvVar)
+		if passphraseFile == "" {
+			return nil, fmt.Errorf("%s is %s but %s is not set", ProviderEnvVar, PassphraseProvider, PassphraseFileEnvVar)
+		}
+		return NewPassphraseProvider(fileOpener, passphraseFile, persistencePath), nil
+	case KMSProvider:
+		socket := os.Getenv(KMSSocketEnvVar)
+		if socket == "" {
+			return nil, fmt.Errorf("%s is %s but %s is not set", ProviderEnvVar, KMSProvider, KMSSocketEnvVar)
+		}
+		keyID := os.Getenv(KMSKeyIDEnvVar)
+		if keyID == "" {
+			keyID = DefaultKMSKeyID
+		}
+		return NewKMSProvider(socket, keyID), nil
+	default:
+		return nil, fmt.Errorf("unsupported %s %s; use %s, %s or %s", ProviderEnvVar, providerType,
+			HookProvider, PassphraseProvider, KMSProvider)
+	}
+}
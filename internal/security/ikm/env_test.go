@@ -0,0 +1,81 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setEnv sets the IKM environment variables in env, unsetting the others, for the duration of the test
+func setEnv(t *testing.T, env map[string]string) {
+	for _, name := range []string{ProviderEnvVar, HookEnvVar, PassphraseFileEnvVar, KMSSocketEnvVar, KMSKeyIDEnvVar} {
+		original, present := os.LookupEnv(name)
+		t.Cleanup(func() {
+			if present {
+				_ = os.Setenv(name, original)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		})
+		if value, ok := env[name]; ok {
+			_ = os.Setenv(name, value)
+		} else {
+			_ = os.Unsetenv(name)
+		}
+	}
+}
+
+func TestNewProviderFromEnv(t *testing.T) {
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+
+	setEnv(t, map[string]string{})
+	provider, err := NewProviderFromEnv(fileOpener, "/tmp")
+	require.NoError(t, err)
+	assert.Nil(t, provider, "no provider configured")
+
+	setEnv(t, map[string]string{HookEnvVar: "/bin/myikm"})
+	provider, err = NewProviderFromEnv(fileOpener, "/tmp")
+	require.NoError(t, err)
+	assert.Equal(t, "hook /bin/myikm", provider.Description(), "IKM_HOOK alone selects the hook provider")
+
+	setEnv(t, map[string]string{ProviderEnvVar: PassphraseProvider, PassphraseFileEnvVar: "/run/secrets/passphrase"})
+	provider, err = NewProviderFromEnv(fileOpener, "/tmp")
+	require.NoError(t, err)
+	assert.Equal(t, "passphrase file /run/secrets/passphrase", provider.Description())
+
+	setEnv(t, map[string]string{ProviderEnvVar: KMSProvider, KMSSocketEnvVar: "/run/kms.sock"})
+	provider, err = NewProviderFromEnv(fileOpener, "/tmp")
+	require.NoError(t, err)
+	assert.Equal(t, "KMS key edgex-vault at /run/kms.sock", provider.Description())
+
+	setEnv(t, map[string]string{ProviderEnvVar: KMSProvider, KMSSocketEnvVar: "/run/kms.sock", KMSKeyIDEnvVar: "key1"})
+	provider, err = NewProviderFromEnv(fileOpener, "/tmp")
+	require.NoError(t, err)
+	assert.Equal(t, "KMS key key1 at /run/kms.sock", provider.Description())
+}
+
+func TestNewProviderFromEnvFailPath(t *testing.T) {
+	badEnvTestcases := []map[string]string{
+		{ProviderEnvVar: HookProvider},
+		{ProviderEnvVar: PassphraseProvider},
+		{ProviderEnvVar: KMSProvider},
+		{ProviderEnvVar: "tpm"},
+	}
+
+	for _, env := range badEnvTestcases {
+		setEnv(t, env)
+		provider, err := NewProviderFromEnv(fileioperformer.NewDefaultFileIoPerformer(), "/tmp")
+		assert.Error(t, err, "Env: %v", env)
+		assert.Nil(t, provider)
+	}
+}
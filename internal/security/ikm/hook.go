@@ -0,0 +1,41 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
+)
+
+// hookProvider reads the input key material as hex from the standard output of an executable
+type hookProvider struct {
+	pipedHexReader pipedhexreader.PipedHexReader
+	executablePath string
+}
+
+// NewHookProvider creates a Provider that runs executablePath, such as a TPM unsealing tool
+func NewHookProvider(pipedHexReader pipedhexreader.PipedHexReader, executablePath string) Provider {
+	return &hookProvider{pipedHexReader: pipedHexReader, executablePath: executablePath}
+}
+
+// ReadIKM see interface.go
+func (p *hookProvider) ReadIKM() ([]byte, error) {
+	if p.executablePath == "" {
+		return nil, fmt.Errorf("ikmBinPath is required")
+	}
+	ikm, err := p.pipedHexReader.ReadHexBytesFromExe(p.executablePath)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading input key material from IKM_HOOK: %w", err)
+	}
+	return ikm, nil
+}
+
+// Description see interface.go
+func (p *hookProvider) Description() string {
+	return fmt.Sprintf("hook %s", p.executablePath)
+}
@@ -0,0 +1,43 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookProvider(t *testing.T) {
+	pipedHexReader := &MockPipedHexReader{}
+	pipedHexReader.On("ReadHexBytesFromExe", "/bin/myikm").Return([]byte{1, 2, 3}, nil)
+
+	provider := NewHookProvider(pipedHexReader, "/bin/myikm")
+	ikm, err := provider.ReadIKM()
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, ikm)
+	assert.Equal(t, "hook /bin/myikm", provider.Description())
+	pipedHexReader.AssertExpectations(t)
+}
+
+func TestHookProviderFailPath(t *testing.T) {
+	pipedHexReader := &MockPipedHexReader{}
+	pipedHexReader.On("ReadHexBytesFromExe", "/bin/myikm").Return([]byte{}, errors.New("error"))
+
+	_, err := NewHookProvider(pipedHexReader, "/bin/myikm").ReadIKM()
+	assert.Error(t, err)
+
+	_, err = NewHookProvider(pipedHexReader, "").ReadIKM()
+	assert.Error(t, err)
+
+	pipedHexReader.AssertExpectations(t)
+}
@@ -0,0 +1,19 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+// Package ikm implements the sources of the input key material (IKM)
+// from which the keys protecting the Vault key shares are derived
+package ikm
+
+// Provider is the interface that the main program expects
+// for obtaining input key material
+type Provider interface {
+	// ReadIKM returns the input key material, which is a secret
+	// that the caller should wipe from memory when done
+	ReadIKM() ([]byte, error)
+	// Description names the source of the input key material for logging
+	Description() string
+}
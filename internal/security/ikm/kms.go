@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// KMSKeyPath is the path, relative to the KMS socket, from which the
+	// input key material identified by a key ID is requested
+	KMSKeyPath = "/v1/ikm/"
+
+	kmsTimeout = 10 * time.Second
+)
+
+// KMSKeyResponse is the response of the local KMS to a request for input key material
+type KMSKeyResponse struct {
+	// IKM is the hex-encoded input key material
+	IKM string `json:"ikm"`
+}
+
+// kmsProvider requests the input key material from a local key management service over a unix socket
+type kmsProvider struct {
+	socketPath string
+	keyID      string
+	client     *http.Client
+}
+
+// NewKMSProvider creates a Provider that sends GET /v1/ikm/<keyID> to the HTTP server listening on the unix
+// socket socketPath, a stand-in for a key management service, and reads the input key material from the
+// KMSKeyResponse
+func NewKMSProvider(socketPath string, keyID string) Provider {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	return &kmsProvider{
+		socketPath: socketPath,
+		keyID:      keyID,
+		client:     &http.Client{Transport: transport, Timeout: kmsTimeout},
+	}
+}
+
+// ReadIKM see interface.go
+func (p *kmsProvider) ReadIKM() ([]byte, error) {
+	if p.socketPath == "" {
+		return nil, errors.New("KMS socket path is required")
+	}
+	if p.keyID == "" {
+		return nil, errors.New("KMS key ID is required")
+	}
+
+	// The host is ignored as the transport always dials the socket
+	resp, err := p.client.Get("http://kms" + KMSKeyPath + url.PathEscape(p.keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach KMS at %s: %w", p.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("KMS returned status %d for key %s: %s", resp.StatusCode, p.keyID,
+			strings.TrimSpace(string(body)))
+	}
+
+	var response KMSKeyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to parse KMS response for key %s: %w", p.keyID, err)
+	}
+	ikm, err := hex.DecodeString(response.IKM)
+	if err != nil {
+		return nil, fmt.Errorf("KMS returned invalid input key material for key %s: %w", p.keyID, err)
+	}
+	if len(ikm) == 0 {
+		return nil, fmt.Errorf("KMS returned no input key material for key %s", p.keyID)
+	}
+	return ikm, nil
+}
+
+// Description see interface.go
+func (p *kmsProvider) Description() string {
+	return fmt.Sprintf("KMS key %s at %s", p.keyID, p.socketPath)
+}
@@ -0,0 +1,70 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startKMS serves keys, hex-encoded input key material by key ID, on a unix socket and returns its path
+func startKMS(t *testing.T, keys map[string]string) string {
+	socketPath := filepath.Join(t.TempDir(), "kms.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		ikm, ok := keys[r.URL.Path[len(KMSKeyPath):]]
+		if !ok {
+			http.Error(w, "unknown key", http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(KMSKeyResponse{IKM: ikm})
+	})}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+	return socketPath
+}
+
+func TestKMSProvider(t *testing.T) {
+	socketPath := startKMS(t, map[string]string{"edgex-vault": "00ff10"})
+
+	provider := NewKMSProvider(socketPath, "edgex-vault")
+	ikm, err := provider.ReadIKM()
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0xff, 0x10}, ikm)
+	assert.Equal(t, "KMS key edgex-vault at "+socketPath, provider.Description())
+}
+
+func TestKMSProviderFailPath(t *testing.T) {
+	socketPath := startKMS(t, map[string]string{"empty": "", "invalid": "xyz"})
+
+	failTestcases := []struct {
+		name       string
+		socketPath string
+		keyID      string
+	}{
+		{"no socket", "", "edgex-vault"},
+		{"no key ID", socketPath, ""},
+		{"unreachable", filepath.Join(t.TempDir(), "missing.sock"), "edgex-vault"},
+		{"unknown key", socketPath, "edgex-vault"},
+		{"empty key", socketPath, "empty"},
+		{"invalid key", socketPath, "invalid"},
+	}
+	for _, testcase := range failTestcases {
+		_, err := NewKMSProvider(testcase.socketPath, testcase.keyID).ReadIKM()
+		assert.Error(t, err, testcase.name)
+	}
+}
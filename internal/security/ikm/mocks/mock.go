@@ -0,0 +1,27 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProvider struct {
+	mock.Mock
+}
+
+func (m *MockProvider) ReadIKM() ([]byte, error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called()
+	return arguments.Get(0).([]byte), arguments.Error(1)
+}
+
+func (m *MockProvider) Description() string {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called()
+	return arguments.String(0)
+}
@@ -0,0 +1,32 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package mocks
+
+import (
+	"testing"
+
+	. "github.com/edgexfoundry/edgex-go/internal/security/ikm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockInterfaceType(t *testing.T) {
+	// Typecast will fail if doesn't implement interface properly
+	var iface Provider = &MockProvider{}
+	assert.NotNil(t, iface)
+}
+
+func TestReadIKM(t *testing.T) {
+	mockProvider := &MockProvider{}
+	mockProvider.On("ReadIKM").Return(make([]byte, 1), nil)
+	mockProvider.On("Description").Return("mock")
+
+	ikm, err := mockProvider.ReadIKM()
+	assert.Nil(t, err)
+	assert.Equal(t, make([]byte, 1), ikm)
+	assert.Equal(t, "mock", mockProvider.Description())
+	mockProvider.AssertExpectations(t)
+}
@@ -0,0 +1,136 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// US Export Control Classification Number (ECCN): 5D002TSU
+//
+
+package ikm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+// The passphrase is stretched with scrypt using the parameters recommended
+// for interactive logins in 2017, and a random salt that is persisted next
+// to the other key material so that the same passphrase yields the same key.
+const (
+	passphraseSaltFile   = "ikm-passphrase-salt.dat"
+	passphraseSaltLength = 32
+	passphraseKeyLength  = 32
+	scryptN              = 32768
+	scryptR              = 8
+	scryptP              = 1
+)
+
+// passphraseProvider derives the input key material from a passphrase read from a file
+type passphraseProvider struct {
+	fileOpener      fileioperformer.FileIoPerformer
+	passphrasePath  string
+	persistencePath string
+}
+
+// NewPassphraseProvider creates a Provider that derives the input key material from the passphrase in
+// passphrasePath, such as a container secret, with a salt kept in persistencePath
+func NewPassphraseProvider(fileOpener fileioperformer.FileIoPerformer, passphrasePath string,
+	persistencePath string) Provider {
+
+	return &passphraseProvider{
+		fileOpener:      fileOpener,
+		passphrasePath:  passphrasePath,
+		persistencePath: persistencePath,
+	}
+}
+
+// ReadIKM see interface.go
+func (p *passphraseProvider) ReadIKM() ([]byte, error) {
+	if p.passphrasePath == "" {
+		return nil, errors.New("passphrase file is required")
+	}
+	reader, err := p.fileOpener.OpenFileReader(p.passphrasePath, os.O_RDONLY, 0400)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open passphrase file %s: %w", p.passphrasePath, err)
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+	contents, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase file %s: %w", p.passphrasePath, err)
+	}
+	defer wipe(contents)
+
+	passphrase := bytes.TrimRight(contents, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", p.passphrasePath)
+	}
+
+	salt, err := p.initializeSalt()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize passphrase salt: %w", err)
+	}
+
+	return scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, passphraseKeyLength)
+}
+
+// Description see interface.go
+func (p *passphraseProvider) Description() string {
+	return fmt.Sprintf("passphrase file %s", p.passphrasePath)
+}
+
+// initializeSalt recovers the salt from its file or installs a new salt
+func (p *passphraseProvider) initializeSalt() ([]byte, error) {
+	saltPath := filepath.Join(p.persistencePath, passphraseSaltFile)
+
+	reader, err := p.fileOpener.OpenFileReader(saltPath, os.O_RDONLY, 0400)
+	if err == nil {
+		readCloser := fileioperformer.MakeReadCloser(reader)
+		defer readCloser.Close()
+		salt, err := ioutil.ReadAll(readCloser)
+		if err != nil {
+			return nil, err
+		}
+		if len(salt) != passphraseSaltLength {
+			return nil, fmt.Errorf("salt file %s does not contain expected length of salt", saltPath)
+		}
+		return salt, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	salt := make([]byte, passphraseSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	// O_EXCL so that a salt that appeared since the read above is never replaced
+	writer, err := p.fileOpener.OpenFileWriter(saltPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, err
+	}
+	_, err = writer.Write(salt)
+	closeErr := writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write salt file %s; encryption key will likely be unrecoverable: %w",
+			saltPath, err)
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+	return salt, nil
+}
+
+// wipe scrubs secret bytes from memory
+func wipe(secret []byte) {
+	copy(secret, make([]byte, len(secret)))
+}
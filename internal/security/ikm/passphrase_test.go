@@ -0,0 +1,75 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package ikm
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePassphrase(t *testing.T, dir string, passphrase string) string {
+	path := filepath.Join(dir, "passphrase")
+	require.NoError(t, ioutil.WriteFile(path, []byte(passphrase), 0600))
+	return path
+}
+
+func TestPassphraseProvider(t *testing.T) {
+	dir := t.TempDir()
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+	provider := NewPassphraseProvider(fileOpener, writePassphrase(t, dir, "correct horse battery staple\n"), dir)
+
+	ikm, err := provider.ReadIKM()
+	require.NoError(t, err)
+	assert.Len(t, ikm, passphraseKeyLength)
+	salt, err := ioutil.ReadFile(filepath.Join(dir, passphraseSaltFile))
+	require.NoError(t, err)
+	assert.Len(t, salt, passphraseSaltLength)
+
+	// The salt is reused so the same passphrase yields the same key, ignoring the trailing newline
+	again, err := NewPassphraseProvider(fileOpener, writePassphrase(t, dir, "correct horse battery staple"), dir).ReadIKM()
+	require.NoError(t, err)
+	assert.Equal(t, ikm, again)
+
+	other, err := NewPassphraseProvider(fileOpener, writePassphrase(t, dir, "another passphrase"), dir).ReadIKM()
+	require.NoError(t, err)
+	assert.NotEqual(t, ikm, other)
+
+	// A fresh salt yields a different key from the same passphrase
+	otherDir := t.TempDir()
+	salted, err := NewPassphraseProvider(fileOpener, writePassphrase(t, otherDir, "correct horse battery staple"),
+		otherDir).ReadIKM()
+	require.NoError(t, err)
+	assert.NotEqual(t, ikm, salted)
+}
+
+func TestPassphraseProviderFailPath(t *testing.T) {
+	dir := t.TempDir()
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+
+	_, err := NewPassphraseProvider(fileOpener, "", dir).ReadIKM()
+	assert.Error(t, err, "no passphrase file")
+
+	_, err = NewPassphraseProvider(fileOpener, filepath.Join(dir, "missing"), dir).ReadIKM()
+	assert.Error(t, err, "missing passphrase file")
+
+	_, err = NewPassphraseProvider(fileOpener, writePassphrase(t, dir, "\n"), dir).ReadIKM()
+	assert.Error(t, err, "empty passphrase")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, passphraseSaltFile), []byte("short"), 0600))
+	_, err = NewPassphraseProvider(fileOpener, writePassphrase(t, dir, "passphrase"), dir).ReadIKM()
+	assert.Error(t, err, "truncated salt")
+
+	_, err = NewPassphraseProvider(fileOpener, writePassphrase(t, dir, "passphrase"),
+		filepath.Join(dir, "missing")).ReadIKM()
+	assert.Error(t, err, "salt folder missing")
+}
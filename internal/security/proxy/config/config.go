@@ -31,6 +31,7 @@ type ConfigurationStruct struct {
 	SecretStore   bootstrapConfig.SecretStoreInfo
 	SecretService SecretServiceInfo
 	Clients       map[string]bootstrapConfig.ClientInfo
+	RoutePlugins  map[string]RoutePluginsInfo
 }
 
 type WritableInfo struct {
@@ -74,6 +75,56 @@ type KongAclInfo struct {
 	WhiteList string
 }
 
+// RoutePluginsInfo holds the Kong plugins applied to the route of one of the Clients, by the same name.
+// A plugin whose section is left empty is not applied.
+type RoutePluginsInfo struct {
+	RateLimit   RateLimitInfo
+	CORS        CORSInfo
+	RequestSize RequestSizeInfo
+}
+
+// RateLimitInfo configures Kong's rate-limiting plugin; limits left at 0 are not enforced
+type RateLimitInfo struct {
+	Second int
+	Minute int
+	Hour   int
+	Day    int
+	// Policy is where the counters are kept: "local" (default) or "cluster"
+	Policy string
+}
+
+// Enabled returns whether any limit is set
+func (r RateLimitInfo) Enabled() bool {
+	return r.Second > 0 || r.Minute > 0 || r.Hour > 0 || r.Day > 0
+}
+
+// CORSInfo configures Kong's cors plugin
+type CORSInfo struct {
+	Origins        []string
+	Methods        []string
+	Headers        []string
+	ExposedHeaders []string
+	Credentials    bool
+	MaxAge         int
+}
+
+// Enabled returns whether any origin is allowed
+func (c CORSInfo) Enabled() bool {
+	return len(c.Origins) > 0
+}
+
+// RequestSizeInfo configures Kong's request-size-limiting plugin
+type RequestSizeInfo struct {
+	AllowedPayloadSize int
+	// SizeUnit is the unit of AllowedPayloadSize: "megabytes" (default), "kilobytes" or "bytes"
+	SizeUnit string
+}
+
+// Enabled returns whether a maximum request body size is set
+func (r RequestSizeInfo) Enabled() bool {
+	return r.AllowedPayloadSize > 0
+}
+
 type SecretServiceInfo struct {
 	Protocol        string
 	Server          string
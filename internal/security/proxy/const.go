@@ -21,7 +21,10 @@ const (
 	ConsumersPath    = "consumers"
 	CertificatesPath = "certificates"
 	PluginsPath      = "plugins"
+	JWTsPath         = "jwts"
+	OAuth2Path       = "oauth2"
 	EdgeXKong        = "edgex-kong"
+	EdgeXTag         = "edgex"
 	VaultToken       = "X-Vault-Token"
 	OAuth2GrantType  = "client_credentials"
 	OAuth2Scopes     = "all"
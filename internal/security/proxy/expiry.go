@@ -0,0 +1,116 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConsumerExpiryTagPrefix prefixes the consumer tag, set by secrets-config proxy setexpiry, holding the Unix time at
+// which the consumer expires
+const ConsumerExpiryTagPrefix = "edgex-expires:"
+
+// ConsumerExpiry returns the time at which a consumer with tags expires, or the zero time if it doesn't
+func ConsumerExpiry(tags []string) time.Time {
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, ConsumerExpiryTagPrefix) {
+			seconds, err := strconv.ParseInt(strings.TrimPrefix(tag, ConsumerExpiryTagPrefix), 10, 64)
+			if err == nil {
+				return time.Unix(seconds, 0).UTC()
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// kongConsumerState is the part of a Kong consumer which expiry enforcement reads
+type kongConsumerState struct {
+	ID       string   `json:"id"`
+	Username string   `json:"username"`
+	Tags     []string `json:"tags"`
+}
+
+// kongCredentialState is the part of a Kong JWT or OAuth2 credential which expiry enforcement reads
+type kongCredentialState struct {
+	ID       string         `json:"id"`
+	Key      string         `json:"key"`
+	ClientID string         `json:"client_id"`
+	Consumer *kongReference `json:"consumer"`
+}
+
+// name returns the JWT key or the OAuth2 client ID identifying the credential to its user
+func (c kongCredentialState) name() string {
+	if c.Key != "" {
+		return c.Key
+	}
+	return c.ClientID
+}
+
+// consumerCredentialKinds are the kinds of Kong credentials a consumer can authenticate with, by the admin API path
+// listing them.  Kong revokes the OAuth2 tokens issued for a credential along with it.
+var consumerCredentialKinds = []struct {
+	kind string
+	path string
+}{
+	{"jwt_credential", JWTsPath},
+	{"oauth2_credential", OAuth2Path},
+}
+
+// expiredCredentialChanges returns the deletion of the JWT and OAuth2 credentials of every consumer whose expiry has
+// passed at now, so that an expired consumer can no longer authenticate
+func (s *Service) expiredCredentialChanges(now time.Time) (KongPlan, error) {
+	var consumers []kongConsumerState
+	if err := s.listKongObjects(ConsumersPath, &consumers); err != nil {
+		return nil, err
+	}
+	expired := make(map[string]kongConsumerState)
+	for _, consumer := range consumers {
+		if expiry := ConsumerExpiry(consumer.Tags); !expiry.IsZero() && !expiry.After(now) {
+			expired[consumer.ID] = consumer
+		}
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	var plan KongPlan
+	for _, credentialKind := range consumerCredentialKinds {
+		var credentials []kongCredentialState
+		if err := s.listKongObjects(credentialKind.path, &credentials); err != nil {
+			return nil, err
+		}
+		var changes KongPlan
+		for _, credential := range credentials {
+			if credential.Consumer == nil {
+				continue
+			}
+			consumer, ok := expired[credential.Consumer.ID]
+			if !ok {
+				continue
+			}
+			changes = append(changes, KongChange{Action: kongDelete, Kind: credentialKind.kind,
+				Name:   consumer.Username + "/" + credential.name(),
+				Detail: "user expired " + ConsumerExpiry(consumer.Tags).Format(time.RFC3339),
+				method: http.MethodDelete, path: credentialKind.path + "/" + credential.ID})
+		}
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+		plan = append(plan, changes...)
+	}
+	return plan, nil
+}
@@ -0,0 +1,77 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerExpiry(t *testing.T) {
+	assert.Equal(t, time.Unix(1614556800, 0).UTC(), ConsumerExpiry([]string{"team", ConsumerExpiryTagPrefix + "1614556800"}))
+	assert.True(t, ConsumerExpiry([]string{"team"}).IsZero())
+	assert.True(t, ConsumerExpiry([]string{ConsumerExpiryTagPrefix + "soon"}).IsZero())
+}
+
+func TestReconcileExpiredConsumers(t *testing.T) {
+	kong := newFakeKong()
+	service, closer := newReconcileService(t, kong)
+	defer closer()
+	past := fmt.Sprintf("%s%d", ConsumerExpiryTagPrefix, time.Now().Add(-time.Hour).Unix())
+	future := fmt.Sprintf("%s%d", ConsumerExpiryTagPrefix, time.Now().Add(time.Hour).Unix())
+	expired := kong.add(ConsumersPath, map[string]interface{}{"username": "expired", "tags": []string{past}})
+	active := kong.add(ConsumersPath, map[string]interface{}{"username": "active", "tags": []string{future}})
+	forever := kong.add(ConsumersPath, map[string]interface{}{"username": "forever"})
+	kong.add(JWTsPath, map[string]interface{}{"key": "old", "consumer": map[string]interface{}{"id": expired}})
+	kong.add(JWTsPath, map[string]interface{}{"key": "new", "consumer": map[string]interface{}{"id": expired}})
+	kong.add(JWTsPath, map[string]interface{}{"key": "active-key", "consumer": map[string]interface{}{"id": active}})
+	kong.add(JWTsPath, map[string]interface{}{"key": "forever-key", "consumer": map[string]interface{}{"id": forever}})
+	kong.add(OAuth2Path, map[string]interface{}{"client_id": "expired-app", "consumer": map[string]interface{}{"id": expired}})
+	kong.add(OAuth2Path, map[string]interface{}{"client_id": "active-app", "consumer": map[string]interface{}{"id": active}})
+
+	plan, err := service.Reconcile(true)
+
+	require.NoError(t, err)
+	var deletions []string
+	for _, change := range plan {
+		if change.Kind == "jwt_credential" || change.Kind == "oauth2_credential" {
+			assert.Equal(t, kongDelete, change.Action)
+			deletions = append(deletions, change.Kind+" "+change.Name)
+		}
+	}
+	assert.Equal(t, []string{
+		"jwt_credential expired/new",
+		"jwt_credential expired/old",
+		"oauth2_credential expired/expired-app",
+	}, deletions)
+	assert.Zero(t, kong.mutating, "dry run must not change Kong")
+
+	_, err = service.Reconcile(false)
+	require.NoError(t, err)
+	var keys []string
+	for _, credential := range kong.objects[JWTsPath] {
+		keys = append(keys, credential["key"].(string))
+	}
+	assert.ElementsMatch(t, []string{"active-key", "forever-key"}, keys)
+	require.Len(t, kong.objects[OAuth2Path], 1)
+	for _, credential := range kong.objects[OAuth2Path] {
+		assert.Equal(t, "active-app", credential["client_id"])
+	}
+	assert.Len(t, kong.objects[ConsumersPath], 3, "consumers are left in place")
+}
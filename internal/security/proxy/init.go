@@ -18,6 +18,7 @@ package proxy
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"sync"
 
@@ -35,17 +36,23 @@ type Bootstrap struct {
 	insecureSkipVerify bool
 	initNeeded         bool
 	resetNeeded        bool
+	reconcileNeeded    bool
+	dryRun             bool
 }
 
 func NewBootstrap(
 	insecureSkipVerify bool,
 	initNeeded bool,
-	resetNeeded bool) *Bootstrap {
+	resetNeeded bool,
+	reconcileNeeded bool,
+	dryRun bool) *Bootstrap {
 
 	return &Bootstrap{
 		insecureSkipVerify: insecureSkipVerify,
 		initNeeded:         initNeeded,
 		resetNeeded:        resetNeeded,
+		reconcileNeeded:    reconcileNeeded,
+		dryRun:             dryRun,
 	}
 }
 
@@ -87,7 +94,25 @@ func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ sta
 	s := NewService(req, lc, configuration)
 	b.haltIfError(lc, s.CheckProxyServiceStatus())
 
-	if b.initNeeded {
+	if b.reconcileNeeded {
+		if b.initNeeded || b.resetNeeded {
+			b.errorAndHalt(lc, "can't run reconciliation together with initialization or reset for security service")
+		}
+
+		plan, err := s.Reconcile(b.dryRun)
+		if b.dryRun {
+			for _, change := range plan {
+				fmt.Println(change.String())
+			}
+			if err == nil {
+				fmt.Printf("%d change(s) needed to reconcile Kong\n", len(plan))
+			}
+		}
+		b.haltIfError(lc, err)
+		if !b.dryRun {
+			lc.Info(fmt.Sprintf("Kong reconciled with %d change(s)", len(plan)))
+		}
+	} else if b.initNeeded {
 		if b.resetNeeded {
 			b.errorAndHalt(lc, "can't run initialization and reset at the same time for security service")
 		}
@@ -41,6 +41,7 @@ type KongServiceResponse struct {
 type KongRoute struct {
 	Paths []string `json:"paths,omitempty"`
 	Name  string   `json:"name,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
 }
 
 type KongOAuth2Plugin struct {
@@ -41,12 +41,17 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 	var initNeeded bool
 	var insecureSkipVerify bool
 	var resetNeeded bool
+	var reconcileNeeded bool
+	var dryRun bool
 
 	// All common command-line flags have been moved to bootstrap. Service specific flags are added below.
 	f := flags.NewWithUsage(
 		"    --insecureSkipVerify=true/false Indicates if skipping the server side SSL cert verification, similar to -k of curl\n" +
 			"    --init=true/false               Indicates if security service should be initialized\n" +
-			"    --reset=true/false              Indicate if security service should be reset to initialization status\n",
+			"    --reset=true/false              Indicate if security service should be reset to initialization status\n" +
+			"    --reconcile=true/false          Indicates if Kong should be reconciled with the configuration, applying\n" +
+			"                                    only the needed creates, updates and deletes\n" +
+			"    --dry-run=true/false            With --reconcile, prints the changes instead of applying them\n",
 	)
 
 	if len(os.Args) < 2 {
@@ -56,6 +61,8 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 	f.FlagSet.BoolVar(&insecureSkipVerify, "insecureSkipVerify", false, "")
 	f.FlagSet.BoolVar(&initNeeded, "init", false, "")
 	f.FlagSet.BoolVar(&resetNeeded, "reset", false, "")
+	f.FlagSet.BoolVar(&reconcileNeeded, "reconcile", false, "")
+	f.FlagSet.BoolVar(&dryRun, "dry-run", false, "")
 	f.Parse(os.Args[1:])
 
 	configuration := &config.ConfigurationStruct{}
@@ -79,7 +86,9 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 			NewBootstrap(
 				insecureSkipVerify,
 				initNeeded,
-				resetNeeded).BootstrapHandler,
+				resetNeeded,
+				reconcileNeeded,
+				dryRun).BootstrapHandler,
 		},
 	)
 }
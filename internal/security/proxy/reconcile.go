@@ -0,0 +1,525 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+)
+
+const (
+	kongCreate = "create"
+	kongUpdate = "update"
+	kongDelete = "delete"
+)
+
+// kongReference is a reference from one Kong object to another
+type kongReference struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// name returns the name of the referenced object, or its ID when it has no name
+func (r kongReference) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.ID
+}
+
+// kongServiceState is the part of a Kong service which reconciliation manages
+type kongServiceState struct {
+	ID       string   `json:"id,omitempty"`
+	Name     string   `json:"name"`
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Protocol string   `json:"protocol"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// kongRouteState is the part of a Kong route which reconciliation manages
+type kongRouteState struct {
+	ID      string         `json:"id,omitempty"`
+	Name    string         `json:"name"`
+	Paths   []string       `json:"paths"`
+	Service *kongReference `json:"service,omitempty"`
+	Tags    []string       `json:"tags,omitempty"`
+}
+
+// kongPluginState is the part of a Kong plugin which reconciliation manages
+type kongPluginState struct {
+	ID       string                 `json:"id,omitempty"`
+	Name     string                 `json:"name"`
+	Config   map[string]interface{} `json:"config,omitempty"`
+	Service  *kongReference         `json:"service,omitempty"`
+	Route    *kongReference         `json:"route,omitempty"`
+	Consumer *kongReference         `json:"consumer,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+}
+
+// hasEdgeXTag reports whether the Kong object with the tags was created by EdgeX
+func hasEdgeXTag(tags []string) bool {
+	for _, tag := range tags {
+		if tag == EdgeXTag {
+			return true
+		}
+	}
+	return false
+}
+
+// key identifies the plugin by its name and the service or route it applies to, global plugins by their name alone
+func (p kongPluginState) key() string {
+	switch {
+	case p.Service != nil:
+		return p.Name + "@service:" + p.Service.name()
+	case p.Route != nil:
+		return p.Name + "@route:" + p.Route.name()
+	default:
+		return p.Name
+	}
+}
+
+// kongState is the set of services, routes and plugins of Kong, each keyed by name (plugins by key())
+type kongState struct {
+	services map[string]kongServiceState
+	routes   map[string]kongRouteState
+	plugins  map[string]kongPluginState
+}
+
+// KongChange is a single create, update or delete of a Kong object
+type KongChange struct {
+	Action string
+	Kind   string
+	Name   string
+	Detail string
+
+	method string
+	path   string
+	body   interface{}
+}
+
+func (c KongChange) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Action, c.Kind, c.Name)
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	return s
+}
+
+// KongPlan is the ordered list of changes bringing Kong to the desired state
+type KongPlan []KongChange
+
+// Reconcile computes the services, routes and plugins Kong should have from the configuration and
+// ADD_PROXY_ROUTE, compares them with those Kong has and, unless dryRun is set, applies the difference.
+// EdgeX tags the services, routes and plugins it creates with EdgeXTag, and only deletes objects with that tag, so
+// objects added to Kong by others are left alone.  An untagged object by the name of a desired one is adopted: it is
+// tagged and brought in line with the configuration.
+// Consumers are out of scope: they are user accounts added and removed at runtime by secrets-config rather than part
+// of the configuration, so neither they nor the plugins applied to them are diffed.  Only the credentials of
+// consumers whose expiry has passed are deleted.
+func (s *Service) Reconcile(dryRun bool) (KongPlan, error) {
+	desired, err := s.desiredKongState()
+	if err != nil {
+		return nil, err
+	}
+	observed, err := s.observedKongState()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planKongChanges(desired, observed)
+	expiredPlan, err := s.expiredCredentialChanges(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	plan = append(plan, expiredPlan...)
+	if dryRun || len(plan) == 0 {
+		return plan, nil
+	}
+
+	for _, change := range plan {
+		if err := s.kongRequest(change.method, change.path, change.body, nil); err != nil {
+			return plan, fmt.Errorf("failed to %s %s %s: %s", change.Action, change.Kind, change.Name, err.Error())
+		}
+		s.loggingClient.Info(fmt.Sprintf("reconciled Kong: %s", change.String()))
+	}
+	return plan, nil
+}
+
+// desiredKongState returns the services, routes and plugins Init sets up
+func (s *Service) desiredKongState() (kongState, error) {
+	additional, err := s.parseAdditionalProxyRoutes()
+	if err != nil {
+		return kongState{}, fmt.Errorf("failed to parse additional proxy Kong routes from env %s: %s",
+			s.additionalRoutes, err.Error())
+	}
+
+	state := kongState{
+		services: make(map[string]kongServiceState),
+		routes:   make(map[string]kongRouteState),
+		plugins:  make(map[string]kongPluginState),
+	}
+	merged := s.mergeRoutesWith(additional)
+	for clientName, client := range merged {
+		name := strings.ToLower(clientName)
+		state.services[name] = kongServiceState{
+			Name:     name,
+			Host:     client.Host,
+			Port:     client.Port,
+			Protocol: client.Protocol,
+		}
+		state.routes[name] = kongRouteState{
+			Name:    name,
+			Paths:   []string{"/" + name},
+			Service: &kongReference{Name: name},
+		}
+	}
+
+	for name, route := range state.routes {
+		route.Tags = []string{EdgeXTag}
+		state.routes[name] = route
+	}
+	for name, service := range state.services {
+		service.Tags = []string{EdgeXTag}
+		state.services[name] = service
+	}
+
+	auth := kongPluginState{Name: s.configuration.KongAuth.Name}
+	switch auth.Name {
+	case "jwt":
+	case "oauth2":
+		auth.Config = map[string]interface{}{
+			"scopes":                    strings.Split(OAuth2Scopes, ","),
+			"mandatory_scope":           true,
+			"enable_client_credentials": true,
+			"global_credentials":        true,
+			"refresh_token_ttl":         s.configuration.KongAuth.TokenTTL,
+		}
+	default:
+		return kongState{}, fmt.Errorf("unsupported authetication method: %s", auth.Name)
+	}
+	state.plugins[auth.key()] = auth
+
+	acl := kongPluginState{
+		Name:   s.configuration.KongACL.Name,
+		Config: map[string]interface{}{"whitelist": strings.Split(s.configuration.KongACL.WhiteList, ",")},
+	}
+	state.plugins[acl.key()] = acl
+
+	routePlugins, err := s.routePlugins(merged)
+	if err != nil {
+		return kongState{}, err
+	}
+	for _, plugin := range routePlugins {
+		state.plugins[plugin.key()] = plugin
+	}
+	for key, plugin := range state.plugins {
+		plugin.Tags = []string{EdgeXTag}
+		state.plugins[key] = plugin
+	}
+
+	return state, nil
+}
+
+// observedKongState returns the services, routes and plugins Kong has, tagged or not, with the services and routes
+// they reference named.  Plugins applied to consumers are left out.
+func (s *Service) observedKongState() (kongState, error) {
+	state := kongState{
+		services: make(map[string]kongServiceState),
+		routes:   make(map[string]kongRouteState),
+		plugins:  make(map[string]kongPluginState),
+	}
+
+	var services []kongServiceState
+	if err := s.listKongObjects(ServicesPath, &services); err != nil {
+		return state, err
+	}
+	serviceNames := make(map[string]string)
+	routeNames := make(map[string]string)
+	for _, service := range services {
+		if service.Name == "" {
+			service.Name = service.ID
+		}
+		serviceNames[service.ID] = service.Name
+		state.services[service.Name] = service
+	}
+
+	var routes []kongRouteState
+	if err := s.listKongObjects(RoutesPath, &routes); err != nil {
+		return state, err
+	}
+	for _, route := range routes {
+		if route.Name == "" {
+			route.Name = route.ID
+		}
+		if route.Service != nil {
+			route.Service.Name = serviceNames[route.Service.ID]
+		}
+		routeNames[route.ID] = route.Name
+		state.routes[route.Name] = route
+	}
+
+	var plugins []kongPluginState
+	if err := s.listKongObjects(PluginsPath, &plugins); err != nil {
+		return state, err
+	}
+	for _, plugin := range plugins {
+		if plugin.Consumer != nil {
+			continue
+		}
+		if plugin.Service != nil {
+			plugin.Service.Name = serviceNames[plugin.Service.ID]
+		}
+		if plugin.Route != nil {
+			plugin.Route.Name = routeNames[plugin.Route.ID]
+		}
+		state.plugins[plugin.key()] = plugin
+	}
+
+	return state, nil
+}
+
+// planKongChanges returns the changes turning observed into desired.  Only observed objects tagged with EdgeXTag are
+// deleted, untagged ones by the name of a desired object are tagged.  Plugins and routes are deleted first, services
+// are created before the routes referencing them and deleted once no route references them.
+func planKongChanges(desired kongState, observed kongState) KongPlan {
+	var deletes, serviceChanges, routeChanges, serviceDeletes, pluginChanges KongPlan
+
+	for _, name := range sortedKeys(observed.plugins) {
+		plugin := observed.plugins[name]
+		if _, ok := desired.plugins[name]; !ok && hasEdgeXTag(plugin.Tags) {
+			deletes = append(deletes, KongChange{Action: kongDelete, Kind: "plugin", Name: name,
+				method: http.MethodDelete, path: PluginsPath + "/" + plugin.ID})
+		}
+	}
+	for _, name := range sortedKeys(observed.routes) {
+		route := observed.routes[name]
+		if _, ok := desired.routes[name]; !ok && hasEdgeXTag(route.Tags) {
+			deletes = append(deletes, KongChange{Action: kongDelete, Kind: "route", Name: name,
+				method: http.MethodDelete, path: RoutesPath + "/" + route.ID})
+		}
+	}
+	for _, name := range sortedKeys(observed.services) {
+		service := observed.services[name]
+		if _, ok := desired.services[name]; !ok && hasEdgeXTag(service.Tags) {
+			serviceDeletes = append(serviceDeletes, KongChange{Action: kongDelete, Kind: "service", Name: name,
+				method: http.MethodDelete, path: ServicesPath + "/" + service.ID})
+		}
+	}
+
+	for _, name := range sortedKeys(desired.services) {
+		want := desired.services[name]
+		have, ok := observed.services[name]
+		if !ok {
+			serviceChanges = append(serviceChanges, KongChange{Action: kongCreate, Kind: "service", Name: name,
+				Detail: fmt.Sprintf("%s://%s:%d", want.Protocol, want.Host, want.Port),
+				method: http.MethodPost, path: ServicesPath, body: want})
+			continue
+		}
+		var details []string
+		if have.Host != want.Host || have.Port != want.Port || have.Protocol != want.Protocol {
+			details = append(details, fmt.Sprintf("%s://%s:%d -> %s://%s:%d",
+				have.Protocol, have.Host, have.Port, want.Protocol, want.Host, want.Port))
+		}
+		if !hasEdgeXTag(have.Tags) {
+			details = append(details, "tag "+EdgeXTag)
+		}
+		if len(details) > 0 {
+			serviceChanges = append(serviceChanges, KongChange{Action: kongUpdate, Kind: "service", Name: name,
+				Detail: strings.Join(details, ", "),
+				method: http.MethodPatch, path: ServicesPath + "/" + have.ID,
+				body: map[string]interface{}{"host": want.Host, "port": want.Port, "protocol": want.Protocol,
+					"tags": taggedWith(have.Tags)}})
+		}
+	}
+
+	for _, name := range sortedKeys(desired.routes) {
+		want := desired.routes[name]
+		have, ok := observed.routes[name]
+		if !ok {
+			serviceName := want.Service.Name
+			want.Service = nil
+			routeChanges = append(routeChanges, KongChange{Action: kongCreate, Kind: "route", Name: name,
+				Detail: fmt.Sprintf("%s on service %s", strings.Join(want.Paths, ","), serviceName),
+				method: http.MethodPost, path: strings.Join([]string{ServicesPath, serviceName, "routes"}, "/"),
+				body: want})
+			continue
+		}
+		var details []string
+		if !reflect.DeepEqual(have.Paths, want.Paths) {
+			details = append(details, fmt.Sprintf("paths %s -> %s",
+				strings.Join(have.Paths, ","), strings.Join(want.Paths, ",")))
+		}
+		if have.Service == nil || have.Service.Name != want.Service.Name {
+			from := ""
+			if have.Service != nil {
+				from = have.Service.name()
+			}
+			details = append(details, fmt.Sprintf("service %s -> %s", from, want.Service.Name))
+		}
+		if !hasEdgeXTag(have.Tags) {
+			details = append(details, "tag "+EdgeXTag)
+		}
+		if len(details) > 0 {
+			// Kong resolves the service by name, so the route keeps its ID and the plugins applied to it
+			routeChanges = append(routeChanges, KongChange{Action: kongUpdate, Kind: "route", Name: name,
+				Detail: strings.Join(details, ", "),
+				method: http.MethodPatch, path: RoutesPath + "/" + have.ID,
+				body: map[string]interface{}{"paths": want.Paths, "service": want.Service,
+					"tags": taggedWith(have.Tags)}})
+		}
+	}
+
+	for _, name := range sortedKeys(desired.plugins) {
+		want := desired.plugins[name]
+		have, ok := observed.plugins[name]
+		switch {
+		case !ok && want.Route != nil:
+			path := strings.Join([]string{RoutesPath, want.Route.Name, PluginsPath}, "/")
+			want.Route = nil
+			pluginChanges = append(pluginChanges, KongChange{Action: kongCreate, Kind: "plugin", Name: name,
+				method: http.MethodPost, path: path, body: want})
+		case !ok:
+			pluginChanges = append(pluginChanges, KongChange{Action: kongCreate, Kind: "plugin", Name: name,
+				method: http.MethodPost, path: PluginsPath, body: want})
+		default:
+			var details []string
+			if drift := configDrift(want.Config, have.Config); len(drift) > 0 {
+				details = append(details, "config "+strings.Join(drift, ","))
+			}
+			if !hasEdgeXTag(have.Tags) {
+				details = append(details, "tag "+EdgeXTag)
+			}
+			if len(details) > 0 {
+				pluginChanges = append(pluginChanges, KongChange{Action: kongUpdate, Kind: "plugin", Name: name,
+					Detail: strings.Join(details, ", "),
+					method: http.MethodPatch, path: PluginsPath + "/" + have.ID,
+					body: map[string]interface{}{"config": want.Config, "tags": taggedWith(have.Tags)}})
+			}
+		}
+	}
+
+	var plan KongPlan
+	plan = append(plan, deletes...)
+	plan = append(plan, serviceChanges...)
+	plan = append(plan, routeChanges...)
+	plan = append(plan, serviceDeletes...)
+	return append(plan, pluginChanges...)
+}
+
+// taggedWith returns the tags with EdgeXTag added, keeping the tags others gave the object
+func taggedWith(tags []string) []string {
+	if hasEdgeXTag(tags) {
+		return tags
+	}
+	return append(append([]string{}, tags...), EdgeXTag)
+}
+
+// configDrift returns the sorted keys of desired whose value differs in the observed plugin config.  Keys which are
+// not desired are left to Kong's defaults and ignored.
+func configDrift(desired map[string]interface{}, observed map[string]interface{}) []string {
+	var drift []string
+	for key, value := range desired {
+		// Round trip through JSON so the desired value has the types of one decoded from Kong
+		data, _ := json.Marshal(value)
+		var want interface{}
+		_ = json.Unmarshal(data, &want)
+		if !reflect.DeepEqual(want, observed[key]) {
+			drift = append(drift, key)
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// listKongObjects reads every page of the Kong admin API list at path into objects, a pointer to a slice
+func (s *Service) listKongObjects(path string, objects interface{}) error {
+	items := reflect.ValueOf(objects).Elem()
+	next := "/" + path
+	for next != "" {
+		var page struct {
+			Data json.RawMessage `json:"data"`
+			Next string          `json:"next"`
+		}
+		if err := s.kongRequest(http.MethodGet, strings.TrimPrefix(next, "/"), nil, &page); err != nil {
+			return fmt.Errorf("failed to list Kong %s: %s", path, err.Error())
+		}
+
+		pageItems := reflect.New(items.Type())
+		if err := json.Unmarshal(page.Data, pageItems.Interface()); err != nil {
+			return fmt.Errorf("failed to parse Kong %s: %s", path, err.Error())
+		}
+		items.Set(reflect.AppendSlice(items, pageItems.Elem()))
+		next = page.Next
+	}
+	return nil
+}
+
+// kongRequest sends a request with a JSON body to the Kong admin API at path and decodes the response into
+// response when it is not nil
+func (s *Service) kongRequest(method string, path string, body interface{}, response interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = strings.NewReader(string(data))
+	}
+
+	req, err := http.NewRequest(method, s.configuration.KongURL.GetProxyBaseURL()+"/"+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Add(clients.ContentType, clients.ContentTypeJSON)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+	default:
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s %s returned status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	if response != nil {
+		return json.NewDecoder(resp.Body).Decode(response)
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKong is an in-memory Kong admin API for services, routes and plugins which lists two objects per page
+type fakeKong struct {
+	mutex    sync.Mutex
+	nextID   int
+	objects  map[string]map[string]map[string]interface{} // kind -> id -> object
+	mutating int
+}
+
+func newFakeKong() *fakeKong {
+	return &fakeKong{objects: map[string]map[string]map[string]interface{}{
+		ServicesPath:  {},
+		RoutesPath:    {},
+		PluginsPath:   {},
+		ConsumersPath: {},
+		"acls":        {},
+		JWTsPath:      {},
+		OAuth2Path:    {},
+	}}
+}
+
+func (k *fakeKong) add(kind string, object map[string]interface{}) string {
+	k.nextID++
+	id := fmt.Sprintf("%s-%d", kind, k.nextID)
+	object["id"] = id
+	k.objects[kind][id] = object
+	return id
+}
+
+func (k *fakeKong) names(kind string) []string {
+	var names []string
+	for _, object := range k.objects[kind] {
+		names = append(names, fmt.Sprint(object["name"]))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (k *fakeKong) byName(kind string, name string) map[string]interface{} {
+	for _, object := range k.objects[kind] {
+		if object["name"] == name {
+			return object
+		}
+	}
+	return nil
+}
+
+func (k *fakeKong) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var body map[string]interface{}
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		k.mutating++
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		var ids []string
+		for id := range k.objects[parts[0]] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		offset := 0
+		fmt.Sscan(r.URL.Query().Get("offset"), &offset)
+		page := map[string]interface{}{"data": []interface{}{}}
+		for i := offset; i < len(ids) && i < offset+2; i++ {
+			page["data"] = append(page["data"].([]interface{}), k.objects[parts[0]][ids[i]])
+		}
+		if offset+2 < len(ids) {
+			page["next"] = fmt.Sprintf("/%s?offset=%d", parts[0], offset+2)
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodPost && len(parts) == 1:
+		k.add(parts[0], body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == ServicesPath && parts[2] == RoutesPath:
+		service := k.byName(ServicesPath, parts[1])
+		if service == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body["service"] = map[string]interface{}{"id": service["id"]}
+		k.add(RoutesPath, body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[0] == RoutesPath && parts[2] == PluginsPath:
+		route := k.byName(RoutesPath, parts[1])
+		if route == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body["route"] = map[string]interface{}{"id": route["id"]}
+		k.add(PluginsPath, body)
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPatch && len(parts) == 2:
+		object := k.objects[parts[0]][parts[1]]
+		if object == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if service, ok := body["service"].(map[string]interface{}); ok && service["name"] != nil {
+			body["service"] = map[string]interface{}{"id": k.byName(ServicesPath, service["name"].(string))["id"]}
+		}
+		for key, value := range body {
+			object[key] = value
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete && len(parts) == 2:
+		k.mutating++
+		if _, ok := k.objects[parts[0]][parts[1]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(k.objects[parts[0]], parts[1])
+		// Kong deletes the plugins of a route along with it
+		for id, plugin := range k.objects[PluginsPath] {
+			if route, ok := plugin["route"].(map[string]interface{}); ok && route["id"] == parts[1] {
+				delete(k.objects[PluginsPath], id)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newReconcileService(t *testing.T, kong *fakeKong) (*Service, func()) {
+	ts := httptest.NewServer(kong)
+	host, port, err := parseHostAndPort(ts, t)
+	require.NoError(t, err)
+
+	configuration := &config.ConfigurationStruct{
+		KongURL:  config.KongUrlInfo{Server: host, AdminPort: port},
+		KongAuth: config.KongAuthInfo{Name: "jwt"},
+		KongACL:  config.KongAclInfo{Name: "acl", WhiteList: "admin"},
+		Clients: map[string]bootstrapConfig.ClientInfo{
+			"CoreData": {Protocol: "http", Host: "edgex-core-data", Port: 48080},
+			"Metadata": {Protocol: "http", Host: "edgex-core-metadata", Port: 48081},
+			"Command":  {Protocol: "http", Host: "edgex-core-command", Port: 48082},
+		},
+	}
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+	service.additionalRoutes = "App.http://edgex-app:48095"
+	return &service, ts.Close
+}
+
+func planStrings(plan KongPlan) []string {
+	var changes []string
+	for _, change := range plan {
+		changes = append(changes, change.String())
+	}
+	return changes
+}
+
+func TestReconcileEmptyKong(t *testing.T) {
+	kong := newFakeKong()
+	service, closer := newReconcileService(t, kong)
+	defer closer()
+
+	plan, err := service.Reconcile(false)
+
+	require.NoError(t, err)
+	assert.Len(t, plan, 10)
+	assert.Equal(t, []string{"app", "command", "coredata", "metadata"}, kong.names(ServicesPath))
+	assert.Equal(t, []string{"app", "command", "coredata", "metadata"}, kong.names(RoutesPath))
+	assert.Equal(t, []string{"acl", "jwt"}, kong.names(PluginsPath))
+	route := kong.byName(RoutesPath, "coredata")
+	assert.Equal(t, []interface{}{"/coredata"}, route["paths"])
+	assert.Equal(t, kong.byName(ServicesPath, "coredata")["id"], route["service"].(map[string]interface{})["id"])
+
+	// Reconciling again finds nothing to change
+	plan, err = service.Reconcile(false)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+}
+
+func TestReconcileDrift(t *testing.T) {
+	kong := newFakeKong()
+	service, closer := newReconcileService(t, kong)
+	defer closer()
+	_, err := service.Reconcile(false)
+	require.NoError(t, err)
+
+	// Drift Kong from the configuration by hand
+	kong.byName(ServicesPath, "coredata")["port"] = 58080
+	kong.byName(RoutesPath, "metadata")["paths"] = []interface{}{"/meta"}
+	kong.byName(PluginsPath, "acl")["config"] = map[string]interface{}{"whitelist": []interface{}{"admin", "guest"}}
+	tags := []interface{}{EdgeXTag}
+	oldService := kong.add(ServicesPath, map[string]interface{}{"name": "old", "host": "old", "port": 1, "protocol": "http",
+		"tags": tags})
+	kong.add(RoutesPath, map[string]interface{}{"name": "old", "paths": []interface{}{"/old"},
+		"service": map[string]interface{}{"id": oldService}, "tags": tags})
+	kong.byName(RoutesPath, "command")["service"] = map[string]interface{}{"id": oldService}
+	kong.add(PluginsPath, map[string]interface{}{"name": "cors", "tags": tags})
+	kong.add(PluginsPath, map[string]interface{}{"name": "acl", "consumer": map[string]interface{}{"id": "someone"}})
+	kong.mutating = 0
+
+	plan, err := service.Reconcile(true)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"delete plugin cors",
+		"delete route old",
+		"update service coredata: http://edgex-core-data:58080 -> http://edgex-core-data:48080",
+		"update route command: service old -> command",
+		"update route metadata: paths /meta -> /metadata",
+		"delete service old",
+		"update plugin acl: config whitelist",
+	}, planStrings(plan))
+	assert.Zero(t, kong.mutating, "dry run must not change Kong")
+
+	_, err = service.Reconcile(false)
+	require.NoError(t, err)
+	plan, err = service.Reconcile(true)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+	assert.Equal(t, []string{"app", "command", "coredata", "metadata"}, kong.names(ServicesPath))
+	assert.Equal(t, []string{"acl", "acl", "jwt"}, kong.names(PluginsPath), "consumer plugins are left untouched")
+}
+
+func TestReconcileRoutePlugins(t *testing.T) {
+	kong := newFakeKong()
+	service, closer := newReconcileService(t, kong)
+	defer closer()
+	service.configuration.RoutePlugins = map[string]config.RoutePluginsInfo{
+		"CoreData": {RateLimit: config.RateLimitInfo{Minute: 600}},
+		"Command":  {CORS: config.CORSInfo{Origins: []string{"*"}}},
+	}
+	_, err := service.Reconcile(false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acl", "cors", "jwt", "rate-limiting"}, kong.names(PluginsPath))
+
+	// A changed limit updates the plugin, and a route moved to another service is moved back keeping its plugins
+	service.configuration.RoutePlugins["CoreData"] = config.RoutePluginsInfo{RateLimit: config.RateLimitInfo{Minute: 60}}
+	for _, plugin := range kong.objects[PluginsPath] {
+		if plugin["name"] == CORSPlugin {
+			delete(kong.objects[PluginsPath], plugin["id"].(string))
+		}
+	}
+	kong.byName(RoutesPath, "command")["service"] = kong.byName(RoutesPath, "metadata")["service"]
+
+	plan, err := service.Reconcile(false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"update route command: service metadata -> command",
+		"create plugin cors@route:command",
+		"update plugin rate-limiting@route:coredata: config minute",
+	}, planStrings(plan))
+	plan, err = service.Reconcile(true)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+}
+
+func TestReconcileUntaggedObjects(t *testing.T) {
+	kong := newFakeKong()
+	service, closer := newReconcileService(t, kong)
+	defer closer()
+	// Objects added to Kong by others, one of them by the name of a service EdgeX wants
+	other := kong.add(ServicesPath, map[string]interface{}{"name": "other", "host": "other", "port": 1, "protocol": "http"})
+	kong.add(RoutesPath, map[string]interface{}{"name": "other", "paths": []interface{}{"/other"},
+		"service": map[string]interface{}{"id": other}})
+	kong.add(PluginsPath, map[string]interface{}{"name": "cors", "tags": []interface{}{"mine"}})
+	kong.add(ServicesPath, map[string]interface{}{"name": "coredata", "host": "edgex-core-data", "port": 48080,
+		"protocol": "http", "tags": []interface{}{"mine"}})
+
+	plan, err := service.Reconcile(false)
+
+	require.NoError(t, err)
+	assert.Contains(t, planStrings(plan), "update service coredata: tag edgex")
+	for _, change := range plan {
+		assert.NotEqual(t, kongDelete, change.Action, change.String())
+	}
+	assert.Equal(t, []string{"app", "command", "coredata", "metadata", "other"}, kong.names(ServicesPath))
+	assert.Equal(t, []string{"app", "command", "coredata", "metadata", "other"}, kong.names(RoutesPath))
+	assert.Equal(t, []string{"acl", "cors", "jwt"}, kong.names(PluginsPath))
+	assert.Equal(t, []interface{}{"mine", EdgeXTag}, kong.byName(ServicesPath, "coredata")["tags"])
+	assert.Nil(t, kong.byName(ServicesPath, "other")["tags"])
+
+	plan, err = service.Reconcile(true)
+	require.NoError(t, err)
+	assert.Empty(t, plan)
+}
+
+func TestReconcileUnsupportedAuth(t *testing.T) {
+	kong := newFakeKong()
+	service, closer := newReconcileService(t, kong)
+	defer closer()
+	service.configuration.KongAuth.Name = "basic"
+
+	_, err := service.Reconcile(true)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+)
+
+const (
+	RateLimitPlugin   = "rate-limiting"
+	CORSPlugin        = "cors"
+	RequestSizePlugin = "request-size-limiting"
+)
+
+// routePlugins returns the Kong plugins configured in RoutePlugins for the routes of clients, sorted by route and
+// plugin name.  Every RoutePlugins entry must name one of clients.
+func (s *Service) routePlugins(clients map[string]bootstrapConfig.ClientInfo) ([]kongPluginState, error) {
+	var names []string
+	for name := range s.configuration.RoutePlugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var plugins []kongPluginState
+	for _, name := range names {
+		routeName := strings.ToLower(name)
+		known := false
+		for clientName := range clients {
+			known = known || strings.ToLower(clientName) == routeName
+		}
+		if !known {
+			return nil, fmt.Errorf("route plugins are configured for %s which is not one of the proxied clients", name)
+		}
+
+		routePlugins, err := kongRoutePlugins(s.configuration.RoutePlugins[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid route plugins for %s: %s", name, err.Error())
+		}
+		for _, plugin := range routePlugins {
+			plugin.Route = &kongReference{Name: routeName}
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins, nil
+}
+
+// kongRoutePlugins returns the Kong plugins enabled in info, sorted by name
+func kongRoutePlugins(info config.RoutePluginsInfo) ([]kongPluginState, error) {
+	var plugins []kongPluginState
+
+	if info.CORS.Enabled() {
+		pluginConfig := map[string]interface{}{
+			"origins":     info.CORS.Origins,
+			"credentials": info.CORS.Credentials,
+		}
+		if len(info.CORS.Methods) > 0 {
+			pluginConfig["methods"] = info.CORS.Methods
+		}
+		if len(info.CORS.Headers) > 0 {
+			pluginConfig["headers"] = info.CORS.Headers
+		}
+		if len(info.CORS.ExposedHeaders) > 0 {
+			pluginConfig["exposed_headers"] = info.CORS.ExposedHeaders
+		}
+		if info.CORS.MaxAge > 0 {
+			pluginConfig["max_age"] = info.CORS.MaxAge
+		}
+		plugins = append(plugins, kongPluginState{Name: CORSPlugin, Config: pluginConfig})
+	}
+
+	if info.RateLimit.Enabled() {
+		policy := info.RateLimit.Policy
+		switch policy {
+		case "":
+			policy = "local"
+		case "local", "cluster":
+		default:
+			return nil, fmt.Errorf("unsupported rate limit policy %s", policy)
+		}
+		pluginConfig := map[string]interface{}{"policy": policy}
+		for unit, limit := range map[string]int{
+			"second": info.RateLimit.Second,
+			"minute": info.RateLimit.Minute,
+			"hour":   info.RateLimit.Hour,
+			"day":    info.RateLimit.Day,
+		} {
+			if limit > 0 {
+				pluginConfig[unit] = limit
+			}
+		}
+		plugins = append(plugins, kongPluginState{Name: RateLimitPlugin, Config: pluginConfig})
+	}
+
+	if info.RequestSize.Enabled() {
+		unit := info.RequestSize.SizeUnit
+		switch unit {
+		case "":
+			unit = "megabytes"
+		case "megabytes", "kilobytes", "bytes":
+		default:
+			return nil, fmt.Errorf("unsupported request size unit %s", unit)
+		}
+		plugins = append(plugins, kongPluginState{Name: RequestSizePlugin, Config: map[string]interface{}{
+			"allowed_payload_size": info.RequestSize.AllowedPayloadSize,
+			"size_unit":            unit,
+		}})
+	}
+
+	return plugins, nil
+}
+
+// initRoutePlugins applies the plugins configured for the routes of clients
+func (s *Service) initRoutePlugins(clients map[string]bootstrapConfig.ClientInfo) error {
+	plugins, err := s.routePlugins(clients)
+	if err != nil {
+		s.loggingClient.Error(err.Error())
+		return err
+	}
+	for _, plugin := range plugins {
+		if err := s.initRoutePlugin(plugin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) initRoutePlugin(plugin kongPluginState) error {
+	routeName := plugin.Route.Name
+	plugin.Route = nil
+	plugin.Tags = []string{EdgeXTag}
+	data, err := json.Marshal(plugin)
+	if err != nil {
+		s.loggingClient.Error(err.Error())
+		return err
+	}
+	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), RoutesPath, routeName, PluginsPath}
+
+	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(string(data)))
+	if err != nil {
+		e := fmt.Sprintf("failed to set up %s for route %s with error %s", plugin.Name, routeName, err.Error())
+		s.loggingClient.Error(e)
+		return err
+	}
+	req.Header.Add(clients.ContentType, clients.ContentTypeJSON)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		e := fmt.Sprintf("failed to set up %s for route %s with error %s", plugin.Name, routeName, err.Error())
+		s.loggingClient.Error(e)
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		s.loggingClient.Info(fmt.Sprintf("successful to set up %s for route %s", plugin.Name, routeName))
+	case http.StatusConflict:
+		s.loggingClient.Info(fmt.Sprintf("%s for route %s has been set up", plugin.Name, routeName))
+	default:
+		b, _ := ioutil.ReadAll(resp.Body)
+		e := fmt.Sprintf("failed to set up %s for route %s with errorcode %d: %s",
+			plugin.Name, routeName, resp.StatusCode, strings.TrimSpace(string(b)))
+		s.loggingClient.Error(e)
+		return errors.New(e)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package proxy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/proxy/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestConfiguration(t *testing.T) *config.ConfigurationStruct {
+	contents, err := ioutil.ReadFile("./testdata/configuration.toml")
+	require.NoError(t, err)
+	configuration := &config.ConfigurationStruct{}
+	require.NoError(t, toml.Unmarshal(contents, configuration))
+	return configuration
+}
+
+func TestRoutePlugins(t *testing.T) {
+	configuration := loadTestConfiguration(t)
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+	plugins, err := service.routePlugins(configuration.Clients)
+
+	require.NoError(t, err)
+	var keys []string
+	for _, plugin := range plugins {
+		keys = append(keys, plugin.key())
+	}
+	assert.Equal(t, []string{
+		"cors@route:command",
+		"rate-limiting@route:command",
+		"request-size-limiting@route:command",
+		"rate-limiting@route:coredata",
+		"request-size-limiting@route:coredata",
+	}, keys)
+	assert.Equal(t, map[string]interface{}{
+		"origins":     []string{"https://integrator.example.com"},
+		"methods":     []string{"GET", "PUT"},
+		"headers":     []string{"Authorization", "Content-Type"},
+		"credentials": false,
+		"max_age":     3600,
+	}, plugins[0].Config)
+	assert.Equal(t, map[string]interface{}{"policy": "cluster", "second": 5}, plugins[1].Config)
+	assert.Equal(t, map[string]interface{}{"allowed_payload_size": 64, "size_unit": "kilobytes"}, plugins[2].Config)
+	assert.Equal(t, map[string]interface{}{"policy": "local", "minute": 600, "hour": 10000}, plugins[3].Config)
+	assert.Equal(t, map[string]interface{}{"allowed_payload_size": 8, "size_unit": "megabytes"}, plugins[4].Config)
+}
+
+func TestRoutePluginsInvalid(t *testing.T) {
+	testcases := map[string]config.RoutePluginsInfo{
+		"Unknown":  {RateLimit: config.RateLimitInfo{Minute: 1}},
+		"CoreData": {RateLimit: config.RateLimitInfo{Minute: 1, Policy: "redis"}},
+		"Command":  {RequestSize: config.RequestSizeInfo{AllowedPayloadSize: 1, SizeUnit: "gigabytes"}},
+	}
+
+	for name, info := range testcases {
+		configuration := loadTestConfiguration(t)
+		configuration.RoutePlugins = map[string]config.RoutePluginsInfo{name: info}
+		mockLogger := logger.MockLogger{}
+		service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+		_, err := service.routePlugins(configuration.Clients)
+
+		assert.Error(t, err, name)
+	}
+}
+
+func TestInitRoutePlugins(t *testing.T) {
+	configuration := loadTestConfiguration(t)
+	var mutex sync.Mutex
+	posted := make(map[string][]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		var plugin map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&plugin))
+		assert.NotContains(t, plugin, "route")
+		mutex.Lock()
+		posted[r.URL.EscapedPath()] = append(posted[r.URL.EscapedPath()], plugin["name"].(string))
+		mutex.Unlock()
+		if plugin["name"] == CORSPlugin {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+	host, port, err := parseHostAndPort(ts, t)
+	require.NoError(t, err)
+	configuration.KongURL = config.KongUrlInfo{Server: host, AdminPort: port}
+	mockLogger := logger.MockLogger{}
+	service := NewService(NewRequestor(true, 10, "", mockLogger), mockLogger, configuration)
+
+	err = service.initRoutePlugins(configuration.Clients)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"/routes/command/plugins":  {CORSPlugin, RateLimitPlugin, RequestSizePlugin},
+		"/routes/coredata/plugins": {RateLimitPlugin, RequestSizePlugin},
+	}, posted)
+}
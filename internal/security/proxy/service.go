@@ -158,6 +158,7 @@ func (s *Service) Init() error {
 		routeParams := &KongRoute{
 			Paths: []string{"/" + strings.ToLower(clientName)},
 			Name:  strings.ToLower(clientName),
+			Tags:  []string{EdgeXTag},
 		}
 
 		err = s.initKongRoutes(routeParams, strings.ToLower(clientName))
@@ -166,7 +167,12 @@ func (s *Service) Init() error {
 		}
 	}
 
-	err := s.initAuthMethod(s.configuration.KongAuth.Name, s.configuration.KongAuth.TokenTTL)
+	err := s.initRoutePlugins(mergedClients)
+	if err != nil {
+		return err
+	}
+
+	err = s.initAuthMethod(s.configuration.KongAuth.Name, s.configuration.KongAuth.TokenTTL)
 	if err != nil {
 		return err
 	}
@@ -332,6 +338,7 @@ func (s *Service) initKongService(service *KongService) error {
 		"host":     {service.Host},
 		"port":     {strconv.Itoa(service.Port)},
 		"protocol": {service.Protocol},
+		"tags[]":   {EdgeXTag},
 	}
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), ServicesPath}
 
@@ -411,6 +418,7 @@ func (s *Service) initACL(name string, whitelist string) error {
 	formVals := url.Values{
 		"name":             {aclParams.Name},
 		"config.whitelist": {aclParams.WhiteList},
+		"tags[]":           {EdgeXTag},
 	}
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), PluginsPath}
 	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
@@ -455,7 +463,8 @@ func (s *Service) initAuthMethod(name string, ttl int) error {
 
 func (s *Service) initJWTAuth() error {
 	formVals := url.Values{
-		"name": {"jwt"},
+		"name":   {"jwt"},
+		"tags[]": {EdgeXTag},
 	}
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), PluginsPath}
 	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
@@ -503,6 +512,7 @@ func (s *Service) initOAuth2(ttl int) error {
 		"config.enable_client_credentials": {oauth2Params.EnableClientCredentials},
 		"config.global_credentials":        {oauth2Params.EnableGlobalCredentials},
 		"config.refresh_token_ttl":         {strconv.Itoa(oauth2Params.TokenTTL)},
+		"tags[]":                           {EdgeXTag},
 	}
 	tokens := []string{s.configuration.KongURL.GetProxyBaseURL(), PluginsPath}
 	req, err := http.NewRequest(http.MethodPost, strings.Join(tokens, "/"), strings.NewReader(formVals.Encode()))
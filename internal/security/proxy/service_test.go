@@ -282,11 +282,11 @@ func TestInitKongService(t *testing.T) {
 		}
 
 		recvd := string(body)
-		if recvd == "host=test&name=conflict&port=80&protocol=http" {
+		if recvd == "host=test&name=conflict&port=80&protocol=http&tags%5B%5D=edgex" {
 			w.WriteHeader(http.StatusConflict)
 			return
 		}
-		if recvd != "host=test&name=test&port=80&protocol=http" {
+		if recvd != "host=test&name=test&port=80&protocol=http&tags%5B%5D=edgex" {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
@@ -410,11 +410,11 @@ func TestInitACL(t *testing.T) {
 		}
 
 		recvd := string(body)
-		if recvd == "config.whitelist=testgroup&name=conflict" {
+		if recvd == "config.whitelist=testgroup&name=conflict&tags%5B%5D=edgex" {
 			w.WriteHeader(http.StatusConflict)
 			return
 		}
-		if recvd != "config.whitelist=testgroup&name=test" {
+		if recvd != "config.whitelist=testgroup&name=test&tags%5B%5D=edgex" {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
@@ -20,3 +20,6 @@ const SecretStore = "EDGEX_SECURITY_SECRET_STORE"
 const Confdir = "res"
 const ConfigFileName = "configuration.toml"
 const VaultToken = "X-Vault-Token"
+
+// ACLSecretPath is the path, relative to the SecretStore Path, of the services' Redis ACL rules
+const ACLSecretPath = "redisacl"
@@ -19,6 +19,8 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
@@ -145,6 +147,58 @@ func (handler *Handler) maybeSetCredentials(ctx context.Context, _ *sync.WaitGro
 	return true
 }
 
+// setACLUsers applies the ACL rules of the services' Redis users, which security-secretstore-setup stores in the vault.
+// Redis doesn't persist them, so they are applied every time this service runs.
+func (handler *Handler) setACLUsers(ctx context.Context, _ *sync.WaitGroup, startupTimer startup.Timer, dic *di.Container) bool {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
+
+	var acl map[string]string
+	for startupTimer.HasNotElapsed() {
+		secrets, err := secretProvider.GetSecrets(ACLSecretPath)
+		if err == nil {
+			acl = secrets
+			break
+		}
+
+		lc.Warn(fmt.Sprintf("Could not retrieve Redis ACL (startup timer has not expired): %s", err.Error()))
+		startupTimer.SleepForInterval()
+	}
+
+	if acl == nil {
+		lc.Error("Failed to retrieve Redis ACL before startup timer expired")
+		return false
+	}
+
+	if err := setUsers(handler.redisConn, acl); err != nil {
+		lc.Error(fmt.Sprintf("Could not set Redis ACL users: %s", err.Error()))
+		return false
+	}
+
+	lc.Info(fmt.Sprintf("ACL users have been set for %d service(s).", len(acl)))
+	return true
+}
+
+// setUsers replaces the rules of each user in acl, which maps the users' names to their space separated rules.
+func setUsers(redisConn redigo.Conn, acl map[string]string) error {
+	var usernames []string
+	for username := range acl {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	for _, username := range usernames {
+		args := []interface{}{"SETUSER", username, "reset"}
+		for _, rule := range strings.Fields(acl[username]) {
+			args = append(args, rule)
+		}
+		if _, err := redisConn.Do("ACL", args...); err != nil {
+			return fmt.Errorf("user %s: %s", username, err.Error())
+		}
+	}
+	return nil
+}
+
 func testConnection(redisConn redigo.Conn) error {
 	_, err := redisConn.Do("INFO", "SERVER")
 	return err
@@ -67,6 +67,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, router *mux.Router, re
 			handler.getCredentials,
 			handler.connect,
 			handler.maybeSetCredentials,
+			handler.setACLUsers,
 		},
 	)
 }
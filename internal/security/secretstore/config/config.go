@@ -25,6 +25,11 @@ type ConfigurationStruct struct {
 	Writable      WritableInfo
 	SecretService secretstoreclient.SecretServiceInfo
 	Databases     map[string]Database
+	PKI           PKIInfo
+	// CredentialRotation schedules the rotation of the generated Redis password
+	CredentialRotation CredentialRotationInfo
+	// KeyShareEscrow splits the Vault key shares across files encrypted to different recipients
+	KeyShareEscrow KeyShareEscrowInfo
 }
 
 type WritableInfo struct {
@@ -35,6 +40,75 @@ type WritableInfo struct {
 type Database struct {
 	Username string
 	Service  string
+	// KeyPatterns are the Redis key patterns the service's ACL user may access; all keys when empty
+	KeyPatterns []string
+	// V1Objects adds the pattern of the bare UUID keys under which the V1 APIs store objects to KeyPatterns; those keys
+	// carry no service prefix, so every service setting it may access the V1 objects of the others
+	V1Objects bool
+}
+
+// PKIInfo configures the internal certificate authority, built on the secret store's PKI secrets engine, which
+// issues the EdgeX services' TLS server certificates.
+type PKIInfo struct {
+	Enabled                bool
+	RootMount              string
+	IntermediateMount      string
+	RootCommonName         string
+	IntermediateCommonName string
+	RootTTL                string
+	IntermediateTTL        string
+	// Role names the PKI role under which service certificates are issued
+	Role           string
+	CertificateTTL string
+	// A certificate is reissued once it is due to expire within RenewBefore
+	RenewBefore string
+	// RenewInterval, when set, keeps security-secretstore-setup running to check for certificates due for renewal
+	// at this interval
+	RenewInterval  string
+	CertFolderPath string
+	Services       map[string]PKIServiceInfo
+}
+
+// PKIServiceInfo configures the certificate issued to a service.  The service's certificate files are written to
+// OutputPath, which defaults to <CertFolderPath>/<service>, and its common name defaults to the service name.
+type PKIServiceInfo struct {
+	CommonName string
+	AltNames   []string
+	IPSans     []string
+	OutputPath string
+}
+
+// CredentialRotationInfo configures the scheduled rotation of the generated Redis password.  The time of the last
+// rotation is kept in StateFile within SecretService.TokenFolderPath.
+type CredentialRotationInfo struct {
+	Enabled bool
+	// Interval is the maximum age of the password, e.g. 2160h for 90 days
+	Interval string
+	// CheckInterval determines how often security-secretstore-setup checks whether the password is due for rotation
+	CheckInterval string
+	RedisHost     string
+	RedisPort     int
+	// ReconnectTimeout bounds the wait for the services' Redis connections to be re-established after a rotation, and
+	// so the grace period during which the previous passwords remain valid
+	ReconnectTimeout string
+	// RestartCommand, when set, is run after a rotation with the names of the services still connected with their
+	// previous password as arguments, so that they restart with the new one
+	RestartCommand string
+	StateFile      string
+}
+
+// KeyShareEscrowInfo configures the escrow of the Vault key shares.  When it has recipients, the key shares are
+// dealt out across one file per recipient, each encrypted to the recipient's RSA public key, and removed from the
+// init response, so no single file holds enough key shares to unseal Vault.
+type KeyShareEscrowInfo struct {
+	Recipients []KeyShareRecipientInfo
+}
+
+// KeyShareRecipientInfo names the PEM-encoded RSA public key of an escrow recipient and the file its key shares are
+// written to, which should be on a different disk from those of the other recipients.
+type KeyShareRecipientInfo struct {
+	PublicKeyPath string
+	OutputPath    string
 }
 
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
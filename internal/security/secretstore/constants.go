@@ -36,6 +36,14 @@ path "auth/token/create/*" {
   capabilities = ["create", "update", "sudo"]
 }
 
+path "auth/token/lookup-accessor" {
+  capabilities = ["update"]
+}
+
+path "auth/token/renew-accessor" {
+  capabilities = ["update"]
+}
+
 path "sys/policies/acl/edgex-service-*"
 {
   capabilities = ["create", "read", "update", "delete" ]
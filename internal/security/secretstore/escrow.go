@@ -0,0 +1,444 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// US Export Control Classification Number (ECCN): 5D002TSU
+//
+
+package secretstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+)
+
+/*
+
+Key share escrow deals the Vault key shares out round-robin across the
+recipients, so that each recipient holds fewer key shares than the unseal
+threshold.  The key shares of a recipient are encrypted with a random
+AES-256-GCM key, which is in turn encrypted to the recipient's RSA public key
+with RSA-OAEP (SHA-256).  Unsealing Vault then requires the private keys of
+enough recipients to reach the threshold.
+
+*/
+
+// escrowLabel is the RSA-OAEP label binding the encrypted keys to key share escrow
+var escrowLabel = []byte("edgex-key-share-escrow")
+
+// EscrowFile is the file of the key shares escrowed to one recipient
+type EscrowFile struct {
+	// Recipient is the hex-encoded SHA-256 fingerprint of the recipient's public key
+	Recipient string `json:"recipient"`
+	// Shares are the indexes of the escrowed key shares among all TotalShares key shares
+	Shares      []int `json:"shares"`
+	TotalShares int   `json:"total_shares"`
+	Threshold   int   `json:"threshold"`
+	// EncryptedKey is the hex-encoded AES key of the key shares, encrypted to the recipient's public key
+	EncryptedKey string `json:"encrypted_key"`
+	Nonce        string `json:"nonce"`
+	// Ciphertext is the hex-encoded AES-GCM encryption of the JSON array of hex-encoded key shares
+	Ciphertext string `json:"ciphertext"`
+}
+
+// KeyShareEscrow writes the key shares of an init response to the files of the escrow recipients
+type KeyShareEscrow struct {
+	fileOpener  fileioperformer.FileIoPerformer
+	publicKeys  []*rsa.PublicKey
+	outputPaths []string
+	threshold   int
+}
+
+// NewKeyShareEscrow loads the public keys of the recipients in escrowConfig and checks that dealing the key shares
+// of secretService out across them leaves each recipient short of the threshold.  Since no root token can be
+// regenerated once the key shares are escrowed, escrow also requires secretService to keep the root token, and
+// vmkEncryption to encrypt it where it is saved.
+// It returns nil if escrow has no recipients.
+func NewKeyShareEscrow(
+	fileOpener fileioperformer.FileIoPerformer,
+	escrowConfig config.KeyShareEscrowInfo,
+	secretService secretstoreclient.SecretServiceInfo,
+	vmkEncryption *VMKEncryption) (*KeyShareEscrow, error) {
+
+	if len(escrowConfig.Recipients) == 0 {
+		return nil, nil
+	}
+	if secretService.RevokeRootTokens {
+		return nil, errors.New("key share escrow requires RevokeRootTokens to be false, as the root token " +
+			"cannot be regenerated without the key shares")
+	}
+	if !vmkEncryption.IsEncrypting() {
+		return nil, errors.New("key share escrow requires vault master key encryption, which encrypts the saved " +
+			"root token; set IKM_PROVIDER or IKM_HOOK")
+	}
+	threshold := secretService.VaultSecretThreshold
+	if err := checkEscrowDeal(secretService.VaultSecretShares, threshold, len(escrowConfig.Recipients)); err != nil {
+		return nil, err
+	}
+
+	escrow := &KeyShareEscrow{fileOpener: fileOpener, threshold: threshold}
+	for i, recipient := range escrowConfig.Recipients {
+		if recipient.PublicKeyPath == "" || recipient.OutputPath == "" {
+			return nil, fmt.Errorf("escrow recipient %d requires PublicKeyPath and OutputPath", i)
+		}
+		publicKey, err := LoadRSAPublicKey(fileOpener, recipient.PublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		escrow.publicKeys = append(escrow.publicKeys, publicKey)
+		escrow.outputPaths = append(escrow.outputPaths, recipient.OutputPath)
+	}
+	return escrow, nil
+}
+
+// checkEscrowDeal returns an error unless every recipient receives at least one and fewer than threshold key shares
+func checkEscrowDeal(secretShares int, threshold int, recipients int) error {
+	if recipients > secretShares {
+		return fmt.Errorf("%d escrow recipients is more than the %d key shares", recipients, secretShares)
+	}
+	mostShares := (secretShares + recipients - 1) / recipients
+	if mostShares >= threshold {
+		return fmt.Errorf("an escrow recipient would hold %d of the %d key shares, enough to meet the unseal "+
+			"threshold of %d; add escrow recipients", mostShares, secretShares, threshold)
+	}
+	return nil
+}
+
+// Recipients returns the number of escrow recipients
+func (e *KeyShareEscrow) Recipients() int {
+	return len(e.publicKeys)
+}
+
+// WriteKeyShares escrows the key shares of initResponse and writes the file of each recipient
+func (e *KeyShareEscrow) WriteKeyShares(initResponse secretstoreclient.InitResponse) error {
+	files, err := EscrowKeyShares(initResponse, e.threshold, e.publicKeys)
+	if err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		path := e.outputPaths[i]
+		if err := e.fileOpener.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return fmt.Errorf("failed to create the folder of escrow file %s: %w", path, err)
+		}
+		writer, err := e.fileOpener.OpenFileWriter(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("could not create escrow file %s: %w", path, err)
+		}
+		if err := json.NewEncoder(writer).Encode(file); err != nil {
+			_ = writer.Close()
+			return fmt.Errorf("unable to write escrow file %s: %w", path, err)
+		}
+		if err := writer.Close(); err != nil {
+			return fmt.Errorf("unable to close escrow file %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// EscrowKeyShares deals the key shares of initResponse out round-robin across the recipients and returns the file
+// of each recipient, in the order of recipients
+func EscrowKeyShares(
+	initResponse secretstoreclient.InitResponse,
+	threshold int,
+	recipients []*rsa.PublicKey) ([]EscrowFile, error) {
+
+	totalShares := len(initResponse.Keys)
+	if totalShares == 0 {
+		return nil, errors.New("the init response holds no key shares to escrow")
+	}
+	if err := checkEscrowDeal(totalShares, threshold, len(recipients)); err != nil {
+		return nil, err
+	}
+
+	files := make([]EscrowFile, len(recipients))
+	shares := make([][]string, len(recipients))
+	for i, key := range initResponse.Keys {
+		recipient := i % len(recipients)
+		files[recipient].Shares = append(files[recipient].Shares, i)
+		shares[recipient] = append(shares[recipient], key)
+	}
+
+	for i, publicKey := range recipients {
+		fingerprint, err := PublicKeyFingerprint(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		files[i].Recipient = fingerprint
+		files[i].TotalShares = totalShares
+		files[i].Threshold = threshold
+		if err := sealEscrowFile(&files[i], shares[i], publicKey); err != nil {
+			return nil, fmt.Errorf("failed to encrypt the key shares of escrow recipient %d: %w", i, err)
+		}
+	}
+	return files, nil
+}
+
+// sealEscrowFile encrypts shares into file with a random AES key encrypted to publicKey
+func sealEscrowFile(file *EscrowFile, shares []string, publicKey *rsa.PublicKey) error {
+	plaintext, err := json.Marshal(shares)
+	if err != nil {
+		return err
+	}
+	defer wipeKey(plaintext)
+
+	key := make([]byte, aesKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	defer wipeKey(key)
+
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aesgcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to initialize random nonce: %w", err)
+	}
+
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, key, escrowLabel)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key: %w", err)
+	}
+
+	file.EncryptedKey = hex.EncodeToString(encryptedKey)
+	file.Nonce = hex.EncodeToString(nonce)
+	file.Ciphertext = hex.EncodeToString(aesgcm.Seal(nil, nonce, plaintext, nil))
+	return nil
+}
+
+// RecoverKeyShares decrypts the escrow files for which privateKeys holds the recipient's private key and sets the
+// recovered key shares in initResponse.  It returns an error unless enough key shares to unseal were recovered.
+func RecoverKeyShares(
+	files []EscrowFile,
+	privateKeys []*rsa.PrivateKey,
+	initResponse *secretstoreclient.InitResponse) error {
+
+	keysByFingerprint := make(map[string]*rsa.PrivateKey)
+	for _, privateKey := range privateKeys {
+		fingerprint, err := PublicKeyFingerprint(&privateKey.PublicKey)
+		if err != nil {
+			return err
+		}
+		keysByFingerprint[fingerprint] = privateKey
+	}
+
+	recovered := make(map[int]string)
+	threshold := 0
+	for _, file := range files {
+		if file.Threshold > threshold {
+			threshold = file.Threshold
+		}
+		privateKey, ok := keysByFingerprint[file.Recipient]
+		if !ok {
+			continue
+		}
+		shares, err := openEscrowFile(file, privateKey)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt the escrow file of recipient %s: %w", file.Recipient, err)
+		}
+		if len(shares) != len(file.Shares) {
+			return fmt.Errorf("the escrow file of recipient %s holds %d key shares instead of %d",
+				file.Recipient, len(shares), len(file.Shares))
+		}
+		for i, index := range file.Shares {
+			recovered[index] = shares[i]
+		}
+	}
+
+	if len(recovered) == 0 || len(recovered) < threshold {
+		return fmt.Errorf("recovered %d of the %d key shares needed to unseal; supply the private keys of more "+
+			"escrow recipients", len(recovered), threshold)
+	}
+
+	indexes := make([]int, 0, len(recovered))
+	for index := range recovered {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	initResponse.Keys = make([]string, len(indexes))
+	initResponse.KeysBase64 = make([]string, len(indexes))
+	for i, index := range indexes {
+		keyShare, err := hex.DecodeString(recovered[index])
+		if err != nil {
+			return fmt.Errorf("failed to decode hex bytes of key share %d: %w", index, err)
+		}
+		initResponse.Keys[i] = recovered[index]
+		initResponse.KeysBase64[i] = base64.StdEncoding.EncodeToString(keyShare)
+		wipeKey(keyShare)
+	}
+	return nil
+}
+
+// openEscrowFile decrypts the key shares of file with privateKey
+func openEscrowFile(file EscrowFile, privateKey *rsa.PrivateKey) ([]string, error) {
+	encryptedKey, err := hex.DecodeString(file.EncryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex bytes of encrypted key: %w", err)
+	}
+	nonce, err := hex.DecodeString(file.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex bytes of nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(file.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex bytes of ciphertext: %w", err)
+	}
+
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privateKey, encryptedKey, escrowLabel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key: %w", err)
+	}
+	defer wipeKey(key)
+
+	aesgcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aesgcm.NonceSize() {
+		return nil, errors.New("nonce has an invalid length")
+	}
+	plaintext, err := aesgcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt ciphertext: %w", err)
+	}
+	defer wipeKey(plaintext)
+
+	var shares []string
+	if err := json.Unmarshal(plaintext, &shares); err != nil {
+		return nil, fmt.Errorf("failed to parse key shares: %w", err)
+	}
+	return shares, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize block cipher: %w", err)
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize AES cipher: %w", err)
+	}
+	return aesgcm, nil
+}
+
+// PublicKeyFingerprint returns the hex-encoded SHA-256 digest of the DER encoding of publicKey
+func PublicKeyFingerprint(publicKey *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+	digest := sha256.Sum256(der)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// LoadRSAPublicKey reads a PEM-encoded RSA public key, in PKIX or PKCS #1 form, from path
+func LoadRSAPublicKey(fileOpener fileioperformer.FileIoPerformer, path string) (*rsa.PublicKey, error) {
+	block, err := readPEMBlock(fileOpener, path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA public key %s: %w", path, err)
+		}
+		return publicKey, nil
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+	}
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an RSA key", path)
+	}
+	return rsaPublicKey, nil
+}
+
+// LoadRSAPrivateKey reads a PEM-encoded RSA private key, in PKCS #8 or PKCS #1 form, from path
+func LoadRSAPrivateKey(fileOpener fileioperformer.FileIoPerformer, path string) (*rsa.PrivateKey, error) {
+	block, err := readPEMBlock(fileOpener, path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse RSA private key %s: %w", path, err)
+		}
+		return privateKey, nil
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key %s is not an RSA key", path)
+	}
+	return rsaPrivateKey, nil
+}
+
+// LoadEscrowFile reads the escrow file at path
+func LoadEscrowFile(fileOpener fileioperformer.FileIoPerformer, path string) (EscrowFile, error) {
+	var file EscrowFile
+	reader, err := fileOpener.OpenFileReader(path, os.O_RDONLY, 0400)
+	if err != nil {
+		return file, fmt.Errorf("could not read escrow file %s: %w", path, err)
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+
+	if err := json.NewDecoder(readCloser).Decode(&file); err != nil {
+		return file, fmt.Errorf("unable to parse escrow file %s: %w", path, err)
+	}
+	return file, nil
+}
+
+func readPEMBlock(fileOpener fileioperformer.FileIoPerformer, path string) (*pem.Block, error) {
+	reader, err := fileOpener.OpenFileReader(path, os.O_RDONLY, 0400)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key %s: %w", path, err)
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer readCloser.Close()
+
+	data, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s is not PEM-encoded", path)
+	}
+	return block, nil
+}
+
+// hasKeyShares returns whether initResponse holds key shares, which it doesn't once they are escrowed
+func hasKeyShares(initResponse secretstoreclient.InitResponse) bool {
+	return len(initResponse.Keys) > 0 || len(initResponse.KeysBase64) > 0 || len(initResponse.EncryptedKeys) > 0
+}
@@ -0,0 +1,209 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package secretstore
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	ikmMocks "github.com/edgexfoundry/edgex-go/internal/security/ikm/mocks"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testInitResponse = secretstoreclient.InitResponse{
+	Keys:       []string{"aabbcc", "ddeeff", "001122", "334455", "667788"},
+	KeysBase64: []string{"qrvM", "3e7/", "ABEi", "M0RV", "ZneI"},
+	RootToken:  "s.root",
+}
+
+func newTestRSAKeys(t *testing.T, count int) []*rsa.PrivateKey {
+	var keys []*rsa.PrivateKey
+	for i := 0; i < count; i++ {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func publicKeys(privateKeys []*rsa.PrivateKey) []*rsa.PublicKey {
+	var keys []*rsa.PublicKey
+	for _, key := range privateKeys {
+		keys = append(keys, &key.PublicKey)
+	}
+	return keys
+}
+
+func TestEscrowKeyShares(t *testing.T) {
+	privateKeys := newTestRSAKeys(t, 3)
+
+	files, err := EscrowKeyShares(testInitResponse, 3, publicKeys(privateKeys))
+
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, []int{0, 3}, files[0].Shares)
+	assert.Equal(t, []int{1, 4}, files[1].Shares)
+	assert.Equal(t, []int{2}, files[2].Shares)
+	for i, file := range files {
+		fingerprint, err := PublicKeyFingerprint(&privateKeys[i].PublicKey)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, file.Recipient)
+		assert.Equal(t, 5, file.TotalShares)
+		assert.Equal(t, 3, file.Threshold)
+		assert.NotContains(t, file.Ciphertext, "aabbcc")
+	}
+
+	// Any two recipients can recover enough key shares to unseal
+	var initResponse secretstoreclient.InitResponse
+	err = RecoverKeyShares(files, privateKeys[1:], &initResponse)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ddeeff", "001122", "667788"}, initResponse.Keys)
+	assert.Equal(t, []string{"3e7/", "ABEi", "ZneI"}, initResponse.KeysBase64)
+
+	initResponse = secretstoreclient.InitResponse{}
+	err = RecoverKeyShares(files, privateKeys, &initResponse)
+	require.NoError(t, err)
+	assert.Equal(t, testInitResponse.Keys, initResponse.Keys)
+	assert.Equal(t, testInitResponse.KeysBase64, initResponse.KeysBase64)
+}
+
+func TestEscrowKeySharesFailPath(t *testing.T) {
+	privateKeys := newTestRSAKeys(t, 3)
+
+	_, err := EscrowKeyShares(testInitResponse, 3, publicKeys(privateKeys[:2]))
+	assert.Error(t, err, "a recipient would hold 3 of 5 shares")
+
+	_, err = EscrowKeyShares(secretstoreclient.InitResponse{Keys: []string{"aabbcc", "ddeeff"}}, 2,
+		publicKeys(privateKeys))
+	assert.Error(t, err, "more recipients than shares")
+
+	_, err = EscrowKeyShares(secretstoreclient.InitResponse{}, 3, publicKeys(privateKeys))
+	assert.Error(t, err, "no key shares")
+
+	files, err := EscrowKeyShares(testInitResponse, 3, publicKeys(privateKeys))
+	require.NoError(t, err)
+
+	var initResponse secretstoreclient.InitResponse
+	err = RecoverKeyShares(files, privateKeys[2:], &initResponse)
+	assert.Error(t, err, "one recipient is short of the threshold")
+	assert.Nil(t, initResponse.Keys)
+
+	err = RecoverKeyShares(files, newTestRSAKeys(t, 1), &initResponse)
+	assert.Error(t, err, "no matching recipient")
+
+	files[0].Ciphertext = files[1].Ciphertext
+	err = RecoverKeyShares(files, privateKeys, &initResponse)
+	assert.Error(t, err, "tampered ciphertext")
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	require.NoError(t, ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+}
+
+func escrowSecretService(shares int, threshold int) secretstoreclient.SecretServiceInfo {
+	return secretstoreclient.SecretServiceInfo{VaultSecretShares: shares, VaultSecretThreshold: threshold}
+}
+
+func newEncryptingVMK(t *testing.T) *VMKEncryption {
+	provider := &ikmMocks.MockProvider{}
+	provider.On("ReadIKM").Return(make([]byte, 32), nil)
+	vmkEncryption := NewVMKEncryption(nil, nil, nil)
+	require.NoError(t, vmkEncryption.LoadIKMFromProvider(provider))
+	return vmkEncryption
+}
+
+func TestKeyShareEscrow(t *testing.T) {
+	dir := t.TempDir()
+	fileOpener := fileioperformer.NewDefaultFileIoPerformer()
+	vmkEncryption := newEncryptingVMK(t)
+	privateKeys := newTestRSAKeys(t, 3)
+	var escrowConfig config.KeyShareEscrowInfo
+	var privateKeyPaths []string
+	for i, key := range privateKeys {
+		publicKeyPath := filepath.Join(dir, "recipient"+string(rune('a'+i))+".pub")
+		privateKeyPath := filepath.Join(dir, "recipient"+string(rune('a'+i))+".key")
+		// Exercise each supported encoding
+		switch i {
+		case 0:
+			der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			require.NoError(t, err)
+			writePEM(t, publicKeyPath, "PUBLIC KEY", der)
+			der, err = x509.MarshalPKCS8PrivateKey(key)
+			require.NoError(t, err)
+			writePEM(t, privateKeyPath, "PRIVATE KEY", der)
+		default:
+			writePEM(t, publicKeyPath, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&key.PublicKey))
+			writePEM(t, privateKeyPath, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key))
+		}
+		escrowConfig.Recipients = append(escrowConfig.Recipients, config.KeyShareRecipientInfo{
+			PublicKeyPath: publicKeyPath,
+			OutputPath:    filepath.Join(dir, "disk"+string(rune('a'+i)), "escrow.json"),
+		})
+		privateKeyPaths = append(privateKeyPaths, privateKeyPath)
+	}
+
+	escrow, err := NewKeyShareEscrow(fileOpener, escrowConfig, escrowSecretService(5, 3), vmkEncryption)
+	require.NoError(t, err)
+	require.Equal(t, 3, escrow.Recipients())
+	require.NoError(t, escrow.WriteKeyShares(testInitResponse))
+
+	var files []EscrowFile
+	var loadedKeys []*rsa.PrivateKey
+	for i, recipient := range escrowConfig.Recipients {
+		file, err := LoadEscrowFile(fileOpener, recipient.OutputPath)
+		require.NoError(t, err)
+		files = append(files, file)
+		key, err := LoadRSAPrivateKey(fileOpener, privateKeyPaths[i])
+		require.NoError(t, err)
+		loadedKeys = append(loadedKeys, key)
+	}
+	var initResponse secretstoreclient.InitResponse
+	require.NoError(t, RecoverKeyShares(files, loadedKeys, &initResponse))
+	assert.Equal(t, testInitResponse.Keys, initResponse.Keys)
+
+	// Escrow is disabled without recipients
+	escrow, err = NewKeyShareEscrow(fileOpener, config.KeyShareEscrowInfo{}, escrowSecretService(5, 3), vmkEncryption)
+	require.NoError(t, err)
+	assert.Nil(t, escrow)
+
+	// Recipients are checked before Vault is initialized
+	_, err = NewKeyShareEscrow(fileOpener, escrowConfig, escrowSecretService(5, 2), vmkEncryption)
+	assert.Error(t, err, "a recipient would meet the threshold")
+	_, err = NewKeyShareEscrow(fileOpener, config.KeyShareEscrowInfo{Recipients: []config.KeyShareRecipientInfo{
+		{PublicKeyPath: privateKeyPaths[0], OutputPath: filepath.Join(dir, "escrow.json")},
+		{PublicKeyPath: filepath.Join(dir, "missing.pub"), OutputPath: filepath.Join(dir, "escrow.json")},
+	}}, escrowSecretService(3, 2), vmkEncryption)
+	assert.Error(t, err, "invalid public keys")
+	_, err = NewKeyShareEscrow(fileOpener, config.KeyShareEscrowInfo{Recipients: []config.KeyShareRecipientInfo{
+		{PublicKeyPath: escrowConfig.Recipients[0].PublicKeyPath}, escrowConfig.Recipients[1],
+	}}, escrowSecretService(3, 2), vmkEncryption)
+	assert.Error(t, err, "missing output path")
+	revoking := escrowSecretService(5, 3)
+	revoking.RevokeRootTokens = true
+	_, err = NewKeyShareEscrow(fileOpener, escrowConfig, revoking, vmkEncryption)
+	assert.Error(t, err, "root tokens would be revoked")
+	_, err = NewKeyShareEscrow(fileOpener, escrowConfig, escrowSecretService(5, 3),
+		NewVMKEncryption(nil, nil, nil))
+	assert.Error(t, err, "the root token would be saved unencrypted")
+}
+
+func TestHasKeyShares(t *testing.T) {
+	assert.True(t, hasKeyShares(testInitResponse))
+	assert.True(t, hasKeyShares(secretstoreclient.InitResponse{EncryptedKeys: []string{"aabbcc"}}))
+	assert.False(t, hasKeyShares(secretstoreclient.InitResponse{RootToken: "s.root"}))
+}
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal"
+	"github.com/edgexfoundry/edgex-go/internal/security/ikm"
 	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
 	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
@@ -47,17 +48,19 @@ import (
 type Bootstrap struct {
 	insecureSkipVerify bool
 	vaultInterval      int
+	rotateCredentials  bool
 }
 
-func NewBootstrap(insecureSkipVerify bool, vaultInterval int) *Bootstrap {
+func NewBootstrap(insecureSkipVerify bool, vaultInterval int, rotateCredentials bool) *Bootstrap {
 	return &Bootstrap{
 		insecureSkipVerify: insecureSkipVerify,
 		vaultInterval:      vaultInterval,
+		rotateCredentials:  rotateCredentials,
 	}
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the data service.
-func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	configuration := container.ConfigurationFrom(dic.Get)
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 
@@ -88,17 +91,31 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 	kdf := kdf.NewKdf(fileOpener, configuration.SecretService.TokenFolderPath, sha256.New)
 	vmkEncryption := NewVMKEncryption(fileOpener, pipedHexReader, kdf)
 
-	hook := os.Getenv("IKM_HOOK")
-	if len(hook) > 0 {
-		err := vmkEncryption.LoadIKM(hook)
+	ikmProvider, err := ikm.NewProviderFromEnv(fileOpener, configuration.SecretService.TokenFolderPath)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to setup vault master key encryption: %s", err.Error()))
+		return false
+	}
+	if ikmProvider != nil {
+		err := vmkEncryption.LoadIKMFromProvider(ikmProvider)
 		defer vmkEncryption.WipeIKM() // Ensure IKM is wiped from memory
 		if err != nil {
 			lc.Error(fmt.Sprintf("failed to setup vault master key encryption: %s", err.Error()))
 			return false
 		}
-		lc.Info("Enabled encryption of Vault master key")
+		lc.Info(fmt.Sprintf("Enabled encryption of Vault master key using %s", ikmProvider.Description()))
 	} else {
-		lc.Info("vault master key encryption not enabled. IKM_HOOK not set.")
+		lc.Info("vault master key encryption not enabled. IKM_PROVIDER and IKM_HOOK not set.")
+	}
+
+	keyShareEscrow, err := NewKeyShareEscrow(fileOpener, configuration.KeyShareEscrow, configuration.SecretService,
+		vmkEncryption)
+	if err != nil {
+		lc.Error(fmt.Sprintf("failed to setup key share escrow: %s", err.Error()))
+		return false
+	}
+	if keyShareEscrow != nil {
+		lc.Info(fmt.Sprintf("Enabled escrow of Vault key shares to %d recipients", keyShareEscrow.Recipients()))
 	}
 
 	var initResponse secretstoreclient.InitResponse // reused many places in below flow
@@ -137,23 +154,49 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 				// We need the unencrypted initResponse in order to generate a temporary root token later
 				// Make a copy and save the copy, possibly encrypted
 				encryptedInitResponse := initResponse
+				// Optionally escrow the key shares, which are then left out of the saved init response
+				escrowFailed := false
+				if keyShareEscrow != nil {
+					if err := keyShareEscrow.WriteKeyShares(initResponse); err != nil {
+						// Keep the key shares in the init response rather than lose them
+						lc.Error(fmt.Sprintf("failed to escrow key shares, saving them in the init response instead: %s",
+							err.Error()))
+						escrowFailed = true
+					} else {
+						encryptedInitResponse.Keys = nil
+						encryptedInitResponse.KeysBase64 = nil
+						lc.Info("Vault key shares escrowed and removed from the init response")
+					}
+				}
 				// Optionally encrypt the vault init response based on whether encryption was enabled
 				if vmkEncryption.IsEncrypting() {
 					if err := vmkEncryption.EncryptInitResponse(&encryptedInitResponse); err != nil {
 						lc.Error(fmt.Sprintf("failed to encrypt init response from secret store: %s", err.Error()))
 						return false
 					}
+					if err := vmkEncryption.EncryptRootToken(&encryptedInitResponse); err != nil {
+						lc.Error(fmt.Sprintf("failed to encrypt root token from secret store: %s", err.Error()))
+						return false
+					}
 				}
 				if err := saveInitResponse(lc, fileOpener, configuration.SecretService, &encryptedInitResponse); err != nil {
 					lc.Error(fmt.Sprintf("unable to save init response: %s", err.Error()))
 					return false
 				}
+				if escrowFailed {
+					return false
+				}
 			case http.StatusServiceUnavailable:
 				lc.Info(fmt.Sprintf("vault is sealed (status code: %d). Starting unseal phase", sCode))
 				if err := loadInitResponse(lc, fileOpener, configuration.SecretService, &initResponse); err != nil {
 					lc.Error(fmt.Sprintf("unable to load init response: %s", err.Error()))
 					return false
 				}
+				if !hasKeyShares(initResponse) {
+					lc.Info("vault key shares are in escrow; waiting for vault to be unsealed with " +
+						"'secrets-config secretstore unseal --escrow-files ... --escrow-keys ...'")
+					return true
+				}
 				// Optionally decrypt the vault init response based on whether encryption was enabled
 				if vmkEncryption.IsEncrypting() {
 					if err := vmkEncryption.DecryptInitResponse(&initResponse); err != nil {
@@ -217,24 +260,41 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 
 	// Create a transient root token from the key shares
 	var rootToken string
-	if err := vc.RegenRootToken(&initResponse, &rootToken); err != nil {
-		lc.Error(fmt.Sprintf("could not regenerate root token %s", err.Error()))
+	if hasKeyShares(initResponse) {
+		if err := vc.RegenRootToken(&initResponse, &rootToken); err != nil {
+			lc.Error(fmt.Sprintf("could not regenerate root token %s", err.Error()))
+			os.Exit(1)
+		}
+		defer func() {
+			// Revoke transient root token at the end of this funciton
+			lc.Info("revoking temporary root token")
+			_, err := vc.RevokeSelf(rootToken)
+			if err != nil {
+				lc.Error(fmt.Sprintf("could not revoke temporary root token %s", err.Error()))
+			}
+		}()
+		lc.Info("generated transient root token")
+	} else if initResponse.EncryptedRootToken != "" && vmkEncryption.IsEncrypting() {
+		// A root token can't be generated without the key shares, so use (and keep) the saved one
+		savedInitResponse := initResponse
+		if err := vmkEncryption.DecryptRootToken(&savedInitResponse); err != nil {
+			lc.Error(fmt.Sprintf("failed to decrypt the saved root token: %s", err.Error()))
+			os.Exit(1)
+		}
+		rootToken = savedInitResponse.RootToken
+		lc.Info("vault key shares are in escrow; using the saved root token")
+	} else {
+		lc.Error("vault key shares are in escrow, so no root token can be generated, and no encrypted root token " +
+			"is saved or vault master key encryption is not enabled to decrypt it")
 		os.Exit(1)
 	}
-	defer func() {
-		// Revoke transient root token at the end of this funciton
-		lc.Info("revoking temporary root token")
-		_, err := vc.RevokeSelf(rootToken)
-		if err != nil {
-			lc.Error(fmt.Sprintf("could not revoke temporary root token %s", err.Error()))
-		}
-	}()
-	lc.Info("generated transient root token")
 
 	// Revoke the other root tokens
 	if configuration.SecretService.RevokeRootTokens {
-		if initResponse.RootToken != "" {
+		if initResponse.RootToken != "" || initResponse.EncryptedRootToken != "" {
 			initResponse.RootToken = ""
+			initResponse.EncryptedRootToken = ""
+			initResponse.RootTokenNonce = ""
 			if err := saveInitResponse(lc, fileOpener, configuration.SecretService, &initResponse); err != nil {
 				lc.Error(fmt.Sprintf("unable to save init response: %s", err.Error()))
 				os.Exit(1)
@@ -296,48 +356,60 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 
 	// continue credential creation
 
-	// A little note on why there are two secrets paths. For each microservice, the
-	// username/password is uploaded to the vault on both /v1/secret/edgex/%s/redisdb and
-	// /v1/secret/edgex/redisdb/%s). The go-mod-secrets client requires a Path property to prefix all
-	// secrets.
-	// So edgex/%s/redisdb is for the microservices (microservices are restricted to their specific
-	// edgex/%s), and edgex/redisdb/* is enumerated to initialize the database.
-	//
-
-	// Redis 5.x only supports a single shared password. When Redis 6 is released, this can be updated
-	// to a per service password.
+	// Each microservice's Redis credential is uploaded to /v1/secret/edgex/%s/redisdb, as the go-mod-secrets
+	// client requires a Path property to prefix all secrets and microservices are restricted to their specific
+	// edgex/%s.  Each service has its own Redis ACL user, whose rules are uploaded to the bootstrap-redis path for
+	// security-bootstrap-redis to apply.  security-bootstrap-redis itself authenticates as the default user.
 
-	redis5Password, err := cred.GeneratePassword(ctx)
+	defaultRedisPassword, err := cred.GeneratePassword(ctx)
 	if err != nil {
-		lc.Error("failed to generate redis5 password")
+		lc.Error("failed to generate default redis password")
 		os.Exit(1)
 	}
-	redis5Pair := UserPasswordPair{
-		User:     "redis5",
-		Password: redis5Password,
-	}
-
-	for _, info := range configuration.Databases {
-		service := info.Service
-
-		// add credentials to service path if specified and they're not already there
-		if len(service) != 0 {
-			err = addServiceCredential(lc, "redisdb", cred, service, redis5Pair)
-			if err != nil {
-				lc.Error(err.Error())
-				os.Exit(1)
-			}
-		}
+	defaultRedisPair := UserPasswordPair{
+		User:     legacySharedRedisUser,
+		Password: defaultRedisPassword,
 	}
 
 	// security-bootstrap-redis uses the path /v1/secret/edgex/bootstrap-redis/ and go-mod-bootstrap
 	// with append the DB type (redisdb)
-	err = addDBCredential(lc, "bootstrap-redis", cred, "redisdb", redis5Pair)
+	err = addDBCredential(lc, "bootstrap-redis", cred, "redisdb", defaultRedisPair)
 	if err != nil {
 		lc.Error(err.Error())
 		os.Exit(1)
 	}
 
+	if err := setupRedisServiceCredentials(ctx, lc, cred, configuration.Databases); err != nil {
+		lc.Error(fmt.Sprintf("failed to set up redis service credentials: %s", err.Error()))
+		os.Exit(1)
+	}
+
+	// Rotate the redis credentials when forced or due, and keep rotating them on schedule if configured to do so
+	keepRunning := false
+	if configuration.CredentialRotation.Enabled || b.rotateCredentials {
+		rotator, err := NewCredentialRotator(lc, vc, fileOpener, configuration)
+		if err != nil {
+			lc.Error(fmt.Sprintf("invalid credential rotation configuration: %s", err.Error()))
+			os.Exit(1)
+		}
+		if b.rotateCredentials || rotator.Due() {
+			// a failed rotation has been rolled back, so the existing credential remains usable
+			if err := rotator.Rotate(ctx, cred); err != nil {
+				lc.Error(fmt.Sprintf("credential rotation failed: %s", err.Error()))
+			}
+		}
+		if configuration.CredentialRotation.Enabled {
+			rotatorToken, err := rotator.CreateRotatorToken(rootToken)
+			if err != nil {
+				lc.Error(fmt.Sprintf("failed to create credential rotator token: %s", err.Error()))
+				os.Exit(1)
+			}
+			rotatorCred := NewCred(req, rotatorToken, gen, configuration.SecretService.GetSecretSvcBaseURL(), lc)
+			rotator.RotatePeriodically(ctx, wg, rotatorToken, rotatorCred)
+			keepRunning = true
+		}
+	}
+
 	// Concat all cert path config vals together to check for empty vals
 	certPathCheck := configuration.SecretService.CertPath +
 		configuration.SecretService.CertFilePath +
@@ -356,56 +428,63 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 
 		if existing {
 			lc.Info("proxy certificate pair are in the secret store already, skip uploading")
-			return false
-		}
+		} else {
+			lc.Info("proxy certificate pair are not in the secret store yet, uploading them")
+			cp, err := cert.ReadFrom(configuration.SecretService.CertFilePath, configuration.SecretService.KeyFilePath)
+			if err != nil {
+				lc.Error("failed to get certificate pair from volume")
+				os.Exit(1)
+			}
 
-		lc.Info("proxy certificate pair are not in the secret store yet, uploading them")
-		cp, err := cert.ReadFrom(configuration.SecretService.CertFilePath, configuration.SecretService.KeyFilePath)
-		if err != nil {
-			lc.Error("failed to get certificate pair from volume")
-			os.Exit(1)
-		}
+			lc.Info("proxy certificate pair are loaded from volume successfully, will upload to secret store")
 
-		lc.Info("proxy certificate pair are loaded from volume successfully, will upload to secret store")
+			err = cert.UploadToStore(cp)
+			if err != nil {
+				lc.Error("failed to upload the proxy cert pair into the secret store")
+				lc.Error(err.Error())
+				os.Exit(1)
+			}
 
-		err = cert.UploadToStore(cp)
-		if err != nil {
-			lc.Error("failed to upload the proxy cert pair into the secret store")
-			lc.Error(err.Error())
-			os.Exit(1)
+			lc.Info("proxy certificate pair are uploaded to secret store successfully")
 		}
 
-		lc.Info("proxy certificate pair are uploaded to secret store successfully")
-
 	} else {
 		lc.Info("proxy certificate pair upload was skipped because cert config value(s) were blank")
 	}
 
-	lc.Info("Vault init done successfully")
-	return false
-
-}
-
-// XXX Collapse addServiceCredential and addDBCredential together by passing in the path or using
-// variadic functions
-
-func addServiceCredential(lc logger.LoggingClient, db string, cred Cred, service string, pair UserPasswordPair) error {
-	path := fmt.Sprintf("/v1/secret/edgex/%s/%s", service, db)
-	existing, err := cred.AlreadyInStore(path)
-	if err != nil {
-		return err
-	}
-	if !existing {
-		err = cred.UploadToStore(&pair, path)
+	// Internal PKI: set up the CA and issue the service certificates due for renewal
+	if configuration.PKI.Enabled {
+		pki, err := NewPKI(lc, vc, configuration.PKI)
 		if err != nil {
-			lc.Error(fmt.Sprintf("failed to upload credential pair for %s on path %s", service, path))
-			return err
+			lc.Error(fmt.Sprintf("invalid PKI configuration: %s", err.Error()))
+			os.Exit(1)
+		}
+		if err := pki.SetupCA(rootToken); err != nil {
+			lc.Error(fmt.Sprintf("failed to set up internal PKI: %s", err.Error()))
+			os.Exit(1)
+		}
+		if err := pki.IssueCertificates(rootToken); err != nil {
+			lc.Error(fmt.Sprintf("failed to issue service certificates: %s", err.Error()))
+			os.Exit(1)
+		}
+		if pki.RenewInterval() > 0 {
+			issuerToken, err := pki.CreateIssuerToken(rootToken)
+			if err != nil {
+				lc.Error(fmt.Sprintf("failed to create pki issuer token: %s", err.Error()))
+				os.Exit(1)
+			}
+			pki.RenewPeriodically(ctx, wg, issuerToken)
+			lc.Info(fmt.Sprintf("checking service certificates for renewal every %s", pki.RenewInterval()))
+			keepRunning = true
 		}
 	} else {
-		lc.Info(fmt.Sprintf("credentials for %s already present at path %s", service, path))
+		lc.Info("internal PKI is not enabled")
 	}
 
-	return err
+	lc.Info("Vault init done successfully")
+	// Returning false stops the service, so only keep running while certificates or credentials are being renewed
+	return keepRunning
+
 }
 
 func addDBCredential(lc logger.LoggingClient, db string, cred Cred, service string, pair UserPasswordPair) error {
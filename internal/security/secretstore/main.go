@@ -43,13 +43,15 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 
 	var insecureSkipVerify bool
 	var vaultInterval int
+	var rotateCredentials bool
 
 	// All common command-line flags have been moved to bootstrap. Service specific flags are add here,
 	// but DO NOT call flag.Parse() as it is called by bootstrap.Run() below
 	// Service specific used is passed below.
 	f := flags.NewWithUsage(
 		"    --insecureSkipVerify=true/false Indicates if skipping the server side SSL cert verification, similar to -k of curl\n" +
-			"    --vaultInterval=<seconds>       Indicates how long the program will pause between vault initialization attempts until it succeeds",
+			"    --vaultInterval=<seconds>       Indicates how long the program will pause between vault initialization attempts until it succeeds\n" +
+			"    --rotateCredentials             Rotates the generated database credentials now, regardless of the rotation schedule",
 	)
 
 	if len(os.Args) < 2 {
@@ -58,6 +60,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 
 	f.FlagSet.BoolVar(&insecureSkipVerify, "insecureSkipVerify", false, "")
 	f.FlagSet.IntVar(&vaultInterval, "vaultInterval", 30, "")
+	f.FlagSet.BoolVar(&rotateCredentials, "rotateCredentials", false, "")
 	f.Parse(os.Args[1:])
 
 	configuration := &config.ConfigurationStruct{}
@@ -77,7 +80,7 @@ func Main(ctx context.Context, cancel context.CancelFunc, _ *mux.Router, _ chan<
 		startupTimer,
 		dic,
 		[]interfaces.BootstrapHandler{
-			NewBootstrap(insecureSkipVerify, vaultInterval).BootstrapHandler,
+			NewBootstrap(insecureSkipVerify, vaultInterval, rotateCredentials).BootstrapHandler,
 		},
 	)
 }
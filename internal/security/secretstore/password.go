@@ -179,8 +179,13 @@ func (cr *Cred) GeneratePassword(ctx context.Context) (string, error) {
 }
 
 func (cr *Cred) UploadToStore(pair *UserPasswordPair, path string) error {
+	return cr.upload(pair, path)
+}
+
+// upload stores secret, which is marshalled to JSON, at path.
+func (cr *Cred) upload(secret interface{}, path string) error {
 	cr.loggingClient.Debug("trying to upload the credential pair into secret store")
-	jsonBytes, err := json.Marshal(pair)
+	jsonBytes, err := json.Marshal(secret)
 	body := bytes.NewBuffer(jsonBytes)
 
 	credURL, err := cr.credPathURL(path)
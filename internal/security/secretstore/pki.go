@@ -0,0 +1,471 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstore
+
+/*
+
+The internal PKI is a two-tier certificate authority kept in the secret store's PKI secrets engine:
+
+1. A root CA, generated once in RootMount, whose certificate is distributed to every service as ca.crt
+2. An intermediate CA in IntermediateMount, signed by the root, which issues the service certificates
+3. A role restricting the intermediate to the configured service names
+4. Per-service server certificates, issued on every run when they're missing, due to expire within RenewBefore
+   or no longer match the configuration
+
+Neither CA's private key ever leaves the secret store.  When RenewInterval is set, a periodic token limited to
+issuing certificates under the role is created and used to repeat step 4 at that interval.
+
+*/
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	PKIIssuerPolicyName = "edgex-pki-issuer"
+
+	// pkiIssuerPolicy allows the holder to issue certificates under a role of the intermediate CA and nothing else
+	pkiIssuerPolicy = `
+path "%s/issue/%s" {
+  capabilities = ["create", "update"]
+}
+`
+
+	ServerCertificateFile = "server.crt"
+	ServerKeyFile         = "server.key"
+	CACertificateFile     = "ca.crt"
+
+	defaultPKIRootMount         = "pki"
+	defaultPKIIntermediateMount = "pki_int"
+	defaultPKIRootName          = "EdgeX Root CA"
+	defaultPKIIntermediateName  = "EdgeX Intermediate CA"
+	defaultPKIRootTTL           = "87600h"
+	defaultPKIIntermediateTTL   = "43800h"
+	defaultPKIRole              = "edgex-service"
+	defaultPKICertificateTTL    = "720h"
+	defaultPKIRenewBefore       = "240h"
+)
+
+// PKI sets up the internal certificate authority and issues the services' certificates.
+type PKI struct {
+	loggingClient   logger.LoggingClient
+	secretClient    secretstoreclient.SecretStoreClient
+	config          config.PKIInfo
+	renewBefore     time.Duration
+	renewInterval   time.Duration
+	rootCertificate string
+	now             func() time.Time
+}
+
+// NewPKI validates the PKI configuration, filling in defaults for the settings left empty, and returns a PKI.
+func NewPKI(
+	lc logger.LoggingClient,
+	secretClient secretstoreclient.SecretStoreClient,
+	pkiConfig config.PKIInfo) (*PKI, error) {
+
+	defaults := []struct {
+		setting      *string
+		defaultValue string
+	}{
+		{&pkiConfig.RootMount, defaultPKIRootMount},
+		{&pkiConfig.IntermediateMount, defaultPKIIntermediateMount},
+		{&pkiConfig.RootCommonName, defaultPKIRootName},
+		{&pkiConfig.IntermediateCommonName, defaultPKIIntermediateName},
+		{&pkiConfig.RootTTL, defaultPKIRootTTL},
+		{&pkiConfig.IntermediateTTL, defaultPKIIntermediateTTL},
+		{&pkiConfig.Role, defaultPKIRole},
+		{&pkiConfig.CertificateTTL, defaultPKICertificateTTL},
+		{&pkiConfig.RenewBefore, defaultPKIRenewBefore},
+	}
+	for _, d := range defaults {
+		if *d.setting == "" {
+			*d.setting = d.defaultValue
+		}
+	}
+	if pkiConfig.CertFolderPath == "" {
+		return nil, errors.New("PKI.CertFolderPath is a required configuration setting")
+	}
+
+	certificateTTL, err := time.ParseDuration(pkiConfig.CertificateTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PKI.CertificateTTL %s: %s", pkiConfig.CertificateTTL, err.Error())
+	}
+	renewBefore, err := time.ParseDuration(pkiConfig.RenewBefore)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PKI.RenewBefore %s: %s", pkiConfig.RenewBefore, err.Error())
+	}
+	if renewBefore >= certificateTTL {
+		// every certificate would be due for renewal as soon as it was issued
+		return nil, fmt.Errorf("PKI.RenewBefore %s must be shorter than PKI.CertificateTTL %s",
+			pkiConfig.RenewBefore, pkiConfig.CertificateTTL)
+	}
+	var renewInterval time.Duration
+	if pkiConfig.RenewInterval != "" {
+		if renewInterval, err = time.ParseDuration(pkiConfig.RenewInterval); err != nil || renewInterval <= 0 {
+			return nil, fmt.Errorf("invalid PKI.RenewInterval %s", pkiConfig.RenewInterval)
+		}
+		if renewInterval >= renewBefore {
+			// a certificate could expire between two checks
+			return nil, fmt.Errorf("PKI.RenewInterval %s must be shorter than PKI.RenewBefore %s",
+				pkiConfig.RenewInterval, pkiConfig.RenewBefore)
+		}
+	}
+
+	return &PKI{
+		loggingClient: lc,
+		secretClient:  secretClient,
+		config:        pkiConfig,
+		renewBefore:   renewBefore,
+		renewInterval: renewInterval,
+		now:           time.Now,
+	}, nil
+}
+
+// RenewInterval returns the interval at which certificates are checked for renewal, or 0 when they're only checked
+// on startup.
+func (p *PKI) RenewInterval() time.Duration {
+	return p.renewInterval
+}
+
+// SetupCA creates the root and intermediate CAs if they don't exist yet and creates or updates the role under which
+// service certificates are issued.
+func (p *PKI) SetupCA(rootToken string) error {
+	rootCertificate, err := p.ensureCA(rootToken, p.config.RootMount, p.config.RootTTL, func() (string, error) {
+		p.loggingClient.Info(fmt.Sprintf("generating root CA %s", p.config.RootCommonName))
+		var certificate string
+		_, err := p.secretClient.GenerateRootCA(
+			rootToken,
+			p.config.RootMount,
+			map[string]interface{}{
+				"common_name": p.config.RootCommonName,
+				"ttl":         p.config.RootTTL,
+				"key_type":    "ec",
+				"key_bits":    384,
+			},
+			&certificate)
+		return certificate, err
+	})
+	if err != nil {
+		return err
+	}
+	p.rootCertificate = rootCertificate
+
+	_, err = p.ensureCA(rootToken, p.config.IntermediateMount, p.config.IntermediateTTL, func() (string, error) {
+		p.loggingClient.Info(fmt.Sprintf("generating intermediate CA %s", p.config.IntermediateCommonName))
+		var csr, certificate string
+		_, err := p.secretClient.GenerateIntermediateCSR(
+			rootToken,
+			p.config.IntermediateMount,
+			map[string]interface{}{
+				"common_name": p.config.IntermediateCommonName,
+				"key_type":    "ec",
+				"key_bits":    384,
+			},
+			&csr)
+		if err != nil {
+			return "", err
+		}
+		_, err = p.secretClient.SignIntermediate(
+			rootToken,
+			p.config.RootMount,
+			map[string]interface{}{
+				"csr":    csr,
+				"format": "pem",
+				"ttl":    p.config.IntermediateTTL,
+			},
+			&certificate)
+		if err != nil {
+			return "", err
+		}
+		_, err = p.secretClient.SetSignedIntermediate(rootToken, p.config.IntermediateMount, certificate)
+		return certificate, err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = p.secretClient.CreatePKIRole(rootToken, p.config.IntermediateMount, p.config.Role, map[string]interface{}{
+		"allowed_domains":    p.allowedNames(),
+		"allow_bare_domains": true,
+		"allow_subdomains":   false,
+		"allow_localhost":    true,
+		"allow_ip_sans":      true,
+		"server_flag":        true,
+		"client_flag":        false,
+		"key_type":           "ec",
+		"key_bits":           256,
+		"max_ttl":            p.config.CertificateTTL,
+	})
+	if err != nil {
+		p.loggingClient.Error(fmt.Sprintf("failed to create PKI role %s: %s", p.config.Role, err.Error()))
+		return err
+	}
+	p.loggingClient.Info(fmt.Sprintf("PKI role %s is up to date", p.config.Role))
+	return nil
+}
+
+// ensureCA enables the PKI secrets engine at mountPoint if needed and returns its CA certificate, calling generate to
+// create the CA when the engine doesn't have one.
+func (p *PKI) ensureCA(
+	rootToken string,
+	mountPoint string,
+	maxLeaseTTL string,
+	generate func() (string, error)) (string, error) {
+
+	installed, err := p.secretClient.CheckSecretEngineInstalled(rootToken, mountPoint+"/", "pki")
+	if err != nil {
+		p.loggingClient.Error(fmt.Sprintf("failed call to check if pki secrets engine is installed: %s", err.Error()))
+		return "", err
+	}
+	if !installed {
+		p.loggingClient.Info(fmt.Sprintf("enabling PKI secrets engine at %s", mountPoint))
+		if _, err := p.secretClient.EnablePKISecretEngine(rootToken, mountPoint, maxLeaseTTL); err != nil {
+			p.loggingClient.Error(fmt.Sprintf("failed call to enable PKI secrets engine: %s", err.Error()))
+			return "", err
+		}
+	}
+
+	var certificate string
+	code, err := p.secretClient.ReadCACertificate(rootToken, mountPoint, &certificate)
+	// depending on its version, the secret store answers with an empty certificate or a client error when the
+	// engine has no CA
+	if err != nil && (code < http.StatusBadRequest || code >= http.StatusInternalServerError) {
+		p.loggingClient.Error(fmt.Sprintf("failed to read the CA certificate at %s: %s", mountPoint, err.Error()))
+		return "", err
+	}
+	if strings.TrimSpace(certificate) != "" {
+		p.loggingClient.Info(fmt.Sprintf("CA at %s already exists", mountPoint))
+		return certificate, nil
+	}
+
+	certificate, err = generate()
+	if err != nil {
+		p.loggingClient.Error(fmt.Sprintf("failed to generate the CA at %s: %s", mountPoint, err.Error()))
+		return "", err
+	}
+	return certificate, nil
+}
+
+// CreateIssuerToken creates a periodic token which may only issue certificates under the PKI role and must be renewed
+// within three renewal intervals.
+func (p *PKI) CreateIssuerToken(rootToken string) (string, error) {
+	return NewTokenMaintenance(p.loggingClient, p.secretClient).CreatePeriodicToken(
+		rootToken,
+		PKIIssuerPolicyName,
+		fmt.Sprintf(pkiIssuerPolicy, p.config.IntermediateMount, p.config.Role),
+		3*p.renewInterval)
+}
+
+// RenewPeriodically reissues the certificates due for renewal every renewal interval until ctx is cancelled.
+func (p *PKI) RenewPeriodically(ctx context.Context, wg *sync.WaitGroup, token string) {
+	NewTokenMaintenance(p.loggingClient, p.secretClient).RunWithPeriodicToken(ctx, wg, token, p.renewInterval, func() {
+		if err := p.IssueCertificates(token); err != nil {
+			p.loggingClient.Error(fmt.Sprintf("failed to renew service certificates: %s", err.Error()))
+		}
+	})
+}
+
+// IssueCertificates issues a certificate for each configured service whose certificate is missing, due for renewal
+// or out of date, and writes it to the service's output path.  It continues past failures, returning the last one.
+func (p *PKI) IssueCertificates(token string) error {
+	services := make([]string, 0, len(p.config.Services))
+	for service := range p.config.Services {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	var lastErr error
+	for _, service := range services {
+		info := p.serviceInfo(service)
+		reason := p.renewalReason(info)
+		if reason == "" {
+			p.loggingClient.Debug(fmt.Sprintf("certificate for %s is up to date", service))
+			continue
+		}
+
+		p.loggingClient.Info(fmt.Sprintf("issuing certificate for %s: %s", service, reason))
+		if err := p.issueCertificate(token, info); err != nil {
+			p.loggingClient.Error(fmt.Sprintf("failed to issue certificate for %s: %s", service, err.Error()))
+			lastErr = err
+			continue
+		}
+		p.loggingClient.Info(fmt.Sprintf("certificate for %s written to %s", service, info.OutputPath))
+	}
+	return lastErr
+}
+
+// serviceInfo returns the service's configuration with the defaults filled in.
+func (p *PKI) serviceInfo(service string) config.PKIServiceInfo {
+	info := p.config.Services[service]
+	if info.CommonName == "" {
+		info.CommonName = service
+	}
+	if info.OutputPath == "" {
+		info.OutputPath = filepath.Join(p.config.CertFolderPath, service)
+	}
+	return info
+}
+
+// allowedNames returns the host names the role may issue certificates for: those of every configured service.
+func (p *PKI) allowedNames() []string {
+	unique := make(map[string]bool)
+	for service := range p.config.Services {
+		info := p.serviceInfo(service)
+		unique[info.CommonName] = true
+		for _, name := range info.AltNames {
+			unique[name] = true
+		}
+	}
+	var names []string
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// renewalReason returns why the service's certificate must be issued, or "" if its current certificate is fine.
+func (p *PKI) renewalReason(info config.PKIServiceInfo) string {
+	certificatePEM, err := ioutil.ReadFile(filepath.Join(info.OutputPath, ServerCertificateFile))
+	if err != nil {
+		return "no certificate"
+	}
+	if _, err := os.Stat(filepath.Join(info.OutputPath, ServerKeyFile)); err != nil {
+		return "no private key"
+	}
+	caPEM, err := ioutil.ReadFile(filepath.Join(info.OutputPath, CACertificateFile))
+	if err != nil || strings.TrimSpace(string(caPEM)) != strings.TrimSpace(p.rootCertificate) {
+		return "CA certificate has changed"
+	}
+
+	block, _ := pem.Decode(certificatePEM)
+	if block == nil {
+		return "certificate is not PEM encoded"
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "certificate is invalid"
+	}
+	if certificate.Subject.CommonName != info.CommonName {
+		return "common name has changed"
+	}
+	for _, name := range info.AltNames {
+		if !contains(certificate.DNSNames, name) {
+			return "alternative names have changed"
+		}
+	}
+	for _, address := range info.IPSans {
+		found := false
+		for _, ip := range certificate.IPAddresses {
+			found = found || ip.String() == address
+		}
+		if !found {
+			return "IP addresses have changed"
+		}
+	}
+	if expiry := certificate.NotAfter; p.now().Add(p.renewBefore).After(expiry) {
+		return fmt.Sprintf("certificate expires %s", expiry.UTC().Format(time.RFC3339))
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// issueCertificate issues the service's certificate and writes the certificate chain, private key and root CA
+// certificate to its output path.
+func (p *PKI) issueCertificate(token string, info config.PKIServiceInfo) error {
+	var issued secretstoreclient.PKICertificate
+	_, err := p.secretClient.IssueCertificate(
+		token,
+		p.config.IntermediateMount,
+		p.config.Role,
+		map[string]interface{}{
+			"common_name": info.CommonName,
+			"alt_names":   strings.Join(info.AltNames, ","),
+			"ip_sans":     strings.Join(info.IPSans, ","),
+			"ttl":         p.config.CertificateTTL,
+		},
+		&issued)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(info.OutputPath, 0700); err != nil {
+		return err
+	}
+	// the chain lets clients which only trust the root CA verify the certificate
+	chain := strings.TrimSpace(issued.Certificate) + "\n" + strings.TrimSpace(issued.IssuingCA) + "\n"
+	files := []struct {
+		name     string
+		contents string
+		mode     os.FileMode
+	}{
+		{ServerKeyFile, strings.TrimSpace(issued.PrivateKey) + "\n", 0600},
+		{ServerCertificateFile, chain, 0644},
+		{CACertificateFile, strings.TrimSpace(p.rootCertificate) + "\n", 0644},
+	}
+	for _, file := range files {
+		if err := writeFileAtomically(filepath.Join(info.OutputPath, file.name), []byte(file.contents), file.mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFileAtomically replaces the file at path so that readers see either its old or its new contents.
+func writeFileAtomically(path string, contents []byte, mode os.FileMode) error {
+	temporary, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(temporary.Name()) }()
+
+	if _, err := temporary.Write(contents); err != nil {
+		_ = temporary.Close()
+		return err
+	}
+	if err := temporary.Chmod(mode); err != nil {
+		_ = temporary.Close()
+		return err
+	}
+	if err := temporary.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporary.Name(), path)
+}
@@ -0,0 +1,272 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstore
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testCertificate returns a PEM-encoded self-signed certificate for commonName valid until notAfter.
+func testCertificate(t *testing.T, commonName string, altNames []string, ipSans []string, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.Add(-720 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     append([]string{commonName}, altNames...),
+	}
+	for _, address := range ipSans {
+		template.IPAddresses = append(template.IPAddresses, net.ParseIP(address))
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestNewPKI(t *testing.T) {
+	tests := []struct {
+		name        string
+		pkiConfig   config.PKIInfo
+		expectError bool
+	}{
+		{"defaults", config.PKIInfo{CertFolderPath: "/tmp"}, false},
+		{"renewal", config.PKIInfo{CertFolderPath: "/tmp", RenewInterval: "1h"}, false},
+		{"no cert folder", config.PKIInfo{}, true},
+		{"invalid ttl", config.PKIInfo{CertFolderPath: "/tmp", CertificateTTL: "month"}, true},
+		{"invalid renew before", config.PKIInfo{CertFolderPath: "/tmp", RenewBefore: "week"}, true},
+		{"renew before ttl", config.PKIInfo{CertFolderPath: "/tmp", CertificateTTL: "24h", RenewBefore: "48h"}, true},
+		{"invalid renew interval", config.PKIInfo{CertFolderPath: "/tmp", RenewInterval: "-1h"}, true},
+		{"renew interval too long", config.PKIInfo{CertFolderPath: "/tmp", RenewInterval: "720h"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pki, err := NewPKI(logger.MockLogger{}, &MockSecretStoreClient{}, test.pkiConfig)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, defaultPKIIntermediateMount, pki.config.IntermediateMount)
+			assert.Equal(t, 240*time.Hour, pki.renewBefore)
+		})
+	}
+}
+
+func TestSetupCAFirstRun(t *testing.T) {
+	secretClient := &MockSecretStoreClient{}
+	pki, err := NewPKI(logger.MockLogger{}, secretClient, config.PKIInfo{
+		CertFolderPath: "/tmp",
+		Services: map[string]config.PKIServiceInfo{
+			"edgex-core-data":     {AltNames: []string{"localhost"}},
+			"edgex-core-metadata": {CommonName: "metadata"},
+		},
+	})
+	require.NoError(t, err)
+
+	secretClient.On("CheckSecretEngineInstalled", "root-token", "pki/", "pki").Return(false, nil)
+	secretClient.On("CheckSecretEngineInstalled", "root-token", "pki_int/", "pki").Return(false, nil)
+	secretClient.On("EnablePKISecretEngine", "root-token", "pki", defaultPKIRootTTL).Return(http.StatusNoContent, nil)
+	secretClient.On("EnablePKISecretEngine", "root-token", "pki_int", defaultPKIIntermediateTTL).
+		Return(http.StatusNoContent, nil)
+	secretClient.On("ReadCACertificate", "root-token", "pki", mock.Anything).Return(http.StatusOK, nil)
+	secretClient.On("ReadCACertificate", "root-token", "pki_int", mock.Anything).
+		Return(http.StatusBadRequest, assert.AnError)
+	secretClient.On("GenerateRootCA", "root-token", "pki", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, defaultPKIRootName, args.Get(2).(map[string]interface{})["common_name"])
+			*args.Get(3).(*string) = "root-pem"
+		}).
+		Return(http.StatusOK, nil)
+	secretClient.On("GenerateIntermediateCSR", "root-token", "pki_int", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(3).(*string) = "csr-pem"
+		}).
+		Return(http.StatusOK, nil)
+	secretClient.On("SignIntermediate", "root-token", "pki", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, "csr-pem", args.Get(2).(map[string]interface{})["csr"])
+			*args.Get(3).(*string) = "intermediate-pem"
+		}).
+		Return(http.StatusOK, nil)
+	secretClient.On("SetSignedIntermediate", "root-token", "pki_int", "intermediate-pem").
+		Return(http.StatusNoContent, nil)
+	secretClient.On("CreatePKIRole", "root-token", "pki_int", defaultPKIRole, mock.Anything).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t,
+				[]string{"edgex-core-data", "localhost", "metadata"},
+				args.Get(3).(map[string]interface{})["allowed_domains"])
+		}).
+		Return(http.StatusNoContent, nil)
+
+	err = pki.SetupCA("root-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "root-pem", pki.rootCertificate)
+	secretClient.AssertExpectations(t)
+}
+
+func TestSetupCAExisting(t *testing.T) {
+	secretClient := &MockSecretStoreClient{}
+	pki, err := NewPKI(logger.MockLogger{}, secretClient, config.PKIInfo{CertFolderPath: "/tmp"})
+	require.NoError(t, err)
+
+	secretClient.On("CheckSecretEngineInstalled", "root-token", mock.Anything, "pki").Return(true, nil)
+	secretClient.On("ReadCACertificate", "root-token", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(2).(*string) = args.String(1) + "-pem"
+		}).
+		Return(http.StatusOK, nil)
+	secretClient.On("CreatePKIRole", "root-token", "pki_int", defaultPKIRole, mock.Anything).
+		Return(http.StatusNoContent, nil)
+
+	err = pki.SetupCA("root-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pki-pem", pki.rootCertificate)
+	secretClient.AssertNotCalled(t, "GenerateRootCA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	secretClient.AssertNotCalled(t, "GenerateIntermediateCSR", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	secretClient.AssertExpectations(t)
+}
+
+func TestSetupCAReadFailure(t *testing.T) {
+	secretClient := &MockSecretStoreClient{}
+	pki, err := NewPKI(logger.MockLogger{}, secretClient, config.PKIInfo{CertFolderPath: "/tmp"})
+	require.NoError(t, err)
+
+	secretClient.On("CheckSecretEngineInstalled", "root-token", "pki/", "pki").Return(true, nil)
+	secretClient.On("ReadCACertificate", "root-token", "pki", mock.Anything).
+		Return(http.StatusInternalServerError, assert.AnError)
+
+	assert.Error(t, pki.SetupCA("root-token"))
+}
+
+func TestIssueCertificates(t *testing.T) {
+	folder, err := ioutil.TempDir("", "pki")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(folder) }()
+
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	rootPEM := testCertificate(t, "root", nil, nil, now.Add(87600*time.Hour))
+	secretClient := &MockSecretStoreClient{}
+	pki, err := NewPKI(logger.MockLogger{}, secretClient, config.PKIInfo{
+		CertFolderPath: folder,
+		Services: map[string]config.PKIServiceInfo{
+			"missing":  {},
+			"current":  {AltNames: []string{"localhost"}, IPSans: []string{"127.0.0.1"}},
+			"expiring": {},
+			"renamed":  {CommonName: "new-name"},
+			"moved":    {OutputPath: filepath.Join(folder, "elsewhere")},
+		},
+	})
+	require.NoError(t, err)
+	pki.rootCertificate = rootPEM
+	pki.now = func() time.Time { return now }
+
+	existing := map[string]string{
+		"current":  testCertificate(t, "current", []string{"localhost"}, []string{"127.0.0.1"}, now.Add(700*time.Hour)),
+		"expiring": testCertificate(t, "expiring", nil, nil, now.Add(200*time.Hour)),
+		"renamed":  testCertificate(t, "renamed", nil, nil, now.Add(700*time.Hour)),
+	}
+	for service, certificatePEM := range existing {
+		directory := filepath.Join(folder, service)
+		require.NoError(t, os.MkdirAll(directory, 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(directory, ServerCertificateFile), []byte(certificatePEM), 0644))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(directory, ServerKeyFile), []byte("old-key"), 0600))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(directory, CACertificateFile), []byte(rootPEM), 0644))
+	}
+
+	var issued []string
+	secretClient.On("IssueCertificate", "token", "pki_int", defaultPKIRole, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			commonName := args.Get(3).(map[string]interface{})["common_name"].(string)
+			issued = append(issued, commonName)
+			*args.Get(4).(*secretstoreclient.PKICertificate) = secretstoreclient.PKICertificate{
+				Certificate: commonName + "-pem",
+				IssuingCA:   "intermediate-pem",
+				PrivateKey:  commonName + "-key",
+			}
+		}).
+		Return(http.StatusOK, nil)
+
+	err = pki.IssueCertificates("token")
+
+	require.NoError(t, err)
+	// services are handled in name order; "current" is up to date
+	assert.Equal(t, []string{"expiring", "missing", "moved", "new-name"}, issued)
+
+	certificate, err := ioutil.ReadFile(filepath.Join(folder, "missing", ServerCertificateFile))
+	require.NoError(t, err)
+	assert.Equal(t, "missing-pem\nintermediate-pem\n", string(certificate))
+	ca, err := ioutil.ReadFile(filepath.Join(folder, "missing", CACertificateFile))
+	require.NoError(t, err)
+	assert.Equal(t, rootPEM, string(ca))
+	info, err := os.Stat(filepath.Join(folder, "missing", ServerKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	_, err = os.Stat(filepath.Join(folder, "elsewhere", ServerCertificateFile))
+	assert.NoError(t, err)
+
+	// a changed CA causes every certificate to be reissued
+	issued = nil
+	pki.rootCertificate = testCertificate(t, "new root", nil, nil, now.Add(87600*time.Hour))
+	require.NoError(t, pki.IssueCertificates("token"))
+	assert.Len(t, issued, 5)
+}
+
+func TestIssueCertificatesContinuesPastFailures(t *testing.T) {
+	folder, err := ioutil.TempDir("", "pki")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(folder) }()
+
+	secretClient := &MockSecretStoreClient{}
+	pki, err := NewPKI(logger.MockLogger{}, secretClient, config.PKIInfo{
+		CertFolderPath: folder,
+		Services:       map[string]config.PKIServiceInfo{"a": {}, "b": {}},
+	})
+	require.NoError(t, err)
+
+	secretClient.On("IssueCertificate", "token", "pki_int", defaultPKIRole, mock.Anything, mock.Anything).
+		Return(http.StatusForbidden, assert.AnError)
+
+	err = pki.IssueCertificates("token")
+
+	assert.Equal(t, assert.AnError, err)
+	secretClient.AssertNumberOfCalls(t, "IssueCertificate", 2)
+}
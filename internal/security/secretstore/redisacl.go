@@ -0,0 +1,162 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	// redisACLPath holds the ACL rule of each service's Redis user, which security-bootstrap-redis applies to Redis
+	redisACLPath = "/v1/secret/edgex/bootstrap-redis/redisacl"
+
+	// legacySharedRedisUser is the user of the password shared by every service before each had its own ACL user
+	legacySharedRedisUser = "redis5"
+
+	// redisV1ObjectPattern matches the bare UUID keys under which the V1 APIs store objects
+	redisV1ObjectPattern = "????????-????-????-????-????????????"
+
+	// redisServiceCommands allows the service users every command but the administrative and dangerous ones
+	redisServiceCommands = "+@all -@dangerous"
+)
+
+// redisServiceUser is the Redis ACL user of a service.
+type redisServiceUser struct {
+	service     string
+	username    string
+	keyPatterns []string
+}
+
+// path returns the secret store path of the user's credential.
+func (u redisServiceUser) path() string {
+	return fmt.Sprintf(serviceCredentialPath, u.service)
+}
+
+// redisServiceUsers returns the ACL user of each service in databases, sorted by service.  When several databases
+// belong to the same service, the first by name defines its user.  Databases with V1Objects set are granted the V1
+// object keys besides their KeyPatterns.
+func redisServiceUsers(databases map[string]config.Database) []redisServiceUser {
+	var names []string
+	for name := range databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var users []redisServiceUser
+	seen := make(map[string]bool)
+	for _, name := range names {
+		info := databases[name]
+		if info.Service == "" || seen[info.Service] {
+			continue
+		}
+		seen[info.Service] = true
+		username := info.Username
+		if username == "" {
+			username = info.Service
+		}
+		keyPatterns := info.KeyPatterns
+		if info.V1Objects && len(keyPatterns) > 0 {
+			keyPatterns = append(append([]string{}, keyPatterns...), redisV1ObjectPattern)
+		}
+		users = append(users, redisServiceUser{
+			service:     info.Service,
+			username:    username,
+			keyPatterns: keyPatterns,
+		})
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].service < users[j].service })
+	return users
+}
+
+// redisPasswordHash returns the hex-encoded SHA-256 hash of password, by which ACL SETUSER rules add (#) or remove (!)
+// a password.
+func redisPasswordHash(password string) string {
+	hash := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(hash[:])
+}
+
+// redisACLRule returns the ACL SETUSER rules enabling a user with password which may access keyPatterns.  The password
+// is given as its SHA-256 hash, so the rule can be handed to security-bootstrap-redis without revealing it.
+func redisACLRule(password string, keyPatterns []string) string {
+	rules := []string{"on", "#" + redisPasswordHash(password)}
+	if len(keyPatterns) == 0 {
+		rules = append(rules, "~*")
+	}
+	for _, pattern := range keyPatterns {
+		rules = append(rules, "~"+pattern)
+	}
+	rules = append(rules, redisServiceCommands)
+	return strings.Join(rules, " ")
+}
+
+// setupRedisServiceCredentials ensures every service has a Redis credential of its own at its redisdb path, keeping the
+// credentials previously created for the service and replacing the shared credential of earlier releases.  The ACL
+// rules of the services' users are then stored for security-bootstrap-redis.
+func setupRedisServiceCredentials(
+	ctx context.Context,
+	lc logger.LoggingClient,
+	cred Cred,
+	databases map[string]config.Database) error {
+
+	acl := make(map[string]string)
+	for _, user := range redisServiceUsers(databases) {
+		if _, exists := acl[user.username]; exists {
+			return fmt.Errorf("redis user %s is configured for more than one service", user.username)
+		}
+
+		pair, err := cred.getUserPasswordPair(user.path())
+		switch {
+		case err == errNotFound:
+			pair = nil
+		case err != nil:
+			return err
+		}
+
+		if pair != nil && pair.User == user.username && pair.Password != "" {
+			lc.Info(fmt.Sprintf("redis credentials for %s already present at path %s", user.service, user.path()))
+		} else {
+			if pair != nil && pair.User != "" {
+				lc.Info(fmt.Sprintf("replacing redis user %s of %s with its own user %s",
+					pair.User, user.service, user.username))
+			}
+			password, err := cred.GeneratePassword(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to generate redis password for %s: %s", user.service, err.Error())
+			}
+			pair = &UserPasswordPair{User: user.username, Password: password}
+			if err := cred.UploadToStore(pair, user.path()); err != nil {
+				lc.Error(fmt.Sprintf("failed to upload credential pair for %s on path %s", user.service, user.path()))
+				return err
+			}
+		}
+		acl[user.username] = redisACLRule(pair.Password, user.keyPatterns)
+	}
+
+	if err := cred.upload(acl, redisACLPath); err != nil {
+		lc.Error(fmt.Sprintf("failed to upload the redis ACL on path %s", redisACLPath))
+		return err
+	}
+	lc.Info(fmt.Sprintf("redis ACL for %d service user(s) uploaded to %s", len(acl), redisACLPath))
+	return nil
+}
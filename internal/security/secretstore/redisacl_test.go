@@ -0,0 +1,99 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisServiceUsers(t *testing.T) {
+	users := redisServiceUsers(map[string]config.Database{
+		"admin":       {Username: "admin"},
+		"scheduler":   {Service: "scheduler", V1Objects: true},
+		"coredata":    {Service: "coredata", Username: "core", KeyPatterns: []string{"cd|*"}, V1Objects: true},
+		"coredata-v2": {Service: "coredata", Username: "core2"},
+		"metadata":    {Service: "metadata", Username: "meta", KeyPatterns: []string{"md|*"}},
+	})
+
+	assert.Equal(t, []redisServiceUser{
+		{service: "coredata", username: "core", keyPatterns: []string{"cd|*", redisV1ObjectPattern}},
+		{service: "metadata", username: "meta", keyPatterns: []string{"md|*"}},
+		{service: "scheduler", username: "scheduler"},
+	}, users)
+	assert.Equal(t, "/v1/secret/edgex/coredata/redisdb", users[0].path())
+}
+
+func TestRedisACLRule(t *testing.T) {
+	// SHA-256 of "password"
+	hash := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+
+	assert.Equal(t, "on #"+hash+" ~cd|evt* ~cd|rd* +@all -@dangerous",
+		redisACLRule("password", []string{"cd|evt*", "cd|rd*"}))
+	assert.Equal(t, "on #"+hash+" ~* +@all -@dangerous", redisACLRule("password", nil))
+}
+
+func TestSetupRedisServiceCredentials(t *testing.T) {
+	kv := &fakeKV{secrets: map[string]UserPasswordPair{
+		"/v1/secret/edgex/coredata/redisdb":      {User: "core", Password: "existing"},
+		"/v1/secret/edgex/notifications/redisdb": {User: legacySharedRedisUser, Password: "shared"},
+	}}
+	server := httptest.NewServer(kv)
+	defer server.Close()
+	cred := NewCred(http.DefaultClient, "token", &sequenceGenerator{prefix: "new"}, server.URL, logger.MockLogger{})
+
+	err := setupRedisServiceCredentials(context.Background(), logger.MockLogger{}, cred, map[string]config.Database{
+		"admin":         {Username: "admin"},
+		"coredata":      {Service: "coredata", Username: "core", KeyPatterns: []string{"cd|*"}},
+		"metadata":      {Service: "metadata", Username: "meta", KeyPatterns: []string{"md|*"}},
+		"notifications": {Service: "notifications", Username: "notifications"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]UserPasswordPair{
+		"/v1/secret/edgex/coredata/redisdb":      {User: "core", Password: "existing"},
+		"/v1/secret/edgex/metadata/redisdb":      {User: "meta", Password: "new-1"},
+		"/v1/secret/edgex/notifications/redisdb": {User: "notifications", Password: "new-2"},
+	}, kv.secrets)
+	assert.Equal(t, map[string]string{
+		"core":          redisACLRule("existing", []string{"cd|*"}),
+		"meta":          redisACLRule("new-1", []string{"md|*"}),
+		"notifications": redisACLRule("new-2", nil),
+	}, kv.acl)
+}
+
+func TestSetupRedisServiceCredentialsDuplicateUser(t *testing.T) {
+	kv := &fakeKV{secrets: map[string]UserPasswordPair{}}
+	server := httptest.NewServer(kv)
+	defer server.Close()
+	cred := NewCred(http.DefaultClient, "token", &sequenceGenerator{prefix: "new"}, server.URL, logger.MockLogger{})
+
+	err := setupRedisServiceCredentials(context.Background(), logger.MockLogger{}, cred, map[string]config.Database{
+		"coredata": {Service: "coredata", Username: "edgex"},
+		"metadata": {Service: "metadata", Username: "edgex"},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, kv.acl)
+}
@@ -0,0 +1,570 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstore
+
+/*
+
+A rotation of the generated Redis passwords proceeds as follows:
+
+1. Read the current credentials from the bootstrap-redis path and every service's redisdb path
+2. Generate a new password for the default user, used by security-bootstrap-redis, and each service's ACL user
+3. Add them to the users with ACL SETUSER, keeping the old passwords valid for a grace period; established
+   connections remain authenticated
+4. Verify the new passwords on fresh connections
+5. Upload the new credentials to their paths, and the services' new ACL rules for security-bootstrap-redis
+6. Record the time of the rotation
+7. Run the restart command for the services still connected with their old password, and wait for the services'
+   connections to be re-established with the new passwords
+8. End the grace period by removing the old passwords from the users
+
+A failure in steps 3-5 rolls back: the old credentials are restored to the paths already updated and in Redis.
+
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	redigo "github.com/gomodule/redigo/redis"
+)
+
+const (
+	CredentialRotatorPolicyName = "edgex-credential-rotator"
+
+	// credentialRotatorPolicy allows the holder to read and replace the Redis credentials and ACL and nothing else
+	credentialRotatorPolicy = `
+path "secret/edgex/+/redisdb" {
+  capabilities = ["read", "create", "update"]
+}
+
+path "secret/edgex/bootstrap-redis/redisacl" {
+  capabilities = ["create", "update"]
+}
+`
+
+	// bootstrapRedisCredentialPath is the authoritative copy of the Redis credential, used by security-bootstrap-redis
+	bootstrapRedisCredentialPath = "/v1/secret/edgex/bootstrap-redis/redisdb"
+	serviceCredentialPath        = "/v1/secret/edgex/%s/redisdb"
+
+	defaultRotationInterval      = "2160h"
+	defaultRotationCheckInterval = "1h"
+	defaultReconnectTimeout      = "5m"
+	defaultRotationStateFile     = "credential-rotation.json"
+
+	reconnectPollInterval = time.Second
+	redisTimeout          = 10 * time.Second
+
+	// redisDefaultUser is the Redis user authenticated by a password alone, as security-bootstrap-redis does
+	redisDefaultUser = "default"
+)
+
+// redisConn is the subset of redigo.Conn used by the CredentialRotator.
+type redisConn interface {
+	Do(commandName string, args ...interface{}) (reply interface{}, err error)
+	Close() error
+}
+
+// rotationState is persisted to record when the credential was last rotated.
+type rotationState struct {
+	LastRotation time.Time
+}
+
+// rotatedCredential is a Redis credential being rotated; user is nil for the default user.
+type rotatedCredential struct {
+	path     string
+	user     *redisServiceUser
+	previous UserPasswordPair
+	rotated  UserPasswordPair
+}
+
+// redisUsername returns the name of the credential's user in Redis.
+func (c rotatedCredential) redisUsername() string {
+	if c.user == nil {
+		return redisDefaultUser
+	}
+	return c.rotated.User
+}
+
+// redisClient is a connection listed by CLIENT LIST.
+type redisClient struct {
+	addr string
+	name string
+	user string
+}
+
+// label returns the name of the client, or its address if unnamed.
+func (c redisClient) label() string {
+	if c.name != "" {
+		return c.name
+	}
+	return c.addr
+}
+
+// CredentialRotator rotates the generated Redis passwords.
+type CredentialRotator struct {
+	loggingClient    logger.LoggingClient
+	secretClient     secretstoreclient.SecretStoreClient
+	fileOpener       fileioperformer.FileIoPerformer
+	users            []redisServiceUser
+	statePath        string
+	interval         time.Duration
+	checkInterval    time.Duration
+	reconnectTimeout time.Duration
+	dial             func(username string, password string) (redisConn, error)
+	restart          func(ctx context.Context, services []string) error
+	now              func() time.Time
+	sleep            func(time.Duration)
+}
+
+// NewCredentialRotator validates the CredentialRotation configuration, filling in defaults for the settings left
+// empty, and returns a CredentialRotator.
+func NewCredentialRotator(
+	lc logger.LoggingClient,
+	secretClient secretstoreclient.SecretStoreClient,
+	fileOpener fileioperformer.FileIoPerformer,
+	configuration *config.ConfigurationStruct) (*CredentialRotator, error) {
+
+	rotationConfig := configuration.CredentialRotation
+	rotator := &CredentialRotator{
+		loggingClient: lc,
+		secretClient:  secretClient,
+		fileOpener:    fileOpener,
+		users:         redisServiceUsers(configuration.Databases),
+		now:           time.Now,
+		sleep:         time.Sleep,
+	}
+
+	durations := []struct {
+		name         string
+		value        string
+		defaultValue string
+		result       *time.Duration
+	}{
+		{"Interval", rotationConfig.Interval, defaultRotationInterval, &rotator.interval},
+		{"CheckInterval", rotationConfig.CheckInterval, defaultRotationCheckInterval, &rotator.checkInterval},
+		{"ReconnectTimeout", rotationConfig.ReconnectTimeout, defaultReconnectTimeout, &rotator.reconnectTimeout},
+	}
+	for _, d := range durations {
+		value := d.value
+		if value == "" {
+			value = d.defaultValue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid CredentialRotation.%s %s", d.name, value)
+		}
+		*d.result = duration
+	}
+
+	stateFile := rotationConfig.StateFile
+	if stateFile == "" {
+		stateFile = defaultRotationStateFile
+	}
+	rotator.statePath = filepath.Join(configuration.SecretService.TokenFolderPath, stateFile)
+
+	address := fmt.Sprintf("%s:%d", rotationConfig.RedisHost, rotationConfig.RedisPort)
+	rotator.dial = func(username string, password string) (redisConn, error) {
+		conn, err := redigo.Dial("tcp", address,
+			redigo.DialConnectTimeout(redisTimeout),
+			redigo.DialReadTimeout(redisTimeout),
+			redigo.DialWriteTimeout(redisTimeout))
+		if err != nil {
+			return nil, err
+		}
+		// redigo's DialPassword can only authenticate the default user
+		args := []interface{}{password}
+		if username != "" {
+			args = []interface{}{username, password}
+		}
+		if _, err := conn.Do("AUTH", args...); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+
+	if command := rotationConfig.RestartCommand; command != "" {
+		rotator.restart = func(ctx context.Context, services []string) error {
+			ctx, cancel := context.WithTimeout(ctx, rotator.reconnectTimeout)
+			defer cancel()
+			output, err := exec.CommandContext(ctx, command, services...).CombinedOutput()
+			if err != nil {
+				return fmt.Errorf("%s failed: %s: %s", command, err.Error(), strings.TrimSpace(string(output)))
+			}
+			return nil
+		}
+	}
+	return rotator, nil
+}
+
+// CreateRotatorToken creates a periodic token which may only read and replace the Redis credentials and must be renewed
+// within three check intervals.
+func (r *CredentialRotator) CreateRotatorToken(rootToken string) (string, error) {
+	return NewTokenMaintenance(r.loggingClient, r.secretClient).CreatePeriodicToken(
+		rootToken,
+		CredentialRotatorPolicyName,
+		credentialRotatorPolicy,
+		3*r.checkInterval)
+}
+
+// RotatePeriodically checks every check interval whether the credential is due for rotation, and rotates it using
+// cred, until ctx is cancelled.
+func (r *CredentialRotator) RotatePeriodically(ctx context.Context, wg *sync.WaitGroup, token string, cred Cred) {
+	NewTokenMaintenance(r.loggingClient, r.secretClient).RunWithPeriodicToken(ctx, wg, token, r.checkInterval, func() {
+		if !r.Due() {
+			return
+		}
+		if err := r.Rotate(ctx, cred); err != nil {
+			r.loggingClient.Error(fmt.Sprintf("scheduled credential rotation failed: %s", err.Error()))
+		}
+	})
+}
+
+// Due reports whether the credential was last rotated at least an interval ago.  When no rotation has been recorded
+// the current time is recorded, so the first scheduled rotation happens an interval after rotation is enabled.
+func (r *CredentialRotator) Due() bool {
+	state, err := r.loadState()
+	if err != nil {
+		r.loggingClient.Info(fmt.Sprintf("no credential rotation recorded (%s); starting the rotation schedule now",
+			err.Error()))
+		if err := r.saveState(r.now()); err != nil {
+			r.loggingClient.Error(fmt.Sprintf("failed to record credential rotation state: %s", err.Error()))
+		}
+		return false
+	}
+	return !r.now().Before(state.LastRotation.Add(r.interval))
+}
+
+// Rotate replaces the Redis passwords, rolling back if Redis or the secret store can't be updated.  Once rotated it
+// restarts the services still connected with their old password and waits for them to reconnect, reporting those
+// which haven't, before removing the old passwords.
+func (r *CredentialRotator) Rotate(ctx context.Context, cred Cred) error {
+	credentials := []rotatedCredential{{path: bootstrapRedisCredentialPath}}
+	for index := range r.users {
+		credentials = append(credentials, rotatedCredential{path: r.users[index].path(), user: &r.users[index]})
+	}
+	for index := range credentials {
+		current, err := cred.getUserPasswordPair(credentials[index].path)
+		if err != nil {
+			return fmt.Errorf("failed to read the current redis credential at %s: %s",
+				credentials[index].path, err.Error())
+		}
+		password, err := cred.GeneratePassword(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate redis password: %s", err.Error())
+		}
+		credentials[index].previous = *current
+		credentials[index].rotated = UserPasswordPair{User: current.User, Password: password}
+	}
+
+	conn, err := r.dial("", credentials[0].previous.Password)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %s", err.Error())
+	}
+	defer func() { _ = conn.Close() }()
+
+	r.loggingClient.Info(fmt.Sprintf("rotating the passwords of %d redis user(s)", len(credentials)))
+	if err := r.grant(conn, credentials); err != nil {
+		r.rollback(conn, cred, credentials, 0)
+		return fmt.Errorf("failed to set redis passwords: %s", err.Error())
+	}
+	rotatedAt := r.now()
+
+	if err := r.verify(credentials); err != nil {
+		r.rollback(conn, cred, credentials, 0)
+		return fmt.Errorf("failed to verify the new redis passwords: %s", err.Error())
+	}
+
+	for index, credential := range credentials {
+		if err := cred.UploadToStore(&credential.rotated, credential.path); err != nil {
+			// the failed upload may have been applied, so it's rolled back too
+			r.rollback(conn, cred, credentials, index+1)
+			return fmt.Errorf("failed to store the new redis credential at %s: %s", credential.path, err.Error())
+		}
+	}
+	if err := cred.upload(redisACL(credentials, rotatedPair), redisACLPath); err != nil {
+		r.rollback(conn, cred, credentials, len(credentials)+1)
+		return fmt.Errorf("failed to store the new redis ACL at %s: %s", redisACLPath, err.Error())
+	}
+	r.loggingClient.Info(fmt.Sprintf("redis passwords rotated and stored at %d secret store paths", len(credentials)))
+
+	if err := r.saveState(rotatedAt); err != nil {
+		r.loggingClient.Error(fmt.Sprintf("failed to record credential rotation state: %s", err.Error()))
+	}
+	r.awaitReconnect(ctx, conn, rotatedAt)
+	r.revokePrevious(conn, credentials)
+	return nil
+}
+
+func previousPair(credential rotatedCredential) UserPasswordPair {
+	return credential.previous
+}
+
+func rotatedPair(credential rotatedCredential) UserPasswordPair {
+	return credential.rotated
+}
+
+// grant adds the rotated password of each credential to its user in Redis over conn, which is authenticated as the
+// default user.  The previous password remains valid until revokePrevious.
+func (r *CredentialRotator) grant(conn redisConn, credentials []rotatedCredential) error {
+	for _, credential := range credentials {
+		args := []interface{}{"SETUSER", credential.redisUsername()}
+		if credential.user != nil {
+			args = append(args, "reset")
+			for _, rule := range strings.Fields(redisACLRule(credential.rotated.Password, credential.user.keyPatterns)) {
+				args = append(args, rule)
+			}
+			args = append(args, "#"+redisPasswordHash(credential.previous.Password))
+		} else {
+			args = append(args, "#"+redisPasswordHash(credential.rotated.Password))
+		}
+		if _, err := conn.Do("ACL", args...); err != nil {
+			return fmt.Errorf("user %s: %s", credential.redisUsername(), err.Error())
+		}
+	}
+	return nil
+}
+
+// revokePrevious ends the grace period by removing the previous password of each credential from its user in Redis
+// over conn.
+func (r *CredentialRotator) revokePrevious(conn redisConn, credentials []rotatedCredential) {
+	for _, credential := range credentials {
+		username := credential.redisUsername()
+		if _, err := conn.Do("ACL", "SETUSER", username, "!"+redisPasswordHash(credential.previous.Password)); err != nil {
+			r.loggingClient.Error(fmt.Sprintf("failed to remove the previous redis password of user %s: %s",
+				username, err.Error()))
+		}
+	}
+	r.loggingClient.Info("removed the previous redis passwords at the end of the grace period")
+}
+
+// apply sets the password selected from each credential, replacing any other, in Redis over conn, which is
+// authenticated as the default user.
+func (r *CredentialRotator) apply(
+	conn redisConn,
+	credentials []rotatedCredential,
+	selectPair func(rotatedCredential) UserPasswordPair) error {
+
+	for _, credential := range credentials {
+		pair := selectPair(credential)
+		args := []interface{}{"SETUSER", credential.redisUsername()}
+		if credential.user != nil {
+			args = append(args, "reset")
+			for _, rule := range strings.Fields(redisACLRule(pair.Password, credential.user.keyPatterns)) {
+				args = append(args, rule)
+			}
+		} else {
+			args = append(args, "resetpass", "#"+redisPasswordHash(pair.Password))
+		}
+		if _, err := conn.Do("ACL", args...); err != nil {
+			return fmt.Errorf("user %s: %s", credential.redisUsername(), err.Error())
+		}
+	}
+	return nil
+}
+
+// redisACL returns the ACL rules of the service users with the password selected from each credential.
+func redisACL(credentials []rotatedCredential, selectPair func(rotatedCredential) UserPasswordPair) map[string]string {
+	acl := make(map[string]string)
+	for _, credential := range credentials {
+		if credential.user != nil {
+			pair := selectPair(credential)
+			acl[pair.User] = redisACLRule(pair.Password, credential.user.keyPatterns)
+		}
+	}
+	return acl
+}
+
+// verify checks that new connections can authenticate with the rotated credentials.
+func (r *CredentialRotator) verify(credentials []rotatedCredential) error {
+	for _, credential := range credentials {
+		username := ""
+		if credential.user != nil {
+			username = credential.rotated.User
+		}
+		conn, err := r.dial(username, credential.rotated.Password)
+		if err != nil {
+			return err
+		}
+		_, err = conn.Do("PING")
+		_ = conn.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollback restores the previous credentials to the first uploaded paths, and the previous ACL if uploaded exceeds the
+// number of credentials, and sets them in Redis over conn, which remains authenticated.
+func (r *CredentialRotator) rollback(conn redisConn, cred Cred, credentials []rotatedCredential, uploaded int) {
+	r.loggingClient.Warn("rolling back redis credential rotation")
+	for index, credential := range credentials {
+		if index >= uploaded {
+			break
+		}
+		if err := cred.UploadToStore(&credential.previous, credential.path); err != nil {
+			r.loggingClient.Error(fmt.Sprintf("failed to restore the redis credential at %s: %s",
+				credential.path, err.Error()))
+		}
+	}
+	if uploaded > len(credentials) {
+		if err := cred.upload(redisACL(credentials, previousPair), redisACLPath); err != nil {
+			r.loggingClient.Error(fmt.Sprintf("failed to restore the redis ACL at %s: %s", redisACLPath, err.Error()))
+		}
+	}
+	if err := r.apply(conn, credentials, previousPair); err != nil {
+		r.loggingClient.Error(fmt.Sprintf("failed to restore the redis passwords: %s", err.Error()))
+		return
+	}
+	r.loggingClient.Info("redis credential rotation rolled back")
+}
+
+// awaitReconnect polls Redis' client list until every client other than conn connected after rotatedAt, or the
+// reconnect timeout elapses.  The services of the clients connected before rotatedAt are restarted on the first poll.
+func (r *CredentialRotator) awaitReconnect(ctx context.Context, conn redisConn, rotatedAt time.Time) {
+	self, err := redigo.Int64(conn.Do("CLIENT", "ID"))
+	if err != nil {
+		r.loggingClient.Warn(fmt.Sprintf("unable to identify redis connection: %s", err.Error()))
+		return
+	}
+
+	deadline := rotatedAt.Add(r.reconnectTimeout)
+	restarted := false
+	for {
+		list, err := redigo.String(conn.Do("CLIENT", "LIST"))
+		if err != nil {
+			r.loggingClient.Warn(fmt.Sprintf("unable to list redis clients: %s", err.Error()))
+			return
+		}
+		stale := staleClients(list, self, r.now().Sub(rotatedAt))
+		if len(stale) == 0 {
+			r.loggingClient.Info("all redis clients have reconnected since the credential rotation")
+			return
+		}
+		if !restarted {
+			restarted = true
+			r.restartServices(ctx, stale)
+		}
+		if !r.now().Before(deadline) {
+			var labels []string
+			for _, client := range stale {
+				labels = append(labels, client.label())
+			}
+			r.loggingClient.Warn(fmt.Sprintf(
+				"%d redis client(s) still use connections opened before the credential rotation and must be "+
+					"restarted to pick up the new credential before they reconnect: %s",
+				len(stale),
+				strings.Join(labels, ", ")))
+			return
+		}
+		r.sleep(reconnectPollInterval)
+	}
+}
+
+// restartServices runs the restart command, if configured, for the services whose users the stale clients are
+// authenticated as.  Clients of the default user, such as security-bootstrap-redis, aren't services.
+func (r *CredentialRotator) restartServices(ctx context.Context, stale []redisClient) {
+	if r.restart == nil {
+		return
+	}
+	services := make(map[string]bool)
+	for _, client := range stale {
+		for _, user := range r.users {
+			if user.username == client.user {
+				services[user.service] = true
+			}
+		}
+	}
+	if len(services) == 0 {
+		return
+	}
+	var names []string
+	for service := range services {
+		names = append(names, service)
+	}
+	sort.Strings(names)
+
+	r.loggingClient.Info(fmt.Sprintf("restarting the services still connected with their previous redis password: %s",
+		strings.Join(names, ", ")))
+	if err := r.restart(ctx, names); err != nil {
+		r.loggingClient.Error(fmt.Sprintf("failed to restart services after the credential rotation: %s",
+			err.Error()))
+	}
+}
+
+// staleClients parses the output of CLIENT LIST and returns each client other than self whose connection is older
+// than elapsed.
+func staleClients(list string, self int64, elapsed time.Duration) []redisClient {
+	var stale []redisClient
+	for _, line := range strings.Split(strings.TrimSpace(list), "\n") {
+		fields := make(map[string]string)
+		for _, field := range strings.Fields(line) {
+			if parts := strings.SplitN(field, "=", 2); len(parts) == 2 {
+				fields[parts[0]] = parts[1]
+			}
+		}
+		id, err := strconv.ParseInt(fields["id"], 10, 64)
+		if err != nil || id == self {
+			continue
+		}
+		age, err := strconv.ParseInt(fields["age"], 10, 64)
+		if err != nil || time.Duration(age)*time.Second <= elapsed {
+			continue
+		}
+		stale = append(stale, redisClient{addr: fields["addr"], name: fields["name"], user: fields["user"]})
+	}
+	return stale
+}
+
+func (r *CredentialRotator) loadState() (rotationState, error) {
+	var state rotationState
+	reader, err := r.fileOpener.OpenFileReader(r.statePath, os.O_RDONLY, 0400)
+	if err != nil {
+		return state, err
+	}
+	readCloser := fileioperformer.MakeReadCloser(reader)
+	defer func() { _ = readCloser.Close() }()
+	err = json.NewDecoder(readCloser).Decode(&state)
+	return state, err
+}
+
+func (r *CredentialRotator) saveState(lastRotation time.Time) error {
+	writer, err := r.fileOpener.OpenFileWriter(r.statePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(writer).Encode(rotationState{LastRotation: lastRotation}); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	return writer.Close()
+}
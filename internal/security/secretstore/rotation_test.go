@@ -0,0 +1,390 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/security/secretstore/config"
+	. "github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-secrets/v2/pkg/token/fileioperformer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis holds the state of a Redis server shared by its fakeRedisConn connections.  users maps each ACL user,
+// including the default user, to its rules.
+type fakeRedis struct {
+	mutex       sync.Mutex
+	users       map[string][]string
+	failSetUser string
+	clients     func() string
+}
+
+func (s *fakeRedis) dial(username string, password string) (redisConn, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if username == "" {
+		username = redisDefaultUser
+	}
+	for _, rule := range s.users[username] {
+		if rule == "#"+redisPasswordHash(password) {
+			return &fakeRedisConn{server: s}, nil
+		}
+	}
+	return nil, errors.New("WRONGPASS invalid username-password pair")
+}
+
+type fakeRedisConn struct {
+	server *fakeRedis
+}
+
+func (c *fakeRedisConn) Do(commandName string, args ...interface{}) (interface{}, error) {
+	c.server.mutex.Lock()
+	defer c.server.mutex.Unlock()
+	switch {
+	case commandName == "PING":
+		return "PONG", nil
+	case commandName == "ACL" && args[0] == "SETUSER":
+		username := args[1].(string)
+		if username == c.server.failSetUser {
+			return nil, errors.New("ERR Error in ACL SETUSER modifier")
+		}
+		rules := c.server.users[username]
+		for _, arg := range args[2:] {
+			rule := arg.(string)
+			switch {
+			case rule == "reset":
+				rules = nil
+			case rule == "resetpass" || strings.HasPrefix(rule, "!"):
+				// resetpass removes every password, !<hash> the one with that hash
+				var kept []string
+				for _, existing := range rules {
+					removed := existing == "#"+strings.TrimPrefix(rule, "!")
+					if rule == "resetpass" {
+						removed = strings.HasPrefix(existing, "#")
+					}
+					if !removed {
+						kept = append(kept, existing)
+					}
+				}
+				rules = kept
+			default:
+				rules = append(rules, rule)
+			}
+		}
+		c.server.users[username] = rules
+		return "OK", nil
+	case commandName == "CLIENT" && args[0] == "ID":
+		return int64(1), nil
+	case commandName == "CLIENT" && args[0] == "LIST":
+		return []byte(c.server.clients()), nil
+	}
+	return nil, fmt.Errorf("unexpected command %s", commandName)
+}
+
+func (c *fakeRedisConn) Close() error {
+	return nil
+}
+
+// fakeKV is a KV secrets engine which fails the next upload to failPath.  The redis ACL is kept apart from the credentials.
+type fakeKV struct {
+	mutex    sync.Mutex
+	secrets  map[string]UserPasswordPair
+	acl      map[string]string
+	failPath string
+}
+
+func (kv *fakeKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.mutex.Lock()
+	defer kv.mutex.Unlock()
+	switch r.Method {
+	case http.MethodGet:
+		pair, ok := kv.secrets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(CredCollect{Pair: pair})
+	case http.MethodPost:
+		if r.URL.Path == kv.failPath {
+			kv.failPath = ""
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == redisACLPath {
+			kv.acl = make(map[string]string)
+			_ = json.NewDecoder(r.Body).Decode(&kv.acl)
+		} else {
+			var pair UserPasswordPair
+			_ = json.NewDecoder(r.Body).Decode(&pair)
+			kv.secrets[r.URL.Path] = pair
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// sequenceGenerator generates the passwords <prefix>-1, <prefix>-2 and so on.
+type sequenceGenerator struct {
+	prefix string
+	count  int
+}
+
+func (g *sequenceGenerator) Generate(_ context.Context) (string, error) {
+	g.count++
+	return fmt.Sprintf("%s-%d", g.prefix, g.count), nil
+}
+
+type rotationFixture struct {
+	rotator *CredentialRotator
+	redis   *fakeRedis
+	kv      *fakeKV
+	cred    Cred
+	now     time.Time
+}
+
+func newRotationFixture(t *testing.T) (*rotationFixture, func()) {
+	folder, err := ioutil.TempDir("", "rotation")
+	require.NoError(t, err)
+
+	configuration := &config.ConfigurationStruct{
+		Databases: map[string]config.Database{
+			"admin":    {Username: "admin"},
+			"metadata": {Service: "metadata", Username: "meta", KeyPatterns: []string{"md|*"}},
+			"coredata": {Service: "coredata", Username: "core", KeyPatterns: []string{"cd|*"}},
+		},
+	}
+	configuration.SecretService.TokenFolderPath = folder
+	rotator, err := NewCredentialRotator(
+		logger.MockLogger{},
+		&MockSecretStoreClient{},
+		fileioperformer.NewDefaultFileIoPerformer(),
+		configuration)
+	require.NoError(t, err)
+
+	f := &rotationFixture{
+		rotator: rotator,
+		redis: &fakeRedis{
+			users: map[string][]string{
+				redisDefaultUser: {"on", "#" + redisPasswordHash("old-default")},
+				"core":           strings.Fields(redisACLRule("old-core", nil)),
+				"meta":           strings.Fields(redisACLRule("old-meta", nil)),
+			},
+			clients: func() string { return "id=1 addr=self age=0\n" },
+		},
+		kv: &fakeKV{
+			secrets: map[string]UserPasswordPair{
+				bootstrapRedisCredentialPath:        {User: "redis5", Password: "old-default"},
+				"/v1/secret/edgex/coredata/redisdb": {User: "core", Password: "old-core"},
+				"/v1/secret/edgex/metadata/redisdb": {User: "meta", Password: "old-meta"},
+			},
+		},
+		now: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	rotator.dial = f.redis.dial
+	rotator.now = func() time.Time { return f.now }
+	rotator.sleep = func(d time.Duration) { f.now = f.now.Add(d) }
+
+	server := httptest.NewServer(f.kv)
+	f.cred = NewCred(http.DefaultClient, "token", &sequenceGenerator{prefix: "new"}, server.URL, logger.MockLogger{})
+	return f, func() {
+		server.Close()
+		_ = os.RemoveAll(folder)
+	}
+}
+
+func TestNewCredentialRotatorInvalidInterval(t *testing.T) {
+	configuration := &config.ConfigurationStruct{}
+	configuration.CredentialRotation.Interval = "90 days"
+
+	_, err := NewCredentialRotator(logger.MockLogger{}, &MockSecretStoreClient{}, nil, configuration)
+
+	assert.Error(t, err)
+}
+
+func TestRotate(t *testing.T) {
+	f, cleanup := newRotationFixture(t)
+	defer cleanup()
+
+	err := f.rotator.Rotate(context.Background(), f.cred)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]UserPasswordPair{
+		bootstrapRedisCredentialPath:        {User: "redis5", Password: "new-1"},
+		"/v1/secret/edgex/coredata/redisdb": {User: "core", Password: "new-2"},
+		"/v1/secret/edgex/metadata/redisdb": {User: "meta", Password: "new-3"},
+	}, f.kv.secrets)
+	assert.Equal(t, map[string]string{
+		"core": redisACLRule("new-2", []string{"cd|*"}),
+		"meta": redisACLRule("new-3", []string{"md|*"}),
+	}, f.kv.acl)
+	for _, pair := range f.kv.secrets {
+		_, err := f.redis.dial(pair.User, pair.Password)
+		if pair.User == "redis5" {
+			_, err = f.redis.dial("", pair.Password)
+		}
+		assert.NoError(t, err, pair.User)
+	}
+	for username, password := range map[string]string{"": "old-default", "core": "old-core", "meta": "old-meta"} {
+		_, err := f.redis.dial(username, password)
+		assert.Error(t, err, "the previous password of %q is removed after the grace period", username)
+	}
+	state, err := f.rotator.loadState()
+	require.NoError(t, err)
+	assert.True(t, f.now.Equal(state.LastRotation))
+}
+
+func TestRotateWaitsForReconnect(t *testing.T) {
+	f, cleanup := newRotationFixture(t)
+	defer cleanup()
+
+	var restarted [][]string
+	f.rotator.restart = func(_ context.Context, services []string) error {
+		restarted = append(restarted, services)
+		return nil
+	}
+	// core-data reconnects after 3 seconds, once restarted; the unnamed client of the default user never does.  The
+	// previous passwords remain valid meanwhile.
+	rotatedAt := f.now
+	var graceRules [][]string
+	f.redis.clients = func() string {
+		// called with the fake's mutex held, so the rules are read rather than dialled
+		graceRules = append(graceRules, f.redis.users["core"])
+		elapsed := int(f.now.Sub(rotatedAt).Seconds())
+		coreDataAge := 3600 + elapsed
+		if elapsed >= 3 {
+			coreDataAge = elapsed - 3
+		}
+		return fmt.Sprintf("id=1 addr=self age=%d user=default\n"+
+			"id=2 addr=10.0.0.2:4000 name=core-data age=%d user=core\n"+
+			"id=3 addr=10.0.0.3:4000 age=%d user=default\n", elapsed, coreDataAge, 3600+elapsed)
+	}
+
+	err := f.rotator.Rotate(context.Background(), f.cred)
+
+	require.NoError(t, err)
+	assert.Equal(t, rotatedAt.Add(f.rotator.reconnectTimeout), f.now)
+	assert.Equal(t, [][]string{{"coredata"}}, restarted, "only services are restarted, once")
+	require.NotEmpty(t, graceRules)
+	for _, rules := range graceRules {
+		assert.Contains(t, rules, "#"+redisPasswordHash("old-core"), "the previous password is valid meanwhile")
+		assert.Contains(t, rules, "#"+redisPasswordHash("new-2"))
+	}
+	assert.Equal(t,
+		[]redisClient{{addr: "10.0.0.3:4000", user: "default"}},
+		staleClients(f.redis.clients(), 1, f.now.Sub(rotatedAt)))
+	_, err = f.redis.dial("core", "old-core")
+	assert.Error(t, err)
+}
+
+func TestRotateRollsBackStoreFailure(t *testing.T) {
+	f, cleanup := newRotationFixture(t)
+	defer cleanup()
+	f.kv.failPath = "/v1/secret/edgex/metadata/redisdb"
+
+	err := f.rotator.Rotate(context.Background(), f.cred)
+
+	require.Error(t, err)
+	assertNotRotated(t, f)
+	_, err = f.rotator.loadState()
+	assert.Error(t, err, "a failed rotation must not be recorded")
+}
+
+func TestRotateRollsBackACLFailure(t *testing.T) {
+	f, cleanup := newRotationFixture(t)
+	defer cleanup()
+	f.kv.failPath = redisACLPath
+
+	err := f.rotator.Rotate(context.Background(), f.cred)
+
+	require.Error(t, err)
+	assertNotRotated(t, f)
+	assert.Equal(t, map[string]string{
+		"core": redisACLRule("old-core", []string{"cd|*"}),
+		"meta": redisACLRule("old-meta", []string{"md|*"}),
+	}, f.kv.acl)
+}
+
+func TestRotateRedisFailure(t *testing.T) {
+	f, cleanup := newRotationFixture(t)
+	defer cleanup()
+	f.redis.failSetUser = "meta"
+
+	err := f.rotator.Rotate(context.Background(), f.cred)
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"on", "#" + redisPasswordHash("old-default")}, f.redis.users[redisDefaultUser])
+	assert.Equal(t, strings.Fields(redisACLRule("old-core", []string{"cd|*"})), f.redis.users["core"])
+	assert.Equal(t, "old-default", f.kv.secrets[bootstrapRedisCredentialPath].Password)
+}
+
+// assertNotRotated asserts that Redis and the secret store both hold the fixture's original credentials.
+func assertNotRotated(t *testing.T, f *rotationFixture) {
+	assert.Equal(t, []string{"on", "#" + redisPasswordHash("old-default")}, f.redis.users[redisDefaultUser])
+	assert.Equal(t, map[string]UserPasswordPair{
+		bootstrapRedisCredentialPath:        {User: "redis5", Password: "old-default"},
+		"/v1/secret/edgex/coredata/redisdb": {User: "core", Password: "old-core"},
+		"/v1/secret/edgex/metadata/redisdb": {User: "meta", Password: "old-meta"},
+	}, f.kv.secrets)
+	for username, password := range map[string]string{"core": "old-core", "meta": "old-meta"} {
+		_, err := f.redis.dial(username, password)
+		assert.NoError(t, err, username)
+	}
+}
+
+func TestDue(t *testing.T) {
+	f, cleanup := newRotationFixture(t)
+	defer cleanup()
+
+	assert.False(t, f.rotator.Due(), "the schedule starts when no rotation has been recorded")
+	state, err := f.rotator.loadState()
+	require.NoError(t, err)
+	assert.True(t, f.now.Equal(state.LastRotation))
+
+	f.now = f.now.Add(f.rotator.interval - time.Second)
+	assert.False(t, f.rotator.Due())
+	f.now = f.now.Add(time.Second)
+	assert.True(t, f.rotator.Due())
+}
+
+func TestStaleClients(t *testing.T) {
+	list := strings.Join([]string{
+		"id=7 addr=127.0.0.1:50000 fd=8 name= age=10 idle=0 flags=N db=0",
+		"id=8 addr=127.0.0.1:50001 fd=9 name=core-data age=100 idle=0 flags=N db=0",
+		"id=9 addr=127.0.0.1:50002 fd=10 name= age=100 idle=0 flags=N db=0",
+		"id=10 addr=127.0.0.1:50003 fd=11 name= age=3 idle=0 flags=N db=0",
+	}, "\n")
+
+	stale := staleClients(list, 7, 5*time.Second)
+	require.Len(t, stale, 2)
+	assert.Equal(t, "core-data", stale[0].label())
+	assert.Equal(t, "127.0.0.1:50002", stale[1].label())
+	assert.Empty(t, staleClients(list, 7, 200*time.Second))
+}
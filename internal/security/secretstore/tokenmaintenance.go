@@ -36,7 +36,11 @@ For Fuji.DOT/Geneva, all root tokens will be revoked.
 */
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
@@ -92,6 +96,70 @@ func (tm *TokenMaintenance) CreateTokenIssuingToken(rootToken string) (map[strin
 	return createTokenResponse, revokeFunc, nil
 }
 
+// CreatePeriodicToken installs policy under policyName and creates a token with only that policy, which must be
+// renewed within period.  It isn't a child of rootToken so it outlives the transient root token.
+func (tm *TokenMaintenance) CreatePeriodicToken(
+	rootToken string,
+	policyName string,
+	policy string,
+	period time.Duration) (string, error) {
+
+	if _, err := tm.secretClient.InstallPolicy(rootToken, policyName, policy); err != nil {
+		tm.logging.Error(fmt.Sprintf("failed installation of %s policy", policyName))
+		return "", err
+	}
+
+	createTokenParameters := make(map[string]interface{})
+	createTokenParameters["display_name"] = policyName
+	createTokenParameters["no_parent"] = true
+	createTokenParameters["period"] = period.String()
+	createTokenParameters["policies"] = []string{policyName}
+	createTokenResponse := make(map[string]interface{})
+	if _, err := tm.secretClient.CreateToken(rootToken, createTokenParameters, &createTokenResponse); err != nil {
+		tm.logging.Error(fmt.Sprintf("failed creation of %s token: %s", policyName, err.Error()))
+		return "", err
+	}
+
+	auth, _ := createTokenResponse["auth"].(map[string]interface{})
+	token, _ := auth["client_token"].(string)
+	if token == "" {
+		return "", errors.New(policyName + " token missing from create token response")
+	}
+	return token, nil
+}
+
+// RunWithPeriodicToken calls task every interval until ctx is cancelled, renewing token before each call and revoking
+// it once ctx is cancelled.
+func (tm *TokenMaintenance) RunWithPeriodicToken(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	token string,
+	interval time.Duration,
+	task func()) {
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				if _, err := tm.secretClient.RevokeSelf(token); err != nil {
+					tm.logging.Warn(fmt.Sprintf("failed revocation of periodic token: %s", err.Error()))
+				}
+				return
+			case <-ticker.C:
+				if _, err := tm.secretClient.RenewSelf(token); err != nil {
+					tm.logging.Error(fmt.Sprintf("failed to renew periodic token: %s", err.Error()))
+				}
+				task()
+			}
+		}
+	}()
+}
+
 // RevokeNonRootTokens revokes non-root tokens that may have been
 // issued in previous EdgeX runs.  Should be called with a high-privileged token.
 func (tm *TokenMaintenance) RevokeNonRootTokens(privilegedToken string) error {
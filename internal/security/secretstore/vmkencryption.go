@@ -16,6 +16,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/edgexfoundry/edgex-go/internal/security/ikm"
 	"github.com/edgexfoundry/edgex-go/internal/security/kdf"
 	"github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
@@ -53,6 +54,9 @@ and other measures.
 
 const aesKeyLength = 32 // for AES-256
 
+// rootTokenInfo is the KDF info string of the key encrypting the root token
+const rootTokenInfo = "vault-root-token"
+
 type VMKEncryption struct {
 	fileOpener     fileioperformer.FileIoPerformer
 	pipedHexReader pipedhexreader.PipedHexReader
@@ -76,14 +80,16 @@ func NewVMKEncryption(fileOpener fileioperformer.FileIoPerformer,
 
 // LoadIKM loads input key material from the specified path
 func (v *VMKEncryption) LoadIKM(ikmBinPath string) error {
-	if ikmBinPath == "" {
-		return fmt.Errorf("ikmBinPath is required")
-	}
-	ikm, err := v.pipedHexReader.ReadHexBytesFromExe(ikmBinPath)
+	return v.LoadIKMFromProvider(ikm.NewHookProvider(v.pipedHexReader, ikmBinPath))
+}
+
+// LoadIKMFromProvider loads input key material from a hook, passphrase or KMS provider
+func (v *VMKEncryption) LoadIKMFromProvider(provider ikm.Provider) error {
+	keyMaterial, err := provider.ReadIKM()
 	if err != nil {
-		return fmt.Errorf("Error reading input key material from IKM_HOOK - encryption not enabled: %w", err)
+		return fmt.Errorf("failed to load input key material - encryption not enabled: %w", err)
 	}
-	v.ikm = ikm
+	v.ikm = keyMaterial
 	v.encrypting = true
 	return nil
 }
@@ -183,6 +189,63 @@ func (v *VMKEncryption) DecryptInitResponse(initResp *secretstoreclient.InitResp
 	return nil
 }
 
+// EncryptRootToken replaces the root token of the InitResponse with
+// EncryptedRootToken and RootTokenNonce, leaving the key shares untouched.
+// An InitResponse without a root token is left as is.
+func (v *VMKEncryption) EncryptRootToken(initResp *secretstoreclient.InitResponse) error {
+
+	// Check prerequisite (key has been loaded)
+	if !v.encrypting {
+		return fmt.Errorf("Cannot encrypt root token as key has not been loaded")
+	}
+	if initResp.RootToken == "" {
+		return nil
+	}
+
+	cipherText, nonce, err := v.gcmEncrypt([]byte(initResp.RootToken), rootTokenInfo)
+	if err != nil {
+		return fmt.Errorf("failed to wrap root token: %w", err)
+	}
+
+	initResp.EncryptedRootToken = hex.EncodeToString(cipherText)
+	initResp.RootTokenNonce = hex.EncodeToString(nonce)
+	initResp.RootToken = "" // strings are immutable, must wait for GC
+	return nil
+}
+
+// DecryptRootToken reverses EncryptRootToken, restoring the root token of
+// the InitResponse.  An InitResponse without an encrypted root token is left as is.
+func (v *VMKEncryption) DecryptRootToken(initResp *secretstoreclient.InitResponse) error {
+
+	// Check prerequisite (key has been loaded)
+	if !v.encrypting {
+		return fmt.Errorf("Cannot decrypt root token as key has not been loaded")
+	}
+	if initResp.EncryptedRootToken == "" {
+		return nil
+	}
+
+	nonce, err := hex.DecodeString(initResp.RootTokenNonce)
+	if err != nil {
+		return fmt.Errorf("failed to decode hex bytes of nonce: %w", err)
+	}
+	cipherText, err := hex.DecodeString(initResp.EncryptedRootToken)
+	if err != nil {
+		return fmt.Errorf("failed to decode hex bytes of ciphertext: %w", err)
+	}
+
+	rootToken, err := v.gcmDecrypt(cipherText, nonce, rootTokenInfo)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap root token: %w", err)
+	}
+	defer wipeKey(rootToken)
+
+	initResp.RootToken = string(rootToken)
+	initResp.EncryptedRootToken = ""
+	initResp.RootTokenNonce = ""
+	return nil
+}
+
 //
 // Internal methods
 //
@@ -191,14 +254,18 @@ func (v *VMKEncryption) DecryptInitResponse(initResp *secretstoreclient.InitResp
 // from the key derivation function based on passing the info
 // string vault0, vault1, ... et cetera to the KDF.
 func (v *VMKEncryption) gcmEncryptKeyshare(keyshare []byte, counter int) ([]byte, []byte, error) {
+	return v.gcmEncrypt(keyshare, fmt.Sprintf("vault%d", counter))
+}
 
-	defer wipeKey(keyshare) // wipe original keyshare on exit
+// gcmEncrypt encrypts plaintext with the key from the key derivation
+// function for the info string
+func (v *VMKEncryption) gcmEncrypt(plaintext []byte, info string) ([]byte, []byte, error) {
 
-	info := fmt.Sprintf("vault%d", counter)
+	defer wipeKey(plaintext) // wipe original plaintext on exit
 
 	key, err := v.kdf.DeriveKey(v.ikm, aesKeyLength, info)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to derive encryption key for vault master key share %w", err)
+		return nil, nil, fmt.Errorf("failed to derive encryption key for %s %w", info, err)
 	}
 	defer wipeKey(key) // wipe encryption key on exit
 
@@ -217,8 +284,8 @@ func (v *VMKEncryption) gcmEncryptKeyshare(keyshare []byte, counter int) ([]byte
 		return nil, nil, fmt.Errorf("failed to initialize random nonce: %w", err)
 	}
 
-	// Encrypt the key share (plaintext to be wiped on exit by deferred function)
-	ciphertext := aesgcm.Seal(nil, nonce, keyshare, nil)
+	// Encrypt the plaintext (to be wiped on exit by deferred function)
+	ciphertext := aesgcm.Seal(nil, nonce, plaintext, nil)
 
 	return ciphertext, nonce, nil
 }
@@ -227,14 +294,18 @@ func (v *VMKEncryption) gcmEncryptKeyshare(keyshare []byte, counter int) ([]byte
 // from the key derivation function based on passing the info
 // string vault0, vault1, ... et cetera to the KDF.
 func (v *VMKEncryption) gcmDecryptKeyshare(keyshare []byte, nonce []byte, counter int) ([]byte, error) {
+	return v.gcmDecrypt(keyshare, nonce, fmt.Sprintf("vault%d", counter))
+}
 
-	defer wipeKey(keyshare) // wipe original (encrypted) keyshare on exit (not technically needed)
+// gcmDecrypt decrypts ciphertext with the key from the key derivation
+// function for the info string
+func (v *VMKEncryption) gcmDecrypt(ciphertext []byte, nonce []byte, info string) ([]byte, error) {
 
-	info := fmt.Sprintf("vault%d", counter)
+	defer wipeKey(ciphertext) // wipe original ciphertext on exit (not technically needed)
 
 	key, err := v.kdf.DeriveKey(v.ikm, aesKeyLength, info)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive encryption key for vault master key share %w", err)
+		return nil, fmt.Errorf("failed to derive encryption key for %s %w", info, err)
 	}
 	defer wipeKey(key) // wipe encryption key on exit
 
@@ -248,8 +319,8 @@ func (v *VMKEncryption) gcmDecryptKeyshare(keyshare []byte, nonce []byte, counte
 		return nil, fmt.Errorf("failed to initialize AES cipher: %w", err)
 	}
 
-	// Decrypt key share; on error, erase any partial results
-	plaintext, err := aesgcm.Open(nil, nonce, keyshare, nil)
+	// Decrypt ciphertext; on error, erase any partial results
+	plaintext, err := aesgcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
 		if plaintext != nil {
 			wipeKey(plaintext)
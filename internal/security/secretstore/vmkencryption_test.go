@@ -10,6 +10,7 @@ import (
 	"errors"
 	"testing"
 
+	ikmMocks "github.com/edgexfoundry/edgex-go/internal/security/ikm/mocks"
 	. "github.com/edgexfoundry/edgex-go/internal/security/kdf/mocks"
 	. "github.com/edgexfoundry/edgex-go/internal/security/pipedhexreader/mocks"
 	"github.com/edgexfoundry/edgex-go/internal/security/secretstoreclient"
@@ -105,3 +106,90 @@ func TestVMKEncryptionFailPath(t *testing.T) {
 	pipedHexReader.AssertExpectations(t)
 	kdf.AssertExpectations(t)
 }
+
+// TestVMKEncryptionProvider tests loading the IKM from a provider
+func TestVMKEncryptionProvider(t *testing.T) {
+	// Arrange
+	fileOpener := &mocks.FileIoPerformer{}
+	pipedHexReader := &MockPipedHexReader{}
+	provider := &ikmMocks.MockProvider{}
+	provider.On("ReadIKM").Return(make([]byte, 32), nil).Once()
+	provider.On("ReadIKM").Return([]byte{}, errors.New("error")).Once()
+	kdf := &MockKeyDeriver{}
+	kdf.On("DeriveKey", make([]byte, 32), uint(32), "vault0").Return(make([]byte, 32), nil)
+	initialInitResp := secretstoreclient.InitResponse{
+		Keys:       []string{"aabbcc"},
+		KeysBase64: []string{"qrvM"},
+	}
+	initResp := initialInitResp
+
+	// Act & Assert
+	vmkEncryption := NewVMKEncryption(fileOpener, pipedHexReader, kdf)
+	err := vmkEncryption.LoadIKMFromProvider(provider)
+	require.NoError(t, err)
+	require.True(t, vmkEncryption.IsEncrypting())
+
+	err = vmkEncryption.EncryptInitResponse(&initResp)
+	require.NoError(t, err)
+
+	err = vmkEncryption.DecryptInitResponse(&initResp)
+	require.NoError(t, err)
+	require.Equal(t, initialInitResp, initResp)
+
+	vmkEncryption.WipeIKM()
+
+	err = vmkEncryption.LoadIKMFromProvider(provider)
+	require.Error(t, err)
+	require.False(t, vmkEncryption.IsEncrypting())
+
+	fileOpener.AssertExpectations(t)
+	pipedHexReader.AssertExpectations(t)
+	provider.AssertExpectations(t)
+	kdf.AssertExpectations(t)
+}
+
+// TestVMKEncryptionRootToken tests encrypting the root token
+func TestVMKEncryptionRootToken(t *testing.T) {
+	// Arrange
+	fileOpener := &mocks.FileIoPerformer{}
+	pipedHexReader := &MockPipedHexReader{}
+	provider := &ikmMocks.MockProvider{}
+	provider.On("ReadIKM").Return(make([]byte, 32), nil)
+	kdf := &MockKeyDeriver{}
+	kdf.On("DeriveKey", make([]byte, 32), uint(32), "vault-root-token").Return(make([]byte, 32), nil)
+	initialInitResp := secretstoreclient.InitResponse{
+		EncryptedKeys: []string{"aabbcc"},
+		Nonces:        []string{"ddeeff"},
+		RootToken:     "s.root",
+	}
+	initResp := initialInitResp
+
+	// Act & Assert
+	vmkEncryption := NewVMKEncryption(fileOpener, pipedHexReader, kdf)
+	err := vmkEncryption.EncryptRootToken(&initResp)
+	require.Error(t, err)
+
+	err = vmkEncryption.LoadIKMFromProvider(provider)
+	require.NoError(t, err)
+
+	err = vmkEncryption.EncryptRootToken(&initResp)
+	require.NoError(t, err)
+	require.Empty(t, initResp.RootToken)
+	require.NotEmpty(t, initResp.EncryptedRootToken)
+	require.Equal(t, initialInitResp.EncryptedKeys, initResp.EncryptedKeys)
+
+	tampered := initResp
+	tampered.EncryptedRootToken = "00" + tampered.EncryptedRootToken[2:]
+	err = vmkEncryption.DecryptRootToken(&tampered)
+	require.Error(t, err)
+
+	err = vmkEncryption.DecryptRootToken(&initResp)
+	require.NoError(t, err)
+	require.Equal(t, initialInitResp, initResp)
+
+	vmkEncryption.WipeIKM()
+
+	fileOpener.AssertExpectations(t)
+	pipedHexReader.AssertExpectations(t)
+	kdf.AssertExpectations(t)
+}
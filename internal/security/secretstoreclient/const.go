@@ -27,9 +27,26 @@ const (
 	ListAccessorsAPI      = "/v1/auth/token/accessors"
 	RevokeAccessorAPI     = "/v1/auth/token/revoke-accessor"
 	LookupAccessorAPI     = "/v1/auth/token/lookup-accessor"
+	RenewAccessorAPI      = "/v1/auth/token/renew-accessor"
 	LookupSelfAPI         = "/v1/auth/token/lookup-self"
 	RevokeSelfAPI         = "/v1/auth/token/revoke-self"
 	RootTokenControlAPI   = "/v1/sys/generate-root/attempt"
 	RootTokenRetrievalAPI = "/v1/sys/generate-root/update"
 	VaultMountsAPI        = "/v1/sys/mounts"
+	RenewSelfAPI          = "/v1/auth/token/renew-self"
+	SealStatusAPI         = "/v1/sys/seal-status"
+	RekeyControlAPI       = "/v1/sys/rekey/init"
+	RekeyUpdateAPI        = "/v1/sys/rekey/update"
+
+	// KVSecretPath is the path of a secret in a KV version 1 secrets engine, relative to /v1
+	KVSecretPath = "/v1/%s"
+
+	// PKI secrets engine paths, relative to the engine's mount point
+	PKICACertificatePath    = "/v1/%s/cert/ca"
+	PKIGenerateRootPath     = "/v1/%s/root/generate/internal"
+	PKIGenerateCSRPath      = "/v1/%s/intermediate/generate/internal"
+	PKISignIntermediatePath = "/v1/%s/root/sign-intermediate"
+	PKISetSignedPath        = "/v1/%s/intermediate/set-signed"
+	PKIRolePath             = "/v1/%s/roles/%s"
+	PKIIssueCertificatePath = "/v1/%s/issue/%s"
 )
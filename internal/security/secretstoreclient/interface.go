@@ -28,9 +28,28 @@ type SecretStoreClient interface {
 	ListAccessors(token string, accessors *[]string) (statusCode int, err error)
 	RevokeAccessor(token string, accessor string) (statusCode int, err error)
 	LookupAccessor(token string, accessor string, tokenMetadata *TokenMetadata) (statusCode int, err error)
+	RenewAccessor(token string, accessor string, increment string) (statusCode int, err error)
 	LookupSelf(token string, tokenMetadata *TokenMetadata) (statusCode int, err error)
 	RevokeSelf(token string) (statusCode int, err error)
 	RegenRootToken(initResponse *InitResponse, rootToken *string) (err error)
 	CheckSecretEngineInstalled(token string, mountPoint string, engine string) (isInstalled bool, err error)
 	EnableKVSecretEngine(token string, mountPoint string, kvVersion string) (statusCode int, err error)
+	RenewSelf(token string) (statusCode int, err error)
+	EnablePKISecretEngine(token string, mountPoint string, maxLeaseTTL string) (statusCode int, err error)
+	ReadCACertificate(token string, mountPoint string, certificate *string) (statusCode int, err error)
+	GenerateRootCA(token string, mountPoint string,
+		parameters map[string]interface{}, certificate *string) (statusCode int, err error)
+	GenerateIntermediateCSR(token string, mountPoint string,
+		parameters map[string]interface{}, csr *string) (statusCode int, err error)
+	SignIntermediate(token string, mountPoint string,
+		parameters map[string]interface{}, certificate *string) (statusCode int, err error)
+	SetSignedIntermediate(token string, mountPoint string, certificate string) (statusCode int, err error)
+	CreatePKIRole(token string, mountPoint string, role string, parameters map[string]interface{}) (statusCode int, err error)
+	IssueCertificate(token string, mountPoint string, role string,
+		parameters map[string]interface{}, certificate *PKICertificate) (statusCode int, err error)
+	SealStatus(sealStatus *SealStatusResponse) (statusCode int, err error)
+	Rekey(initResponse *InitResponse, secretShares int, secretThreshold int, rekeyResponse *InitResponse) (err error)
+	ListSecrets(token string, path string, keys *[]string) (statusCode int, err error)
+	ReadSecret(token string, path string, data *map[string]interface{}) (statusCode int, err error)
+	WriteSecret(token string, path string, data map[string]interface{}) (statusCode int, err error)
 }
@@ -0,0 +1,67 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstoreclient
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ListSecrets lists the keys under path of a KV secrets engine; keys ending in "/" are folders.
+// Vault answers 404 when path holds no keys.
+func (vc *vaultClient) ListSecrets(token string, path string, keys *[]string) (statusCode int, err error) {
+	var response ListSecretsResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               "LIST",
+		Path:                 fmt.Sprintf(KVSecretPath, path),
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "list secrets",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*keys = response.Data.Keys
+	return code, err
+}
+
+func (vc *vaultClient) ReadSecret(token string, path string, data *map[string]interface{}) (statusCode int, err error) {
+	var response ReadSecretResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodGet,
+		Path:                 fmt.Sprintf(KVSecretPath, path),
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "read secret",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*data = response.Data
+	return code, err
+}
+
+func (vc *vaultClient) WriteSecret(token string, path string, data map[string]interface{}) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(KVSecretPath, path),
+		JSONObject:           data,
+		BodyReader:           nil,
+		OperationDescription: "write secret",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
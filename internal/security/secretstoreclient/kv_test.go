@@ -0,0 +1,108 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstoreclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealStatus(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodGet, r.Method)
+		assert.Equal(SealStatusAPI, r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"initialized":true,"sealed":true,"t":3,"n":5,"progress":1,"version":"1.5.0"}`))
+	})
+	defer closer()
+
+	var status SealStatusResponse
+	code, err := vc.SealStatus(&status)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(SealStatusResponse{Initialized: true, Sealed: true, T: 3, N: 5, Progress: 1, Version: "1.5.0"}, status)
+}
+
+func TestListSecrets(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("LIST", r.Method)
+		assert.Equal("/v1/secret/edgex/", r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get(VaultToken))
+		_, _ = w.Write([]byte(`{"data":{"keys":["coredata/","redisdb"]}}`))
+	})
+	defer closer()
+
+	var keys []string
+	code, err := vc.ListSecrets("fake-token", "secret/edgex/", &keys)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal([]string{"coredata/", "redisdb"}, keys)
+}
+
+func TestListSecretsEmpty(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer closer()
+
+	var keys []string
+	code, err := vc.ListSecrets("fake-token", "secret/empty/", &keys)
+
+	assert.Error(err)
+	assert.Equal(http.StatusNotFound, code)
+	assert.Empty(keys)
+}
+
+func TestReadSecret(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodGet, r.Method)
+		assert.Equal("/v1/secret/edgex/coredata/redisdb", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"data":{"username":"core","password":"secret"}}`))
+	})
+	defer closer()
+
+	var data map[string]interface{}
+	code, err := vc.ReadSecret("fake-token", "secret/edgex/coredata/redisdb", &data)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal(map[string]interface{}{"username": "core", "password": "secret"}, data)
+}
+
+func TestWriteSecret(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("/v1/secret/edgex/coredata/redisdb", r.URL.EscapedPath())
+		var body map[string]interface{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(map[string]interface{}{"username": "core"}, body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer closer()
+
+	code, err := vc.WriteSecret("fake-token", "secret/edgex/coredata/redisdb", map[string]interface{}{"username": "core"})
+
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+}
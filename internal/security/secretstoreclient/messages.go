@@ -25,11 +25,13 @@ type InitRequest struct {
 
 // InitResponse contains a Vault init response
 type InitResponse struct {
-	Keys          []string `json:"keys,omitempty"`
-	KeysBase64    []string `json:"keys_base64,omitempty"`
-	EncryptedKeys []string `json:"encrypted_keys,omitempty"`
-	Nonces        []string `json:"nonces,omitempty"`
-	RootToken     string   `json:"root_token,omitempty"`
+	Keys               []string `json:"keys,omitempty"`
+	KeysBase64         []string `json:"keys_base64,omitempty"`
+	EncryptedKeys      []string `json:"encrypted_keys,omitempty"`
+	Nonces             []string `json:"nonces,omitempty"`
+	RootToken          string   `json:"root_token,omitempty"`
+	EncryptedRootToken string   `json:"encrypted_root_token,omitempty"`
+	RootTokenNonce     string   `json:"root_token_nonce,omitempty"`
 }
 
 // UnsealRequest contains a Vault unseal request
@@ -69,6 +71,8 @@ type TokenMetadata struct {
 	ExpireTime string   `json:"expire_time"`
 	Path       string   `json:"path"`
 	Policies   []string `json:"policies"`
+	TTL        int      `json:"ttl"`
+	Renewable  bool     `json:"renewable"`
 }
 
 // LookupAccessorRequest is used by accessor lookup API
@@ -76,6 +80,12 @@ type LookupAccessorRequest struct {
 	Accessor string `json:"accessor"`
 }
 
+// RenewAccessorRequest is used by the renew token by accessor API
+type RenewAccessorRequest struct {
+	Accessor  string `json:"accessor"`
+	Increment string `json:"increment,omitempty"`
+}
+
 // TokenLookupResponse is the response to the token lookup API
 type TokenLookupResponse struct {
 	Data TokenMetadata
@@ -100,6 +110,57 @@ type RootTokenRetrievalResponse struct {
 	EncodedToken string `json:"encoded_token"`
 }
 
+// SealStatusResponse is the response to /v1/sys/seal-status
+type SealStatusResponse struct {
+	Initialized bool   `json:"initialized"`
+	Sealed      bool   `json:"sealed"`
+	T           int    `json:"t"`
+	N           int    `json:"n"`
+	Progress    int    `json:"progress"`
+	Version     string `json:"version"`
+}
+
+// RekeyInitRequest is the request to start a rekey at /v1/sys/rekey/init
+type RekeyInitRequest struct {
+	SecretShares    int `json:"secret_shares"`
+	SecretThreshold int `json:"secret_threshold"`
+}
+
+// RekeyControlResponse is the response to /v1/sys/rekey/init
+type RekeyControlResponse struct {
+	Nonce    string `json:"nonce"`
+	Started  bool   `json:"started"`
+	T        int    `json:"t"`
+	N        int    `json:"n"`
+	Progress int    `json:"progress"`
+	Required int    `json:"required"`
+}
+
+// RekeyUpdateRequest is the request to /v1/sys/rekey/update
+type RekeyUpdateRequest struct {
+	Key   string `json:"key"`
+	Nonce string `json:"nonce"`
+}
+
+// RekeyUpdateResponse is the response to /v1/sys/rekey/update; the new key shares are returned once complete
+type RekeyUpdateResponse struct {
+	Complete   bool     `json:"complete"`
+	Keys       []string `json:"keys"`
+	KeysBase64 []string `json:"keys_base64"`
+}
+
+// ListSecretsResponse is the response to LIST on a KV secrets engine path
+type ListSecretsResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+// ReadSecretResponse is the response to GET on a KV version 1 secrets engine path
+type ReadSecretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
 // ListSecretEnginesResponse is the response to GET /v1/sys/mounts
 type ListSecretEnginesResponse struct {
 	Data map[string]struct {
@@ -115,3 +176,35 @@ type EnableSecretsEngineRequest struct {
 		Version string `json:"version"`
 	} `json:"options"`
 }
+
+// EnablePKISecretsEngineRequest is the POST request to /v1/sys/mounts that enables a PKI secrets engine
+type EnablePKISecretsEngineRequest struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Config      struct {
+		MaxLeaseTTL string `json:"max_lease_ttl"`
+	} `json:"config"`
+}
+
+// PKICertificate holds the certificate material returned by the PKI secrets engine; which fields are set depends
+// on the API called
+type PKICertificate struct {
+	Certificate    string   `json:"certificate"`
+	CSR            string   `json:"csr"`
+	IssuingCA      string   `json:"issuing_ca"`
+	CAChain        []string `json:"ca_chain"`
+	PrivateKey     string   `json:"private_key"`
+	PrivateKeyType string   `json:"private_key_type"`
+	SerialNumber   string   `json:"serial_number"`
+	Expiration     int64    `json:"expiration"`
+}
+
+// PKICertificateResponse is the response to the PKI secrets engine's certificate APIs
+type PKICertificateResponse struct {
+	Data PKICertificate `json:"data"`
+}
+
+// SetSignedIntermediateRequest is the request to /v1/<mount>/intermediate/set-signed
+type SetSignedIntermediateRequest struct {
+	Certificate string `json:"certificate"`
+}
@@ -73,6 +73,12 @@ func (m *MockSecretStoreClient) LookupAccessor(token string, accessor string, to
 	return arguments.Int(0), arguments.Error(1)
 }
 
+func (m *MockSecretStoreClient) RenewAccessor(token string, accessor string, increment string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, accessor, increment)
+	return arguments.Int(0), arguments.Error(1)
+}
+
 func (m *MockSecretStoreClient) LookupSelf(token string, tokenMetadata *TokenMetadata) (statusCode int, err error) {
 	// Boilerplate that returns whatever Mock.On().Returns() is configured for
 	arguments := m.Called(token, tokenMetadata)
@@ -102,3 +108,87 @@ func (m *MockSecretStoreClient) EnableKVSecretEngine(token string, mountPoint st
 	arguments := m.Called(token, mountPoint, kvVersion)
 	return arguments.Int(0), arguments.Error(1)
 }
+
+func (m *MockSecretStoreClient) RenewSelf(token string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) EnablePKISecretEngine(token string, mountPoint string, maxLeaseTTL string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, maxLeaseTTL)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) ReadCACertificate(token string, mountPoint string, certificate *string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, certificate)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) GenerateRootCA(token string, mountPoint string, parameters map[string]interface{}, certificate *string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, parameters, certificate)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) GenerateIntermediateCSR(token string, mountPoint string, parameters map[string]interface{}, csr *string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, parameters, csr)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) SignIntermediate(token string, mountPoint string, parameters map[string]interface{}, certificate *string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, parameters, certificate)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) SetSignedIntermediate(token string, mountPoint string, certificate string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, certificate)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) CreatePKIRole(token string, mountPoint string, role string, parameters map[string]interface{}) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, role, parameters)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) IssueCertificate(token string, mountPoint string, role string, parameters map[string]interface{}, certificate *PKICertificate) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, mountPoint, role, parameters, certificate)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) SealStatus(sealStatus *SealStatusResponse) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(sealStatus)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) Rekey(initResponse *InitResponse, secretShares int, secretThreshold int, rekeyResponse *InitResponse) (err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(initResponse, secretShares, secretThreshold, rekeyResponse)
+	return arguments.Error(0)
+}
+
+func (m *MockSecretStoreClient) ListSecrets(token string, path string, keys *[]string) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, path, keys)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) ReadSecret(token string, path string, data *map[string]interface{}) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, path, data)
+	return arguments.Int(0), arguments.Error(1)
+}
+
+func (m *MockSecretStoreClient) WriteSecret(token string, path string, data map[string]interface{}) (statusCode int, err error) {
+	// Boilerplate that returns whatever Mock.On().Returns() is configured for
+	arguments := m.Called(token, path, data)
+	return arguments.Int(0), arguments.Error(1)
+}
@@ -117,6 +117,16 @@ func TestMockLookupAccessor(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestMockRenewAccessor(t *testing.T) {
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("RenewAccessor", "fake-token", "8609694a-cdbc-db9b-d345-e782dbb562ed", "1h").Return(http.StatusOK, nil)
+
+	rc, err := mockClient.RenewAccessor("fake-token", "8609694a-cdbc-db9b-d345-e782dbb562ed", "1h")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	mockClient.AssertExpectations(t)
+}
+
 func TestMockLookupSelf(t *testing.T) {
 	mockClient := &MockSecretStoreClient{}
 	mockClient.On("LookupSelf", "fake-token", mock.Anything).Return(http.StatusOK, nil)
@@ -168,3 +178,76 @@ func TestMockEnableKVSecretEngine(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rc)
 	mockClient.AssertExpectations(t)
 }
+
+func TestMockRenewSelf(t *testing.T) {
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("RenewSelf", "fake-token").Return(http.StatusOK, nil)
+
+	rc, err := mockClient.RenewSelf("fake-token")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockIssueCertificate(t *testing.T) {
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("IssueCertificate", "fake-token", "pki_int", "role", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(4).(*PKICertificate).Certificate = "leaf-pem"
+		}).
+		Return(http.StatusOK, nil)
+
+	var certificate PKICertificate
+	rc, err := mockClient.IssueCertificate("fake-token", "pki_int", "role", map[string]interface{}{}, &certificate)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	assert.Equal(t, "leaf-pem", certificate.Certificate)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockSealStatus(t *testing.T) {
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("SealStatus", mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(0).(*SealStatusResponse).Sealed = true
+		}).
+		Return(http.StatusOK, nil)
+
+	var status SealStatusResponse
+	rc, err := mockClient.SealStatus(&status)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	assert.True(t, status.Sealed)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockRekey(t *testing.T) {
+	var initResp, rekeyResp InitResponse
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("Rekey", &initResp, 5, 3, &rekeyResp).Return(nil)
+
+	err := mockClient.Rekey(&initResp, 5, 3, &rekeyResp)
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMockSecrets(t *testing.T) {
+	var keys []string
+	var data map[string]interface{}
+	mockClient := &MockSecretStoreClient{}
+	mockClient.On("ListSecrets", "fake-token", "secret/", &keys).Return(http.StatusOK, nil)
+	mockClient.On("ReadSecret", "fake-token", "secret/a", &data).Return(http.StatusOK, nil)
+	mockClient.On("WriteSecret", "fake-token", "secret/a", map[string]interface{}{"k": "v"}).
+		Return(http.StatusNoContent, nil)
+
+	rc, err := mockClient.ListSecrets("fake-token", "secret/", &keys)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	rc, err = mockClient.ReadSecret("fake-token", "secret/a", &data)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rc)
+	rc, err = mockClient.WriteSecret("fake-token", "secret/a", map[string]interface{}{"k": "v"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rc)
+	mockClient.AssertExpectations(t)
+}
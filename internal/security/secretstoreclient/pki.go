@@ -0,0 +1,192 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstoreclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+func (vc *vaultClient) RenewSelf(token string) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 RenewSelfAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "renew self token",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       nil,
+	})
+}
+
+func (vc *vaultClient) EnablePKISecretEngine(token string, mountPoint string, maxLeaseTTL string) (statusCode int, err error) {
+	parameters := EnablePKISecretsEngineRequest{Type: "pki", Description: "EdgeX internal certificate authority"}
+	parameters.Config.MaxLeaseTTL = maxLeaseTTL
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 path.Join(VaultMountsAPI, mountPoint),
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "enable pki secrets engine",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
+
+// ReadCACertificate returns the PEM-encoded CA certificate of the PKI secrets engine at mountPoint.  The certificate is
+// empty when the engine has no CA yet.
+func (vc *vaultClient) ReadCACertificate(token string, mountPoint string, certificate *string) (statusCode int, err error) {
+	var response PKICertificateResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodGet,
+		Path:                 fmt.Sprintf(PKICACertificatePath, mountPoint),
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "read CA certificate",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*certificate = response.Data.Certificate
+	return code, err
+}
+
+// GenerateRootCA generates a self-signed root CA, whose private key never leaves the secret store, and returns its
+// PEM-encoded certificate.
+func (vc *vaultClient) GenerateRootCA(
+	token string,
+	mountPoint string,
+	parameters map[string]interface{},
+	certificate *string) (statusCode int, err error) {
+
+	var response PKICertificateResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(PKIGenerateRootPath, mountPoint),
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "generate root CA",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*certificate = response.Data.Certificate
+	return code, err
+}
+
+// GenerateIntermediateCSR generates an intermediate CA key pair and returns the PEM-encoded certificate signing request
+// to be signed by the root CA.
+func (vc *vaultClient) GenerateIntermediateCSR(
+	token string,
+	mountPoint string,
+	parameters map[string]interface{},
+	csr *string) (statusCode int, err error) {
+
+	var response PKICertificateResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(PKIGenerateCSRPath, mountPoint),
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "generate intermediate CSR",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*csr = response.Data.CSR
+	return code, err
+}
+
+// SignIntermediate signs an intermediate CA's certificate signing request with the root CA at mountPoint.
+func (vc *vaultClient) SignIntermediate(
+	token string,
+	mountPoint string,
+	parameters map[string]interface{},
+	certificate *string) (statusCode int, err error) {
+
+	var response PKICertificateResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(PKISignIntermediatePath, mountPoint),
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "sign intermediate CA",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*certificate = response.Data.Certificate
+	return code, err
+}
+
+// SetSignedIntermediate installs the signed intermediate CA certificate in the PKI secrets engine at mountPoint.
+func (vc *vaultClient) SetSignedIntermediate(token string, mountPoint string, certificate string) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(PKISetSignedPath, mountPoint),
+		JSONObject:           SetSignedIntermediateRequest{Certificate: certificate},
+		BodyReader:           nil,
+		OperationDescription: "set signed intermediate CA",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
+
+// CreatePKIRole creates or updates a role, which determines the certificates that may be issued under its name.
+func (vc *vaultClient) CreatePKIRole(
+	token string,
+	mountPoint string,
+	role string,
+	parameters map[string]interface{}) (statusCode int, err error) {
+
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(PKIRolePath, mountPoint, url.PathEscape(role)),
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "create pki role",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+}
+
+// IssueCertificate issues a certificate and private key under role.
+func (vc *vaultClient) IssueCertificate(
+	token string,
+	mountPoint string,
+	role string,
+	parameters map[string]interface{},
+	certificate *PKICertificate) (statusCode int, err error) {
+
+	var response PKICertificateResponse
+	code, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 fmt.Sprintf(PKIIssueCertificatePath, mountPoint, url.PathEscape(role)),
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "issue certificate",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*certificate = response.Data
+	return code, err
+}
@@ -0,0 +1,182 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secretstoreclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client of a TLS server which serves handler.
+func newTestClient(handler http.HandlerFunc) (SecretStoreClient, func()) {
+	mockLogger := logger.MockLogger{}
+	ts := httptest.NewTLSServer(handler)
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	return NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host), ts.Close
+}
+
+func TestRenewSelf(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal(RenewSelfAPI, r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get(VaultToken))
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"fake-token"}}`))
+	})
+	defer closer()
+
+	code, err := vc.RenewSelf("fake-token")
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+}
+
+func TestEnablePKISecretEngine(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal(VaultMountsAPI+"/pki_int", r.URL.EscapedPath())
+
+		var body EnablePKISecretsEngineRequest
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal("pki", body.Type)
+		assert.Equal("43800h", body.Config.MaxLeaseTTL)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer closer()
+
+	code, err := vc.EnablePKISecretEngine("fake-token", "pki_int", "43800h")
+
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+}
+
+func TestReadCACertificate(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodGet, r.Method)
+		assert.Equal("/v1/pki/cert/ca", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"data":{"certificate":"root-pem"}}`))
+	})
+	defer closer()
+
+	var certificate string
+	code, err := vc.ReadCACertificate("fake-token", "pki", &certificate)
+
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+	assert.Equal("root-pem", certificate)
+}
+
+func TestIntermediateCA(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		var body map[string]interface{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.EscapedPath() {
+		case "/v1/pki/root/generate/internal":
+			assert.Equal("EdgeX Root CA", body["common_name"])
+			_, _ = w.Write([]byte(`{"data":{"certificate":"root-pem"}}`))
+		case "/v1/pki_int/intermediate/generate/internal":
+			assert.Equal("EdgeX Intermediate CA", body["common_name"])
+			_, _ = w.Write([]byte(`{"data":{"csr":"csr-pem"}}`))
+		case "/v1/pki/root/sign-intermediate":
+			assert.Equal("csr-pem", body["csr"])
+			_, _ = w.Write([]byte(`{"data":{"certificate":"intermediate-pem"}}`))
+		case "/v1/pki_int/intermediate/set-signed":
+			assert.Equal("intermediate-pem", body["certificate"])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closer()
+
+	var root, csr, intermediate string
+	_, err := vc.GenerateRootCA("fake-token", "pki", map[string]interface{}{"common_name": "EdgeX Root CA"}, &root)
+	assert.NoError(err)
+	assert.Equal("root-pem", root)
+
+	_, err = vc.GenerateIntermediateCSR(
+		"fake-token",
+		"pki_int",
+		map[string]interface{}{"common_name": "EdgeX Intermediate CA"},
+		&csr)
+	assert.NoError(err)
+	assert.Equal("csr-pem", csr)
+
+	_, err = vc.SignIntermediate("fake-token", "pki", map[string]interface{}{"csr": csr}, &intermediate)
+	assert.NoError(err)
+	assert.Equal("intermediate-pem", intermediate)
+
+	code, err := vc.SetSignedIntermediate("fake-token", "pki_int", intermediate)
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+}
+
+func TestIssueCertificate(t *testing.T) {
+	assert := assert.New(t)
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(http.MethodPost, r.Method)
+		var body map[string]interface{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+
+		switch r.URL.EscapedPath() {
+		case "/v1/pki_int/roles/edgex-service":
+			assert.Equal(true, body["allow_bare_domains"])
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/pki_int/issue/edgex-service":
+			assert.Equal("edgex-core-data", body["common_name"])
+			_, _ = w.Write([]byte(`{"data":{"certificate":"leaf-pem","issuing_ca":"intermediate-pem",` +
+				`"ca_chain":["intermediate-pem"],"private_key":"key-pem","serial_number":"01","expiration":1614556800}}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer closer()
+
+	code, err := vc.CreatePKIRole("fake-token", "pki_int", "edgex-service", map[string]interface{}{"allow_bare_domains": true})
+	assert.NoError(err)
+	assert.Equal(http.StatusNoContent, code)
+
+	var certificate PKICertificate
+	_, err = vc.IssueCertificate(
+		"fake-token",
+		"pki_int",
+		"edgex-service",
+		map[string]interface{}{"common_name": "edgex-core-data"},
+		&certificate)
+	assert.NoError(err)
+	assert.Equal(PKICertificate{
+		Certificate:  "leaf-pem",
+		IssuingCA:    "intermediate-pem",
+		CAChain:      []string{"intermediate-pem"},
+		PrivateKey:   "key-pem",
+		SerialNumber: "01",
+		Expiration:   1614556800,
+	}, certificate)
+}
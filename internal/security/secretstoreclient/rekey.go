@@ -0,0 +1,110 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+func (vc *vaultClient) Rekey(initResp *InitResponse, secretShares int, secretThreshold int, rekeyResp *InitResponse) (err error) {
+	// cancel any previous rekey attempt
+	// start rekey with the new shares and threshold --> nonce
+	// provide current keys, nonce --> new keys
+	// the root token is not affected by a rekey
+	var nonce string
+
+	if err := vc.rekeyCancelPrevious(); err != nil {
+		vc.logger.Warn(fmt.Sprintf("failed to cancel previous rekey: %s", err.Error()))
+		// Not fatal, continue
+	}
+
+	if err := vc.rekeyStart(secretShares, secretThreshold, &nonce); err != nil {
+		vc.logger.Error(fmt.Sprintf("failed to start rekey: %s", err.Error()))
+		return err
+	}
+
+	for _, key := range initResp.KeysBase64 {
+		complete, err := vc.rekeySubmitKey(key, nonce, rekeyResp)
+		if err != nil {
+			vc.logger.Error(fmt.Sprintf("rekey aborted due to error: %s", err.Error()))
+			_ = vc.rekeyCancelPrevious()
+			return err
+		} else if complete {
+			rekeyResp.RootToken = initResp.RootToken
+			rekeyResp.EncryptedRootToken = initResp.EncryptedRootToken
+			rekeyResp.RootTokenNonce = initResp.RootTokenNonce
+			return nil
+		}
+	}
+
+	_ = vc.rekeyCancelPrevious()
+	return errors.New("not enough key shares to complete the rekey")
+}
+
+func (vc *vaultClient) rekeyCancelPrevious() error {
+	_, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            "",
+		Method:               http.MethodDelete,
+		Path:                 RekeyControlAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "cancel previous rekey",
+		ExpectedStatusCode:   http.StatusNoContent,
+		ResponseObject:       nil,
+	})
+	return err
+}
+
+func (vc *vaultClient) rekeyStart(secretShares int, secretThreshold int, nonce *string) error {
+	var response RekeyControlResponse
+	_, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            "",
+		Method:               http.MethodPut,
+		Path:                 RekeyControlAPI,
+		JSONObject:           RekeyInitRequest{SecretShares: secretShares, SecretThreshold: secretThreshold},
+		BodyReader:           nil,
+		OperationDescription: "start rekey",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	*nonce = response.Nonce
+	return err
+}
+
+func (vc *vaultClient) rekeySubmitKey(key string, nonce string, rekeyResp *InitResponse) (bool, error) {
+	var response RekeyUpdateResponse
+	_, err := vc.doRequest(commonRequestArgs{
+		AuthToken:            "",
+		Method:               http.MethodPut,
+		Path:                 RekeyUpdateAPI,
+		JSONObject:           RekeyUpdateRequest{Key: key, Nonce: nonce},
+		BodyReader:           nil,
+		OperationDescription: "submit rekey keyshare",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       &response,
+	})
+	if err != nil {
+		return false, err
+	}
+	if response.Complete {
+		rekeyResp.Keys = response.Keys
+		rekeyResp.KeysBase64 = response.KeysBase64
+	}
+	return response.Complete, nil
+}
@@ -0,0 +1,105 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+//
+// SPDX-License-Identifier: Apache-2.0'
+//
+
+package secretstoreclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRekey(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	requestNumber := 0
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requestNumber++
+		switch requestNumber {
+		case 1:
+			assert.Equal(http.MethodDelete, r.Method)
+			assert.Equal(RekeyControlAPI, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNoContent)
+		case 2:
+			assert.Equal(http.MethodPut, r.Method)
+			assert.Equal(RekeyControlAPI, r.URL.EscapedPath())
+			var body RekeyInitRequest
+			assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(RekeyInitRequest{SecretShares: 3, SecretThreshold: 2}, body)
+			_ = json.NewEncoder(w).Encode(RekeyControlResponse{Nonce: "fake-nonce", Started: true})
+		case 3, 4:
+			assert.Equal(http.MethodPut, r.Method)
+			assert.Equal(RekeyUpdateAPI, r.URL.EscapedPath())
+			var body RekeyUpdateRequest
+			assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal("fake-nonce", body.Nonce)
+			if requestNumber == 3 {
+				assert.Equal("a2V5LTE=", body.Key)
+				_ = json.NewEncoder(w).Encode(RekeyUpdateResponse{Complete: false})
+				return
+			}
+			assert.Equal("a2V5LTI=", body.Key)
+			_ = json.NewEncoder(w).Encode(RekeyUpdateResponse{
+				Complete:   true,
+				Keys:       []string{"6e6577"},
+				KeysBase64: []string{"bmV3"},
+			})
+		default:
+			t.Errorf("unexpected request %d", requestNumber)
+		}
+	})
+	defer closer()
+
+	// Act
+	initResp := InitResponse{KeysBase64: []string{"a2V5LTE=", "a2V5LTI=", "a2V5LTM="}, RootToken: "root"}
+	var rekeyResp InitResponse
+	err := vc.Rekey(&initResp, 3, 2, &rekeyResp)
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(InitResponse{Keys: []string{"6e6577"}, KeysBase64: []string{"bmV3"}, RootToken: "root"}, rekeyResp)
+	assert.Equal(4, requestNumber)
+}
+
+func TestRekeyNotEnoughKeys(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	cancelled := 0
+	vc, closer := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			cancelled++
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.EscapedPath() == RekeyControlAPI:
+			_ = json.NewEncoder(w).Encode(RekeyControlResponse{Nonce: "fake-nonce", Started: true})
+		default:
+			_ = json.NewEncoder(w).Encode(RekeyUpdateResponse{Complete: false})
+		}
+	})
+	defer closer()
+
+	// Act
+	initResp := InitResponse{KeysBase64: []string{"a2V5LTE="}}
+	var rekeyResp InitResponse
+	err := vc.Rekey(&initResp, 1, 1, &rekeyResp)
+
+	// Assert
+	assert.Error(err)
+	assert.Empty(rekeyResp.KeysBase64)
+	assert.Equal(2, cancelled)
+}
@@ -65,6 +65,19 @@ func (vc *vaultClient) HealthCheck() (int, error) {
 	return code, nil
 }
 
+func (vc *vaultClient) SealStatus(sealStatus *SealStatusResponse) (statusCode int, err error) {
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            "",
+		Method:               http.MethodGet,
+		Path:                 SealStatusAPI,
+		JSONObject:           nil,
+		BodyReader:           nil,
+		OperationDescription: "read seal status",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       sealStatus,
+	})
+}
+
 func (vc *vaultClient) Init(secretThreshold int, secretShares int, initResponse *InitResponse) (statusCode int, err error) {
 	initRequest := InitRequest{
 		SecretShares:    secretShares,
@@ -194,6 +207,20 @@ func (vc *vaultClient) LookupAccessor(token string, accessor string, tokenMetada
 	return code, err
 }
 
+func (vc *vaultClient) RenewAccessor(token string, accessor string, increment string) (statusCode int, err error) {
+	parameters := RenewAccessorRequest{Accessor: accessor, Increment: increment}
+	return vc.doRequest(commonRequestArgs{
+		AuthToken:            token,
+		Method:               http.MethodPost,
+		Path:                 RenewAccessorAPI,
+		JSONObject:           parameters,
+		BodyReader:           nil,
+		OperationDescription: "renew token accessor",
+		ExpectedStatusCode:   http.StatusOK,
+		ResponseObject:       nil,
+	})
+}
+
 func (vc *vaultClient) LookupSelf(token string, tokenMetadata *TokenMetadata) (statusCode int, err error) {
 	var response TokenLookupResponse
 	code, err := vc.doRequest(commonRequestArgs{
@@ -353,6 +353,38 @@ func TestLookupAccessor(t *testing.T) {
 	assert.Equal("accessor-value", md.Accessor)
 }
 
+func TestRenewAccessor(t *testing.T) {
+	// Arrange
+	assert := assert.New(t)
+	mockLogger := logger.MockLogger{}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("POST", r.Method)
+		assert.Equal(RenewAccessorAPI, r.URL.EscapedPath())
+		assert.Equal("fake-token", r.Header.Get("X-Vault-Token"))
+
+		body := make(map[string]interface{})
+		err := json.NewDecoder(r.Body).Decode(&body)
+		assert.NoError(err)
+
+		assert.Equal("8609694a-cdbc-db9b-d345-e782dbb562ed", body["accessor"])
+		assert.Equal("1h", body["increment"])
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	host := strings.Replace(ts.URL, "https://", "", -1)
+	vc := NewSecretStoreClient(mockLogger, NewRequestor(mockLogger).Insecure(), "https", host)
+
+	// Act
+	code, err := vc.RenewAccessor("fake-token", "8609694a-cdbc-db9b-d345-e782dbb562ed", "1h")
+
+	// Assert
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, code)
+}
+
 func TestLookupSelf(t *testing.T) {
 	// Arrange
 	assert := assert.New(t)
@@ -0,0 +1,268 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const (
+	authModeBasic  = "basic"
+	authModeBearer = "bearer"
+)
+
+// httpEndpoint is the common description of a templated HTTP request used by the webhook and SMS gateway senders.
+type httpEndpoint struct {
+	url          string
+	method       string
+	contentType  string
+	bodyTemplate string
+	headers      map[string]string
+	authMode     string
+	username     string
+	password     string
+	token        string
+}
+
+// webhookSender posts notifications to a configured webhook, referenced by the channel URL webhook://<name>.
+type webhookSender struct{}
+
+func (webhookSender) Send(
+	n models.Notification,
+	message interfaces.ChannelMessage,
+	c models.Channel,
+	receiver string,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) models.TransmissionRecord {
+
+	name, _, err := parseNamedChannelUrl(c.Url)
+	if err != nil {
+		return failedHttpChannelRecord(c, err, lc)
+	}
+	info, ok := config.Channels.Webhooks[name]
+	if !ok {
+		return failedHttpChannelRecord(c, fmt.Errorf("no webhook configured with name %s", name), lc)
+	}
+
+	credentials := channelCredentialsAt(info.SecretPath)
+	endpoint := httpEndpoint{
+		url:          info.Url,
+		method:       info.Method,
+		contentType:  info.ContentType,
+		bodyTemplate: info.BodyTemplate,
+		headers:      info.Headers,
+		authMode:     info.AuthMode,
+		username:     credentials.username,
+		password:     credentials.password,
+		token:        credentials.token,
+	}
+
+	data := newTemplateData(n, receiver)
+	data.Content = message.Text
+	data.ContentType = message.ContentType
+	data.Json = message.Json
+
+	// without a body template the JSON rendering is preferred over the text rendering
+	defaultBody, defaultContentType := message.Text, message.ContentType
+	if message.Json != "" {
+		defaultBody, defaultContentType = message.Json, "application/json"
+	}
+
+	response, err := endpoint.send(data, defaultBody, defaultContentType, config.Service.Timeout)
+	if err != nil {
+		return failedHttpChannelRecord(c, err, lc)
+	}
+	return getTransmissionRecord(response, models.Sent)
+}
+
+// smsSender sends notifications through a configured SMS gateway, referenced by the channel URL
+// sms://<gateway name>/<phone>[,<phone>...]. One gateway request is made per phone number.
+type smsSender struct{}
+
+func (smsSender) Send(
+	n models.Notification,
+	message interfaces.ChannelMessage,
+	c models.Channel,
+	receiver string,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) models.TransmissionRecord {
+
+	name, recipients, err := parseNamedChannelUrl(c.Url)
+	if err != nil {
+		return failedHttpChannelRecord(c, err, lc)
+	}
+	if len(recipients) == 0 {
+		return failedHttpChannelRecord(c, fmt.Errorf("no SMS recipients in %s", c.Url), lc)
+	}
+	info, ok := config.Channels.SmsGateways[name]
+	if !ok {
+		return failedHttpChannelRecord(c, fmt.Errorf("no SMS gateway configured with name %s", name), lc)
+	}
+
+	credentials := channelCredentialsAt(info.SecretPath)
+	endpoint := httpEndpoint{
+		url:          info.Url,
+		method:       info.Method,
+		contentType:  info.ContentType,
+		bodyTemplate: info.BodyTemplate,
+		headers:      info.Headers,
+		authMode:     info.AuthMode,
+		username:     credentials.username,
+		password:     credentials.password,
+		token:        credentials.token,
+	}
+
+	content := message.Sms
+	if content == "" {
+		content = message.Text
+	}
+	content = truncateRunes(content, info.MaxMessageLength)
+
+	var responses []string
+	for _, recipient := range recipients {
+		data := newTemplateData(n, receiver)
+		data.Content = content
+		data.ContentType = "text/plain"
+		data.Recipient = recipient
+
+		response, err := endpoint.send(data, content, "text/plain", config.Service.Timeout)
+		if err != nil {
+			return failedHttpChannelRecord(c, fmt.Errorf("sending to %s: %s", recipient, err.Error()), lc)
+		}
+		responses = append(responses, recipient+": "+response)
+	}
+	return getTransmissionRecord(strings.Join(responses, "; "), models.Sent)
+}
+
+// parseNamedChannelUrl splits URLs of the form scheme://<name>/<item>[,<item>...] into the name and the items.
+func parseNamedChannelUrl(rawUrl string) (string, []string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", nil, err
+	}
+	if u.Host == "" {
+		return "", nil, fmt.Errorf("missing channel name in %s", rawUrl)
+	}
+
+	var items []string
+	for _, item := range strings.Split(strings.Trim(u.Path, "/"), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return u.Host, items, nil
+}
+
+func failedHttpChannelRecord(c models.Channel, err error, lc logger.LoggingClient) models.TransmissionRecord {
+	lc.Error("Problems sending message to: " + c.Url + ", issue: " + err.Error())
+	return getTransmissionRecord(err.Error(), models.Failed)
+}
+
+// send renders the URL and body templates with the data and performs the request; without a body template the
+// default body is sent. Responses with a status code outside of the 2xx range are reported as errors.
+func (e httpEndpoint) send(
+	data templateData,
+	defaultBody string,
+	defaultContentType string,
+	timeoutMillis int) (string, error) {
+
+	requestUrl, err := renderText("url", e.url, data)
+	if err != nil {
+		return "", err
+	}
+	body, err := renderText("body", e.bodyTemplate, data)
+	if err != nil {
+		return "", err
+	}
+	contentType := e.contentType
+	if e.bodyTemplate == "" {
+		body = defaultBody
+		if contentType == "" {
+			contentType = defaultContentType
+		}
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+	}
+
+	method := e.method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	req, err := http.NewRequest(method, requestUrl, bytes.NewBufferString(body))
+	if err != nil {
+		return "", err
+	}
+
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+
+	switch strings.ToLower(e.authMode) {
+	case "":
+	case authModeBasic:
+		req.SetBasicAuth(e.username, e.password)
+	case authModeBearer:
+		req.Header.Set("Authorization", "Bearer "+e.token)
+	default:
+		return "", fmt.Errorf("unsupported AuthMode %s", e.authMode)
+	}
+
+	client := &http.Client{Timeout: time.Duration(timeoutMillis) * time.Millisecond}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return "", fmt.Errorf("got response status code: %s %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return "Got response status code: " + resp.Status, nil
+}
+
+// truncateRunes limits content to maxLength characters, 0 meaning no limit, without splitting a UTF-8 sequence.
+func truncateRunes(content string, maxLength int) string {
+	if maxLength <= 0 {
+		return content
+	}
+	count := 0
+	for index := range content {
+		if count == maxLength {
+			return content[:index]
+		}
+		count++
+	}
+	return content
+}
@@ -0,0 +1,89 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"sync"
+
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+
+	bootstrapInterfaces "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// The keys of the channel credentials in the secrets at the SecretPath of a channel.
+const (
+	secretUsername = "username"
+	secretPassword = "password"
+	secretToken    = "token"
+)
+
+// channelCredentials authenticate a channel against its MQTT broker or HTTP endpoint.
+type channelCredentials struct {
+	username string
+	password string
+	token    string
+}
+
+var (
+	channelCredentialsMutex sync.RWMutex
+	channelCredentialsPaths = map[string]channelCredentials{}
+)
+
+// loadChannelCredentials reads the credentials of the configured channels from the secret store, or from
+// Writable.InsecureSecrets in non-secure mode. Channels whose secret cannot be read connect without credentials.
+func loadChannelCredentials(
+	config notificationsConfig.ConfigurationStruct,
+	secretProvider bootstrapInterfaces.SecretProvider,
+	lc logger.LoggingClient) {
+
+	paths := []string{config.Channels.Mqtt.SecretPath}
+	for _, webhook := range config.Channels.Webhooks {
+		paths = append(paths, webhook.SecretPath)
+	}
+	for _, gateway := range config.Channels.SmsGateways {
+		paths = append(paths, gateway.SecretPath)
+	}
+
+	credentials := make(map[string]channelCredentials)
+	for _, path := range paths {
+		if _, loaded := credentials[path]; loaded || path == "" {
+			continue
+		}
+		secrets, err := secretProvider.GetSecrets(path)
+		if err != nil {
+			lc.Error("Unable to read the channel credentials at " + path + ": " + err.Error())
+			continue
+		}
+		credentials[path] = channelCredentials{
+			username: secrets[secretUsername],
+			password: secrets[secretPassword],
+			token:    secrets[secretToken],
+		}
+	}
+
+	channelCredentialsMutex.Lock()
+	defer channelCredentialsMutex.Unlock()
+	channelCredentialsPaths = credentials
+}
+
+// channelCredentialsAt returns the credentials loaded from the secret path, none when the path is empty or unknown.
+func channelCredentialsAt(secretPath string) channelCredentials {
+	channelCredentialsMutex.RLock()
+	defer channelCredentialsMutex.RUnlock()
+
+	return channelCredentialsPaths[secretPath]
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+var (
+	channelSendersMutex sync.RWMutex
+	channelSenders      = map[string]interfaces.ChannelSender{
+		EmailChannel:   emailSender{},
+		RestChannel:    restSender{},
+		MqttChannel:    mqttSender{},
+		WebhookChannel: webhookSender{},
+		SmsChannel:     smsSender{},
+	}
+)
+
+// RegisterChannelSender makes a channel sender available to subscriptions under the given kind. A REST channel whose
+// URL scheme matches the kind (case-insensitive) is delivered through the sender. Registering an existing kind
+// replaces its sender.
+func RegisterChannelSender(kind string, sender interfaces.ChannelSender) {
+	channelSendersMutex.Lock()
+	defer channelSendersMutex.Unlock()
+
+	channelSenders[strings.ToUpper(kind)] = sender
+}
+
+// channelKind determines which channel sender is responsible for the channel. EMAIL channels use the email sender,
+// REST channels are dispatched on the scheme of their URL.
+func channelKind(c models.Channel) string {
+	if c.Type == models.ChannelType(models.Email) {
+		return EmailChannel
+	}
+
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return RestChannel
+	}
+
+	switch scheme := strings.ToLower(u.Scheme); scheme {
+	case "", "http", "https":
+		return RestChannel
+	case "mqtt", "mqtts", "tcp", "ssl", "ws", "wss":
+		return MqttChannel
+	default:
+		return strings.ToUpper(scheme)
+	}
+}
+
+// transmit delivers the rendered notification through the channel sender registered for the channel's kind.
+func transmit(
+	n models.Notification,
+	message interfaces.ChannelMessage,
+	c models.Channel,
+	receiver string,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) models.TransmissionRecord {
+
+	kind := channelKind(c)
+
+	channelSendersMutex.RLock()
+	sender, ok := channelSenders[kind]
+	channelSendersMutex.RUnlock()
+
+	if !ok {
+		lc.Error("No channel sender registered for kind " + kind + ", channel: " + c.String())
+		return getTransmissionRecord("no channel sender registered for "+kind, models.Failed)
+	}
+
+	return sender.Send(n, message, c, receiver, lc, config)
+}
+
+// emailSender delivers notifications to the mail addresses of EMAIL channels through the configured SMTP server, with
+// both the text and the HTML rendering when there is one.
+type emailSender struct{}
+
+func (emailSender) Send(
+	_ models.Notification,
+	message interfaces.ChannelMessage,
+	c models.Channel,
+	_ string,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) models.TransmissionRecord {
+
+	return sendMail(message, c.MailAddresses, lc, config.Smtp)
+}
+
+// restSender posts notifications to the http(s) URL of REST channels, preferring the JSON rendering over the text
+// rendering.
+type restSender struct{}
+
+func (restSender) Send(
+	_ models.Notification,
+	message interfaces.ChannelMessage,
+	c models.Channel,
+	_ string,
+	lc logger.LoggingClient,
+	_ notificationsConfig.ConfigurationStruct) models.TransmissionRecord {
+
+	if message.Json != "" {
+		return restSend(message.Json, c.Url, "application/json", lc)
+	}
+	return restSend(message.Text, c.Url, message.ContentType, lc)
+}
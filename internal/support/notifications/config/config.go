@@ -26,13 +26,137 @@ type ConfigurationStruct struct {
 	Registry    bootstrapConfig.RegistryInfo
 	Service     bootstrapConfig.ServiceInfo
 	Smtp        SmtpInfo
+	Channels    ChannelsInfo
 	SecretStore bootstrapConfig.SecretStoreInfo
 }
 
 type WritableInfo struct {
-	ResendLimit     int
-	LogLevel        string
-	InsecureSecrets bootstrapConfig.InsecureSecrets
+	ResendLimit          int
+	LogLevel             string
+	InsecureSecrets      bootstrapConfig.InsecureSecrets
+	Templates            map[string]TemplateInfo
+	RetryPolicies        map[string]RetryPolicyInfo
+	EscalationPolicies   map[string]EscalationPolicyInfo
+	Deduplication        DeduplicationInfo
+	SubscriptionSettings map[string]SubscriptionSettingsInfo
+}
+
+// DeduplicationInfo suppresses the distribution of notifications duplicating a notification distributed within the
+// window. Suppressed notifications are still stored.
+type DeduplicationInfo struct {
+	// Keys are the notification fields identifying duplicates: Sender, Category, Severity, Labels, Description and
+	// Content. Deduplication is disabled when empty
+	Keys []string
+	// Window is the duration during which duplicates are suppressed, e.g. "10m"
+	Window string
+}
+
+// TemplateInfo is a named notification template. Each field is a Go template rendered with the notification's
+// .Slug, .Sender, .Category, .Severity, .Status, .Description, .Labels, .Content, .ContentType, .Receiver and
+// .Timestamp; empty fields fall back to the notification content. Html is rendered with html/template escaping.
+type TemplateInfo struct {
+	// Subject is the email subject
+	Subject string
+	// Text is the plain text rendering used by channels without a rendering of their own
+	Text string
+	// Html is the HTML rendering for email
+	Html string
+	// Sms is the short plain text rendering for SMS gateways
+	Sms string
+	// Json is the JSON rendering for webhooks, REST and MQTT channels
+	Json string
+	// Attachments are files attached to emails, such as a CSV of the readings which triggered the notification
+	Attachments []AttachmentInfo
+}
+
+// AttachmentInfo is an email attachment whose Name and Content are Go templates rendered like TemplateInfo.
+type AttachmentInfo struct {
+	Name string
+	// ContentType is the attachment's content type, "application/octet-stream" when empty
+	ContentType string
+	Content     string
+}
+
+// SubscriptionSettingsInfo holds the delivery settings of the subscription whose slug is its key. They are stored with
+// a V1 subscription at startup unless it has stored settings already, afterwards they are managed through
+// /api/v1/subscription/slug/{slug}/settings.
+type SubscriptionSettingsInfo struct {
+	// Template is the name of the template in Writable.Templates used to render the subscription's notifications
+	Template string
+	// EmailSender is the sender address of the subscription's emails, Smtp.Sender when empty
+	EmailSender string
+	// EmailSubject is the subject of the subscription's emails unless its template has one, Smtp.Subject when empty
+	EmailSubject string
+	// MinSeverity is the lowest notification severity delivered to the subscription, NORMAL or CRITICAL. All
+	// severities are delivered when empty
+	MinSeverity string
+	// TimeZone is the IANA time zone of the delivery windows, e.g. "Europe/Berlin", UTC when empty
+	TimeZone string
+	// DeliveryWindows are the times notifications are delivered to the subscription, any time when empty
+	DeliveryWindows []DeliveryWindowInfo
+	// QuietHours handles the notifications arriving outside the delivery windows: "delay" delivers them once the
+	// next window opens, "drop" discards them and "critical" delivers CRITICAL notifications right away and discards
+	// the others. "delay" when empty
+	QuietHours string
+	// RetryPolicy is the name of the policy in Writable.RetryPolicies applied to failed transmissions, "default"
+	// when empty
+	RetryPolicy string
+	// EscalationPolicy is the name of the policy in Writable.EscalationPolicies applied to failed transmissions,
+	// "default" when empty
+	EscalationPolicy string
+	// RateLimit is the number of notifications delivered to the subscription per RateLimitPeriod, 0 for no limit.
+	// Notifications beyond the limit are delivered in a digest at the end of the period
+	RateLimit int
+	// RateLimitPeriod is the period of the rate limit, "1m" when empty
+	RateLimitPeriod string
+	// DigestInterval batches the subscription's notifications into a digest delivered every interval, e.g. "15m".
+	// Notifications are delivered individually when empty
+	DigestInterval string
+}
+
+// DeliveryWindowInfo is a daily time range in which notifications are delivered. A window ending at or before its
+// start ends on the following day.
+type DeliveryWindowInfo struct {
+	// Days are the week days the window starts on, e.g. ["Mon", "Tue"], every day when empty
+	Days []string
+	// Start is the time of day the window opens, e.g. "08:00", midnight when empty
+	Start string
+	// End is the time of day the window closes, e.g. "18:00", the end of the day when empty
+	End string
+}
+
+// RetryPolicyInfo describes how failed transmissions are retried. Without a "default" policy failed transmissions of
+// CRITICAL notifications are retried every 5 seconds up to ResendLimit times.
+type RetryPolicyInfo struct {
+	// Severities are the notification severities whose failed transmissions are retried and escalated, CRITICAL
+	// when empty
+	Severities []string
+	// MaxRetries is the number of resends after the first failed transmission
+	MaxRetries int
+	// InitialInterval is the duration to wait before the first resend, 5s when empty
+	InitialInterval string
+	// MaxInterval caps the duration between resends, no cap when empty
+	MaxInterval string
+	// Multiplier grows the duration between consecutive resends, values below 1 keep it constant
+	Multiplier float64
+	// Jitter randomly shifts the duration between resends by up to this fraction of it (0 to 1)
+	Jitter float64
+}
+
+// EscalationPolicyInfo is a chain of escalation levels, each of which is escalated once per notification. Without a
+// "default" policy transmissions escalate to the ESCALATION subscription once the retries are exhausted.
+type EscalationPolicyInfo struct {
+	Levels []EscalationLevelInfo
+}
+
+// EscalationLevelInfo escalates a failed transmission to a subscription once either of its thresholds is reached.
+type EscalationLevelInfo struct {
+	// Subscription is the slug of the subscription receiving the escalated notification
+	Subscription string
+	// AfterFailures is the number of failed transmission attempts, including the first one, 0 to disable
+	AfterFailures int
+	// AfterDuration is the time since the first transmission attempt, e.g. "15m", empty to disable
+	AfterDuration string
 }
 
 type SmtpInfo struct {
@@ -43,6 +167,75 @@ type SmtpInfo struct {
 	Sender               string
 	EnableSelfSignedCert bool
 	Subject              string
+	// TLSMode is "starttls" to require STARTTLS, "tls" for implicit TLS (usually port 465) or "none" for plain
+	// connections. When empty STARTTLS is used if the server offers it
+	TLSMode string
+	// AuthMechanism is "plain", "login", "cram-md5" or "xoauth2", where XOAUTH2 takes an OAuth2 access token as
+	// Password. "plain" when empty
+	AuthMechanism string
+	// MaxAttachmentSize is the largest total size in bytes of the attachments of a message, 256 KiB when 0. Attachments
+	// beyond it are left out
+	MaxAttachmentSize int
+}
+
+// ChannelsInfo configures the channel types beyond EMAIL and plain REST. A subscription selects one of them through
+// the scheme of its channel URL: mqtt://broker:port/topic, webhook://<webhook name> or sms://<gateway name>/<phone>.
+// The channel credentials are read at startup from the secrets at the SecretPath of each channel, stored in the
+// secret store or, in non-secure mode, in Writable.InsecureSecrets.
+type ChannelsInfo struct {
+	Mqtt        MqttInfo
+	Webhooks    map[string]WebhookInfo
+	SmsGateways map[string]SmsGatewayInfo
+}
+
+type MqttInfo struct {
+	// ClientId is the MQTT client identifier used when publishing notifications
+	ClientId string
+	// Qos is the quality of service level (0, 1 or 2) of the published messages
+	Qos byte
+	// Retained marks the published messages as retained by the broker
+	Retained bool
+	// ConnectTimeout is the duration to wait for the broker connection and the publish acknowledgement
+	ConnectTimeout string
+	// SecretPath is the path of the secret holding the username and password authenticating against the broker,
+	// unless the channel URL carries its own user info
+	SecretPath string
+	// SkipCertVerify allows self-signed broker certificates for mqtts:// channels
+	SkipCertVerify bool
+	// DefaultTopic is used when the channel URL does not contain a topic
+	DefaultTopic string
+}
+
+// WebhookInfo describes a chat-tool style webhook. BodyTemplate is a Go text/template rendered with the notification
+// fields (see TemplateInfo), where .Content is the text rendering and .Json the JSON rendering of the notification.
+// Without a BodyTemplate the JSON rendering, or the text rendering when there is none, is sent as is.
+type WebhookInfo struct {
+	Url          string
+	Method       string
+	ContentType  string
+	BodyTemplate string
+	Headers      map[string]string
+	// AuthMode is empty for no authentication, "basic" for the username and password or "bearer" for the token
+	AuthMode string
+	// SecretPath is the path of the secret holding the username, password and token
+	SecretPath string
+}
+
+// SmsGatewayInfo describes a generic HTTP SMS gateway. BodyTemplate and Url are Go text/templates rendered with the
+// notification fields (see TemplateInfo), where .Content is the SMS rendering, plus .Recipient, the phone number taken
+// from the channel URL, once per recipient.
+type SmsGatewayInfo struct {
+	Url          string
+	Method       string
+	ContentType  string
+	BodyTemplate string
+	Headers      map[string]string
+	// AuthMode is empty for no authentication, "basic" for the username and password or "bearer" for the token
+	AuthMode string
+	// SecretPath is the path of the secret holding the username, password and token
+	SecretPath string
+	// MaxMessageLength truncates the message content to this many characters, 0 means no limit
+	MaxMessageLength int
 }
 
 // The earlier releases do not have Username field and are using Sender field where Usename will
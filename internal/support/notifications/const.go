@@ -21,6 +21,31 @@ var (
 	ESCALATIONSUBSCRIPTIONSLUG = "ESCALATION"
 	ESCALATIONPREFIX           = "escalated-"
 	ESCALATEDCONTENTNOTICE     = "This notification is escalated by the transmission"
+	DEFAULTPOLICY              = "default"
+	DIGESTPREFIX               = "digest-"
+
+	/* ---------------- CHANNEL SENDER KINDS -----------------------*/
+	EmailChannel   = "EMAIL"
+	RestChannel    = "REST"
+	MqttChannel    = "MQTT"
+	WebhookChannel = "WEBHOOK"
+	SmsChannel     = "SMS"
+
+	/* ---------------- SMTP TLS MODES -----------------------*/
+	SMTPTLSSTARTTLS = "starttls"
+	SMTPTLSIMPLICIT = "tls"
+	SMTPTLSNONE     = "none"
+
+	/* ---------------- SMTP AUTH MECHANISMS -----------------------*/
+	SMTPAUTHPLAIN   = "plain"
+	SMTPAUTHLOGIN   = "login"
+	SMTPAUTHCRAMMD5 = "cram-md5"
+	SMTPAUTHXOAUTH2 = "xoauth2"
+
+	/* ---------------- QUIET HOURS BEHAVIORS -----------------------*/
+	QUIETHOURSDELAY    = "delay"
+	QUIETHOURSDROP     = "drop"
+	QUIETHOURSCRITICAL = "critical"
 
 	/* ---------------- URL PARAM NAMES -----------------------*/
 	START        = "start"
@@ -42,4 +67,14 @@ var (
 	ACKNOWLEDGED = "acknowledged"
 	FAILED       = "failed"
 	SENT         = "sent"
+	SETTINGS     = "settings"
+
+	/* ---------------- NOTIFICATION LIFECYCLE -----------------------*/
+	ACTION         = "action"
+	LIFECYCLE      = "lifecycle"
+	UNACKNOWLEDGED = "unacknowledged"
+	ACKNOWLEDGE    = "acknowledge"
+	RESOLVE        = "resolve"
+	SNOOZE         = "snooze"
+	REOPEN         = "reopen"
 )
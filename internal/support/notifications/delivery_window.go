@@ -0,0 +1,332 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const (
+	delayedPollInterval = time.Second
+	delayedBatchSize    = 100
+)
+
+type deliveryAction int
+
+const (
+	deliverNow deliveryAction = iota
+	dropDelivery
+	delayDelivery
+)
+
+var severityRanks = map[models.NotificationsSeverity]int{
+	models.Normal:   0,
+	models.Critical: 1,
+}
+
+// deliveryWindow is a parsed DeliveryWindow, its start and end are minutes since midnight of the start day.
+type deliveryWindow struct {
+	days  []time.Weekday
+	start int
+	end   int
+}
+
+// deliveryFor decides whether the notification is delivered to the subscription now, dropped, or delayed until the
+// returned time, according to the subscription's minimum severity, delivery windows and quiet hours behavior.
+func deliveryFor(
+	n models.Notification,
+	subscriptionSlug string,
+	settings notificationsModels.SubscriptionSettings,
+	now time.Time,
+	lc logger.LoggingClient) (deliveryAction, time.Time) {
+
+	if settings.MinSeverity != "" {
+		min, ok := severityRanks[models.NotificationsSeverity(strings.ToUpper(settings.MinSeverity))]
+		if !ok {
+			lc.Error("Invalid MinSeverity " + settings.MinSeverity + " in settings of subscription " + subscriptionSlug)
+		} else if severityRanks[n.Severity] < min {
+			return dropDelivery, time.Time{}
+		}
+	}
+
+	windows := parseDeliveryWindows(settings.DeliveryWindows, subscriptionSlug, lc)
+	if len(windows) == 0 {
+		return deliverNow, time.Time{}
+	}
+	local := now.In(deliveryLocation(settings.TimeZone, subscriptionSlug, lc))
+	if inDeliveryWindow(windows, local) {
+		return deliverNow, time.Time{}
+	}
+
+	switch strings.ToLower(settings.QuietHours) {
+	case QUIETHOURSDROP:
+		return dropDelivery, time.Time{}
+	case QUIETHOURSCRITICAL:
+		if n.Severity == models.Critical {
+			return deliverNow, time.Time{}
+		}
+		return dropDelivery, time.Time{}
+	case "", QUIETHOURSDELAY:
+	default:
+		lc.Error("Invalid QuietHours " + settings.QuietHours + " in settings of subscription " + subscriptionSlug +
+			", delaying notifications")
+	}
+	next, ok := nextDeliveryWindow(windows, local)
+	if !ok {
+		return dropDelivery, time.Time{}
+	}
+	return delayDelivery, next
+}
+
+// parseDeliveryWindows parses the subscription's windows, skipping and logging the invalid ones.
+func parseDeliveryWindows(
+	settings []notificationsModels.DeliveryWindow,
+	subscriptionSlug string,
+	lc logger.LoggingClient) []deliveryWindow {
+
+	var windows []deliveryWindow
+	for _, setting := range settings {
+		w, err := parseDeliveryWindow(setting)
+		if err != nil {
+			lc.Error("Invalid delivery window in settings of subscription " + subscriptionSlug + ": " + err.Error())
+			continue
+		}
+		windows = append(windows, w)
+	}
+	return windows
+}
+
+func parseDeliveryWindow(setting notificationsModels.DeliveryWindow) (deliveryWindow, error) {
+	w := deliveryWindow{end: 24 * 60}
+	for _, day := range setting.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return w, errors.New("invalid day " + day)
+		}
+		w.days = append(w.days, weekday)
+	}
+
+	var err error
+	if setting.Start != "" {
+		if w.start, err = parseTimeOfDay(setting.Start); err != nil {
+			return w, err
+		}
+	}
+	if setting.End != "" {
+		if w.end, err = parseTimeOfDay(setting.End); err != nil {
+			return w, err
+		}
+		if w.end <= w.start {
+			w.end += 24 * 60
+		}
+	}
+	return w, nil
+}
+
+// parseWeekday accepts the full English day names and their three letter abbreviations, ignoring case.
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// parseTimeOfDay returns the minutes since midnight of a "15:04" formatted time.
+func parseTimeOfDay(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.New("invalid time of day " + value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func deliveryLocation(timeZone string, subscriptionSlug string, lc logger.LoggingClient) *time.Location {
+	if timeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		lc.Error("Invalid TimeZone " + timeZone + " in settings of subscription " + subscriptionSlug + ", using UTC")
+		return time.UTC
+	}
+	return loc
+}
+
+// startsOn reports whether the window opens on the day.
+func (w deliveryWindow) startsOn(day time.Weekday) bool {
+	if len(w.days) == 0 {
+		return true
+	}
+	for _, d := range w.days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// bounds returns the opening and closing times of the window starting on the day of the given date. Wall clock
+// times are used so the window follows daylight saving time changes.
+func (w deliveryWindow) bounds(year int, month time.Month, day int, loc *time.Location) (time.Time, time.Time) {
+	return time.Date(year, month, day, 0, w.start, 0, 0, loc), time.Date(year, month, day, 0, w.end, 0, 0, loc)
+}
+
+func inDeliveryWindow(windows []deliveryWindow, local time.Time) bool {
+	for _, w := range windows {
+		// a window may have opened on the previous day
+		for offset := -1; offset <= 0; offset++ {
+			date := local.AddDate(0, 0, offset)
+			if !w.startsOn(date.Weekday()) {
+				continue
+			}
+			start, end := w.bounds(date.Year(), date.Month(), date.Day(), local.Location())
+			if !local.Before(start) && local.Before(end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextDeliveryWindow returns the time the next window opens after local, if any opens within the coming week.
+func nextDeliveryWindow(windows []deliveryWindow, local time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, w := range windows {
+		for offset := 0; offset <= 7; offset++ {
+			date := local.AddDate(0, 0, offset)
+			if !w.startsOn(date.Weekday()) {
+				continue
+			}
+			start, _ := w.bounds(date.Year(), date.Month(), date.Day(), local.Location())
+			if start.After(local) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// delayNotification stores the delivery of the notification to the subscription until the time.
+func delayNotification(
+	n models.Notification,
+	s models.Subscription,
+	until time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) {
+
+	d := notificationsModels.DelayedNotification{Notification: n.Slug, Subscription: s.Slug}
+	if err := dbClient.AddDelayedNotification(d, timeToMillis(until)); err != nil {
+		lc.Error("Unable to delay notification " + n.Slug + " for subscription " + s.Slug + ": " + err.Error())
+		return
+	}
+	lc.Debug("Delaying notification: " + n.Slug + " for subscription: " + s.Slug + " until " + until.Format(time.RFC3339))
+}
+
+// startDelayedNotificationProcessor delivers the delayed notifications as their delivery windows open, until the
+// context is done.
+func startDelayedNotificationProcessor(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(delayedPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				processDueDelayedNotifications(
+					now,
+					notificationsContainer.ThrottleFrom(dic.Get),
+					bootstrapContainer.LoggingClientFrom(dic.Get),
+					distributionDBClientFrom(dic.Get),
+					*notificationsContainer.ConfigurationFrom(dic.Get))
+			}
+		}
+	}()
+}
+
+func processDueDelayedNotifications(
+	now time.Time,
+	throttle interfaces.NotificationThrottle,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	delayed, err := dbClient.GetDelayedNotifications(timeToMillis(now), delayedBatchSize)
+	if err != nil {
+		lc.Error("Unable to get due delayed notifications: " + err.Error())
+		return
+	}
+
+	for _, d := range delayed {
+		// only the caller removing the delayed notification delivers it
+		claimed, err := dbClient.DeleteDelayedNotification(d)
+		if err != nil || !claimed {
+			continue
+		}
+		deliverDelayedNotification(d, now, throttle, lc, dbClient, config)
+	}
+}
+
+// deliverDelayedNotification delivers the delayed notification unless it or its subscription is gone, or someone
+// acknowledged, resolved or snoozed the notification in the meantime.
+func deliverDelayedNotification(
+	d notificationsModels.DelayedNotification,
+	now time.Time,
+	throttle interfaces.NotificationThrottle,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	n, err := dbClient.GetNotificationBySlug(d.Notification)
+	if err != nil {
+		lc.Warn("Dropping delayed notification " + d.Notification + ": " + err.Error())
+		return
+	}
+	s, err := dbClient.GetSubscriptionBySlug(d.Subscription)
+	if err != nil {
+		lc.Warn("Dropping delayed notification " + d.Notification + " for subscription " + d.Subscription + ": " +
+			err.Error())
+		return
+	}
+	if hold, _ := lifecycleHold(n.Slug, now, lc, dbClient); hold {
+		lc.Debug("Dropping delayed notification " + n.Slug + " which is no longer open")
+		return
+	}
+	deliver(n, s, now, throttle, lc, dbClient, config)
+}
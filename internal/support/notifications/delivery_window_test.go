@@ -0,0 +1,158 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveryFor(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	weekdays := []notificationsModels.DeliveryWindow{
+		{Days: []string{"Mon", "tue", "Wednesday", "Thu", "Fri"}, Start: "08:00", End: "18:00"},
+	}
+	nights := []notificationsModels.DeliveryWindow{{Start: "22:00", End: "06:00"}}
+
+	// 2021-03-01 is a Monday
+	mondayNoon := time.Date(2021, 3, 1, 12, 0, 0, 0, berlin)
+	mondayNight := time.Date(2021, 3, 1, 23, 0, 0, 0, berlin)
+	fridayNight := time.Date(2021, 3, 5, 20, 0, 0, 0, berlin)
+	tuesdayMorning := time.Date(2021, 3, 2, 8, 0, 0, 0, berlin)
+	nextMonday := time.Date(2021, 3, 8, 8, 0, 0, 0, berlin)
+
+	normal := models.Notification{Slug: "n1", Severity: models.Normal}
+	critical := models.Notification{Slug: "n2", Severity: models.Critical}
+
+	tests := []struct {
+		name           string
+		settings       notificationsModels.SubscriptionSettings
+		notification   models.Notification
+		now            time.Time
+		expectedAction deliveryAction
+		expectedUntil  time.Time
+	}{
+		{"no settings", notificationsModels.SubscriptionSettings{}, normal, mondayNight, deliverNow, time.Time{}},
+		{"below min severity", notificationsModels.SubscriptionSettings{MinSeverity: "CRITICAL"}, normal, mondayNoon, dropDelivery, time.Time{}},
+		{"at min severity", notificationsModels.SubscriptionSettings{MinSeverity: "critical"}, critical, mondayNoon, deliverNow, time.Time{}},
+		{"invalid min severity", notificationsModels.SubscriptionSettings{MinSeverity: "MAJOR"}, normal, mondayNoon, deliverNow, time.Time{}},
+		{"in window", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays}, normal, mondayNoon, deliverNow, time.Time{}},
+		{"window opening", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays}, normal, tuesdayMorning, deliverNow, time.Time{}},
+		{"delay to next day", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays}, normal, mondayNight, delayDelivery, tuesdayMorning},
+		{"delay over weekend", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays, QuietHours: "delay"}, critical, fridayNight, delayDelivery, nextMonday},
+		{"drop", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays, QuietHours: "drop"}, critical, mondayNight, dropDelivery, time.Time{}},
+		{"critical only drops normal", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays, QuietHours: "critical"}, normal, mondayNight, dropDelivery, time.Time{}},
+		{"critical only delivers critical", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: weekdays, QuietHours: "CRITICAL"}, critical, mondayNight, deliverNow, time.Time{}},
+		{"window across midnight", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: nights}, normal, tuesdayMorning.Add(-3 * time.Hour), deliverNow, time.Time{}},
+		{"window across midnight delays", notificationsModels.SubscriptionSettings{TimeZone: "Europe/Berlin", DeliveryWindows: nights}, normal, mondayNoon, delayDelivery, time.Date(2021, 3, 1, 22, 0, 0, 0, berlin)},
+		{"utc by default", notificationsModels.SubscriptionSettings{DeliveryWindows: weekdays}, normal, time.Date(2021, 3, 1, 18, 30, 0, 0, berlin), deliverNow, time.Time{}},
+		{"invalid windows are ignored", notificationsModels.SubscriptionSettings{DeliveryWindows: []notificationsModels.DeliveryWindow{{Days: []string{"Caturday"}}, {Start: "25:00"}}}, normal, mondayNight, deliverNow, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, until := deliveryFor(tt.notification, "s1", tt.settings, tt.now, logger.NewMockClient())
+			assert.Equal(t, tt.expectedAction, action)
+			assert.True(t, tt.expectedUntil.Equal(until), "expected %v, got %v", tt.expectedUntil, until)
+		})
+	}
+}
+
+func TestDeliveryWindowFollowsDaylightSavingTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	settings := notificationsModels.SubscriptionSettings{
+		TimeZone:        "Europe/Berlin",
+		DeliveryWindows: []notificationsModels.DeliveryWindow{{Start: "08:00", End: "18:00"}},
+	}
+
+	// clocks move forward on 2021-03-28
+	action, until := deliveryFor(models.Notification{Severity: models.Normal}, "s1", settings,
+		time.Date(2021, 3, 27, 20, 0, 0, 0, berlin), logger.NewMockClient())
+
+	assert.Equal(t, delayDelivery, action)
+	assert.Equal(t, "2021-03-28T08:00:00+02:00", until.Format(time.RFC3339))
+}
+
+func TestDistributeDelaysNotificationsOutsideDeliveryWindows(t *testing.T) {
+	n := models.Notification{Slug: "n1", Category: models.Swhealth, Severity: models.Normal}
+	s := models.Subscription{ID: "id1", Slug: "s1"}
+	// a whole day window starting the day after tomorrow
+	opening := time.Now().UTC().AddDate(0, 0, 2)
+	settings := notificationsModels.SubscriptionSettings{
+		DeliveryWindows: []notificationsModels.DeliveryWindow{{Days: []string{opening.Weekday().String()}}},
+	}
+	until := time.Date(opening.Year(), opening.Month(), opening.Day(), 0, 0, 0, 0, time.UTC)
+
+	throttle := NewNotificationThrottle()
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetSubscriptionByCategoriesLabels", []string{string(models.Swhealth)}, []string(nil)).
+		Return([]models.Subscription{s}, nil)
+	dbClient.On("GetSubscriptionSettings", "id1").Return(settings, nil)
+	dbClient.On("AddDelayedNotification", notificationsModels.DelayedNotification{Notification: "n1", Subscription: "s1"},
+		timeToMillis(until)).Return(nil)
+
+	err := distribute(n, throttle, logger.NewMockClient(), dbClient, notificationsConfig.ConfigurationStruct{})
+
+	require.NoError(t, err)
+	dbClient.AssertExpectations(t)
+}
+
+func TestProcessDueDelayedNotifications(t *testing.T) {
+	due := notificationsModels.DelayedNotification{Notification: "n1", Subscription: "s1"}
+	taken := notificationsModels.DelayedNotification{Notification: "n2", Subscription: "s1"}
+	acknowledged := notificationsModels.DelayedNotification{Notification: "n3", Subscription: "s1"}
+	n1 := models.Notification{Slug: "n1", Severity: models.Normal}
+	n3 := models.Notification{Slug: "n3", Severity: models.Normal}
+	s := models.Subscription{Slug: "s1"}
+	now := time.Now()
+
+	lifecycle := notificationsModels.NewNotificationLifecycle("n3")
+	require.NoError(t, lifecycle.Transition(notificationsModels.Acknowledged, "operator", timeToMillis(now), 0, ""))
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetDelayedNotifications", timeToMillis(now), delayedBatchSize).
+		Return([]notificationsModels.DelayedNotification{due, taken, acknowledged}, nil)
+	dbClient.On("DeleteDelayedNotification", due).Return(true, nil)
+	dbClient.On("DeleteDelayedNotification", taken).Return(false, nil)
+	dbClient.On("DeleteDelayedNotification", acknowledged).Return(true, nil)
+	dbClient.On("GetNotificationBySlug", "n1").Return(n1, nil)
+	dbClient.On("GetNotificationBySlug", "n3").Return(n3, nil)
+	dbClient.On("GetSubscriptionBySlug", "s1").Return(s, nil)
+	dbClient.On("GetNotificationLifecycle", "n1").Return(notificationsModels.NotificationLifecycle{}, db.ErrNotFound)
+	dbClient.On("GetNotificationLifecycle", "n3").Return(lifecycle, nil)
+
+	throttle := &mocks.NotificationThrottle{}
+	// the subscription has no channels, admitting the notification is the last step of its delivery
+	throttle.On("Admit", n1, s, mock.Anything, now, mock.Anything, mock.Anything).Return(true)
+
+	processDueDelayedNotifications(now, throttle, logger.NewMockClient(), dbClient, notificationsConfig.ConfigurationStruct{})
+
+	dbClient.AssertExpectations(t)
+	throttle.AssertExpectations(t)
+}
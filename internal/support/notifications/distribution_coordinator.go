@@ -16,6 +16,10 @@
 package notifications
 
 import (
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
 
@@ -25,10 +29,17 @@ import (
 
 func distribute(
 	n models.Notification,
+	throttle interfaces.NotificationThrottle,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
 	config notificationsConfig.ConfigurationStruct) error {
 
+	now := time.Now()
+	if throttle.Suppress(n, now, lc, config) {
+		lc.Info("Suppressing duplicate notification: " + n.Slug)
+		return nil
+	}
+
 	lc.Debug("DistributionCoordinator start distributing notification: " + n.Slug)
 	var categories []string
 	categories = append(categories, string(n.Category))
@@ -38,11 +49,39 @@ func distribute(
 		return err
 	}
 	for _, sub := range subs {
-		send(n, sub, lc, dbClient, config)
+		deliver(n, sub, now, throttle, lc, dbClient, config)
 	}
 	return nil
 }
 
+// deliver sends the notification to the subscription unless the subscription's delivery settings drop or delay it,
+// or its throttle defers it to a digest.
+func deliver(
+	n models.Notification,
+	s models.Subscription,
+	now time.Time,
+	throttle interfaces.NotificationThrottle,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	settings := subscriptionSettings(s, lc, dbClient)
+	switch action, until := deliveryFor(n, s.Slug, settings, now, lc); action {
+	case dropDelivery:
+		lc.Debug("Dropping notification: " + n.Slug + " filtered by the delivery settings of subscription: " + s.Slug)
+		return
+	case delayDelivery:
+		delayNotification(n, s, until, lc, dbClient)
+		return
+	}
+
+	if !throttle.Admit(n, s, settings, now, lc, dbClient) {
+		lc.Debug("Deferring notification: " + n.Slug + " to the digest of subscription: " + s.Slug)
+		return
+	}
+	send(n, s, lc, dbClient, config)
+}
+
 func resend(
 	t models.Transmission,
 	lc logger.LoggingClient,
@@ -61,16 +100,22 @@ func send(
 	config notificationsConfig.ConfigurationStruct) {
 
 	for _, ch := range s.Channels {
-		sendViaChannel(n, ch, s.Receiver, lc, dbClient, config)
+		sendViaChannel(n, ch, s, lc, dbClient, config)
 	}
 }
 
-func criticalSeverityResend(
-	t models.Transmission,
+// subscriptionSettings returns the delivery settings stored with the subscription, the defaults when it has none.
+func subscriptionSettings(
+	s models.Subscription,
 	lc logger.LoggingClient,
-	dbClient interfaces.DBClient,
-	config notificationsConfig.ConfigurationStruct) {
+	dbClient interfaces.DBClient) notificationsModels.SubscriptionSettings {
 
-	lc.Info("Critical severity resend scheduler is triggered.")
-	resend(t, lc, dbClient, config)
+	if s.ID == "" {
+		return notificationsModels.SubscriptionSettings{}
+	}
+	settings, err := dbClient.GetSubscriptionSettings(s.ID)
+	if err != nil && err != db.ErrNotFound {
+		lc.Error("Unable to get the settings of subscription " + s.Slug + ": " + err.Error())
+	}
+	return settings
 }
@@ -16,6 +16,9 @@
 package notifications
 
 import (
+	"fmt"
+	"strconv"
+
 	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
 
@@ -23,39 +26,61 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
+// escalate sends an escalated notification for the failed transmission to the subscription with the given slug. The
+// escalated notification is stored under the escalation slug, which makes every escalation level happen once per
+// notification.
 func escalate(
 	t models.Transmission,
+	subscriptionSlug string,
+	escalationSlug string,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
-	config notificationsConfig.ConfigurationStruct) {
+	config notificationsConfig.ConfigurationStruct) bool {
 
-	lc.Warn("Escalating transmission: " + t.ID + ", for: " + t.Notification.Slug)
+	lc.Warn("Escalating transmission: " + t.ID + ", for: " + t.Notification.Slug + ", to: " + subscriptionSlug)
 
 	var err error
-	s, err := dbClient.GetSubscriptionBySlug(ESCALATIONSUBSCRIPTIONSLUG)
+	s, err := dbClient.GetSubscriptionBySlug(subscriptionSlug)
 	if err != nil {
-		lc.Error("Unable to find Escalation subscriber to send escalation notice for " + t.ID)
-		return
+		lc.Error("Unable to find Escalation subscriber " + subscriptionSlug + " to send escalation notice for " + t.ID)
+		return false
 	}
 
-	n, err := createEscalatedNotification(t, dbClient)
+	n, err := createEscalatedNotification(t, escalationSlug, dbClient)
 	if err != nil {
 		lc.Error("Unable to create new escalating notice to send escalation notice for " + t.ID)
-		return
+		return false
 	}
 
 	send(n, s, lc, dbClient, config)
+	return true
+}
+
+// escalationSlug returns the slug of the notification escalating the notification at the level (starting at 0) of its
+// escalation policy.
+func escalationSlug(level int, slug string) string {
+	if level == 0 {
+		return ESCALATIONPREFIX + slug
+	}
+	return ESCALATIONPREFIX + strconv.Itoa(level+1) + "-" + slug
 }
 
 func createEscalatedNotification(
 	t models.Transmission,
+	slug string,
 	dbClient interfaces.DBClient) (models.Notification, error) {
 
 	old := t.Notification
 	n := models.Notification{Category: old.Category, Severity: old.Severity, Description: old.Description, Labels: old.Labels, ContentType: "text/plain"}
-	n.Slug = ESCALATIONPREFIX + old.Slug
+	n.Slug = slug
 	n.Sender = ESCALATIONPREFIX + old.Sender
-	n.Content = ESCALATEDCONTENTNOTICE + " " + t.String() + " " + old.Content
+	n.Content = fmt.Sprintf(
+		"%s %s to %s after %d resends: %s",
+		ESCALATEDCONTENTNOTICE,
+		t.ID,
+		t.Receiver,
+		t.ResendCount,
+		old.Content)
 	n.Status = models.Escalated
 	_, err := dbClient.AddNotification(n)
 	return n, err
@@ -18,6 +18,12 @@ import (
 	"context"
 	"sync"
 
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
 
@@ -37,7 +43,31 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization for the notifications service.
-func (b *Bootstrap) BootstrapHandler(_ context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+	throttle := NewNotificationThrottle()
+	dic.Update(di.ServiceConstructorMap{
+		notificationsContainer.ThrottleName: func(get di.Get) interface{} {
+			return throttle
+		},
+		v2NotificationsContainer.NotificationDistributorName: func(get di.Get) interface{} {
+			return v2NotificationDistributor{dic: dic}
+		},
+	})
+
+	loadChannelCredentials(
+		*notificationsContainer.ConfigurationFrom(dic.Get),
+		bootstrapContainer.SecretProviderFrom(dic.Get),
+		bootstrapContainer.LoggingClientFrom(dic.Get))
+
+	loadConfiguredSubscriptionSettings(
+		*notificationsContainer.ConfigurationFrom(dic.Get),
+		bootstrapContainer.LoggingClientFrom(dic.Get),
+		container.DBClientFrom(dic.Get))
+
 	loadRestRoutes(b.router, dic)
+	v2.LoadRestRoutes(b.router, dic)
+	startRetryProcessor(ctx, wg, dic)
+	startDigestProcessor(ctx, wg, dic)
+	startDelayedNotificationProcessor(ctx, wg, dic)
 	return true
 }
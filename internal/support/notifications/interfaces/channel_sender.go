@@ -0,0 +1,63 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package interfaces
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// ChannelMessage is the content of a notification rendered for delivery. Senders pick the rendering suited to their
+// channel and fall back to Text when it is empty.
+type ChannelMessage struct {
+	// Subject is the email subject
+	Subject string
+	// Text is the plain text rendering, ContentType its content type
+	Text        string
+	ContentType string
+	// Html is the HTML rendering for email
+	Html string
+	// Sms is the short plain text rendering for SMS
+	Sms string
+	// Json is the JSON rendering for webhooks, REST and MQTT
+	Json string
+	// From is the email sender address, the configured SMTP sender when empty
+	From string
+	// Attachments are attached to emails
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+// ChannelSender delivers a notification through one type of channel (email, REST, MQTT, webhook, SMS, ...).
+type ChannelSender interface {
+	// Send delivers the rendered notification to the channel of the given receiver and reports the outcome as a
+	// transmission record; failures are reported through the record's status rather than an error.
+	Send(
+		n contract.Notification,
+		message ChannelMessage,
+		c contract.Channel,
+		receiver string,
+		lc logger.LoggingClient,
+		config config.ConfigurationStruct) contract.TransmissionRecord
+}
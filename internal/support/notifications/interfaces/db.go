@@ -16,6 +16,8 @@
 package interfaces
 
 import (
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 )
 
@@ -33,6 +35,7 @@ type DBClient interface {
 	GetNotificationsByEnd(end int64, limit int) ([]contract.Notification, error)
 	GetNewNotifications(limit int) ([]contract.Notification, error)
 	GetNewNormalNotifications(limit int) ([]contract.Notification, error)
+	GetNotificationsBySeverity(severity string, limit int) ([]contract.Notification, error)
 	AddNotification(n contract.Notification) (string, error)
 	UpdateNotification(n contract.Notification) error
 	MarkNotificationProcessed(n contract.Notification) error
@@ -40,6 +43,20 @@ type DBClient interface {
 	DeleteNotificationBySlug(id string) error
 	DeleteNotificationsOld(age int) error
 
+	// Notification lifecycles
+	GetNotificationLifecycle(slug string) (notificationsModels.NotificationLifecycle, error)
+	GetNotificationLifecycles(slugs []string) ([]notificationsModels.NotificationLifecycle, error)
+	UpdateNotificationLifecycle(
+		slug string,
+		update func(l *notificationsModels.NotificationLifecycle) error) (notificationsModels.NotificationLifecycle, error)
+	GetUnacknowledgedCriticalNotifications(offset int, limit int) ([]contract.Notification, error)
+	AddDelayedNotification(d notificationsModels.DelayedNotification, due int64) error
+	GetDelayedNotifications(end int64, limit int) ([]notificationsModels.DelayedNotification, error)
+	DeleteDelayedNotification(d notificationsModels.DelayedNotification) (bool, error)
+	AddDigestNotification(subscription string, notification string, due int64) error
+	GetDigests(end int64, limit int) ([]string, error)
+	DeleteDigest(subscription string) ([]string, error)
+
 	// Subscriptions
 	GetSubscriptions() ([]contract.Subscription, error)
 	GetSubscriptionById(id string) (contract.Subscription, error)
@@ -52,6 +69,8 @@ type DBClient interface {
 	UpdateSubscription(s contract.Subscription) error
 	DeleteSubscriptionById(id string) error
 	DeleteSubscriptionBySlug(id string) error
+	GetSubscriptionSettings(id string) (notificationsModels.SubscriptionSettings, error)
+	UpdateSubscriptionSettings(id string, settings notificationsModels.SubscriptionSettings) error
 
 	// Transmissions
 	GetTransmissionById(id string) (contract.Transmission, error)
@@ -61,6 +80,9 @@ type DBClient interface {
 	GetTransmissionsByStart(start int64, limit int) ([]contract.Transmission, error)
 	GetTransmissionsByEnd(end int64, limit int) ([]contract.Transmission, error)
 	GetTransmissionsByStatus(limit int, status contract.TransmissionStatus) ([]contract.Transmission, error)
+	AddTransmissionRetry(id string, due int64) error
+	GetTransmissionRetries(end int64, limit int) ([]string, error)
+	DeleteTransmissionRetry(id string) (bool, error)
 	AddTransmission(t contract.Transmission) (string, error)
 	UpdateTransmission(t contract.Transmission) error
 	DeleteTransmission(age int64, status contract.TransmissionStatus) error
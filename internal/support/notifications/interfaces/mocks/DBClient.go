@@ -4,12 +4,41 @@ package mocks
 
 import mock "github.com/stretchr/testify/mock"
 import models "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+import notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
 
 // DBClient is an autogenerated mock type for the DBClient type
 type DBClient struct {
 	mock.Mock
 }
 
+// AddDelayedNotification provides a mock function with given fields: d, due
+func (_m *DBClient) AddDelayedNotification(d notificationsModels.DelayedNotification, due int64) error {
+	ret := _m.Called(d, due)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(notificationsModels.DelayedNotification, int64) error); ok {
+		r0 = rf(d, due)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddDigestNotification provides a mock function with given fields: subscription, notification, due
+func (_m *DBClient) AddDigestNotification(subscription string, notification string, due int64) error {
+	ret := _m.Called(subscription, notification, due)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int64) error); ok {
+		r0 = rf(subscription, notification, due)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddNotification provides a mock function with given fields: n
 func (_m *DBClient) AddNotification(n models.Notification) (string, error) {
 	ret := _m.Called(n)
@@ -73,6 +102,20 @@ func (_m *DBClient) AddTransmission(t models.Transmission) (string, error) {
 	return r0, r1
 }
 
+// AddTransmissionRetry provides a mock function with given fields: id, due
+func (_m *DBClient) AddTransmissionRetry(id string, due int64) error {
+	ret := _m.Called(id, due)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(id, due)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Cleanup provides a mock function with given fields:
 func (_m *DBClient) Cleanup() error {
 	ret := _m.Called()
@@ -106,6 +149,50 @@ func (_m *DBClient) CloseSession() {
 	_m.Called()
 }
 
+// DeleteDelayedNotification provides a mock function with given fields: d
+func (_m *DBClient) DeleteDelayedNotification(d notificationsModels.DelayedNotification) (bool, error) {
+	ret := _m.Called(d)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(notificationsModels.DelayedNotification) bool); ok {
+		r0 = rf(d)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(notificationsModels.DelayedNotification) error); ok {
+		r1 = rf(d)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteDigest provides a mock function with given fields: subscription
+func (_m *DBClient) DeleteDigest(subscription string) ([]string, error) {
+	ret := _m.Called(subscription)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteNotificationById provides a mock function with given fields: id
 func (_m *DBClient) DeleteNotificationById(id string) error {
 	ret := _m.Called(id)
@@ -190,6 +277,73 @@ func (_m *DBClient) DeleteTransmission(age int64, status models.TransmissionStat
 	return r0
 }
 
+// DeleteTransmissionRetry provides a mock function with given fields: id
+func (_m *DBClient) DeleteTransmissionRetry(id string) (bool, error) {
+	ret := _m.Called(id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDelayedNotifications provides a mock function with given fields: end, limit
+func (_m *DBClient) GetDelayedNotifications(end int64, limit int) ([]notificationsModels.DelayedNotification, error) {
+	ret := _m.Called(end, limit)
+
+	var r0 []notificationsModels.DelayedNotification
+	if rf, ok := ret.Get(0).(func(int64, int) []notificationsModels.DelayedNotification); ok {
+		r0 = rf(end, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notificationsModels.DelayedNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(end, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDigests provides a mock function with given fields: end, limit
+func (_m *DBClient) GetDigests(end int64, limit int) ([]string, error) {
+	ret := _m.Called(end, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(end, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(end, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNewNormalNotifications provides a mock function with given fields: limit
 func (_m *DBClient) GetNewNormalNotifications(limit int) ([]models.Notification, error) {
 	ret := _m.Called(limit)
@@ -301,6 +455,50 @@ func (_m *DBClient) GetNotificationBySlug(slug string) (models.Notification, err
 	return r0, r1
 }
 
+// GetNotificationLifecycle provides a mock function with given fields: slug
+func (_m *DBClient) GetNotificationLifecycle(slug string) (notificationsModels.NotificationLifecycle, error) {
+	ret := _m.Called(slug)
+
+	var r0 notificationsModels.NotificationLifecycle
+	if rf, ok := ret.Get(0).(func(string) notificationsModels.NotificationLifecycle); ok {
+		r0 = rf(slug)
+	} else {
+		r0 = ret.Get(0).(notificationsModels.NotificationLifecycle)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(slug)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNotificationLifecycles provides a mock function with given fields: slugs
+func (_m *DBClient) GetNotificationLifecycles(slugs []string) ([]notificationsModels.NotificationLifecycle, error) {
+	ret := _m.Called(slugs)
+
+	var r0 []notificationsModels.NotificationLifecycle
+	if rf, ok := ret.Get(0).(func([]string) []notificationsModels.NotificationLifecycle); ok {
+		r0 = rf(slugs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notificationsModels.NotificationLifecycle)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(slugs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNotifications provides a mock function with given fields:
 func (_m *DBClient) GetNotifications() ([]models.Notification, error) {
 	ret := _m.Called()
@@ -370,6 +568,29 @@ func (_m *DBClient) GetNotificationsByLabels(labels []string, limit int) ([]mode
 	return r0, r1
 }
 
+// GetNotificationsBySeverity provides a mock function with given fields: severity, limit
+func (_m *DBClient) GetNotificationsBySeverity(severity string, limit int) ([]models.Notification, error) {
+	ret := _m.Called(severity, limit)
+
+	var r0 []models.Notification
+	if rf, ok := ret.Get(0).(func(string, int) []models.Notification); ok {
+		r0 = rf(severity, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int) error); ok {
+		r1 = rf(severity, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNotificationsByStart provides a mock function with given fields: start, limit
 func (_m *DBClient) GetNotificationsByStart(start int64, limit int) ([]models.Notification, error) {
 	ret := _m.Called(start, limit)
@@ -550,6 +771,27 @@ func (_m *DBClient) GetSubscriptionBySlug(slug string) (models.Subscription, err
 	return r0, r1
 }
 
+// GetSubscriptionSettings provides a mock function with given fields: id
+func (_m *DBClient) GetSubscriptionSettings(id string) (notificationsModels.SubscriptionSettings, error) {
+	ret := _m.Called(id)
+
+	var r0 notificationsModels.SubscriptionSettings
+	if rf, ok := ret.Get(0).(func(string) notificationsModels.SubscriptionSettings); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(notificationsModels.SubscriptionSettings)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubscriptions provides a mock function with given fields:
 func (_m *DBClient) GetSubscriptions() ([]models.Subscription, error) {
 	ret := _m.Called()
@@ -594,6 +836,29 @@ func (_m *DBClient) GetTransmissionById(id string) (models.Transmission, error)
 	return r0, r1
 }
 
+// GetTransmissionRetries provides a mock function with given fields: end, limit
+func (_m *DBClient) GetTransmissionRetries(end int64, limit int) ([]string, error) {
+	ret := _m.Called(end, limit)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int64, int) []string); ok {
+		r0 = rf(end, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(end, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransmissionsByEnd provides a mock function with given fields: end, limit
 func (_m *DBClient) GetTransmissionsByEnd(end int64, limit int) ([]models.Transmission, error) {
 	ret := _m.Called(end, limit)
@@ -732,6 +997,29 @@ func (_m *DBClient) GetTransmissionsByStatus(limit int, status models.Transmissi
 	return r0, r1
 }
 
+// GetUnacknowledgedCriticalNotifications provides a mock function with given fields: offset, limit
+func (_m *DBClient) GetUnacknowledgedCriticalNotifications(offset int, limit int) ([]models.Notification, error) {
+	ret := _m.Called(offset, limit)
+
+	var r0 []models.Notification
+	if rf, ok := ret.Get(0).(func(int, int) []models.Notification); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MarkNotificationProcessed provides a mock function with given fields: n
 func (_m *DBClient) MarkNotificationProcessed(n models.Notification) error {
 	ret := _m.Called(n)
@@ -760,6 +1048,27 @@ func (_m *DBClient) UpdateNotification(n models.Notification) error {
 	return r0
 }
 
+// UpdateNotificationLifecycle provides a mock function with given fields: slug, update
+func (_m *DBClient) UpdateNotificationLifecycle(slug string, update func(*notificationsModels.NotificationLifecycle) error) (notificationsModels.NotificationLifecycle, error) {
+	ret := _m.Called(slug, update)
+
+	var r0 notificationsModels.NotificationLifecycle
+	if rf, ok := ret.Get(0).(func(string, func(*notificationsModels.NotificationLifecycle) error) notificationsModels.NotificationLifecycle); ok {
+		r0 = rf(slug, update)
+	} else {
+		r0 = ret.Get(0).(notificationsModels.NotificationLifecycle)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, func(*notificationsModels.NotificationLifecycle) error) error); ok {
+		r1 = rf(slug, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateSubscription provides a mock function with given fields: s
 func (_m *DBClient) UpdateSubscription(s models.Subscription) error {
 	ret := _m.Called(s)
@@ -774,6 +1083,20 @@ func (_m *DBClient) UpdateSubscription(s models.Subscription) error {
 	return r0
 }
 
+// UpdateSubscriptionSettings provides a mock function with given fields: id, settings
+func (_m *DBClient) UpdateSubscriptionSettings(id string, settings notificationsModels.SubscriptionSettings) error {
+	ret := _m.Called(id, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, notificationsModels.SubscriptionSettings) error); ok {
+		r0 = rf(id, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTransmission provides a mock function with given fields: t
 func (_m *DBClient) UpdateTransmission(t models.Transmission) error {
 	ret := _m.Called(t)
@@ -0,0 +1,44 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package interfaces
+
+import (
+	"time"
+
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// NotificationThrottle decides which notifications are distributed and which subscriptions receive them right away.
+// Notifications held back from a subscription are collected into its digest.
+type NotificationThrottle interface {
+	// Suppress reports whether the notification duplicates a notification distributed within the deduplication
+	// window, otherwise the notification is recorded as distributed.
+	Suppress(n contract.Notification, now time.Time, lc logger.LoggingClient, config config.ConfigurationStruct) bool
+	// Admit reports whether the notification is delivered to the subscription right away according to the rate limit
+	// and digest interval of the subscription's settings, otherwise it is added to the subscription's digest stored
+	// in the database.
+	Admit(
+		n contract.Notification,
+		s contract.Subscription,
+		settings notificationsModels.SubscriptionSettings,
+		now time.Time,
+		lc logger.LoggingClient,
+		dbClient DBClient) bool
+}
@@ -0,0 +1,218 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// lifecycleRequest is the body of a lifecycle action. Snoozing requires either Until, in milliseconds, or Duration,
+// e.g. "30m".
+type lifecycleRequest struct {
+	By       string `json:"by"`
+	Comment  string `json:"comment,omitempty"`
+	Until    int64  `json:"until,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// unacknowledgedBatchSize is the number of unacknowledged notifications read from the database at once.
+const unacknowledgedBatchSize = 100
+
+// lifecycleActions maps the lifecycle actions of the REST API to the states they change to.
+var lifecycleActions = map[string]notificationsModels.LifecycleState{
+	ACKNOWLEDGE: notificationsModels.Acknowledged,
+	RESOLVE:     notificationsModels.Resolved,
+	SNOOZE:      notificationsModels.Snoozed,
+	REOPEN:      notificationsModels.Open,
+}
+
+func millisToTime(millis int64) time.Time {
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+func timeToMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// getNotificationLifecycle returns the lifecycle of the notification, an open one if nobody acted on it yet.
+func getNotificationLifecycle(slug string, dbClient interfaces.DBClient) (notificationsModels.NotificationLifecycle, error) {
+	l, err := dbClient.GetNotificationLifecycle(slug)
+	if err == db.ErrNotFound {
+		return notificationsModels.NewNotificationLifecycle(slug), nil
+	}
+	return l, err
+}
+
+// lifecycleHold reports whether retries and escalations of the notification are on hold because it is acknowledged,
+// resolved or snoozed. A snoozed notification is held until the returned time, the others for good.
+func lifecycleHold(
+	slug string,
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) (bool, time.Time) {
+
+	l, err := getNotificationLifecycle(slug, dbClient)
+	if err != nil {
+		lc.Error("Unable to get lifecycle of notification " + slug + ": " + err.Error())
+		return false, time.Time{}
+	}
+
+	switch l.StateAt(timeToMillis(now)) {
+	case notificationsModels.Acknowledged, notificationsModels.Resolved:
+		return true, time.Time{}
+	case notificationsModels.Snoozed:
+		return true, millisToTime(l.SnoozedUntil)
+	default:
+		return false, time.Time{}
+	}
+}
+
+// snoozeUntil returns the end in milliseconds of the snooze requested at the given time, zero when the state is not
+// snoozed.
+func snoozeUntil(state notificationsModels.LifecycleState, request lifecycleRequest, now time.Time) (int64, error) {
+	if state != notificationsModels.Snoozed {
+		return 0, nil
+	}
+	if request.Duration == "" {
+		if request.Until <= timeToMillis(now) {
+			return 0, fmt.Errorf("the snooze must end in the future, set either until or duration")
+		}
+		return request.Until, nil
+	}
+
+	d, err := time.ParseDuration(request.Duration)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid snooze duration %s", request.Duration)
+	}
+	return timeToMillis(now.Add(d)), nil
+}
+
+// changeNotificationLifecycle moves the notification's lifecycle to the state, snoozed until the given time. Failed
+// transmissions of a notification which is open again are retried right away.
+func changeNotificationLifecycle(
+	slug string,
+	state notificationsModels.LifecycleState,
+	request lifecycleRequest,
+	until int64,
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) (notificationsModels.NotificationLifecycle, error) {
+
+	l, err := dbClient.UpdateNotificationLifecycle(slug, func(l *notificationsModels.NotificationLifecycle) error {
+		return l.Transition(state, request.By, timeToMillis(now), until, request.Comment)
+	})
+	if err != nil {
+		return l, err
+	}
+	lc.Info("Notification " + slug + " is " + string(state) + " by " + request.By)
+
+	if state == notificationsModels.Open {
+		transmissions, err := dbClient.GetTransmissionsByNotificationSlug(slug, -1)
+		if err != nil {
+			lc.Error("Unable to get transmissions of reopened notification " + slug + ": " + err.Error())
+		}
+		for _, t := range transmissions {
+			if t.Status != models.Failed {
+				continue
+			}
+			if err = dbClient.AddTransmissionRetry(t.ID, timeToMillis(now)); err != nil {
+				lc.Error("Unable to schedule retry of transmission " + t.ID + ": " + err.Error())
+			}
+		}
+	}
+	return l, nil
+}
+
+// getUnacknowledgedCriticalNotifications returns the open CRITICAL notifications, most recent first, which match any
+// of the categories and any of the labels when given. The index of the unacknowledged notifications is read in
+// batches, leaving out those whose snooze has not ended yet.
+func getUnacknowledgedCriticalNotifications(
+	categories []string,
+	labels []string,
+	limit int,
+	now time.Time,
+	dbClient interfaces.DBClient) ([]models.Notification, error) {
+
+	var result []models.Notification
+	for offset := 0; ; offset += unacknowledgedBatchSize {
+		notifications, err := dbClient.GetUnacknowledgedCriticalNotifications(offset, unacknowledgedBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var candidates []models.Notification
+		var slugs []string
+		for _, n := range notifications {
+			if (len(categories) > 0 && !containsString(categories, string(n.Category))) ||
+				(len(labels) > 0 && !containsAny(labels, n.Labels)) {
+				continue
+			}
+			candidates = append(candidates, n)
+			slugs = append(slugs, n.Slug)
+		}
+
+		lifecycles, err := dbClient.GetNotificationLifecycles(slugs)
+		if err != nil {
+			return nil, err
+		}
+		states := make(map[string]notificationsModels.LifecycleState)
+		for _, l := range lifecycles {
+			states[l.Slug] = l.StateAt(timeToMillis(now))
+		}
+
+		for _, n := range candidates {
+			if state, ok := states[n.Slug]; ok && state != notificationsModels.Open {
+				continue
+			}
+			result = append(result, n)
+			if limit > 0 && len(result) == limit {
+				return result, nil
+			}
+		}
+
+		if len(notifications) < unacknowledgedBatchSize {
+			return result, nil
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values []string, candidates []string) bool {
+	for _, c := range candidates {
+		if containsString(values, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -25,11 +25,12 @@ import (
 
 func distributeAndMark(
 	n models.Notification,
+	throttle interfaces.NotificationThrottle,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
 	config notificationsConfig.ConfigurationStruct) error {
 
-	go distribute(n, lc, dbClient, config)
+	go distribute(n, throttle, lc, dbClient, config)
 
 	err := dbClient.MarkNotificationProcessed(n)
 	if err != nil {
@@ -0,0 +1,180 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/errors"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/operators/notification"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gorilla/mux"
+)
+
+func restGetNotificationLifecycle(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) {
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	slug := mux.Vars(r)[SLUG]
+	if !notificationExists(w, slug, lc, dbClient) {
+		return
+	}
+
+	l, err := getNotificationLifecycle(slug, dbClient)
+	if err != nil {
+		lc.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pkg.Encode(l, w, lc)
+}
+
+func restUpdateNotificationLifecycle(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) {
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	vars := mux.Vars(r)
+	slug := vars[SLUG]
+	state, ok := lifecycleActions[vars[ACTION]]
+	if !ok {
+		http.Error(w, "Unknown lifecycle action "+vars[ACTION], http.StatusBadRequest)
+		return
+	}
+
+	var request lifecycleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		lc.Error("Error decoding lifecycle request: " + err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.By == "" {
+		http.Error(w, "The lifecycle request must name who it is by", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	until, err := snoozeUntil(state, request, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !notificationExists(w, slug, lc, dbClient) {
+		return
+	}
+
+	l, err := changeNotificationLifecycle(slug, state, request, until, now, lc, dbClient)
+	if err != nil {
+		lc.Error(err.Error())
+		switch err.(type) {
+		case notificationsModels.ErrInvalidTransition:
+			http.Error(w, err.Error(), http.StatusConflict)
+		case notificationsModels.ErrInvalidSnooze:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	pkg.Encode(l, w, lc)
+}
+
+func restGetUnacknowledgedNotifications(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	vars := mux.Vars(r)
+	limitNum, err := strconv.Atoi(vars[LIMIT])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		lc.Error("Error converting limit to integer: " + err.Error())
+		return
+	}
+
+	// Check the length
+	if err = checkMaxLimit(limitNum, lc, config); err != nil {
+		http.Error(w, ExceededMaxResultCount, http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var categories, labels []string
+	if vars[CATEGORIES] != "" {
+		categories = splitVars(vars[CATEGORIES])
+	}
+	if vars[LABELS] != "" {
+		labels = splitVars(vars[LABELS])
+	}
+
+	results, err := getUnacknowledgedCriticalNotifications(categories, labels, limitNum, time.Now(), dbClient)
+	if err != nil {
+		lc.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(results) == 0 {
+		http.Error(w, "Notification not found", http.StatusNotFound)
+		lc.Error(db.ErrNotFound.Error())
+		return
+	}
+
+	pkg.Encode(results, w, lc)
+}
+
+// notificationExists writes the error response when the notification with the slug cannot be found.
+func notificationExists(w http.ResponseWriter, slug string, lc logger.LoggingClient, dbClient interfaces.DBClient) bool {
+	_, err := notification.NewSlugExecutor(dbClient, slug).Execute()
+	if err != nil {
+		lc.Error(err.Error())
+		switch err.(type) {
+		case errors.ErrNotificationNotFound:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createLifecycleRequest(action string, body lifecycleRequest) *http.Request {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPut, TestURI, bytes.NewReader(b))
+	return mux.SetURLVars(req, map[string]string{SLUG: "n1", ACTION: action})
+}
+
+// mockLifecycleUpdate makes the database apply the lifecycle updates to the stored lifecycle, or fail with the error.
+func mockLifecycleUpdate(dbClient *mocks.DBClient, stored notificationsModels.NotificationLifecycle, dbErr error) {
+	var updateErr error
+	dbClient.On("UpdateNotificationLifecycle", stored.Slug, mock.Anything).Return(
+		func(_ string, update func(*notificationsModels.NotificationLifecycle) error) notificationsModels.NotificationLifecycle {
+			l := stored
+			updateErr = dbErr
+			if updateErr == nil {
+				updateErr = update(&l)
+			}
+			return l
+		},
+		func(string, func(*notificationsModels.NotificationLifecycle) error) error {
+			return updateErr
+		})
+}
+
+func TestUpdateNotificationLifecycle(t *testing.T) {
+	open := notificationsModels.NewNotificationLifecycle("n1")
+	acknowledged := notificationsModels.NewNotificationLifecycle("n1")
+	_ = acknowledged.Transition(notificationsModels.Acknowledged, "alice", 100, 0, "")
+	past := timeToMillis(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name           string
+		request        *http.Request
+		notificationOk bool
+		lifecycle      notificationsModels.NotificationLifecycle
+		dbErr          error
+		expectedState  notificationsModels.LifecycleState
+		expectedStatus int
+	}{
+		{"acknowledge", createLifecycleRequest(ACKNOWLEDGE, lifecycleRequest{By: "alice"}), true, open, nil, notificationsModels.Acknowledged, http.StatusOK},
+		{"snooze", createLifecycleRequest(SNOOZE, lifecycleRequest{By: "alice", Duration: "30m"}), true, open, nil, notificationsModels.Snoozed, http.StatusOK},
+		{"resolve acknowledged", createLifecycleRequest(RESOLVE, lifecycleRequest{By: "bob"}), true, acknowledged, nil, notificationsModels.Resolved, http.StatusOK},
+		{"snooze acknowledged", createLifecycleRequest(SNOOZE, lifecycleRequest{By: "bob", Duration: "30m"}), true, acknowledged, nil, "", http.StatusConflict},
+		{"invalid snooze duration", createLifecycleRequest(SNOOZE, lifecycleRequest{By: "bob", Duration: "soon"}), true, open, nil, "", http.StatusBadRequest},
+		{"negative snooze duration", createLifecycleRequest(SNOOZE, lifecycleRequest{By: "bob", Duration: "-5m"}), true, open, nil, "", http.StatusBadRequest},
+		{"snooze until past", createLifecycleRequest(SNOOZE, lifecycleRequest{By: "bob", Until: past}), true, open, nil, "", http.StatusBadRequest},
+		{"snooze without end", createLifecycleRequest(SNOOZE, lifecycleRequest{By: "bob"}), true, open, nil, "", http.StatusBadRequest},
+		{"unknown action", createLifecycleRequest("forget", lifecycleRequest{By: "bob"}), true, acknowledged, nil, "", http.StatusBadRequest},
+		{"anonymous", createLifecycleRequest(ACKNOWLEDGE, lifecycleRequest{}), true, acknowledged, nil, "", http.StatusBadRequest},
+		{"unknown notification", createLifecycleRequest(ACKNOWLEDGE, lifecycleRequest{By: "alice"}), false, acknowledged, nil, "", http.StatusNotFound},
+		{"database error", createLifecycleRequest(ACKNOWLEDGE, lifecycleRequest{By: "alice"}), true, open, fmt.Errorf("connection refused"), "", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClient := &mocks.DBClient{}
+			if tt.notificationOk {
+				dbClient.On("GetNotificationBySlug", "n1").Return(contract.Notification{Slug: "n1"}, nil)
+			} else {
+				dbClient.On("GetNotificationBySlug", "n1").Return(contract.Notification{}, db.ErrNotFound)
+			}
+			mockLifecycleUpdate(dbClient, tt.lifecycle, tt.dbErr)
+
+			rr := httptest.NewRecorder()
+			restUpdateNotificationLifecycle(rr, tt.request, logger.NewMockClient(), dbClient)
+
+			require.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var l notificationsModels.NotificationLifecycle
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &l))
+			assert.Equal(t, tt.expectedState, l.State)
+			dbClient.AssertCalled(t, "UpdateNotificationLifecycle", "n1", mock.Anything)
+		})
+	}
+}
+
+func TestReopenNotificationRetriesFailedTransmissions(t *testing.T) {
+	resolved := notificationsModels.NewNotificationLifecycle("n1")
+	_ = resolved.Transition(notificationsModels.Resolved, "alice", 100, 0, "")
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetNotificationBySlug", "n1").Return(contract.Notification{Slug: "n1"}, nil)
+	mockLifecycleUpdate(dbClient, resolved, nil)
+	dbClient.On("GetTransmissionsByNotificationSlug", "n1", -1).Return([]contract.Transmission{
+		{ID: "t1", Status: contract.Failed},
+		{ID: "t2", Status: contract.Sent},
+	}, nil)
+	dbClient.On("AddTransmissionRetry", "t1", mock.Anything).Return(nil)
+
+	rr := httptest.NewRecorder()
+	restUpdateNotificationLifecycle(rr, createLifecycleRequest(REOPEN, lifecycleRequest{By: "bob"}), logger.NewMockClient(), dbClient)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	dbClient.AssertExpectations(t)
+}
+
+func TestGetUnacknowledgedNotifications(t *testing.T) {
+	now := time.Now()
+	notification := func(slug string, category contract.NotificationsCategory, labels []string, created int64) contract.Notification {
+		return contract.Notification{
+			Slug:       slug,
+			Sender:     "sensor",
+			Category:   category,
+			Severity:   contract.Critical,
+			Content:    "alarm",
+			Labels:     labels,
+			Timestamps: contract.Timestamps{Created: created},
+		}
+	}
+	notifications := []contract.Notification{
+		notification("open", contract.Security, []string{"door"}, 4),
+		notification("snoozed", contract.Security, []string{"door"}, 3),
+		notification("snooze-ended", contract.Hwhealth, []string{"fan"}, 2),
+	}
+	lifecycles := []notificationsModels.NotificationLifecycle{
+		{Slug: "snoozed", State: notificationsModels.Snoozed, SnoozedUntil: timeToMillis(now.Add(time.Hour))},
+		{Slug: "snooze-ended", State: notificationsModels.Snoozed, SnoozedUntil: timeToMillis(now.Add(-time.Minute))},
+	}
+	config := notificationsConfig.ConfigurationStruct{Service: bootstrapConfig.ServiceInfo{MaxResultCount: 10}}
+
+	tests := []struct {
+		name           string
+		vars           map[string]string
+		expectedSlugs  []string
+		expectedStatus int
+	}{
+		{"all", map[string]string{LIMIT: "10"}, []string{"open", "snooze-ended"}, http.StatusOK},
+		{"limited", map[string]string{LIMIT: "1"}, []string{"open"}, http.StatusOK},
+		{"by category", map[string]string{CATEGORIES: "HW_HEALTH", LIMIT: "10"}, []string{"snooze-ended"}, http.StatusOK},
+		{"by labels", map[string]string{LABELS: "door,window", LIMIT: "10"}, []string{"open"}, http.StatusOK},
+		{"none", map[string]string{LABELS: "window", LIMIT: "10"}, nil, http.StatusNotFound},
+		{"exceeds limit", map[string]string{LIMIT: "11"}, nil, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClient := &mocks.DBClient{}
+			dbClient.On("GetUnacknowledgedCriticalNotifications", 0, unacknowledgedBatchSize).Return(notifications, nil)
+			dbClient.On("GetNotificationLifecycles", mock.Anything).Return(lifecycles, nil)
+
+			rr := httptest.NewRecorder()
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, TestURI, nil), tt.vars)
+			restGetUnacknowledgedNotifications(rr, req, logger.NewMockClient(), dbClient, config)
+
+			require.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var results []contract.Notification
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &results))
+			var slugs []string
+			for _, n := range results {
+				slugs = append(slugs, n.Slug)
+			}
+			assert.Equal(t, tt.expectedSlugs, slugs)
+		})
+	}
+}
+
+func TestGetUnacknowledgedNotificationsReadsBatches(t *testing.T) {
+	now := time.Now()
+	var snoozed []contract.Notification
+	var lifecycles []notificationsModels.NotificationLifecycle
+	for i := 0; i < unacknowledgedBatchSize; i++ {
+		slug := fmt.Sprintf("snoozed-%d", i)
+		snoozed = append(snoozed, contract.Notification{Slug: slug, Severity: contract.Critical})
+		lifecycles = append(lifecycles, notificationsModels.NotificationLifecycle{
+			Slug:         slug,
+			State:        notificationsModels.Snoozed,
+			SnoozedUntil: timeToMillis(now.Add(time.Hour)),
+		})
+	}
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetUnacknowledgedCriticalNotifications", 0, unacknowledgedBatchSize).Return(snoozed, nil)
+	dbClient.On("GetUnacknowledgedCriticalNotifications", unacknowledgedBatchSize, unacknowledgedBatchSize).
+		Return([]contract.Notification{{Slug: "open", Severity: contract.Critical}}, nil)
+	dbClient.On("GetNotificationLifecycles", mock.Anything).Return(lifecycles, nil)
+
+	results, err := getUnacknowledgedCriticalNotifications(nil, nil, 10, now, dbClient)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "open", results[0].Slug)
+}
@@ -39,6 +39,7 @@ func notificationHandler(
 	r *http.Request,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
+	throttle interfaces.NotificationThrottle,
 	config notificationsConfig.ConfigurationStruct) {
 
 	if r.Body != nil {
@@ -72,7 +73,7 @@ func notificationHandler(
 		return
 	}
 
-	err = distributeAndMark(n, lc, dbClient, config)
+	err = distributeAndMark(n, throttle, lc, dbClient, config)
 	if err != nil {
 		return
 	}
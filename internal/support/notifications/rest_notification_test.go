@@ -1005,6 +1005,7 @@ func TestNotificationHandler(t *testing.T) {
 				tt.request,
 				logger.NewMockClient(),
 				tt.dbMock,
+				NewNotificationThrottle(),
 				notificationsConfig.ConfigurationStruct{Service: bootstrapConfig.ServiceInfo{MaxResultCount: 5}})
 			response := rr.Result()
 			if response.StatusCode != tt.expectedStatus {
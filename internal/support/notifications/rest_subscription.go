@@ -17,11 +17,15 @@ package notifications
 
 import (
 	"encoding/json"
+	goErrors "errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/errors"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/operators/subscription"
@@ -395,3 +399,129 @@ func subscriptionsByReceiverHandler(
 	pkg.Encode(s, w, lc)
 
 }
+
+func restGetSubscriptionSettings(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) {
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	s, ok := subscriptionExists(w, mux.Vars(r)[SLUG], lc, dbClient)
+	if !ok {
+		return
+	}
+
+	settings, err := dbClient.GetSubscriptionSettings(s.ID)
+	if err != nil && err != db.ErrNotFound {
+		lc.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pkg.Encode(settings, w, lc)
+}
+
+func restUpdateSubscriptionSettings(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) {
+
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	var settings notificationsModels.SubscriptionSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		lc.Error("Error decoding subscription settings: " + err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateSubscriptionSettings(settings); err != nil {
+		lc.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	slug := mux.Vars(r)[SLUG]
+	s, ok := subscriptionExists(w, slug, lc, dbClient)
+	if !ok {
+		return
+	}
+
+	lc.Info("Updating settings of subscription by slug: " + slug)
+	if err := dbClient.UpdateSubscriptionSettings(s.ID, settings); err != nil {
+		lc.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	pkg.Encode(settings, w, lc)
+}
+
+// validateSubscriptionSettings rejects the settings which would break the messages sent to the subscription.
+func validateSubscriptionSettings(settings notificationsModels.SubscriptionSettings) error {
+	if strings.ContainsAny(settings.EmailSender, "\n\r") {
+		return goErrors.New("The email sender contains invalid CRLF characters")
+	}
+	if strings.ContainsAny(settings.EmailSubject, "\n\r") {
+		return goErrors.New("The email subject contains invalid CRLF characters")
+	}
+	if settings.MinSeverity != "" {
+		if _, ok := severityRanks[models.NotificationsSeverity(strings.ToUpper(settings.MinSeverity))]; !ok {
+			return goErrors.New("Invalid minimum severity " + settings.MinSeverity)
+		}
+	}
+	if settings.TimeZone != "" {
+		if _, err := time.LoadLocation(settings.TimeZone); err != nil {
+			return goErrors.New("Invalid time zone " + settings.TimeZone)
+		}
+	}
+	for _, w := range settings.DeliveryWindows {
+		if _, err := parseDeliveryWindow(w); err != nil {
+			return goErrors.New("Invalid delivery window: " + err.Error())
+		}
+	}
+	switch strings.ToLower(settings.QuietHours) {
+	case "", QUIETHOURSDELAY, QUIETHOURSDROP, QUIETHOURSCRITICAL:
+	default:
+		return goErrors.New("Invalid quiet hours " + settings.QuietHours)
+	}
+	if settings.RateLimit < 0 {
+		return goErrors.New("Invalid rate limit " + strconv.Itoa(settings.RateLimit))
+	}
+	for _, duration := range []string{settings.RateLimitPeriod, settings.DigestInterval} {
+		if duration == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(duration); err != nil || d <= 0 {
+			return goErrors.New("Invalid duration " + duration)
+		}
+	}
+	return nil
+}
+
+// subscriptionExists writes the error response when the subscription with the slug cannot be found.
+func subscriptionExists(
+	w http.ResponseWriter,
+	slug string,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) (models.Subscription, bool) {
+
+	s, err := subscription.NewSlugExecutor(dbClient, slug).Execute()
+	if err != nil {
+		lc.Error(err.Error())
+		switch err.(type) {
+		case errors.ErrSubscriptionNotFound:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return s, false
+	}
+	return s, true
+}
@@ -25,6 +25,8 @@ import (
 	"testing"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
 	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
 
@@ -32,6 +34,9 @@ import (
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 
 	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var TestSubscriptionURI = "/subscription"
@@ -565,3 +570,128 @@ func createMockSubscriptionLoaderUpdateGetErr() interfaces.DBClient {
 	myMock.On("UpdateSubscription", subscriptionForAdd).Return(errors.New("test error"))
 	return &myMock
 }
+
+func createSubscriptionSettingsRequest(body interface{}) *http.Request {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPut, TestURI, bytes.NewReader(b))
+	return mux.SetURLVars(req, map[string]string{SLUG: "ops"})
+}
+
+func TestGetSubscriptionSettings(t *testing.T) {
+	settings := notificationsModels.SubscriptionSettings{Template: "alert"}
+	tests := []struct {
+		name             string
+		settings         notificationsModels.SubscriptionSettings
+		settingsErr      error
+		expectedSettings notificationsModels.SubscriptionSettings
+		expectedStatus   int
+	}{
+		{"stored settings", settings, nil, settings, http.StatusOK},
+		{"no settings", notificationsModels.SubscriptionSettings{}, db.ErrNotFound, notificationsModels.SubscriptionSettings{}, http.StatusOK},
+		{"database error", notificationsModels.SubscriptionSettings{}, errors.New("test error"), notificationsModels.SubscriptionSettings{}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClient := &mocks.DBClient{}
+			dbClient.On("GetSubscriptionBySlug", "ops").Return(contract.Subscription{ID: "s1", Slug: "ops"}, nil)
+			dbClient.On("GetSubscriptionSettings", "s1").Return(tt.settings, tt.settingsErr)
+
+			rr := httptest.NewRecorder()
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, TestURI, nil), map[string]string{SLUG: "ops"})
+			restGetSubscriptionSettings(rr, req, logger.NewMockClient(), dbClient)
+
+			require.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var actual notificationsModels.SubscriptionSettings
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &actual))
+			assert.Equal(t, tt.expectedSettings, actual)
+		})
+	}
+}
+
+func TestUpdateSubscriptionSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           interface{}
+		subscriptionOk bool
+		expectedStatus int
+	}{
+		{"valid", notificationsModels.SubscriptionSettings{Template: "alert", EmailSubject: "Plant alert"}, true, http.StatusOK},
+		{"subject with CRLF", notificationsModels.SubscriptionSettings{EmailSubject: "alert\r\nBcc: x@example.com"}, true, http.StatusBadRequest},
+		{"sender with CRLF", notificationsModels.SubscriptionSettings{EmailSender: "a@example.com\n"}, true, http.StatusBadRequest},
+		{"delivery settings", notificationsModels.SubscriptionSettings{MinSeverity: "CRITICAL", TimeZone: "Europe/Berlin", DeliveryWindows: []notificationsModels.DeliveryWindow{{Days: []string{"Mon"}, Start: "08:00", End: "18:00"}}, QuietHours: "drop"}, true, http.StatusOK},
+		{"invalid min severity", notificationsModels.SubscriptionSettings{MinSeverity: "MAJOR"}, true, http.StatusBadRequest},
+		{"invalid time zone", notificationsModels.SubscriptionSettings{TimeZone: "Mars/Olympus"}, true, http.StatusBadRequest},
+		{"invalid delivery window", notificationsModels.SubscriptionSettings{DeliveryWindows: []notificationsModels.DeliveryWindow{{Start: "25:00"}}}, true, http.StatusBadRequest},
+		{"invalid quiet hours", notificationsModels.SubscriptionSettings{QuietHours: "snooze"}, true, http.StatusBadRequest},
+		{"throttle settings", notificationsModels.SubscriptionSettings{RetryPolicy: "aggressive", RateLimit: 10, RateLimitPeriod: "1h", DigestInterval: "15m"}, true, http.StatusOK},
+		{"negative rate limit", notificationsModels.SubscriptionSettings{RateLimit: -1}, true, http.StatusBadRequest},
+		{"invalid rate limit period", notificationsModels.SubscriptionSettings{RateLimit: 10, RateLimitPeriod: "hourly"}, true, http.StatusBadRequest},
+		{"invalid digest interval", notificationsModels.SubscriptionSettings{DigestInterval: "-15m"}, true, http.StatusBadRequest},
+		{"invalid body", "settings", true, http.StatusBadRequest},
+		{"unknown subscription", notificationsModels.SubscriptionSettings{Template: "alert"}, false, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClient := &mocks.DBClient{}
+			if tt.subscriptionOk {
+				dbClient.On("GetSubscriptionBySlug", "ops").Return(contract.Subscription{ID: "s1", Slug: "ops"}, nil)
+			} else {
+				dbClient.On("GetSubscriptionBySlug", "ops").Return(contract.Subscription{}, db.ErrNotFound)
+			}
+			dbClient.On("UpdateSubscriptionSettings", "s1", mock.Anything).Return(nil)
+
+			rr := httptest.NewRecorder()
+			restUpdateSubscriptionSettings(rr, createSubscriptionSettingsRequest(tt.body), logger.NewMockClient(), dbClient)
+
+			require.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedStatus != http.StatusOK {
+				dbClient.AssertNotCalled(t, "UpdateSubscriptionSettings", mock.Anything, mock.Anything)
+				return
+			}
+			dbClient.AssertCalled(t, "UpdateSubscriptionSettings", "s1", tt.body)
+		})
+	}
+}
+
+func TestLoadConfiguredSubscriptionSettings(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.SubscriptionSettings = map[string]notificationsConfig.SubscriptionSettingsInfo{
+		"new": {
+			Template:        "alert",
+			EmailSubject:    "Plant alert",
+			TimeZone:        "Europe/Berlin",
+			DeliveryWindows: []notificationsConfig.DeliveryWindowInfo{{Days: []string{"Mon"}, Start: "08:00", End: "18:00"}},
+			QuietHours:      "critical",
+			RetryPolicy:     "aggressive",
+			DigestInterval:  "15m",
+		},
+		"stored":  {Template: "alert"},
+		"invalid": {EmailSubject: "alert\r\nBcc: x@example.com"},
+		"unknown": {Template: "alert"},
+	}
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetSubscriptionBySlug", "new").Return(contract.Subscription{ID: "s1", Slug: "new"}, nil)
+	dbClient.On("GetSubscriptionBySlug", "stored").Return(contract.Subscription{ID: "s2", Slug: "stored"}, nil)
+	dbClient.On("GetSubscriptionBySlug", "invalid").Return(contract.Subscription{ID: "s3", Slug: "invalid"}, nil)
+	dbClient.On("GetSubscriptionBySlug", "unknown").Return(contract.Subscription{}, db.ErrNotFound)
+	dbClient.On("GetSubscriptionSettings", "s1").Return(notificationsModels.SubscriptionSettings{}, db.ErrNotFound)
+	dbClient.On("GetSubscriptionSettings", "s2").Return(notificationsModels.SubscriptionSettings{Template: "other"}, nil)
+	dbClient.On("GetSubscriptionSettings", "s3").Return(notificationsModels.SubscriptionSettings{}, db.ErrNotFound)
+	dbClient.On("UpdateSubscriptionSettings", mock.Anything, mock.Anything).Return(nil)
+
+	loadConfiguredSubscriptionSettings(config, logger.NewMockClient(), dbClient)
+
+	dbClient.AssertCalled(t, "UpdateSubscriptionSettings", "s1", notificationsModels.SubscriptionSettings{
+		Template:        "alert",
+		EmailSubject:    "Plant alert",
+		TimeZone:        "Europe/Berlin",
+		DeliveryWindows: []notificationsModels.DeliveryWindow{{Days: []string{"Mon"}, Start: "08:00", End: "18:00"}},
+		QuietHours:      "critical",
+		RetryPolicy:     "aggressive",
+		DigestInterval:  "15m",
+	})
+	dbClient.AssertNumberOfCalls(t, "UpdateSubscriptionSettings", 1)
+}
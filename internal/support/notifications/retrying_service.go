@@ -0,0 +1,302 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const (
+	retryPollInterval    = time.Second
+	retryBatchSize       = 100
+	defaultRetryInterval = 5 * time.Second
+)
+
+type retryPolicy struct {
+	severities      []string
+	maxRetries      int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+	jitter          float64
+}
+
+type escalationLevel struct {
+	subscription  string
+	afterFailures int
+	afterDuration time.Duration
+}
+
+// retryPolicyFor returns the retry policy of the subscription, falling back to the default policy and, without one,
+// to retrying CRITICAL notifications every 5 seconds up to ResendLimit times.
+func retryPolicyFor(
+	subscriptionSlug string,
+	settings notificationsModels.SubscriptionSettings,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) retryPolicy {
+
+	for _, name := range policyNames(settings.RetryPolicy) {
+		info, ok := config.Writable.RetryPolicies[name]
+		if !ok {
+			if name != DEFAULTPOLICY {
+				lc.Error("Retry policy " + name + " of subscription " + subscriptionSlug + " is not configured")
+			}
+			continue
+		}
+
+		policy := retryPolicy{
+			severities:      info.Severities,
+			maxRetries:      info.MaxRetries,
+			initialInterval: parsePolicyDuration(info.InitialInterval, defaultRetryInterval, "retry policy "+name, lc),
+			maxInterval:     parsePolicyDuration(info.MaxInterval, 0, "retry policy "+name, lc),
+			multiplier:      info.Multiplier,
+			jitter:          math.Min(math.Max(info.Jitter, 0), 1),
+		}
+		if len(policy.severities) == 0 {
+			policy.severities = []string{models.Critical}
+		}
+		return policy
+	}
+
+	return retryPolicy{
+		severities:      []string{models.Critical},
+		maxRetries:      config.Writable.ResendLimit,
+		initialInterval: defaultRetryInterval,
+	}
+}
+
+// escalationLevelsFor returns the escalation levels of the subscription, falling back to the default policy and,
+// without one, to escalating to the ESCALATION subscription once the retries of the retry policy are exhausted.
+func escalationLevelsFor(
+	subscriptionSlug string,
+	settings notificationsModels.SubscriptionSettings,
+	policy retryPolicy,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) []escalationLevel {
+
+	for _, name := range policyNames(settings.EscalationPolicy) {
+		info, ok := config.Writable.EscalationPolicies[name]
+		if !ok {
+			if name != DEFAULTPOLICY {
+				lc.Error("Escalation policy " + name + " of subscription " + subscriptionSlug + " is not configured")
+			}
+			continue
+		}
+
+		var levels []escalationLevel
+		for _, level := range info.Levels {
+			levels = append(levels, escalationLevel{
+				subscription:  level.Subscription,
+				afterFailures: level.AfterFailures,
+				afterDuration: parsePolicyDuration(level.AfterDuration, 0, "escalation policy "+name, lc),
+			})
+		}
+		return levels
+	}
+
+	return []escalationLevel{{subscription: ESCALATIONSUBSCRIPTIONSLUG, afterFailures: policy.maxRetries + 1}}
+}
+
+func policyNames(name string) []string {
+	if name == "" || name == DEFAULTPOLICY {
+		return []string{DEFAULTPOLICY}
+	}
+	return []string{name, DEFAULTPOLICY}
+}
+
+func parsePolicyDuration(value string, fallback time.Duration, setting string, lc logger.LoggingClient) time.Duration {
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		lc.Error("Invalid duration " + value + " in " + setting + ", using " + fallback.String())
+		return fallback
+	}
+	return d
+}
+
+func (p retryPolicy) handles(severity models.NotificationsSeverity) bool {
+	for _, s := range p.severities {
+		if s == string(severity) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the duration between the last and the next attempt of the transmission. The jitter is derived from
+// the transmission and its resend count, so the schedule of a transmission is the same after a restart.
+func (p retryPolicy) backoff(t models.Transmission) time.Duration {
+	d := float64(p.initialInterval)
+	if p.multiplier > 1 {
+		d *= math.Pow(p.multiplier, float64(t.ResendCount))
+	}
+	if p.maxInterval > 0 {
+		d = math.Min(d, float64(p.maxInterval))
+	}
+	if p.jitter > 0 {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(t.ID + ":" + strconv.Itoa(t.ResendCount)))
+		u := float64(h.Sum64()%10000) / 10000
+		d += d * p.jitter * (2*u - 1)
+	}
+	// retries are scheduled with millisecond precision
+	return time.Duration(d).Truncate(time.Millisecond)
+}
+
+// lastAttempt returns the time of the last transmission attempt.
+func lastAttempt(t models.Transmission) time.Time {
+	sent := t.Created
+	if len(t.Records) > 0 {
+		sent = t.Records[len(t.Records)-1].Sent
+	}
+	return time.Unix(0, sent*int64(time.Millisecond))
+}
+
+// escalateDueLevels escalates the levels whose thresholds the transmission has reached and which have not been
+// escalated for the notification yet. It reports whether any level is escalated and the time the next pending level
+// becomes due by duration, zero if there is none.
+func escalateDueLevels(
+	t models.Transmission,
+	levels []escalationLevel,
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) (bool, time.Time) {
+
+	var escalated bool
+	var next time.Time
+	failures := t.ResendCount + 1
+	firstAttempt := time.Unix(0, t.Created*int64(time.Millisecond))
+
+	for i, level := range levels {
+		slug := escalationSlug(i, t.Notification.Slug)
+		if _, err := dbClient.GetNotificationBySlug(slug); err == nil {
+			escalated = true
+			continue
+		}
+
+		due := level.afterFailures > 0 && failures >= level.afterFailures
+		if !due && level.afterDuration > 0 {
+			at := firstAttempt.Add(level.afterDuration)
+			if !now.Before(at) {
+				due = true
+			} else if next.IsZero() || at.Before(next) {
+				next = at
+			}
+		}
+
+		if due && escalate(t, level.subscription, slug, lc, dbClient, config) {
+			escalated = true
+		}
+	}
+	return escalated, next
+}
+
+// startRetryProcessor resends and escalates failed transmissions as their scheduled retries become due, until the
+// context is done. Retries are kept in the database so they survive a restart of the service.
+func startRetryProcessor(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(retryPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				processDueRetries(
+					now,
+					bootstrapContainer.LoggingClientFrom(dic.Get),
+					distributionDBClientFrom(dic.Get),
+					*notificationsContainer.ConfigurationFrom(dic.Get))
+			}
+		}
+	}()
+}
+
+func processDueRetries(
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	ids, err := dbClient.GetTransmissionRetries(timeToMillis(now), retryBatchSize)
+	if err != nil {
+		lc.Error("Unable to get due transmission retries: " + err.Error())
+		return
+	}
+
+	for _, id := range ids {
+		// only the caller removing the retry processes it
+		claimed, err := dbClient.DeleteTransmissionRetry(id)
+		if err != nil || !claimed {
+			continue
+		}
+		t, err := dbClient.GetTransmissionById(id)
+		if err != nil {
+			lc.Warn("Dropping retry of transmission " + id + ": " + err.Error())
+			continue
+		}
+		go retryTransmission(t, now, lc, dbClient, config)
+	}
+}
+
+// retryTransmission resends the transmission when its resend is due, otherwise it only applies the escalation
+// policy.
+func retryTransmission(
+	t models.Transmission,
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	if t.Status != models.Failed {
+		return
+	}
+
+	s, _ := subscriptionForTransmission(t, dbClient)
+	policy := retryPolicyFor(s.Slug, subscriptionSettings(s, lc, dbClient), lc, config)
+	if hold, _ := lifecycleHold(t.Notification.Slug, now, lc, dbClient); hold {
+		handleFailedTransmission(t, s, lc, dbClient, config)
+		return
+	}
+	if t.ResendCount < policy.maxRetries && !now.Before(lastAttempt(t).Add(policy.backoff(t))) {
+		resend(t, lc, dbClient, config)
+		return
+	}
+	handleFailedTransmission(t, s, lc, dbClient, config)
+}
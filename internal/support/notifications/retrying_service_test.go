@@ -0,0 +1,218 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func millis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func newFailedTransmission(severity models.NotificationsSeverity, resendCount int, created time.Time) models.Transmission {
+	return models.Transmission{
+		ID:           "t1",
+		Timestamps:   models.Timestamps{Created: millis(created)},
+		Notification: models.Notification{Slug: "n1", Severity: severity},
+		Receiver:     "ops",
+		Status:       models.Failed,
+		ResendCount:  resendCount,
+		Records:      []models.TransmissionRecord{{Status: models.Failed, Sent: millis(created)}},
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := retryPolicy{initialInterval: time.Second, maxInterval: 5 * time.Second, multiplier: 2}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for count, d := range expected {
+		assert.Equal(t, d, policy.backoff(models.Transmission{ID: "t1", ResendCount: count}))
+	}
+
+	policy.jitter = 0.5
+	tr := models.Transmission{ID: "t1", ResendCount: 1}
+	d := policy.backoff(tr)
+	assert.True(t, d >= time.Second && d <= 3*time.Second, "jittered backoff %s out of range", d)
+	assert.Equal(t, d, policy.backoff(tr), "jitter must be stable for a transmission")
+}
+
+func TestRetryPolicyFor(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.ResendLimit = 2
+	lc := logger.NewMockClient()
+
+	settings := notificationsModels.SubscriptionSettings{}
+	policy := retryPolicyFor("sub", settings, lc, config)
+	assert.Equal(t, retryPolicy{severities: []string{models.Critical}, maxRetries: 2, initialInterval: 5 * time.Second}, policy)
+	assert.Equal(t, []escalationLevel{{subscription: ESCALATIONSUBSCRIPTIONSLUG, afterFailures: 3}}, escalationLevelsFor("sub", settings, policy, lc, config))
+
+	config.Writable.RetryPolicies = map[string]notificationsConfig.RetryPolicyInfo{
+		DEFAULTPOLICY: {MaxRetries: 1},
+		"aggressive":  {Severities: []string{models.Critical, models.Normal}, MaxRetries: 10, InitialInterval: "1s", MaxInterval: "bad"},
+	}
+
+	policy = retryPolicyFor("sub", notificationsModels.SubscriptionSettings{RetryPolicy: "aggressive"}, lc, config)
+	assert.Equal(t, 10, policy.maxRetries)
+	assert.Equal(t, time.Second, policy.initialInterval)
+	assert.Equal(t, time.Duration(0), policy.maxInterval)
+	assert.True(t, policy.handles(models.Normal))
+
+	// an unknown policy falls back to the default one
+	policy = retryPolicyFor("missing", notificationsModels.SubscriptionSettings{RetryPolicy: "unknown"}, lc, config)
+	assert.Equal(t, 1, policy.maxRetries)
+	assert.False(t, policy.handles(models.Normal))
+}
+
+func TestHandleFailedTransmissionSchedulesRetry(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.ResendLimit = 2
+	created := time.Now().Add(-time.Second)
+	tr := newFailedTransmission(models.Critical, 0, created)
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetNotificationLifecycle", "n1").Return(notificationsModels.NotificationLifecycle{}, db.ErrNotFound)
+	dbClient.On("GetNotificationBySlug", "escalated-n1").Return(models.Notification{}, db.ErrNotFound)
+	dbClient.On("AddTransmissionRetry", "t1", millis(created.Add(defaultRetryInterval))).Return(nil)
+
+	handleFailedTransmission(tr, models.Subscription{Slug: "sub"}, logger.NewMockClient(), dbClient, config)
+
+	dbClient.AssertExpectations(t)
+}
+
+func TestHandleFailedTransmissionEscalatesWhenRetriesAreExhausted(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.ResendLimit = 2
+	tr := newFailedTransmission(models.Critical, 2, time.Now())
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetNotificationLifecycle", "n1").Return(notificationsModels.NotificationLifecycle{}, db.ErrNotFound)
+	dbClient.On("GetNotificationBySlug", "escalated-n1").Return(models.Notification{}, db.ErrNotFound)
+	dbClient.On("GetSubscriptionBySlug", ESCALATIONSUBSCRIPTIONSLUG).Return(models.Subscription{Slug: ESCALATIONSUBSCRIPTIONSLUG}, nil)
+	dbClient.On("AddNotification", mock.MatchedBy(func(n models.Notification) bool {
+		return n.Slug == "escalated-n1" && n.Status == models.Escalated
+	})).Return("id", nil)
+	dbClient.On("UpdateTransmission", mock.MatchedBy(func(t models.Transmission) bool {
+		return t.ID == "t1" && t.Status == models.Trxescalated
+	})).Return(nil)
+
+	handleFailedTransmission(tr, models.Subscription{Slug: "sub"}, logger.NewMockClient(), dbClient, config)
+
+	dbClient.AssertExpectations(t)
+}
+
+func TestHandleFailedTransmissionEscalationChain(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.RetryPolicies = map[string]notificationsConfig.RetryPolicyInfo{
+		DEFAULTPOLICY: {MaxRetries: 5, InitialInterval: "1m"},
+	}
+	config.Writable.EscalationPolicies = map[string]notificationsConfig.EscalationPolicyInfo{
+		"chain": {Levels: []notificationsConfig.EscalationLevelInfo{
+			{Subscription: "team-a", AfterFailures: 2},
+			{Subscription: "team-b", AfterDuration: "30m"},
+			{Subscription: "team-c", AfterDuration: "2h"},
+		}},
+	}
+	created := time.Now().Add(-time.Hour)
+	tr := newFailedTransmission(models.Critical, 1, created)
+	tr.Records = append(tr.Records, models.TransmissionRecord{Status: models.Failed, Sent: millis(time.Now())})
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetSubscriptionSettings", "s1").Return(notificationsModels.SubscriptionSettings{EscalationPolicy: "chain"}, nil)
+	dbClient.On("GetNotificationLifecycle", "n1").Return(notificationsModels.NotificationLifecycle{}, db.ErrNotFound)
+	// team-a was escalated earlier, team-b is due by duration, team-c is pending
+	dbClient.On("GetNotificationBySlug", "escalated-n1").Return(models.Notification{Slug: "escalated-n1"}, nil)
+	dbClient.On("GetNotificationBySlug", "escalated-2-n1").Return(models.Notification{}, db.ErrNotFound)
+	dbClient.On("GetNotificationBySlug", "escalated-3-n1").Return(models.Notification{}, db.ErrNotFound)
+	dbClient.On("GetSubscriptionBySlug", "team-b").Return(models.Subscription{Slug: "team-b"}, nil)
+	dbClient.On("AddNotification", mock.MatchedBy(func(n models.Notification) bool {
+		return n.Slug == "escalated-2-n1"
+	})).Return("id", nil)
+	dbClient.On("AddTransmissionRetry", "t1", millis(lastAttempt(tr).Add(time.Minute))).Return(nil)
+
+	handleFailedTransmission(tr, models.Subscription{ID: "s1", Slug: "sub"}, logger.NewMockClient(), dbClient, config)
+
+	dbClient.AssertExpectations(t)
+}
+
+func TestHandleFailedTransmissionRespectsLifecycle(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.ResendLimit = 2
+	tr := newFailedTransmission(models.Critical, 2, time.Now())
+	snoozedUntil := millis(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name      string
+		lifecycle notificationsModels.NotificationLifecycle
+	}{
+		{"acknowledged", notificationsModels.NotificationLifecycle{Slug: "n1", State: notificationsModels.Acknowledged}},
+		{"resolved", notificationsModels.NotificationLifecycle{Slug: "n1", State: notificationsModels.Resolved}},
+		{"snoozed", notificationsModels.NotificationLifecycle{Slug: "n1", State: notificationsModels.Snoozed, SnoozedUntil: snoozedUntil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbClient := &mocks.DBClient{}
+			dbClient.On("GetNotificationLifecycle", "n1").Return(tt.lifecycle, nil)
+			if tt.lifecycle.State == notificationsModels.Snoozed {
+				dbClient.On("AddTransmissionRetry", "t1", snoozedUntil).Return(nil)
+			}
+
+			// neither escalated nor retried, although the retries are exhausted
+			handleFailedTransmission(tr, models.Subscription{Slug: "sub"}, logger.NewMockClient(), dbClient, config)
+
+			dbClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleFailedTransmissionIgnoresUnhandledSeverity(t *testing.T) {
+	dbClient := &mocks.DBClient{}
+
+	handleFailedTransmission(
+		newFailedTransmission(models.Normal, 0, time.Now()),
+		models.Subscription{Slug: "sub"},
+		logger.NewMockClient(),
+		dbClient,
+		notificationsConfig.ConfigurationStruct{})
+
+	dbClient.AssertExpectations(t)
+}
+
+func TestProcessDueRetriesClaimsRetries(t *testing.T) {
+	now := time.Now()
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetTransmissionRetries", millis(now), retryBatchSize).Return([]string{"t1", "t2", "t3"}, nil)
+	dbClient.On("DeleteTransmissionRetry", "t1").Return(true, nil)
+	dbClient.On("DeleteTransmissionRetry", "t2").Return(false, nil)
+	dbClient.On("DeleteTransmissionRetry", "t3").Return(true, nil)
+	dbClient.On("GetTransmissionById", "t1").Return(models.Transmission{ID: "t1", Status: models.Sent}, nil)
+	dbClient.On("GetTransmissionById", "t3").Return(models.Transmission{}, errors.New("not found"))
+
+	processDueRetries(now, logger.NewMockClient(), dbClient, notificationsConfig.ConfigurationStruct{})
+
+	dbClient.AssertExpectations(t)
+}
@@ -68,7 +68,8 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 				w,
 				r,
 				bootstrapContainer.LoggingClientFrom(dic.Get),
-				container.DBClientFrom(dic.Get),
+				distributionDBClientFrom(dic.Get),
+				notificationsContainer.ThrottleFrom(dic.Get),
 				*notificationsContainer.ConfigurationFrom(dic.Get))
 		}).Methods(http.MethodPost)
 	b.HandleFunc(
@@ -177,6 +178,42 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 				*notificationsContainer.ConfigurationFrom(dic.Get))
 		}).Methods(http.MethodGet)
 
+	// Notification lifecycle
+	b.HandleFunc(
+		"/"+NOTIFICATION+"/"+SLUG+"/{"+SLUG+"}/"+LIFECYCLE,
+		func(w http.ResponseWriter, r *http.Request) {
+			restGetNotificationLifecycle(
+				w,
+				r,
+				bootstrapContainer.LoggingClientFrom(dic.Get),
+				distributionDBClientFrom(dic.Get))
+		}).Methods(http.MethodGet)
+	b.HandleFunc(
+		"/"+NOTIFICATION+"/"+SLUG+"/{"+SLUG+"}/{"+ACTION+":"+ACKNOWLEDGE+"|"+RESOLVE+"|"+SNOOZE+"|"+REOPEN+"}",
+		func(w http.ResponseWriter, r *http.Request) {
+			restUpdateNotificationLifecycle(
+				w,
+				r,
+				bootstrapContainer.LoggingClientFrom(dic.Get),
+				distributionDBClientFrom(dic.Get))
+		}).Methods(http.MethodPut)
+	for _, path := range []string{
+		"/" + NOTIFICATION + "/" + UNACKNOWLEDGED + "/{" + LIMIT + ":[0-9]+}",
+		"/" + NOTIFICATION + "/" + UNACKNOWLEDGED + "/" + CATEGORIES + "/{" + CATEGORIES + "}/{" + LIMIT + ":[0-9]+}",
+		"/" + NOTIFICATION + "/" + UNACKNOWLEDGED + "/" + LABELS + "/{" + LABELS + "}/{" + LIMIT + ":[0-9]+}",
+	} {
+		b.HandleFunc(
+			path,
+			func(w http.ResponseWriter, r *http.Request) {
+				restGetUnacknowledgedNotifications(
+					w,
+					r,
+					bootstrapContainer.LoggingClientFrom(dic.Get),
+					container.DBClientFrom(dic.Get),
+					*notificationsContainer.ConfigurationFrom(dic.Get))
+			}).Methods(http.MethodGet)
+	}
+
 	// GetSubscriptions
 	b.HandleFunc(
 		"/"+SUBSCRIPTION,
@@ -241,6 +278,24 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 				bootstrapContainer.LoggingClientFrom(dic.Get),
 				container.DBClientFrom(dic.Get))
 		}).Methods(http.MethodDelete)
+	b.HandleFunc(
+		"/"+SUBSCRIPTION+"/"+SLUG+"/{"+SLUG+"}/"+SETTINGS,
+		func(w http.ResponseWriter, r *http.Request) {
+			restGetSubscriptionSettings(
+				w,
+				r,
+				bootstrapContainer.LoggingClientFrom(dic.Get),
+				container.DBClientFrom(dic.Get))
+		}).Methods(http.MethodGet)
+	b.HandleFunc(
+		"/"+SUBSCRIPTION+"/"+SLUG+"/{"+SLUG+"}/"+SETTINGS,
+		func(w http.ResponseWriter, r *http.Request) {
+			restUpdateSubscriptionSettings(
+				w,
+				r,
+				bootstrapContainer.LoggingClientFrom(dic.Get),
+				container.DBClientFrom(dic.Get))
+		}).Methods(http.MethodPut)
 	b.HandleFunc(
 		"/"+SUBSCRIPTION+"/"+CATEGORIES+"/{"+CATEGORIES+"}/"+LABELS+"/{"+LABELS+"}",
 		func(w http.ResponseWriter, r *http.Request) {
@@ -19,10 +19,9 @@ import (
 	"bytes"
 	"crypto/tls"
 	"errors"
-	"fmt"
-	"net"
 	"net/http"
 	mail "net/smtp"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -38,21 +37,17 @@ import (
 func sendViaChannel(
 	n models.Notification,
 	c models.Channel,
-	receiver string,
+	s models.Subscription,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
 	config notificationsConfig.ConfigurationStruct) {
 
 	lc.Debug("Sending notification: " + n.Slug + ", via channel: " + c.String())
-	var tr models.TransmissionRecord
-	if c.Type == models.ChannelType(models.Email) {
-		tr = sendMail(n.Content, c.MailAddresses, n.ContentType, lc, config.Smtp)
-	} else {
-		tr = restSend(n.Content, c.Url, n.ContentType, lc)
-	}
-	t, err := persistTransmission(tr, n, c, receiver, lc, dbClient)
+	message := renderChannelMessage(n, s.Slug, subscriptionSettings(s, lc, dbClient), s.Receiver, lc, config)
+	tr := transmit(n, message, c, s.Receiver, lc, config)
+	t, err := persistTransmission(tr, n, c, s.Receiver, lc, dbClient)
 	if err == nil {
-		handleFailedTransmission(t, lc, dbClient, config)
+		handleFailedTransmission(t, s, lc, dbClient, config)
 	}
 }
 
@@ -62,19 +57,37 @@ func resendViaChannel(
 	dbClient interfaces.DBClient,
 	config notificationsConfig.ConfigurationStruct) {
 
-	var tr models.TransmissionRecord
-	if t.Channel.Type == models.ChannelType(models.Email) {
-		tr = sendMail(t.Notification.Content, t.Channel.MailAddresses, t.Notification.ContentType, lc, config.Smtp)
-	} else {
-		tr = restSend(t.Notification.Content, t.Channel.Url, t.Notification.ContentType, lc)
-	}
+	s, _ := subscriptionForTransmission(t, dbClient)
+	settings := subscriptionSettings(s, lc, dbClient)
+	message := renderChannelMessage(t.Notification, s.Slug, settings, t.Receiver, lc, config)
+	tr := transmit(t.Notification, message, t.Channel, t.Receiver, lc, config)
 	t.ResendCount = t.ResendCount + 1
 	t.Status = tr.Status
 	t.Records = append(t.Records, tr)
 	err := dbClient.UpdateTransmission(t)
 	if err == nil {
-		handleFailedTransmission(t, lc, dbClient, config)
+		handleFailedTransmission(t, s, lc, dbClient, config)
+	}
+}
+
+// subscriptionForTransmission finds the subscription a transmission was made for: the subscription of the
+// transmission's receiver which contains the transmission's channel.
+func subscriptionForTransmission(
+	t models.Transmission,
+	dbClient interfaces.DBClient) (models.Subscription, bool) {
+
+	subscriptions, err := dbClient.GetSubscriptionByReceiver(t.Receiver)
+	if err != nil {
+		return models.Subscription{}, false
+	}
+	for _, s := range subscriptions {
+		for _, c := range s.Channels {
+			if reflect.DeepEqual(c, t.Channel) {
+				return s, true
+			}
+		}
 	}
+	return models.Subscription{}, false
 }
 
 func getTransmissionRecord(msg string, st models.TransmissionStatus) models.TransmissionRecord {
@@ -110,18 +123,33 @@ func persistTransmission(
 	return trx, nil
 }
 
+// sendMail emails the rendered message to the addressees through the SMTP server. Attachments beyond the maximum
+// attachment size are left out.
 func sendMail(
-	message string,
+	message interfaces.ChannelMessage,
 	addressees []string,
-	contentType string,
 	lc logger.LoggingClient,
 	smtp notificationsConfig.SmtpInfo) models.TransmissionRecord {
 
 	tr := getTransmissionRecord("SMTP server received", models.Sent)
 
-	smtpMessage := buildSmtpMessage(smtp.Sender, smtp.Subject, addressees, contentType, message)
+	from := message.From
+	if from == "" {
+		from = smtp.Sender
+	}
+	if message.Subject == "" {
+		message.Subject = smtp.Subject
+	}
+	var dropped []string
+	message.Attachments, dropped = limitAttachments(message.Attachments, smtp.MaxAttachmentSize)
+	if len(dropped) > 0 {
+		lc.Warn("Leaving out attachments exceeding the maximum attachment size: " + strings.Join(dropped, ","))
+	}
 
-	err := smtpSend(addressees, smtpMessage, smtp)
+	smtpMessage, err := buildSmtpMessage(from, addressees, message, time.Now())
+	if err == nil {
+		err = smtpSend(envelopeAddress(from), addressees, smtpMessage, smtp)
+	}
 	if err != nil {
 		lc.Error("Problems sending message to: " + strings.Join(addressees, ",") + ", issue: " + err.Error())
 		tr.Status = models.Failed
@@ -131,39 +159,6 @@ func sendMail(
 	return tr
 }
 
-func buildSmtpMessage(sender string, subject string, toAddresses []string, contentType string, message string) []byte {
-	smtpNewline := "\r\n"
-
-	// required CRLF at ends of lines and CRLF between header and body for SMTP RFC 822 style email
-	buf := bytes.NewBufferString("Subject: " + subject + smtpNewline)
-
-	buf.WriteString("From: " + sender + smtpNewline)
-
-	buf.WriteString("To: " + strings.Join(toAddresses, ",") + smtpNewline)
-
-	// only add MIME header if notification content type was set
-	// maybe provide charset overrides as well?
-	if contentType != "" {
-		buf.WriteString(fmt.Sprintf("MIME-version: 1.0;\r\nContent-Type: %s; charset=\"UTF-8\";\r\n", contentType))
-	}
-
-	buf.WriteString(smtpNewline)
-
-	//maximum line size is 1000
-	//split on newline first then break further as needed
-	for _, line := range strings.Split(message, smtpNewline) {
-		ln := 998
-		idx := 0
-		for len(line) > idx+ln {
-			buf.WriteString(line[idx:idx+ln] + smtpNewline)
-			idx += ln
-		}
-		buf.WriteString(line[idx:] + smtpNewline)
-	}
-
-	return []byte(buf.String())
-}
-
 func restSend(message string, url string, contentType string, lc logger.LoggingClient) models.TransmissionRecord {
 	tr := getTransmissionRecord("", models.Sent)
 
@@ -179,33 +174,72 @@ func restSend(message string, url string, contentType string, lc logger.LoggingC
 		tr.Response = err.Error()
 		return tr
 	}
+	defer rs.Body.Close()
+
 	tr.Response = "Got response status code: " + rs.Status
+	if rs.StatusCode < http.StatusOK || rs.StatusCode >= http.StatusMultipleChoices {
+		lc.Error("Problems sending message to: " + url + ", got response status code: " + rs.Status)
+		tr.Status = models.Failed
+	}
 	return tr
 }
 
+// handleFailedTransmission applies the retry and escalation policies of the subscription to a failed transmission:
+// due escalation levels are escalated and the next retry or escalation is scheduled in the database, where the retry
+// processor picks it up once it is due.
 func handleFailedTransmission(
 	t models.Transmission,
+	s models.Subscription,
 	lc logger.LoggingClient,
 	dbClient interfaces.DBClient,
 	config notificationsConfig.ConfigurationStruct) {
 
 	n := t.Notification
-	if t.ResendCount >= config.Writable.ResendLimit {
+	if t.Status != models.Failed || n.Status == models.Escalated {
+		return
+	}
+	settings := subscriptionSettings(s, lc, dbClient)
+	policy := retryPolicyFor(s.Slug, settings, lc, config)
+	if !policy.handles(n.Severity) {
+		return
+	}
+	lc.Debug("Handling failed transmission for: " + t.ID + " for notification: " + t.Notification.Slug + ", resends so far: " + strconv.Itoa(t.ResendCount))
+
+	if hold, until := lifecycleHold(n.Slug, time.Now(), lc, dbClient); hold {
+		if until.IsZero() {
+			lc.Debug("Notification " + n.Slug + " is acknowledged, no longer retrying transmission: " + t.ID)
+			return
+		}
+		if err := dbClient.AddTransmissionRetry(t.ID, timeToMillis(until)); err != nil {
+			lc.Error("Unable to schedule retry of transmission " + t.ID + ": " + err.Error())
+		}
+		return
+	}
+
+	levels := escalationLevelsFor(s.Slug, settings, policy, lc, config)
+	escalated, next := escalateDueLevels(t, levels, time.Now(), lc, dbClient, config)
+
+	if t.ResendCount < policy.maxRetries {
+		retry := lastAttempt(t).Add(policy.backoff(t))
+		if next.IsZero() || retry.Before(next) {
+			next = retry
+		}
+	} else {
 		lc.Error("Too many transmission resend attempts!  Giving up on transmission: " + t.ID + ", for notification: " + n.Slug)
 	}
-	if t.Status == models.Failed && n.Status != models.Escalated {
-		lc.Debug("Handling failed transmission for: " + t.ID + " for notification: " + t.Notification.Slug + ", resends so far: " + strconv.Itoa(t.ResendCount))
-		if n.Severity == models.Critical {
-			if t.ResendCount < config.Writable.ResendLimit {
-				time.AfterFunc(time.Second*5, func() {
-					criticalSeverityResend(t, lc, dbClient, config)
-				})
-			} else {
-				escalate(t, lc, dbClient, config)
-				t.Status = models.Trxescalated
-				dbClient.UpdateTransmission(t)
+
+	if next.IsZero() {
+		if escalated {
+			t.Status = models.Trxescalated
+			if err := dbClient.UpdateTransmission(t); err != nil {
+				lc.Error("Unable to mark transmission " + t.ID + " escalated: " + err.Error())
 			}
 		}
+		return
+	}
+
+	if err := dbClient.AddTransmissionRetry(t.ID, timeToMillis(next)); err != nil {
+		lc.Error("Unable to schedule retry of transmission " + t.ID + ": " + err.Error())
 	}
 }
 
@@ -219,7 +253,18 @@ func deduceAuth(s notificationsConfig.SmtpInfo) (mail.Auth, error) {
 	if s.CheckUsername() == "" && s.Password != "" {
 		return nil, errors.New("Notifications: Expecting username")
 	}
-	return mail.PlainAuth("", s.CheckUsername(), s.Password, s.Host), nil
+	switch strings.ToLower(s.AuthMechanism) {
+	case "", SMTPAUTHPLAIN:
+		return mail.PlainAuth("", s.CheckUsername(), s.Password, s.Host), nil
+	case SMTPAUTHLOGIN:
+		return &loginAuth{username: s.CheckUsername(), password: s.Password, host: s.Host}, nil
+	case SMTPAUTHCRAMMD5:
+		return mail.CRAMMD5Auth(s.CheckUsername(), s.Password), nil
+	case SMTPAUTHXOAUTH2:
+		return &xoauth2Auth{username: s.CheckUsername(), token: s.Password, host: s.Host}, nil
+	default:
+		return nil, errors.New("Notifications: Unknown SMTP auth mechanism " + s.AuthMechanism)
+	}
 }
 
 // The function smtpSend replicates the functionality provided by the SendMail function
@@ -230,35 +275,57 @@ func deduceAuth(s notificationsConfig.SmtpInfo) (mail.Auth, error) {
 // As it is replicating the functionality from smtp.SendMail, it borrows heavily from the
 // original function in its design and implementation. This version adds new functionality
 // for handling the SmtpInfo configuration and authentication management, along with the
-// requirement of ability to set-reset the InsecureSkipVerify flag.
+// requirement of ability to set-reset the InsecureSkipVerify flag, and the choice between
+// opportunistic STARTTLS, required STARTTLS, implicit TLS and plain connections.
 //
 // This is using a lot of unexported methods and types from smtp package through exported
 // interfaces, which makes it a little bit trickier to modify. Since, the intention for
 // this function is to use it as a support function for handling the low level SMTP
 // protocol mechanism, it is not exported.
-func smtpSend(to []string, msg []byte, s notificationsConfig.SmtpInfo) error {
+func smtpSend(from string, to []string, msg []byte, s notificationsConfig.SmtpInfo) error {
 	addr := s.Host + ":" + strconv.Itoa(s.Port)
 	auth, err := deduceAuth(s)
 	if err != nil {
 		return err
 	}
-	c, err := mail.Dial(addr)
-	if err != nil {
-		return errors.New("Notifications: Error dialing address")
+	mode := strings.ToLower(s.TLSMode)
+	config := &tls.Config{ServerName: s.Host}
+	config.InsecureSkipVerify = s.EnableSelfSignedCert
+
+	var c *mail.Client
+	switch mode {
+	case SMTPTLSIMPLICIT:
+		conn, err := tls.Dial("tcp", addr, config)
+		if err != nil {
+			return errors.New("Notifications: Error dialing address")
+		}
+		c, err = mail.NewClient(conn, s.Host)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+	case "", SMTPTLSSTARTTLS, SMTPTLSNONE:
+		c, err = mail.Dial(addr)
+		if err != nil {
+			return errors.New("Notifications: Error dialing address")
+		}
+	default:
+		return errors.New("Notifications: Unknown SMTP TLS mode " + s.TLSMode)
 	}
 	defer c.Close()
-	serverName, _, err := net.SplitHostPort(addr)
-	if err != nil {
-		return err
-	}
-	if err = c.Hello(addr); err != nil {
+	// relays verify the client's name, which is the local host and not the server address
+	if err = c.Hello(localHostname()); err != nil {
 		return err
 	}
-	if ok, _ := c.Extension("STARTTLS"); ok {
-		config := &tls.Config{ServerName: serverName}
-		config.InsecureSkipVerify = s.EnableSelfSignedCert
-		if err = c.StartTLS(config); err != nil {
-			return err
+	if mode == "" || mode == SMTPTLSSTARTTLS {
+		ok, _ := c.Extension("STARTTLS")
+		if !ok && mode == SMTPTLSSTARTTLS {
+			return errors.New("Notifications: server doesn't support STARTTLS")
+		}
+		if ok {
+			if err = c.StartTLS(config); err != nil {
+				return err
+			}
 		}
 	}
 	if auth != nil {
@@ -270,7 +337,7 @@ func smtpSend(to []string, msg []byte, s notificationsConfig.SmtpInfo) error {
 			return err
 		}
 	}
-	if err = c.Mail(s.Sender); err != nil {
+	if err = c.Mail(from); err != nil {
 		return err
 	}
 	for _, addr := range to {
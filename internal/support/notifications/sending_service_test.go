@@ -12,125 +12,343 @@
  * the License.
  *
  *******************************************************************************/
+
 package notifications
 
 import (
+	"bufio"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	netSmtp "net/smtp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"testing"
 )
 
-func TestBuildSmtpMessageNoContentType(t *testing.T) {
-	subject := uuid.New().String()
-	from := uuid.New().String()
-	to1 := uuid.New().String()
-	to2 := uuid.New().String()
-
-	message := uuid.New().String()
-
-	result := buildSmtpMessage(from, subject, []string{to1, to2}, "", message)
-
-	require.NotNil(t, result)
-
-	stringResult := string(result)
-
-	expected := fmt.Sprintf("Subject: %s\r\nFrom: %s\r\nTo: %s,%s\r\n\r\n%s\r\n", subject, from, to1, to2, message)
-	assert.Equal(t, expected, stringResult)
+func parseSmtpMessage(t *testing.T, raw []byte) *mail.Message {
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		require.LessOrEqual(t, len(line), 998, "SMTP lines must not exceed 998 characters")
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	return msg
 }
 
-func TestBuildSmtpMessageContentType(t *testing.T) {
-	subject := uuid.New().String()
-	from := uuid.New().String()
-	to := uuid.New().String()
-	contentType := uuid.New().String()
-	message := uuid.New().String()
-
-	result := buildSmtpMessage(from, subject, []string{to}, contentType, message)
-
-	require.NotNil(t, result)
-
-	stringResult := string(result)
-
-	expected := fmt.Sprintf("Subject: %s\r\nFrom: %s\r\nTo: %s\r\nMIME-version: 1.0;\r\nContent-Type: %s; charset=\"UTF-8\";\r\n\r\n%s\r\n", subject, from, to, contentType, message)
-	assert.Equal(t, expected, stringResult)
+func readQuotedPrintable(t *testing.T, r io.Reader) string {
+	body, err := ioutil.ReadAll(quotedprintable.NewReader(r))
+	require.NoError(t, err)
+	return strings.TrimSuffix(string(body), "\r\n")
 }
 
-func TestBuildSmtpMessageLongMessageIsChunkedIfNeeded(t *testing.T) {
+func TestBuildSmtpMessageNoContentType(t *testing.T) {
 	subject := uuid.New().String()
-	from := uuid.New().String()
-	to := uuid.New().String()
-
+	to1 := uuid.New().String() + "@example.com"
+	to2 := uuid.New().String() + "@example.com"
 	message := uuid.New().String()
-
-	for i := 0; i < 5; i++ {
-		message += message
-	}
-
-	require.Greater(t, len(message), 998)
-	require.Less(t, len(message), 1896)
-
-	result := buildSmtpMessage(from, subject, []string{to}, "", message)
-
-	require.NotNil(t, result)
-
-	stringResult := string(result)
-
-	expected := fmt.Sprintf("Subject: %s\r\nFrom: %s\r\nTo: %s\r\n\r\n%s\r\n%s\r\n", subject, from, to, message[0:998], message[998:])
-	assert.Equal(t, expected, stringResult)
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	result, err := buildSmtpMessage("EdgeX <edgex@example.com>", []string{to1, to2},
+		interfaces.ChannelMessage{Subject: subject, Text: message}, now)
+	require.NoError(t, err)
+
+	msg := parseSmtpMessage(t, result)
+	assert.Equal(t, "EdgeX <edgex@example.com>", msg.Header.Get("From"))
+	assert.Equal(t, to1+", "+to2, msg.Header.Get("To"))
+	assert.Equal(t, subject, msg.Header.Get("Subject"))
+	assert.Equal(t, "Mon, 01 Mar 2021 12:00:00 +0000", msg.Header.Get("Date"))
+	assert.Regexp(t, "^<[0-9a-f-]{36}@example.com>$", msg.Header.Get("Message-ID"))
+	assert.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+	assert.Equal(t, "text/plain; charset=UTF-8", msg.Header.Get("Content-Type"))
+	assert.Equal(t, "quoted-printable", msg.Header.Get("Content-Transfer-Encoding"))
+	assert.Equal(t, message, readQuotedPrintable(t, msg.Body))
 }
 
-func TestBuildSmtpMessageLongMessageIsPreChunked(t *testing.T) {
-	subject := uuid.New().String()
-	from := uuid.New().String()
-	to := uuid.New().String()
+func TestBuildSmtpMessageContentType(t *testing.T) {
+	result, err := buildSmtpMessage("edgex@example.com", []string{"ops@example.com"},
+		interfaces.ChannelMessage{Subject: "Température élevée", Text: `{"temp": 120}`, ContentType: "application/json"},
+		time.Now())
+	require.NoError(t, err)
+
+	msg := parseSmtpMessage(t, result)
+	assert.Equal(t, "application/json; charset=UTF-8", msg.Header.Get("Content-Type"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Température élevée", subject)
+	assert.Equal(t, `{"temp": 120}`, readQuotedPrintable(t, msg.Body))
+}
 
+func TestBuildSmtpMessageLongLinesAreWrapped(t *testing.T) {
 	longLine := uuid.New().String()
-
 	for i := 0; i < 5; i++ {
 		longLine += longLine
 	}
-
 	require.Greater(t, len(longLine), 998)
-	require.Less(t, len(longLine), 1896)
 
-	formattedMessage := fmt.Sprintf("%s\r\n%s", longLine[0:998], longLine[998:])
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{"long line", longLine},
+		{"pre-chunked", longLine[0:998] + "\r\n" + longLine[998:]},
+		{"partly chunked", "short line\r\n" + longLine[0:998] + "\r\n" + longLine[998:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := buildSmtpMessage("edgex@example.com", []string{"ops@example.com"},
+				interfaces.ChannelMessage{Text: tt.message}, time.Now())
+			require.NoError(t, err)
+
+			msg := parseSmtpMessage(t, result)
+			assert.Equal(t, tt.message, readQuotedPrintable(t, msg.Body))
+		})
+	}
+}
+
+func TestBuildSmtpMessageMultipart(t *testing.T) {
+	message := interfaces.ChannelMessage{
+		Subject: "alert",
+		Text:    "temperature high",
+		Html:    "<p>temperature high</p>",
+		Attachments: []interfaces.Attachment{
+			{Name: "readings.csv", ContentType: "text/csv", Content: []byte("time,value\n1,120\n")},
+		},
+	}
+
+	result, err := buildSmtpMessage("edgex@example.com", []string{"ops@example.com"}, message, time.Now())
+	require.NoError(t, err)
+
+	msg := parseSmtpMessage(t, result)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+	mixed := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := mixed.NextPart()
+	require.NoError(t, err)
+	mediaType, params, err = mime.ParseMediaType(body.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+	alternative := multipart.NewReader(body, params["boundary"])
+
+	text, err := alternative.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=UTF-8", text.Header.Get("Content-Type"))
+	// multipart.Reader decodes quoted-printable parts itself
+	content, err := ioutil.ReadAll(text)
+	require.NoError(t, err)
+	assert.Equal(t, "temperature high\r\n", string(content))
+
+	html, err := alternative.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=UTF-8", html.Header.Get("Content-Type"))
+	content, err = ioutil.ReadAll(html)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>temperature high</p>\r\n", string(content))
+	_, err = alternative.NextPart()
+	assert.Equal(t, io.EOF, err)
+
+	attachment, err := mixed.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "readings.csv", attachment.FileName())
+	assert.Equal(t, "text/csv; name=readings.csv", attachment.Header.Get("Content-Type"))
+	assert.Equal(t, "base64", attachment.Header.Get("Content-Transfer-Encoding"))
+	content, err = ioutil.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment))
+	require.NoError(t, err)
+	assert.Equal(t, "time,value\n1,120\n", string(content))
+	_, err = mixed.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
 
-	result := buildSmtpMessage(from, subject, []string{to}, "", formattedMessage)
+func TestLimitAttachments(t *testing.T) {
+	attachments := []interfaces.Attachment{
+		{Name: "a", Content: make([]byte, 60)},
+		{Name: "b", Content: make([]byte, 50)},
+		{Name: "c", Content: make([]byte, 40)},
+	}
 
-	require.NotNil(t, result)
+	kept, dropped := limitAttachments(attachments, 100)
 
-	stringResult := string(result)
+	assert.Equal(t, []interfaces.Attachment{attachments[0], attachments[2]}, kept)
+	assert.Equal(t, []string{"b"}, dropped)
 
-	expected := fmt.Sprintf("Subject: %s\r\nFrom: %s\r\nTo: %s\r\n\r\n%s\r\n", subject, from, to, formattedMessage)
-	assert.Equal(t, expected, stringResult)
+	kept, dropped = limitAttachments(attachments, 0)
+	assert.Equal(t, attachments, kept)
+	assert.Empty(t, dropped)
 }
 
-func TestBuildSmtpMessageLongMessageIsPartlyChunked(t *testing.T) {
-	subject := uuid.New().String()
-	from := uuid.New().String()
-	to := uuid.New().String()
+func TestDeduceAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		mechanism     string
+		expectedType  string
+		expectedError bool
+	}{
+		{"default", "", "*smtp.plainAuth", false},
+		{"plain", "PLAIN", "*smtp.plainAuth", false},
+		{"login", "login", "*notifications.loginAuth", false},
+		{"cram-md5", "cram-md5", "*smtp.cramMD5Auth", false},
+		{"xoauth2", "XOAUTH2", "*notifications.xoauth2Auth", false},
+		{"unknown", "digest-md5", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := deduceAuth(notificationsConfig.SmtpInfo{
+				Host: "smtp.example.com", Username: "user", Password: "secret", AuthMechanism: tt.mechanism,
+			})
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, fmt.Sprintf("%T", auth))
+		})
+	}
+}
 
-	longLine := uuid.New().String()
+// fakeSmtpServer accepts a single SMTP session offering AUTH LOGIN and records the commands and the message data.
+func fakeSmtpServer(t *testing.T) (net.Listener, chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	session := make(chan []string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var commands []string
+		r := bufio.NewReader(conn)
+		reply := func(lines ...string) {
+			for _, line := range lines {
+				_, _ = conn.Write([]byte(line + "\r\n"))
+			}
+		}
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				session <- commands
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			commands = append(commands, line)
+			switch verb := strings.ToUpper(strings.Fields(line + " x")[0]); verb {
+			case "EHLO":
+				reply("250-fake", "250 AUTH PLAIN LOGIN")
+			case "AUTH":
+				reply("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+			case "DATA":
+				reply("354 go ahead")
+				var data []string
+				for {
+					dataLine, _ := r.ReadString('\n')
+					dataLine = strings.TrimRight(dataLine, "\r\n")
+					if dataLine == "." {
+						break
+					}
+					data = append(data, dataLine)
+				}
+				commands = append(commands, strings.Join(data, "\r\n"))
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				session <- commands
+				return
+			default:
+				switch {
+				case strings.HasPrefix(commands[len(commands)-2], "AUTH"):
+					reply("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+				case len(commands) > 2 && strings.HasPrefix(commands[len(commands)-3], "AUTH"):
+					reply("235 authenticated")
+				default:
+					reply("250 ok")
+				}
+			}
+		}
+	}()
+	return listener, session
+}
 
-	for i := 0; i < 5; i++ {
-		longLine += longLine
+func TestSendMailLoginAuth(t *testing.T) {
+	listener, session := fakeSmtpServer(t)
+	defer listener.Close()
+	port, err := strconv.Atoi(strings.Split(listener.Addr().String(), ":")[1])
+	require.NoError(t, err)
+
+	smtp := notificationsConfig.SmtpInfo{
+		Host:          "127.0.0.1",
+		Port:          port,
+		Username:      "user",
+		Password:      "secret",
+		Sender:        "edgex@example.com",
+		Subject:       "EdgeX Notification",
+		TLSMode:       "none",
+		AuthMechanism: "login",
 	}
+	message := interfaces.ChannelMessage{From: "Night Shift <night@example.com>", Text: "temperature high"}
+
+	tr := sendMail(message, []string{"ops@example.com"}, logger.NewMockClient(), smtp)
+
+	require.Equal(t, models.TransmissionStatus(models.Sent), tr.Status, tr.Response)
+	commands := <-session
+	require.Len(t, commands, 9)
+	assert.True(t, strings.HasPrefix(commands[0], "EHLO "))
+	assert.NotContains(t, commands[0], "127.0.0.1")
+	assert.Equal(t, "AUTH LOGIN", commands[1])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("user")), commands[2])
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("secret")), commands[3])
+	assert.Equal(t, "MAIL FROM:<night@example.com>", commands[4])
+	assert.Equal(t, "RCPT TO:<ops@example.com>", commands[5])
+	assert.Equal(t, "DATA", commands[6])
+	assert.Equal(t, "QUIT", commands[8])
+
+	msg := parseSmtpMessage(t, []byte(commands[7]+"\r\n"))
+	assert.Equal(t, "Night Shift <night@example.com>", msg.Header.Get("From"))
+	assert.Equal(t, "EdgeX Notification", msg.Header.Get("Subject"))
+	assert.Equal(t, "temperature high", readQuotedPrintable(t, msg.Body))
+}
 
-	require.Greater(t, len(longLine), 998)
-	require.Less(t, len(longLine), 1896)
-
-	goodLine := uuid.New().String() + uuid.New().String() + "\r\n"
+func TestSendMailRequiredStartTlsUnsupported(t *testing.T) {
+	listener, _ := fakeSmtpServer(t)
+	defer listener.Close()
+	port, err := strconv.Atoi(strings.Split(listener.Addr().String(), ":")[1])
+	require.NoError(t, err)
 
-	formattedMessage := fmt.Sprintf("%s\r\n%s", longLine[0:998], longLine[998:])
+	smtp := notificationsConfig.SmtpInfo{Host: "127.0.0.1", Port: port, Sender: "edgex@example.com", TLSMode: "starttls"}
+	tr := sendMail(interfaces.ChannelMessage{Text: "temperature high"}, []string{"ops@example.com"},
+		logger.NewMockClient(), smtp)
 
-	result := buildSmtpMessage(from, subject, []string{to}, "", goodLine+formattedMessage)
+	assert.Equal(t, models.TransmissionStatus(models.Failed), tr.Status)
+	assert.Contains(t, tr.Response, "STARTTLS")
+}
 
-	require.NotNil(t, result)
+func TestXoauth2Auth(t *testing.T) {
+	auth := &xoauth2Auth{username: "user@example.com", token: "token", host: "smtp.example.com"}
 
-	stringResult := string(result)
+	mechanism, response, err := auth.Start(&netSmtp.ServerInfo{Name: "smtp.example.com", TLS: true})
+	require.NoError(t, err)
+	assert.Equal(t, "XOAUTH2", mechanism)
+	assert.Equal(t, "user=user@example.com\x01auth=Bearer token\x01\x01", string(response))
 
-	expected := fmt.Sprintf("Subject: %s\r\nFrom: %s\r\nTo: %s\r\n\r\n%s%s\r\n%s\r\n", subject, from, to, goodLine, longLine[0:998], longLine[998:])
-	assert.Equal(t, expected, stringResult)
+	_, _, err = auth.Start(&netSmtp.ServerInfo{Name: "smtp.example.com", TLS: false})
+	assert.Error(t, err, "credentials must not be sent unencrypted")
 }
@@ -0,0 +1,214 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/google/uuid"
+)
+
+const (
+	smtpNewline              = "\r\n"
+	defaultMaxAttachmentSize = 256 * 1024
+	base64LineLength         = 76
+)
+
+// mimeEntity is a MIME header with its encoded body.
+type mimeEntity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// buildSmtpMessage assembles an RFC 5322 message. A text and an HTML rendering are sent as multipart/alternative, and
+// attachments turn the message into multipart/mixed. Text is quoted-printable encoded, which keeps the lines within
+// the SMTP limits whatever the content.
+func buildSmtpMessage(
+	from string,
+	toAddresses []string,
+	message interfaces.ChannelMessage,
+	now time.Time) ([]byte, error) {
+
+	var entity mimeEntity
+	var err error
+	switch {
+	case message.Html != "" && message.Text != "":
+		entity, err = multipartEntity("alternative",
+			textEntity(message.ContentType, message.Text),
+			textEntity("text/html", message.Html))
+	case message.Html != "":
+		entity = textEntity("text/html", message.Html)
+	default:
+		entity = textEntity(message.ContentType, message.Text)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(message.Attachments) > 0 {
+		parts := []mimeEntity{entity}
+		for _, a := range message.Attachments {
+			parts = append(parts, attachmentEntity(a))
+		}
+		if entity, err = multipartEntity("mixed", parts...); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := bytes.NewBufferString("From: " + from + smtpNewline)
+	buf.WriteString("To: " + strings.Join(toAddresses, ", ") + smtpNewline)
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + smtpNewline)
+	buf.WriteString("Date: " + now.Format(time.RFC1123Z) + smtpNewline)
+	buf.WriteString("Message-ID: " + messageId(from) + smtpNewline)
+	buf.WriteString("MIME-Version: 1.0" + smtpNewline)
+	for _, key := range []string{"Content-Type", "Content-Transfer-Encoding", "Content-Disposition"} {
+		if value := entity.header.Get(key); value != "" {
+			buf.WriteString(key + ": " + value + smtpNewline)
+		}
+	}
+	buf.WriteString(smtpNewline)
+	buf.Write(entity.body)
+	return buf.Bytes(), nil
+}
+
+// textEntity encodes text of the content type, text/plain when empty, in UTF-8.
+func textEntity(contentType string, text string) mimeEntity {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if params["charset"] == "" {
+		params["charset"] = "UTF-8"
+	}
+
+	var body bytes.Buffer
+	w := quotedprintable.NewWriter(&body)
+	// quoted-printable keeps line breaks as they are, SMTP requires them to be CRLF
+	_, _ = w.Write([]byte(strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", smtpNewline)))
+	_ = w.Close()
+	body.WriteString(smtpNewline)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(mediaType, params))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return mimeEntity{header: header, body: body.Bytes()}
+}
+
+func attachmentEntity(a interfaces.Attachment) mimeEntity {
+	contentType := mime.FormatMediaType(a.ContentType, map[string]string{"name": a.Name})
+	if contentType == "" {
+		contentType = mime.FormatMediaType("application/octet-stream", map[string]string{"name": a.Name})
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(a.Content)
+	var body bytes.Buffer
+	for len(encoded) > base64LineLength {
+		body.WriteString(encoded[:base64LineLength] + smtpNewline)
+		encoded = encoded[base64LineLength:]
+	}
+	body.WriteString(encoded + smtpNewline)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Name}))
+	return mimeEntity{header: header, body: body.Bytes()}
+}
+
+func multipartEntity(subtype string, parts ...mimeEntity) (mimeEntity, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, part := range parts {
+		pw, err := w.CreatePart(part.header)
+		if err != nil {
+			return mimeEntity{}, err
+		}
+		if _, err = pw.Write(part.body); err != nil {
+			return mimeEntity{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return mimeEntity{}, err
+	}
+	body.WriteString(smtpNewline)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": w.Boundary()}))
+	return mimeEntity{header: header, body: body.Bytes()}, nil
+}
+
+// messageId returns a unique Message-ID in the domain of the sender address, or of the local host name when the
+// sender has none.
+func messageId(from string) string {
+	domain := ""
+	if address, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndex(address.Address, "@"); at >= 0 {
+			domain = address.Address[at+1:]
+		}
+	}
+	if domain == "" {
+		domain = localHostname()
+	}
+	return fmt.Sprintf("<%s@%s>", uuid.New().String(), domain)
+}
+
+// envelopeAddress returns the bare address of a sender, which may carry a display name, for the SMTP envelope.
+func envelopeAddress(from string) string {
+	if address, err := mail.ParseAddress(from); err == nil {
+		return address.Address
+	}
+	return from
+}
+
+func localHostname() string {
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "localhost"
+}
+
+// limitAttachments keeps the attachments whose total size stays within the maximum size, 256 KiB when 0, and reports
+// the names of those left out.
+func limitAttachments(attachments []interfaces.Attachment, maxSize int) ([]interfaces.Attachment, []string) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxAttachmentSize
+	}
+
+	var kept []interfaces.Attachment
+	var dropped []string
+	size := 0
+	for _, a := range attachments {
+		if size+len(a.Content) > maxSize {
+			dropped = append(dropped, a.Name)
+			continue
+		}
+		size += len(a.Content)
+		kept = append(kept, a)
+	}
+	return kept, dropped
+}
@@ -0,0 +1,83 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// loadConfiguredSubscriptionSettings stores the settings configured in Writable.SubscriptionSettings with the V1
+// subscriptions which have no stored settings yet. The stored settings take precedence, so the configured settings of
+// a subscription which has some are ignored.
+func loadConfiguredSubscriptionSettings(
+	config notificationsConfig.ConfigurationStruct,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) {
+
+	for slug, info := range config.Writable.SubscriptionSettings {
+		s, err := dbClient.GetSubscriptionBySlug(slug)
+		if err != nil {
+			lc.Warn("Ignoring the configured settings of subscription " + slug + ": " + err.Error())
+			continue
+		}
+
+		_, err = dbClient.GetSubscriptionSettings(s.ID)
+		if err == nil {
+			lc.Info("Ignoring the configured settings of subscription " + slug + " which has stored settings")
+			continue
+		} else if err != db.ErrNotFound {
+			lc.Error("Unable to get the settings of subscription " + slug + ": " + err.Error())
+			continue
+		}
+
+		settings := toStoredSubscriptionSettings(info)
+		if err := validateSubscriptionSettings(settings); err != nil {
+			lc.Error("Invalid configured settings of subscription " + slug + ": " + err.Error())
+			continue
+		}
+		if err := dbClient.UpdateSubscriptionSettings(s.ID, settings); err != nil {
+			lc.Error("Unable to store the configured settings of subscription " + slug + ": " + err.Error())
+			continue
+		}
+		lc.Info("Stored the configured settings of subscription " + slug)
+	}
+}
+
+func toStoredSubscriptionSettings(info notificationsConfig.SubscriptionSettingsInfo) notificationsModels.SubscriptionSettings {
+	var windows []notificationsModels.DeliveryWindow
+	for _, w := range info.DeliveryWindows {
+		windows = append(windows, notificationsModels.DeliveryWindow{Days: w.Days, Start: w.Start, End: w.End})
+	}
+	return notificationsModels.SubscriptionSettings{
+		Template:         info.Template,
+		EmailSender:      info.EmailSender,
+		EmailSubject:     info.EmailSubject,
+		MinSeverity:      info.MinSeverity,
+		TimeZone:         info.TimeZone,
+		DeliveryWindows:  windows,
+		QuietHours:       info.QuietHours,
+		RetryPolicy:      info.RetryPolicy,
+		EscalationPolicy: info.EscalationPolicy,
+		RateLimit:        info.RateLimit,
+		RateLimitPeriod:  info.RateLimitPeriod,
+		DigestInterval:   info.DigestInterval,
+	}
+}
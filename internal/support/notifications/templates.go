@@ -0,0 +1,212 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// templateData is the data available to notification templates as well as webhook and SMS gateway templates.
+type templateData struct {
+	Slug        string
+	Sender      string
+	Category    string
+	Severity    string
+	Status      string
+	Description string
+	Labels      []string
+	Content     string
+	ContentType string
+	Json        string
+	Receiver    string
+	Created     int64
+	Timestamp   time.Time
+	Recipient   string
+}
+
+var templateFuncs = map[string]interface{}{
+	// json renders the value as a JSON literal, so that content can be embedded safely in JSON documents
+	"json": func(v interface{}) (string, error) {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		err := encoder.Encode(v)
+		return strings.TrimSuffix(buf.String(), "\n"), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+func newTemplateData(n models.Notification, receiver string) templateData {
+	return templateData{
+		Slug:        n.Slug,
+		Sender:      n.Sender,
+		Category:    string(n.Category),
+		Severity:    string(n.Severity),
+		Status:      string(n.Status),
+		Description: n.Description,
+		Labels:      n.Labels,
+		Content:     n.Content,
+		ContentType: n.ContentType,
+		Receiver:    receiver,
+		Created:     n.Created,
+		Timestamp:   time.Unix(0, n.Created*int64(time.Millisecond)).UTC(),
+	}
+}
+
+// renderChannelMessage renders the notification with the template named in the subscription's settings. Without a
+// template the notification content is used as is; a template which fails to render is logged and skipped.
+func renderChannelMessage(
+	n models.Notification,
+	subscriptionSlug string,
+	settings notificationsModels.SubscriptionSettings,
+	receiver string,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) interfaces.ChannelMessage {
+
+	message := interfaces.ChannelMessage{
+		Subject:     settings.EmailSubject,
+		Text:        n.Content,
+		ContentType: n.ContentType,
+		From:        settings.EmailSender,
+	}
+	if message.Subject == "" {
+		message.Subject = config.Smtp.Subject
+	}
+
+	name := settings.Template
+	if name == "" {
+		return message
+	}
+
+	tmpl, ok := config.Writable.Templates[name]
+	if !ok {
+		lc.Error(fmt.Sprintf("Template %s of subscription %s is not configured", name, subscriptionSlug))
+		return message
+	}
+
+	rendered, err := renderTemplate(tmpl, newTemplateData(n, receiver))
+	if err != nil {
+		lc.Error(fmt.Sprintf("Unable to render template %s for notification %s: %s", name, n.Slug, err.Error()))
+		return message
+	}
+	rendered.From = message.From
+	if rendered.Subject == "" {
+		rendered.Subject = message.Subject
+	}
+	if rendered.Text == "" {
+		rendered.Text = message.Text
+		rendered.ContentType = message.ContentType
+	}
+	return rendered
+}
+
+func renderTemplate(tmpl notificationsConfig.TemplateInfo, data templateData) (interfaces.ChannelMessage, error) {
+	var message interfaces.ChannelMessage
+	var err error
+
+	if message.Subject, err = renderText("subject", tmpl.Subject, data); err != nil {
+		return message, err
+	}
+	// a subject spanning lines would break the message headers
+	message.Subject = strings.Join(strings.Fields(message.Subject), " ")
+
+	if message.Text, err = renderText("text", tmpl.Text, data); err != nil {
+		return message, err
+	}
+	if message.Text != "" {
+		message.ContentType = "text/plain"
+	}
+	if message.Html, err = renderHtml("html", tmpl.Html, data); err != nil {
+		return message, err
+	}
+	if message.Sms, err = renderText("sms", tmpl.Sms, data); err != nil {
+		return message, err
+	}
+	if message.Json, err = renderText("json", tmpl.Json, data); err != nil {
+		return message, err
+	}
+	if message.Json != "" && !json.Valid([]byte(message.Json)) {
+		return message, fmt.Errorf("json template did not render valid JSON")
+	}
+	for i, attachment := range tmpl.Attachments {
+		a := interfaces.Attachment{ContentType: attachment.ContentType}
+		if a.Name, err = renderText(fmt.Sprintf("attachment %d name", i), attachment.Name, data); err != nil {
+			return message, err
+		}
+		content, err := renderText(fmt.Sprintf("attachment %d", i), attachment.Content, data)
+		if err != nil {
+			return message, err
+		}
+		a.Content = []byte(content)
+		if a.Name == "" {
+			a.Name = fmt.Sprintf("attachment-%d", i+1)
+		}
+		if a.ContentType == "" {
+			a.ContentType = "application/octet-stream"
+		}
+		message.Attachments = append(message.Attachments, a)
+	}
+	return message, nil
+}
+
+func renderText(name string, text string, data interface{}) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := textTemplate.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %s", name, err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to render %s template: %s", name, err.Error())
+	}
+	return buf.String(), nil
+}
+
+func renderHtml(name string, text string, data interface{}) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := htmlTemplate.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %s", name, err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to render %s template: %s", name, err.Error())
+	}
+	return buf.String(), nil
+}
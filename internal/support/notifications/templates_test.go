@@ -0,0 +1,121 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"testing"
+
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func templateTestConfig(tmpl notificationsConfig.TemplateInfo) notificationsConfig.ConfigurationStruct {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Smtp.Subject = "EdgeX Notification"
+	config.Writable.Templates = map[string]notificationsConfig.TemplateInfo{"alert": tmpl}
+	return config
+}
+
+var alertSettings = notificationsModels.SubscriptionSettings{Template: "alert"}
+
+func TestRenderChannelMessage(t *testing.T) {
+	n := models.Notification{
+		Slug:     "temp-high",
+		Sender:   "device-virtual",
+		Category: models.Hwhealth,
+		Severity: models.Critical,
+		Labels:   []string{"boiler", "floor-1"},
+		Content:  "temperature <120>",
+	}
+	n.Created = 1609459200000
+
+	config := templateTestConfig(notificationsConfig.TemplateInfo{
+		Subject: "[{{.Severity}}] {{.Category}}\nfrom {{.Sender}}",
+		Text:    "{{.Content}} ({{join .Labels \",\"}})",
+		Html:    "<p>{{.Content}}</p><p>{{.Timestamp.Format \"2006-01-02\"}}</p>",
+		Sms:     "{{upper .Severity}}: {{.Content}}",
+		Json:    `{"severity": {{json .Severity}}, "content": {{json .Content}}, "receiver": {{json .Receiver}}}`,
+	})
+
+	message := renderChannelMessage(n, "ops", alertSettings, "Operators", logger.NewMockClient(), config)
+
+	assert.Equal(t, interfaces.ChannelMessage{
+		Subject:     "[CRITICAL] HW_HEALTH from device-virtual",
+		Text:        "temperature <120> (boiler,floor-1)",
+		ContentType: "text/plain",
+		Html:        "<p>temperature &lt;120&gt;</p><p>2021-01-01</p>",
+		Sms:         "CRITICAL: temperature <120>",
+		Json:        `{"severity": "CRITICAL", "content": "temperature <120>", "receiver": "Operators"}`,
+	}, message)
+}
+
+func TestRenderChannelMessageFallsBackToContent(t *testing.T) {
+	n := models.Notification{Slug: "n1", Content: "raw content", ContentType: "text/csv"}
+	expected := interfaces.ChannelMessage{Subject: "EdgeX Notification", Text: "raw content", ContentType: "text/csv"}
+
+	tests := []struct {
+		name     string
+		settings notificationsModels.SubscriptionSettings
+		tmpl     notificationsConfig.TemplateInfo
+	}{
+		{"no template for subscription", notificationsModels.SubscriptionSettings{}, notificationsConfig.TemplateInfo{Text: "unused"}},
+		{"unknown template", notificationsModels.SubscriptionSettings{Template: "other"}, notificationsConfig.TemplateInfo{Text: "unused"}},
+		{"invalid template", alertSettings, notificationsConfig.TemplateInfo{Text: "{{.Content"}},
+		{"unknown field", alertSettings, notificationsConfig.TemplateInfo{Text: "{{.Unknown}}"}},
+		{"invalid json", alertSettings, notificationsConfig.TemplateInfo{Json: "{{.Content}}"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := templateTestConfig(tt.tmpl)
+			message := renderChannelMessage(n, "ops", tt.settings, "Operators", logger.NewMockClient(), config)
+			assert.Equal(t, expected, message)
+		})
+	}
+}
+
+func TestRenderChannelMessageEmailSettings(t *testing.T) {
+	n := models.Notification{Slug: "temp-high", Sender: "device-virtual", Content: "120"}
+	config := templateTestConfig(notificationsConfig.TemplateInfo{
+		Text: "temperature {{.Content}}",
+		Attachments: []notificationsConfig.AttachmentInfo{
+			{Name: "{{.Slug}}.csv", ContentType: "text/csv", Content: "sender,value\n{{.Sender}},{{.Content}}\n"},
+			{Content: "raw"},
+		},
+	})
+	settings := notificationsModels.SubscriptionSettings{
+		Template:     "alert",
+		EmailSender:  "Night Shift <night@example.com>",
+		EmailSubject: "Boiler alert",
+	}
+
+	message := renderChannelMessage(n, "ops", settings, "Operators", logger.NewMockClient(), config)
+
+	assert.Equal(t, interfaces.ChannelMessage{
+		Subject:     "Boiler alert",
+		Text:        "temperature 120",
+		ContentType: "text/plain",
+		From:        "Night Shift <night@example.com>",
+		Attachments: []interfaces.Attachment{
+			{Name: "temp-high.csv", ContentType: "text/csv", Content: []byte("sender,value\ndevice-virtual,120\n")},
+			{Name: "attachment-2", ContentType: "application/octet-stream", Content: []byte("raw")},
+		},
+	}, message)
+}
@@ -0,0 +1,312 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+const (
+	digestPollInterval     = time.Second
+	digestBatchSize        = 100
+	defaultRateLimitPeriod = time.Minute
+)
+
+// notificationThrottle keeps the deduplication and rate limit windows in memory. The digests of the notifications it
+// holds back are stored in the database, so they survive a restart of the service.
+type notificationThrottle struct {
+	mutex       sync.Mutex
+	distributed map[string]time.Time
+	delivered   map[string][]time.Time
+}
+
+// digest is a batch of notifications delivered to a subscription as a single notification.
+type digest struct {
+	subscription  models.Subscription
+	notifications []models.Notification
+}
+
+// NewNotificationThrottle returns an interfaces.NotificationThrottle keeping its windows in memory.
+func NewNotificationThrottle() interfaces.NotificationThrottle {
+	return &notificationThrottle{
+		distributed: make(map[string]time.Time),
+		delivered:   make(map[string][]time.Time),
+	}
+}
+
+func (t *notificationThrottle) Suppress(
+	n models.Notification,
+	now time.Time,
+	lc logger.LoggingClient,
+	config notificationsConfig.ConfigurationStruct) bool {
+
+	dedup := config.Writable.Deduplication
+	window := parsePolicyDuration(dedup.Window, 0, "Deduplication", lc)
+	if len(dedup.Keys) == 0 || window == 0 {
+		return false
+	}
+	key := deduplicationKey(n, dedup.Keys)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for k, distributed := range t.distributed {
+		if now.Sub(distributed) >= window {
+			delete(t.distributed, k)
+		}
+	}
+	if _, ok := t.distributed[key]; ok {
+		return true
+	}
+	t.distributed[key] = now
+	return false
+}
+
+func (t *notificationThrottle) Admit(
+	n models.Notification,
+	s models.Subscription,
+	settings notificationsModels.SubscriptionSettings,
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) bool {
+
+	digestInterval := parsePolicyDuration(settings.DigestInterval, 0, "settings of subscription "+s.Slug, lc)
+	if digestInterval == 0 && settings.RateLimit <= 0 {
+		return true
+	}
+	if digestInterval > 0 {
+		return !addToDigest(n, s, now.Add(digestInterval), lc, dbClient)
+	}
+
+	period := parsePolicyDuration(settings.RateLimitPeriod, defaultRateLimitPeriod, "settings of subscription "+s.Slug, lc)
+	admitted, due := t.deliver(s.Slug, settings.RateLimit, period, now)
+	if admitted {
+		return true
+	}
+	return !addToDigest(n, s, due, lc, dbClient)
+}
+
+// deliver records a delivery to the subscription unless the rate limit is reached within the period. Otherwise it
+// returns the time the first delivery leaves the period.
+func (t *notificationThrottle) deliver(
+	subscriptionSlug string,
+	limit int,
+	period time.Duration,
+	now time.Time) (bool, time.Time) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delivered := t.delivered[subscriptionSlug]
+	for len(delivered) > 0 && now.Sub(delivered[0]) >= period {
+		delivered = delivered[1:]
+	}
+	if len(delivered) >= limit {
+		t.delivered[subscriptionSlug] = delivered
+		return false, delivered[0].Add(period)
+	}
+	t.delivered[subscriptionSlug] = append(delivered, now)
+	return true, time.Time{}
+}
+
+// addToDigest adds the notification to the digest of the subscription, which becomes due at the given time unless it
+// is pending already. It reports whether the notification is added, otherwise it has to be delivered right away.
+func addToDigest(
+	n models.Notification,
+	s models.Subscription,
+	due time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) bool {
+
+	if err := dbClient.AddDigestNotification(s.Slug, n.Slug, timeToMillis(due)); err != nil {
+		lc.Error("Unable to add notification " + n.Slug + " to the digest of subscription " + s.Slug + ": " + err.Error())
+		return false
+	}
+	return true
+}
+
+// deduplicationKey joins the values of the notification fields named by the keys.
+func deduplicationKey(n models.Notification, keys []string) string {
+	var values []string
+	for _, key := range keys {
+		switch strings.ToLower(key) {
+		case "sender":
+			values = append(values, n.Sender)
+		case "category":
+			values = append(values, string(n.Category))
+		case "severity":
+			values = append(values, string(n.Severity))
+		case "labels":
+			labels := append([]string(nil), n.Labels...)
+			sort.Strings(labels)
+			values = append(values, strings.Join(labels, ","))
+		case "description":
+			values = append(values, n.Description)
+		case "content":
+			values = append(values, n.Content)
+		}
+	}
+	return strings.Join(values, "\x00")
+}
+
+// startDigestProcessor delivers the stored digests as they become due, until the context is done.
+func startDigestProcessor(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(digestPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				processDueDigests(
+					now,
+					bootstrapContainer.LoggingClientFrom(dic.Get),
+					distributionDBClientFrom(dic.Get),
+					*notificationsContainer.ConfigurationFrom(dic.Get))
+			}
+		}
+	}()
+}
+
+func processDueDigests(
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	subscriptions, err := dbClient.GetDigests(timeToMillis(now), digestBatchSize)
+	if err != nil {
+		lc.Error("Unable to get due digests: " + err.Error())
+		return
+	}
+
+	for _, subscriptionSlug := range subscriptions {
+		// only the caller removing the digest gets its notifications
+		slugs, err := dbClient.DeleteDigest(subscriptionSlug)
+		if err != nil {
+			lc.Error("Unable to remove the digest of subscription " + subscriptionSlug + ": " + err.Error())
+			continue
+		}
+		if len(slugs) == 0 {
+			continue
+		}
+		d, ok := loadDigest(subscriptionSlug, slugs, lc, dbClient)
+		if !ok {
+			continue
+		}
+		sendDigest(d, now, lc, dbClient, config)
+	}
+}
+
+// loadDigest loads the subscription and the notifications of a digest, leaving out the notifications which are gone.
+func loadDigest(
+	subscriptionSlug string,
+	notificationSlugs []string,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient) (digest, bool) {
+
+	s, err := dbClient.GetSubscriptionBySlug(subscriptionSlug)
+	if err != nil {
+		lc.Warn("Dropping the digest of subscription " + subscriptionSlug + ": " + err.Error())
+		return digest{}, false
+	}
+
+	d := digest{subscription: s}
+	for _, slug := range notificationSlugs {
+		n, err := dbClient.GetNotificationBySlug(slug)
+		if err != nil {
+			lc.Warn("Dropping notification " + slug + " from the digest of subscription " + subscriptionSlug + ": " +
+				err.Error())
+			continue
+		}
+		d.notifications = append(d.notifications, n)
+	}
+	return d, len(d.notifications) > 0
+}
+
+// sendDigest stores a notification summarizing the digest's notifications and sends it to the digest's subscription.
+func sendDigest(
+	d digest,
+	now time.Time,
+	lc logger.LoggingClient,
+	dbClient interfaces.DBClient,
+	config notificationsConfig.ConfigurationStruct) {
+
+	n := newDigestNotification(d, now)
+	id, err := dbClient.AddNotification(n)
+	if err != nil {
+		lc.Error("Unable to store digest " + n.Slug + ": " + err.Error())
+		return
+	}
+	n.ID = id
+
+	lc.Debug(fmt.Sprintf("Sending digest of %d notifications to %s", len(d.notifications), d.subscription.Slug))
+	send(n, d.subscription, lc, dbClient, config)
+}
+
+func newDigestNotification(d digest, now time.Time) models.Notification {
+	n := models.Notification{
+		Slug:        DIGESTPREFIX + d.subscription.Slug + "-" + strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10),
+		Sender:      clients.SupportNotificationsServiceKey,
+		Category:    d.notifications[0].Category,
+		Severity:    models.Normal,
+		Status:      models.Processed,
+		Description: fmt.Sprintf("Digest of %d notifications", len(d.notifications)),
+		ContentType: "text/plain",
+	}
+
+	labels := make(map[string]bool)
+	var lines []string
+	for _, dn := range d.notifications {
+		if dn.Severity == models.Critical {
+			n.Severity = models.Critical
+		}
+		for _, label := range dn.Labels {
+			labels[label] = true
+		}
+		created := time.Unix(0, dn.Created*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+		lines = append(lines, fmt.Sprintf("%s [%s] %s from %s: %s", created, dn.Severity, dn.Category, dn.Sender, dn.Content))
+	}
+	for label := range labels {
+		n.Labels = append(n.Labels, label)
+	}
+	sort.Strings(n.Labels)
+	n.Content = strings.Join(lines, "\n")
+	return n
+}
@@ -0,0 +1,153 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	notificationsConfig "github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleSuppressesDuplicates(t *testing.T) {
+	config := notificationsConfig.ConfigurationStruct{}
+	config.Writable.Deduplication = notificationsConfig.DeduplicationInfo{
+		Keys:   []string{"Sender", "Category", "Labels"},
+		Window: "10m",
+	}
+	lc := logger.NewMockClient()
+	throttle := NewNotificationThrottle()
+	now := time.Now()
+
+	n := models.Notification{Slug: "n1", Sender: "sensor", Category: models.Hwhealth, Labels: []string{"b", "a"}}
+	flapped := models.Notification{Slug: "n2", Sender: "sensor", Category: models.Hwhealth, Labels: []string{"a", "b"}}
+	other := models.Notification{Slug: "n3", Sender: "other", Category: models.Hwhealth, Labels: []string{"a", "b"}}
+
+	assert.False(t, throttle.Suppress(n, now, lc, config))
+	assert.True(t, throttle.Suppress(flapped, now.Add(time.Minute), lc, config))
+	assert.False(t, throttle.Suppress(other, now.Add(time.Minute), lc, config))
+	// suppressed duplicates do not extend the window
+	assert.False(t, throttle.Suppress(flapped, now.Add(10*time.Minute), lc, config))
+
+	config.Writable.Deduplication.Keys = nil
+	assert.False(t, throttle.Suppress(flapped, now.Add(11*time.Minute), lc, config))
+}
+
+func TestThrottleRateLimit(t *testing.T) {
+	settings := notificationsModels.SubscriptionSettings{RateLimit: 2, RateLimitPeriod: "1h"}
+	lc := logger.NewMockClient()
+	throttle := NewNotificationThrottle()
+	s := models.Subscription{Slug: "limited"}
+	now := time.Now()
+	dbClient := &mocks.DBClient{}
+	// the held back notifications are due once the first delivery leaves the period
+	dbClient.On("AddDigestNotification", "limited", "n3", millis(now.Add(time.Hour))).Return(nil)
+	dbClient.On("AddDigestNotification", "limited", "n4", millis(now.Add(time.Hour))).Return(nil)
+	dbClient.On("AddDigestNotification", "limited", "n5", millis(now.Add(time.Hour))).Return(errors.New("test error"))
+
+	assert.True(t, throttle.Admit(models.Notification{Slug: "n1"}, s, settings, now, lc, dbClient))
+	assert.True(t, throttle.Admit(models.Notification{Slug: "n2"}, s, settings, now.Add(time.Minute), lc, dbClient))
+	assert.False(t, throttle.Admit(models.Notification{Slug: "n3"}, s, settings, now.Add(2*time.Minute), lc, dbClient))
+	assert.False(t, throttle.Admit(models.Notification{Slug: "n4"}, s, settings, now.Add(3*time.Minute), lc, dbClient))
+	// a notification which cannot be added to the digest is delivered right away
+	assert.True(t, throttle.Admit(models.Notification{Slug: "n5"}, s, settings, now.Add(4*time.Minute), lc, dbClient))
+	assert.True(t, throttle.Admit(models.Notification{Slug: "n6"}, models.Subscription{Slug: "unlimited"}, notificationsModels.SubscriptionSettings{}, now, lc, dbClient))
+
+	assert.True(t, throttle.Admit(models.Notification{Slug: "n7"}, s, settings, now.Add(time.Hour), lc, dbClient))
+	dbClient.AssertExpectations(t)
+}
+
+func TestThrottleDigestInterval(t *testing.T) {
+	settings := notificationsModels.SubscriptionSettings{DigestInterval: "15m"}
+	lc := logger.NewMockClient()
+	throttle := NewNotificationThrottle()
+	s := models.Subscription{Slug: "digest"}
+	now := time.Now()
+	dbClient := &mocks.DBClient{}
+	dbClient.On("AddDigestNotification", "digest", "n1", millis(now.Add(15*time.Minute))).Return(nil)
+	dbClient.On("AddDigestNotification", "digest", "n2", millis(now.Add(25*time.Minute))).Return(nil)
+
+	assert.False(t, throttle.Admit(models.Notification{Slug: "n1"}, s, settings, now, lc, dbClient))
+	assert.False(t, throttle.Admit(models.Notification{Slug: "n2"}, s, settings, now.Add(10*time.Minute), lc, dbClient))
+	dbClient.AssertExpectations(t)
+}
+
+func TestProcessDueDigests(t *testing.T) {
+	now := time.Now()
+	s := models.Subscription{Slug: "ops"}
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetDigests", millis(now), digestBatchSize).Return([]string{"ops", "claimed", "gone"}, nil)
+	dbClient.On("DeleteDigest", "ops").Return([]string{"n1", "deleted"}, nil)
+	dbClient.On("DeleteDigest", "claimed").Return([]string{}, nil)
+	dbClient.On("DeleteDigest", "gone").Return([]string{"n2"}, nil)
+	dbClient.On("GetSubscriptionBySlug", "ops").Return(s, nil)
+	dbClient.On("GetSubscriptionBySlug", "gone").Return(models.Subscription{}, db.ErrNotFound)
+	dbClient.On("GetNotificationBySlug", "n1").Return(models.Notification{Slug: "n1", Category: models.Hwhealth}, nil)
+	dbClient.On("GetNotificationBySlug", "deleted").Return(models.Notification{}, db.ErrNotFound)
+	dbClient.On("AddNotification", mock.MatchedBy(func(n models.Notification) bool {
+		return n.Description == "Digest of 1 notifications"
+	})).Return("id", nil)
+
+	processDueDigests(now, logger.NewMockClient(), dbClient, notificationsConfig.ConfigurationStruct{})
+
+	dbClient.AssertExpectations(t)
+	dbClient.AssertNumberOfCalls(t, "AddNotification", 1)
+}
+
+func TestNewDigestNotification(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Minute).UnixNano() / int64(time.Millisecond)
+	d := digest{
+		subscription: models.Subscription{Slug: "ops"},
+		notifications: []models.Notification{
+			{Sender: "s1", Category: models.Hwhealth, Severity: models.Normal, Labels: []string{"b"}, Content: "first", Timestamps: models.Timestamps{Created: created}},
+			{Sender: "s2", Category: models.Swhealth, Severity: models.Critical, Labels: []string{"a", "b"}, Content: "second", Timestamps: models.Timestamps{Created: created}},
+		},
+	}
+
+	n := newDigestNotification(d, now)
+
+	assert.Equal(t, "digest-ops-1614600000000", n.Slug)
+	assert.Equal(t, models.NotificationsSeverity(models.Critical), n.Severity)
+	assert.Equal(t, models.NotificationsCategory(models.Hwhealth), n.Category)
+	assert.Equal(t, []string{"a", "b"}, n.Labels)
+	assert.Equal(t,
+		"2021-03-01T11:59:00Z [NORMAL] HW_HEALTH from s1: first\n2021-03-01T11:59:00Z [CRITICAL] SW_HEALTH from s2: second",
+		n.Content)
+}
+
+func TestDistributeSkipsSuppressedNotifications(t *testing.T) {
+	n := models.Notification{Slug: "n1"}
+	throttle := &mocks.NotificationThrottle{}
+	throttle.On("Suppress", n, mock.Anything, mock.Anything, mock.Anything).Return(true)
+	dbClient := &mocks.DBClient{}
+
+	err := distribute(n, throttle, logger.NewMockClient(), dbClient, notificationsConfig.ConfigurationStruct{})
+
+	require.NoError(t, err)
+	throttle.AssertExpectations(t)
+	dbClient.AssertExpectations(t)
+}
@@ -0,0 +1,163 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// The AddNotification function accepts the new notification model from the controller function
+// and then invokes AddNotification function of infrastructure layer to add new notification.
+// The stored notification is distributed to its subscriptions the same way as the V1 notifications.
+func AddNotification(n models.Notification, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	distributor := v2NotificationsContainer.NotificationDistributorFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	// the notification is handed to the distribution right after it is stored, which is when V1 marks it processed
+	if n.Status == "" || n.Status == models.New {
+		n.Status = models.Processed
+	}
+
+	addedNotification, err := dbClient.AddNotification(n)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf(
+		"Notification created on DB successfully. Notification ID: %s, Correlation-ID: %s ",
+		addedNotification.Id,
+		correlation.FromContext(ctx),
+	)
+
+	distributor.Distribute(addedNotification)
+	return addedNotification.Id, nil
+}
+
+// NotificationBySlug query the notification by slug
+func NotificationBySlug(slug string, dic *di.Container) (notification dtos.Notification, err errors.EdgeX) {
+	if slug == "" {
+		return notification, errors.NewCommonEdgeX(errors.KindContractInvalid, "slug is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	n, err := dbClient.NotificationBySlug(slug)
+	if err != nil {
+		return notification, errors.NewCommonEdgeXWrapper(err)
+	}
+	return dtos.FromNotificationModelToDTO(n), nil
+}
+
+// NotificationsByCategory query notifications with offset, limit, and category
+func NotificationsByCategory(offset, limit int, category string, dic *di.Container) (notifications []dtos.Notification, err errors.EdgeX) {
+	if err = validateCategory(category); err != nil {
+		return notifications, err
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	notificationModels, err := dbClient.NotificationsByCategory(offset, limit, category)
+	if err != nil {
+		return notifications, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromNotificationModelsToDTOs(notificationModels), nil
+}
+
+// NotificationsByLabel query notifications with offset, limit, and label
+func NotificationsByLabel(offset, limit int, label string, dic *di.Container) (notifications []dtos.Notification, err errors.EdgeX) {
+	if label == "" {
+		return notifications, errors.NewCommonEdgeX(errors.KindContractInvalid, "label is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	notificationModels, err := dbClient.NotificationsByLabel(offset, limit, label)
+	if err != nil {
+		return notifications, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromNotificationModelsToDTOs(notificationModels), nil
+}
+
+// NotificationsByStatus query notifications with offset, limit, and status
+func NotificationsByStatus(offset, limit int, status string, dic *di.Container) (notifications []dtos.Notification, err errors.EdgeX) {
+	switch models.NotificationStatus(status) {
+	case models.New, models.Processed, models.Escalated:
+	default:
+		return notifications, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid notification status %s", status), nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	notificationModels, err := dbClient.NotificationsByStatus(offset, limit, status)
+	if err != nil {
+		return notifications, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromNotificationModelsToDTOs(notificationModels), nil
+}
+
+// NotificationsByTimeRange query notifications with offset, limit and time range
+func NotificationsByTimeRange(start int, end int, offset int, limit int, dic *di.Container) (notifications []dtos.Notification, err errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	notificationModels, err := dbClient.NotificationsByTimeRange(start, end, offset, limit)
+	if err != nil {
+		return notifications, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromNotificationModelsToDTOs(notificationModels), nil
+}
+
+// DeleteNotificationBySlug deletes the notification and its transmissions by slug
+func DeleteNotificationBySlug(slug string, dic *di.Container) errors.EdgeX {
+	if slug == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "slug is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteNotificationBySlug(slug)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// DeleteProcessedNotificationsByAge removes the processed notifications and their transmissions that are older
+// than age. Age is supposed in milliseconds since the last modification.
+func DeleteProcessedNotificationsByAge(age int64, dic *di.Container) errors.EdgeX {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteProcessedNotificationsByAge(age)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// CleanupNotificationsByAge removes all notifications and their transmissions that are older than age, regardless
+// of their status. Age is supposed in milliseconds since the last modification.
+func CleanupNotificationsByAge(age int64, dic *di.Container) errors.EdgeX {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.CleanupNotificationsByAge(age)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+func fromNotificationModelsToDTOs(notificationModels []models.Notification) []dtos.Notification {
+	notifications := make([]dtos.Notification, len(notificationModels))
+	for i, n := range notificationModels {
+		notifications[i] = dtos.FromNotificationModelToDTO(n)
+	}
+	return notifications
+}
+
+func validateCategory(category string) errors.EdgeX {
+	switch models.NotificationCategory(category) {
+	case models.Security, models.HwHealth, models.SwHealth:
+		return nil
+	}
+	return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid notification category %s", category), nil)
+}
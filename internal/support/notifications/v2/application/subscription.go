@@ -0,0 +1,192 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/requests"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// The AddSubscription function accepts the new subscription model from the controller function
+// and then invokes AddSubscription function of infrastructure layer to add new subscription
+func AddSubscription(s models.Subscription, ctx context.Context, dic *di.Container) (id string, err errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	addedSubscription, err := dbClient.AddSubscription(s)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+
+	lc.Debugf(
+		"Subscription created on DB successfully. Subscription ID: %s, Correlation-ID: %s ",
+		addedSubscription.Id,
+		correlation.FromContext(ctx),
+	)
+
+	return addedSubscription.Id, nil
+}
+
+// SubscriptionById query the subscription by id
+func SubscriptionById(id string, dic *di.Container) (subscription dtos.Subscription, err errors.EdgeX) {
+	if id == "" {
+		return subscription, errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	s, err := dbClient.SubscriptionById(id)
+	if err != nil {
+		return subscription, errors.NewCommonEdgeXWrapper(err)
+	}
+	return dtos.FromSubscriptionModelToDTO(s), nil
+}
+
+// SubscriptionBySlug query the subscription by slug
+func SubscriptionBySlug(slug string, dic *di.Container) (subscription dtos.Subscription, err errors.EdgeX) {
+	if slug == "" {
+		return subscription, errors.NewCommonEdgeX(errors.KindContractInvalid, "slug is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	s, err := dbClient.SubscriptionBySlug(slug)
+	if err != nil {
+		return subscription, errors.NewCommonEdgeXWrapper(err)
+	}
+	return dtos.FromSubscriptionModelToDTO(s), nil
+}
+
+// AllSubscriptions query the subscriptions with offset and limit
+func AllSubscriptions(offset int, limit int, dic *di.Container) (subscriptions []dtos.Subscription, err errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	subscriptionModels, err := dbClient.AllSubscriptions(offset, limit)
+	if err != nil {
+		return subscriptions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromSubscriptionModelsToDTOs(subscriptionModels), nil
+}
+
+// SubscriptionsByCategory query subscriptions with offset, limit, and category
+func SubscriptionsByCategory(offset, limit int, category string, dic *di.Container) (subscriptions []dtos.Subscription, err errors.EdgeX) {
+	if err = validateCategory(category); err != nil {
+		return subscriptions, err
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	subscriptionModels, err := dbClient.SubscriptionsByCategory(offset, limit, category)
+	if err != nil {
+		return subscriptions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromSubscriptionModelsToDTOs(subscriptionModels), nil
+}
+
+// SubscriptionsByLabel query subscriptions with offset, limit, and label
+func SubscriptionsByLabel(offset, limit int, label string, dic *di.Container) (subscriptions []dtos.Subscription, err errors.EdgeX) {
+	if label == "" {
+		return subscriptions, errors.NewCommonEdgeX(errors.KindContractInvalid, "label is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	subscriptionModels, err := dbClient.SubscriptionsByLabel(offset, limit, label)
+	if err != nil {
+		return subscriptions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromSubscriptionModelsToDTOs(subscriptionModels), nil
+}
+
+// SubscriptionsByReceiver query subscriptions with offset, limit, and receiver
+func SubscriptionsByReceiver(offset, limit int, receiver string, dic *di.Container) (subscriptions []dtos.Subscription, err errors.EdgeX) {
+	if receiver == "" {
+		return subscriptions, errors.NewCommonEdgeX(errors.KindContractInvalid, "receiver is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	subscriptionModels, err := dbClient.SubscriptionsByReceiver(offset, limit, receiver)
+	if err != nil {
+		return subscriptions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromSubscriptionModelsToDTOs(subscriptionModels), nil
+}
+
+// PatchSubscription executes the PATCH operation with the subscription DTO to replace the old data
+func PatchSubscription(dto dtos.UpdateSubscription, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	var subscription models.Subscription
+	var edgeXerr errors.EdgeX
+	if dto.Id != nil {
+		subscription, edgeXerr = dbClient.SubscriptionById(*dto.Id)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	} else {
+		subscription, edgeXerr = dbClient.SubscriptionBySlug(*dto.Slug)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+	if dto.Slug != nil && *dto.Slug != subscription.Slug {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("subscription slug '%s' not match the exsting '%s' ", *dto.Slug, subscription.Slug), nil)
+	}
+
+	requests.ReplaceSubscriptionModelFieldsWithDTO(&subscription, dto)
+
+	edgeXerr = dbClient.DeleteSubscriptionById(subscription.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	_, edgeXerr = dbClient.AddSubscription(subscription)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	lc.Debugf(
+		"Subscription patched on DB successfully. Correlation-ID: %s ",
+		correlation.FromContext(ctx),
+	)
+
+	return nil
+}
+
+// DeleteSubscriptionById deletes the subscription by id
+func DeleteSubscriptionById(id string, dic *di.Container) errors.EdgeX {
+	if id == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "id is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteSubscriptionById(id)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+// DeleteSubscriptionBySlug deletes the subscription by slug
+func DeleteSubscriptionBySlug(slug string, dic *di.Container) errors.EdgeX {
+	if slug == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "slug is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteSubscriptionBySlug(slug)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+func fromSubscriptionModelsToDTOs(subscriptionModels []models.Subscription) []dtos.Subscription {
+	subscriptions := make([]dtos.Subscription, len(subscriptionModels))
+	for i, s := range subscriptionModels {
+		subscriptions[i] = dtos.FromSubscriptionModelToDTO(s)
+	}
+	return subscriptions
+}
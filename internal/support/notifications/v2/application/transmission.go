@@ -0,0 +1,84 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// AllTransmissions query the transmissions with offset and limit
+func AllTransmissions(offset int, limit int, dic *di.Container) (transmissions []dtos.Transmission, err errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	transmissionModels, err := dbClient.AllTransmissions(offset, limit)
+	if err != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromTransmissionModelsToDTOs(transmissionModels), nil
+}
+
+// TransmissionsByNotificationSlug query the transmissions that originated with the notification identified by slug
+func TransmissionsByNotificationSlug(offset int, limit int, slug string, dic *di.Container) (transmissions []dtos.Transmission, err errors.EdgeX) {
+	if slug == "" {
+		return transmissions, errors.NewCommonEdgeX(errors.KindContractInvalid, "slug is empty", nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	transmissionModels, err := dbClient.TransmissionsByNotificationSlug(offset, limit, slug)
+	if err != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromTransmissionModelsToDTOs(transmissionModels), nil
+}
+
+// TransmissionsByStatus query the transmissions with offset, limit, and status
+func TransmissionsByStatus(offset int, limit int, status string, dic *di.Container) (transmissions []dtos.Transmission, err errors.EdgeX) {
+	switch models.TransmissionStatus(status) {
+	case models.Acknowledged, models.Failed, models.Sent, models.Trxescalated:
+	default:
+		return transmissions, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid transmission status %s", status), nil)
+	}
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	transmissionModels, err := dbClient.TransmissionsByStatus(offset, limit, status)
+	if err != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromTransmissionModelsToDTOs(transmissionModels), nil
+}
+
+// TransmissionsByTimeRange query the transmissions with offset, limit and time range
+func TransmissionsByTimeRange(start int, end int, offset int, limit int, dic *di.Container) (transmissions []dtos.Transmission, err errors.EdgeX) {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	transmissionModels, err := dbClient.TransmissionsByTimeRange(start, end, offset, limit)
+	if err != nil {
+		return transmissions, errors.NewCommonEdgeXWrapper(err)
+	}
+	return fromTransmissionModelsToDTOs(transmissionModels), nil
+}
+
+// DeleteProcessedTransmissionsByAge removes the processed transmissions that are older than age.
+// Age is supposed in milliseconds since the last modification.
+func DeleteProcessedTransmissionsByAge(age int64, dic *di.Container) errors.EdgeX {
+	dbClient := v2NotificationsContainer.DBClientFrom(dic.Get)
+	err := dbClient.DeleteProcessedTransmissionsByAge(age)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
+
+func fromTransmissionModelsToDTOs(transmissionModels []models.Transmission) []dtos.Transmission {
+	transmissions := make([]dtos.Transmission, len(transmissionModels))
+	for i, t := range transmissionModels {
+		transmissions[i] = dtos.FromTransmissionModelToDTO(t)
+	}
+	return transmissions
+}
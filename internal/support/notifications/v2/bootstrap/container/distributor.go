@@ -0,0 +1,21 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+)
+
+// NotificationDistributorName contains the name of the interfaces.NotificationDistributor implementation in the DIC.
+var NotificationDistributorName = di.TypeInstanceToName((*interfaces.NotificationDistributor)(nil))
+
+// NotificationDistributorFrom helper function queries the DIC and returns the interfaces.NotificationDistributor
+// implementation.
+func NotificationDistributorFrom(get di.Get) interfaces.NotificationDistributor {
+	return get(NotificationDistributorName).(interfaces.NotificationDistributor)
+}
@@ -0,0 +1,48 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+import (
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// Constants related to defined routes in the support-notifications v2 service APIs
+const (
+	ApiCleanupRoute      = contractsV2.ApiBase + "/cleanup"
+	ApiCleanupByAgeRoute = ApiCleanupRoute + "/" + contractsV2.Age + "/{" + contractsV2.Age + "}"
+
+	ApiNotificationRoute            = contractsV2.ApiBase + "/notification"
+	ApiNotificationBySlugRoute      = ApiNotificationRoute + "/" + Slug + "/{" + Slug + "}"
+	ApiNotificationByCategoryRoute  = ApiNotificationRoute + "/" + Category + "/{" + Category + "}"
+	ApiNotificationByLabelRoute     = ApiNotificationRoute + "/" + contractsV2.Label + "/{" + contractsV2.Label + "}"
+	ApiNotificationByStatusRoute    = ApiNotificationRoute + "/" + Status + "/{" + Status + "}"
+	ApiNotificationByTimeRangeRoute = ApiNotificationRoute + "/" + contractsV2.Start + "/{" + contractsV2.Start + "}/" + contractsV2.End + "/{" + contractsV2.End + "}"
+	ApiNotificationByAgeRoute       = ApiNotificationRoute + "/" + contractsV2.Age + "/{" + contractsV2.Age + "}"
+
+	ApiSubscriptionRoute           = contractsV2.ApiBase + "/subscription"
+	ApiAllSubscriptionRoute        = ApiSubscriptionRoute + "/" + contractsV2.All
+	ApiSubscriptionByIdRoute       = ApiSubscriptionRoute + "/" + contractsV2.Id + "/{" + contractsV2.Id + "}"
+	ApiSubscriptionBySlugRoute     = ApiSubscriptionRoute + "/" + Slug + "/{" + Slug + "}"
+	ApiSubscriptionByCategoryRoute = ApiSubscriptionRoute + "/" + Category + "/{" + Category + "}"
+	ApiSubscriptionByLabelRoute    = ApiSubscriptionRoute + "/" + contractsV2.Label + "/{" + contractsV2.Label + "}"
+	ApiSubscriptionByReceiverRoute = ApiSubscriptionRoute + "/" + Receiver + "/{" + Receiver + "}"
+
+	ApiTransmissionRoute            = contractsV2.ApiBase + "/transmission"
+	ApiAllTransmissionRoute         = ApiTransmissionRoute + "/" + contractsV2.All
+	ApiTransmissionBySlugRoute      = ApiTransmissionRoute + "/" + Slug + "/{" + Slug + "}"
+	ApiTransmissionByStatusRoute    = ApiTransmissionRoute + "/" + Status + "/{" + Status + "}"
+	ApiTransmissionByTimeRangeRoute = ApiTransmissionRoute + "/" + contractsV2.Start + "/{" + contractsV2.Start + "}/" + contractsV2.End + "/{" + contractsV2.End + "}"
+	ApiTransmissionByAgeRoute       = ApiTransmissionRoute + "/" + contractsV2.Age + "/{" + contractsV2.Age + "}"
+)
+
+// Constants related to defined url path names and parameters in the support-notifications v2 service APIs
+const (
+	Slug         = "slug"
+	Category     = "category"
+	Status       = "status"
+	Receiver     = "receiver"
+	Notification = "notification"
+)
@@ -0,0 +1,18 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+const (
+	ExampleUUID          = "82eb2e26-0f24-48aa-ae4c-de9dac3fb9bc"
+	TestNotificationSlug = "TestNotificationSlug"
+	TestSubscriptionSlug = "TestSubscriptionSlug"
+	TestSender           = "TestSender"
+	TestReceiver         = "TestReceiver"
+	TestContent          = "TestContent"
+	TestDescription      = "TestDescription"
+	TestLabel            = "TestLabel"
+	TestUrl              = "http://localhost:48089/api/v2/notify"
+)
@@ -0,0 +1,312 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/io"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+type NotificationController struct {
+	reader io.NotificationReader
+	dic    *di.Container
+}
+
+// NewNotificationController creates and initializes an NotificationController
+func NewNotificationController(dic *di.Container) *NotificationController {
+	return &NotificationController{
+		reader: io.NewNotificationRequestReader(),
+		dic:    dic,
+	}
+}
+
+func (nc *NotificationController) AddNotification(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(nc.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	addNotificationDTOs, err := nc.reader.ReadAddNotificationRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		// Encode and send the resp body as JSON format
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+	notifications := requestDTO.AddNotificationReqToNotificationModels(addNotificationDTOs)
+
+	var addResponses []interface{}
+	for i, n := range notifications {
+		newId, err := application.AddNotification(n, ctx, nc.dic)
+		var addNotificationResponse interface{}
+		// get the requestID from addNotificationDTOs
+		reqId := addNotificationDTOs[i].RequestId
+
+		if err == nil {
+			addNotificationResponse = commonDTO.NewBaseWithIdResponse(
+				reqId,
+				"",
+				http.StatusCreated,
+				newId)
+		} else {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			addNotificationResponse = commonDTO.NewBaseResponse(
+				reqId,
+				err.Error(),
+				err.Code())
+		}
+		addResponses = append(addResponses, addNotificationResponse)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	// Encode and send the resp body as JSON format
+	pkg.Encode(addResponses, w, lc)
+}
+
+func (nc *NotificationController) NotificationBySlug(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(nc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	slug := vars[constants.Slug]
+
+	var response interface{}
+	var statusCode int
+
+	notification, err := application.NotificationBySlug(slug, nc.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = responseDTO.NewNotificationResponse("", "", http.StatusOK, notification)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (nc *NotificationController) NotificationsByCategory(w http.ResponseWriter, r *http.Request) {
+	nc.notificationsByPathParam(w, r, constants.Category, application.NotificationsByCategory)
+}
+
+func (nc *NotificationController) NotificationsByLabel(w http.ResponseWriter, r *http.Request) {
+	nc.notificationsByPathParam(w, r, contractsV2.Label, application.NotificationsByLabel)
+}
+
+func (nc *NotificationController) NotificationsByStatus(w http.ResponseWriter, r *http.Request) {
+	nc.notificationsByPathParam(w, r, constants.Status, application.NotificationsByStatus)
+}
+
+// notificationsByPathParam queries notifications by the value of the specified path parameter with offset and limit
+func (nc *NotificationController) notificationsByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	query func(offset, limit int, value string, dic *di.Container) ([]dtos.Notification, errors.EdgeX)) {
+
+	lc := container.LoggingClientFrom(nc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := notificationsContainer.ConfigurationFrom(nc.dic.Get)
+
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		notifications, err := query(offset, limit, value, nc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiNotificationsResponse("", "", http.StatusOK, notifications)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (nc *NotificationController) NotificationsByTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(nc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := notificationsContainer.ConfigurationFrom(nc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse time range (start, end), offset, and limit from incoming request
+	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		notifications, err := application.NotificationsByTimeRange(start, end, offset, limit, nc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiNotificationsResponse("", "", http.StatusOK, notifications)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (nc *NotificationController) DeleteNotificationBySlug(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(nc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	slug := vars[constants.Slug]
+
+	var response interface{}
+	var statusCode int
+
+	err := application.DeleteNotificationBySlug(slug, nc.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (nc *NotificationController) DeleteProcessedNotificationsByAge(w http.ResponseWriter, r *http.Request) {
+	deleteByAge(w, r, nc.dic, application.DeleteProcessedNotificationsByAge)
+}
+
+func (nc *NotificationController) CleanupNotificationsByAge(w http.ResponseWriter, r *http.Request) {
+	deleteByAge(w, r, nc.dic, application.CleanupNotificationsByAge)
+}
+
+func (nc *NotificationController) CleanupNotifications(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(nc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	err := application.CleanupNotificationsByAge(0, nc.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+// deleteByAge parses the age path parameter and hands it to the specified deletion
+func deleteByAge(w http.ResponseWriter, r *http.Request, dic *di.Container, deletion func(age int64, dic *di.Container) errors.EdgeX) {
+	lc := container.LoggingClientFrom(dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	var response interface{}
+	var statusCode int
+
+	vars := mux.Vars(r)
+	age, parsingErr := strconv.ParseInt(vars[contractsV2.Age], 10, 64)
+
+	if parsingErr != nil {
+		err := errors.NewCommonEdgeX(errors.KindContractInvalid, "age format parsing failed", parsingErr)
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		err := deletion(age, dic)
+		if err != nil {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
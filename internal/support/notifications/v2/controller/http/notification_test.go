@@ -0,0 +1,382 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/config"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/requests"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/responses"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockDic() *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		notificationsContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					LogLevel: "DEBUG",
+				},
+				Service: bootstrapConfig.ServiceInfo{
+					MaxResultCount: 30,
+				},
+			}
+		},
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+}
+
+func buildTestAddNotificationRequest() requests.AddNotificationRequest {
+	return requests.AddNotificationRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Notification: dtos.Notification{
+			Id:       ExampleUUID,
+			Slug:     TestNotificationSlug,
+			Sender:   TestSender,
+			Category: string(models.SwHealth),
+			Severity: string(models.Normal),
+			Content:  TestContent,
+			Labels:   []string{TestLabel},
+		},
+	}
+}
+
+func TestAddNotification(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+
+	valid := buildTestAddNotificationRequest()
+	model := dtos.ToNotificationModel(valid.Notification)
+	dbClientMock.On("AddNotification", mock.Anything).Return(model, nil).Once()
+	noRequestId := buildTestAddNotificationRequest()
+	noRequestId.RequestId = ""
+	dbClientMock.On("AddNotification", mock.Anything).Return(model, nil).Once()
+	duplicated := buildTestAddNotificationRequest()
+	dbClientMock.On("AddNotification", mock.Anything).Return(model, errors.NewCommonEdgeX(errors.KindDuplicateName, "notification slug exists", nil))
+
+	noSlug := buildTestAddNotificationRequest()
+	noSlug.Notification.Slug = ""
+	invalidCategory := buildTestAddNotificationRequest()
+	invalidCategory.Notification.Category = "foo"
+	invalidSeverity := buildTestAddNotificationRequest()
+	invalidSeverity.Notification.Severity = "foo"
+	noContent := buildTestAddNotificationRequest()
+	noContent.Notification.Content = ""
+
+	distributorMock := &dbMock.NotificationDistributor{}
+	distributorMock.On("Distribute", model).Return()
+
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		v2NotificationsContainer.NotificationDistributorName: func(get di.Get) interface{} {
+			return distributorMock
+		},
+	})
+	controller := NewNotificationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.AddNotificationRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.AddNotificationRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Valid - no requestId", []requests.AddNotificationRequest{noRequestId}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - duplicated slug", []requests.AddNotificationRequest{duplicated}, http.StatusMultiStatus, http.StatusConflict},
+		{"Invalid - no slug", []requests.AddNotificationRequest{noSlug}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid category", []requests.AddNotificationRequest{invalidCategory}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid severity", []requests.AddNotificationRequest{invalidSeverity}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no content", []requests.AddNotificationRequest{noContent}, http.StatusBadRequest, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPost, constants.ApiNotificationRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddNotification)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseWithIdResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+	dbClientMock.AssertCalled(t, "AddNotification", mock.MatchedBy(func(n models.Notification) bool {
+		return n.Status == models.Processed
+	}))
+	distributorMock.AssertNumberOfCalls(t, "Distribute", 2)
+}
+
+func TestNotificationBySlug(t *testing.T) {
+	notification := dtos.ToNotificationModel(buildTestAddNotificationRequest().Notification)
+	emptySlug := ""
+	notFoundSlug := "notFoundSlug"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("NotificationBySlug", notification.Slug).Return(notification, nil)
+	dbClientMock.On("NotificationBySlug", notFoundSlug).Return(models.Notification{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "notification doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewNotificationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		slug               string
+		errorExpected      bool
+		expectedStatusCode int
+	}{
+		{"Valid - find notification by slug", notification.Slug, false, http.StatusOK},
+		{"Invalid - slug parameter is empty", emptySlug, true, http.StatusBadRequest},
+		{"Invalid - notification not found by slug", notFoundSlug, true, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiNotificationBySlugRoute, testCase.slug)
+			req, err := http.NewRequest(http.MethodGet, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{constants.Slug: testCase.slug})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.NotificationBySlug)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.NotificationResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.slug, res.Notification.Slug, "Slug not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestNotificationsByCategory(t *testing.T) {
+	notification := dtos.ToNotificationModel(buildTestAddNotificationRequest().Notification)
+	category := string(models.SwHealth)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("NotificationsByCategory", 0, 20, category).Return([]models.Notification{notification}, nil)
+	dbClientMock.On("NotificationsByCategory", 0, 1, category).Return([]models.Notification{notification}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewNotificationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		category           string
+		offset             string
+		limit              string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - get notifications by category", category, "0", "20", false, 1, http.StatusOK},
+		{"Valid - get notifications by category with limit", category, "0", "1", false, 1, http.StatusOK},
+		{"Invalid - unknown category", "foo", "0", "20", true, 0, http.StatusBadRequest},
+		{"Invalid - invalid offset format", category, "aaa", "20", true, 0, http.StatusBadRequest},
+		{"Invalid - invalid limit format", category, "0", "aaa", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, constants.ApiNotificationByCategoryRoute, http.NoBody)
+			query := req.URL.Query()
+			query.Add(contractsV2.Offset, testCase.offset)
+			query.Add(contractsV2.Limit, testCase.limit)
+			req.URL.RawQuery = query.Encode()
+			req = mux.SetURLVars(req, map[string]string{constants.Category: testCase.category})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.NotificationsByCategory)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.MultiNotificationsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.expectedCount, len(res.Notifications), "Notification count not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestDeleteNotificationBySlug(t *testing.T) {
+	notFoundSlug := "notFoundSlug"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeleteNotificationBySlug", TestNotificationSlug).Return(nil)
+	dbClientMock.On("DeleteNotificationBySlug", notFoundSlug).Return(errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "notification doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewNotificationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		slug               string
+		expectedStatusCode int
+	}{
+		{"Valid - delete notification by slug", TestNotificationSlug, http.StatusOK},
+		{"Invalid - slug parameter is empty", "", http.StatusBadRequest},
+		{"Invalid - notification not found by slug", notFoundSlug, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiNotificationBySlugRoute, testCase.slug)
+			req, err := http.NewRequest(http.MethodDelete, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{constants.Slug: testCase.slug})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteNotificationBySlug)
+			handler.ServeHTTP(recorder, req)
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+		})
+	}
+}
+
+func TestDeleteProcessedNotificationsByAge(t *testing.T) {
+	age := int64(1000)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeleteProcessedNotificationsByAge", age).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewNotificationController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		age                string
+		expectedStatusCode int
+	}{
+		{"Valid - delete processed notifications by age", fmt.Sprint(age), http.StatusOK},
+		{"Invalid - age is not a number", "aaa", http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodDelete, constants.ApiNotificationByAgeRoute, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Age: testCase.age})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteProcessedNotificationsByAge)
+			handler.ServeHTTP(recorder, req)
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+		})
+	}
+}
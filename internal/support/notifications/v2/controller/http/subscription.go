@@ -0,0 +1,292 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/io"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+type SubscriptionController struct {
+	reader io.SubscriptionReader
+	dic    *di.Container
+}
+
+// NewSubscriptionController creates and initializes an SubscriptionController
+func NewSubscriptionController(dic *di.Container) *SubscriptionController {
+	return &SubscriptionController{
+		reader: io.NewSubscriptionRequestReader(),
+		dic:    dic,
+	}
+}
+
+func (sc *SubscriptionController) AddSubscription(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	addSubscriptionDTOs, err := sc.reader.ReadAddSubscriptionRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		// Encode and send the resp body as JSON format
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+	subscriptions := requestDTO.AddSubscriptionReqToSubscriptionModels(addSubscriptionDTOs)
+
+	var addResponses []interface{}
+	for i, s := range subscriptions {
+		newId, err := application.AddSubscription(s, ctx, sc.dic)
+		var addSubscriptionResponse interface{}
+		// get the requestID from addSubscriptionDTOs
+		reqId := addSubscriptionDTOs[i].RequestId
+
+		if err == nil {
+			addSubscriptionResponse = commonDTO.NewBaseWithIdResponse(
+				reqId,
+				"",
+				http.StatusCreated,
+				newId)
+		} else {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			addSubscriptionResponse = commonDTO.NewBaseResponse(
+				reqId,
+				err.Error(),
+				err.Code())
+		}
+		addResponses = append(addResponses, addSubscriptionResponse)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	// Encode and send the resp body as JSON format
+	pkg.Encode(addResponses, w, lc)
+}
+
+func (sc *SubscriptionController) PatchSubscription(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	updateSubscriptionDTOs, err := sc.reader.ReadUpdateSubscriptionRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+
+	var updateResponses []interface{}
+	for _, dto := range updateSubscriptionDTOs {
+		var response interface{}
+		reqId := dto.RequestId
+		err := application.PatchSubscription(dto.Subscription, ctx, sc.dic)
+		if err != nil {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(
+				reqId,
+				err.Message(),
+				err.Code())
+		} else {
+			response = commonDTO.NewBaseResponse(
+				reqId,
+				"",
+				http.StatusOK)
+		}
+		updateResponses = append(updateResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	pkg.Encode(updateResponses, w, lc)
+}
+
+func (sc *SubscriptionController) SubscriptionById(w http.ResponseWriter, r *http.Request) {
+	sc.subscriptionByPathParam(w, r, contractsV2.Id, application.SubscriptionById)
+}
+
+func (sc *SubscriptionController) SubscriptionBySlug(w http.ResponseWriter, r *http.Request) {
+	sc.subscriptionByPathParam(w, r, constants.Slug, application.SubscriptionBySlug)
+}
+
+// subscriptionByPathParam queries a single subscription by the value of the specified path parameter
+func (sc *SubscriptionController) subscriptionByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	query func(value string, dic *di.Container) (dtos.Subscription, errors.EdgeX)) {
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	subscription, err := query(value, sc.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = responseDTO.NewSubscriptionResponse("", "", http.StatusOK, subscription)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (sc *SubscriptionController) AllSubscriptions(w http.ResponseWriter, r *http.Request) {
+	sc.subscriptionsByPathParam(w, r, "", func(offset, limit int, _ string, dic *di.Container) ([]dtos.Subscription, errors.EdgeX) {
+		return application.AllSubscriptions(offset, limit, dic)
+	})
+}
+
+func (sc *SubscriptionController) SubscriptionsByCategory(w http.ResponseWriter, r *http.Request) {
+	sc.subscriptionsByPathParam(w, r, constants.Category, application.SubscriptionsByCategory)
+}
+
+func (sc *SubscriptionController) SubscriptionsByLabel(w http.ResponseWriter, r *http.Request) {
+	sc.subscriptionsByPathParam(w, r, contractsV2.Label, application.SubscriptionsByLabel)
+}
+
+func (sc *SubscriptionController) SubscriptionsByReceiver(w http.ResponseWriter, r *http.Request) {
+	sc.subscriptionsByPathParam(w, r, constants.Receiver, application.SubscriptionsByReceiver)
+}
+
+// subscriptionsByPathParam queries subscriptions by the value of the specified path parameter with offset and limit
+func (sc *SubscriptionController) subscriptionsByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	query func(offset, limit int, value string, dic *di.Container) ([]dtos.Subscription, errors.EdgeX)) {
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := notificationsContainer.ConfigurationFrom(sc.dic.Get)
+
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		subscriptions, err := query(offset, limit, value, sc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiSubscriptionsResponse("", "", http.StatusOK, subscriptions)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (sc *SubscriptionController) DeleteSubscriptionById(w http.ResponseWriter, r *http.Request) {
+	sc.deleteSubscriptionByPathParam(w, r, contractsV2.Id, application.DeleteSubscriptionById)
+}
+
+func (sc *SubscriptionController) DeleteSubscriptionBySlug(w http.ResponseWriter, r *http.Request) {
+	sc.deleteSubscriptionByPathParam(w, r, constants.Slug, application.DeleteSubscriptionBySlug)
+}
+
+// deleteSubscriptionByPathParam deletes a subscription by the value of the specified path parameter
+func (sc *SubscriptionController) deleteSubscriptionByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	deletion func(value string, dic *di.Container) errors.EdgeX) {
+
+	lc := container.LoggingClientFrom(sc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	err := deletion(value, sc.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
@@ -0,0 +1,487 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/requests"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/responses"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestAddSubscriptionRequest() requests.AddSubscriptionRequest {
+	return requests.AddSubscriptionRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Subscription: dtos.Subscription{
+			Id:       ExampleUUID,
+			Slug:     TestSubscriptionSlug,
+			Receiver: TestReceiver,
+			Channels: []dtos.Channel{
+				{Type: string(models.Rest), Url: TestUrl},
+				{Type: string(models.Mqtt), Url: "mqtt://broker:1883/alerts"},
+				{Type: string(models.Webhook), Webhook: "pagerduty"},
+				{Type: string(models.Sms), SmsGateway: "twilio", PhoneNumbers: []string{"+4915112345678"}},
+			},
+			Categories:  []string{string(models.SwHealth)},
+			Labels:      []string{TestLabel},
+			MinSeverity: string(models.Critical),
+			TimeZone:    "Europe/Berlin",
+			DeliveryWindows: []dtos.DeliveryWindow{
+				{Days: []string{"Mon", "Fri"}, Start: "08:00", End: "18:00"},
+			},
+			QuietHours: "delay",
+		},
+	}
+}
+
+func buildTestUpdateSubscriptionRequest() requests.UpdateSubscriptionRequest {
+	testUUID := ExampleUUID
+	testSlug := TestSubscriptionSlug
+	testReceiver := TestReceiver
+	testDescription := TestDescription
+	return requests.UpdateSubscriptionRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Subscription: dtos.UpdateSubscription{
+			Id:          &testUUID,
+			Slug:        &testSlug,
+			Receiver:    &testReceiver,
+			Description: &testDescription,
+			Channels: []dtos.Channel{
+				{Type: string(models.Email), EmailAddresses: []string{"test@example.com"}},
+			},
+			Labels: []string{TestLabel},
+		},
+	}
+}
+
+func TestAddSubscription(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+
+	valid := buildTestAddSubscriptionRequest()
+	model := dtos.ToSubscriptionModel(valid.Subscription)
+	dbClientMock.On("AddSubscription", model).Return(model, nil).Once()
+	noRequestId := buildTestAddSubscriptionRequest()
+	noRequestId.RequestId = ""
+	dbClientMock.On("AddSubscription", model).Return(model, nil).Once()
+	duplicated := buildTestAddSubscriptionRequest()
+	dbClientMock.On("AddSubscription", model).Return(model, errors.NewCommonEdgeX(errors.KindDuplicateName, "subscription slug exists", nil))
+
+	noSlug := buildTestAddSubscriptionRequest()
+	noSlug.Subscription.Slug = ""
+	noReceiver := buildTestAddSubscriptionRequest()
+	noReceiver.Subscription.Receiver = ""
+	noChannels := buildTestAddSubscriptionRequest()
+	noChannels.Subscription.Channels = nil
+	invalidChannelType := buildTestAddSubscriptionRequest()
+	invalidChannelType.Subscription.Channels = []dtos.Channel{{Type: "foo", Url: TestUrl}}
+	restChannelWithoutUrl := buildTestAddSubscriptionRequest()
+	restChannelWithoutUrl.Subscription.Channels = []dtos.Channel{{Type: string(models.Rest)}}
+	emailChannelWithoutAddress := buildTestAddSubscriptionRequest()
+	emailChannelWithoutAddress.Subscription.Channels = []dtos.Channel{{Type: string(models.Email)}}
+	mqttChannelWithHttpUrl := buildTestAddSubscriptionRequest()
+	mqttChannelWithHttpUrl.Subscription.Channels = []dtos.Channel{{Type: string(models.Mqtt), Url: TestUrl}}
+	webhookChannelWithoutWebhook := buildTestAddSubscriptionRequest()
+	webhookChannelWithoutWebhook.Subscription.Channels = []dtos.Channel{{Type: string(models.Webhook)}}
+	smsChannelWithoutPhoneNumbers := buildTestAddSubscriptionRequest()
+	smsChannelWithoutPhoneNumbers.Subscription.Channels = []dtos.Channel{{Type: string(models.Sms), SmsGateway: "twilio"}}
+	smsChannelWithInvalidPhoneNumber := buildTestAddSubscriptionRequest()
+	smsChannelWithInvalidPhoneNumber.Subscription.Channels = []dtos.Channel{
+		{Type: string(models.Sms), SmsGateway: "twilio", PhoneNumbers: []string{"0151,12345678"}},
+	}
+	invalidCategory := buildTestAddSubscriptionRequest()
+	invalidCategory.Subscription.Categories = []string{"foo"}
+	invalidEmailSubject := buildTestAddSubscriptionRequest()
+	invalidEmailSubject.Subscription.EmailSubject = "alert\r\nBcc: x@example.com"
+	invalidMinSeverity := buildTestAddSubscriptionRequest()
+	invalidMinSeverity.Subscription.MinSeverity = "MAJOR"
+	invalidTimeZone := buildTestAddSubscriptionRequest()
+	invalidTimeZone.Subscription.TimeZone = "Mars/Olympus"
+	invalidDeliveryWindow := buildTestAddSubscriptionRequest()
+	invalidDeliveryWindow.Subscription.DeliveryWindows = []dtos.DeliveryWindow{{Days: []string{"Caturday"}, Start: "08:00"}}
+	invalidQuietHours := buildTestAddSubscriptionRequest()
+	invalidQuietHours.Subscription.QuietHours = "snooze"
+	negativeRateLimit := buildTestAddSubscriptionRequest()
+	negativeRateLimit.Subscription.RateLimit = -1
+	invalidDigestInterval := buildTestAddSubscriptionRequest()
+	invalidDigestInterval.Subscription.DigestInterval = "hourly"
+
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewSubscriptionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.AddSubscriptionRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.AddSubscriptionRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Valid - no requestId", []requests.AddSubscriptionRequest{noRequestId}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - duplicated slug", []requests.AddSubscriptionRequest{duplicated}, http.StatusMultiStatus, http.StatusConflict},
+		{"Invalid - no slug", []requests.AddSubscriptionRequest{noSlug}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no receiver", []requests.AddSubscriptionRequest{noReceiver}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no channels", []requests.AddSubscriptionRequest{noChannels}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid channel type", []requests.AddSubscriptionRequest{invalidChannelType}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - REST channel without url", []requests.AddSubscriptionRequest{restChannelWithoutUrl}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - EMAIL channel without address", []requests.AddSubscriptionRequest{emailChannelWithoutAddress}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - MQTT channel with http url", []requests.AddSubscriptionRequest{mqttChannelWithHttpUrl}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - WEBHOOK channel without webhook", []requests.AddSubscriptionRequest{webhookChannelWithoutWebhook}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - SMS channel without phone numbers", []requests.AddSubscriptionRequest{smsChannelWithoutPhoneNumbers}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - SMS channel with invalid phone number", []requests.AddSubscriptionRequest{smsChannelWithInvalidPhoneNumber}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid category", []requests.AddSubscriptionRequest{invalidCategory}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - email subject with CRLF", []requests.AddSubscriptionRequest{invalidEmailSubject}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - min severity", []requests.AddSubscriptionRequest{invalidMinSeverity}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - time zone", []requests.AddSubscriptionRequest{invalidTimeZone}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - delivery window", []requests.AddSubscriptionRequest{invalidDeliveryWindow}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - quiet hours", []requests.AddSubscriptionRequest{invalidQuietHours}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - negative rate limit", []requests.AddSubscriptionRequest{negativeRateLimit}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - digest interval", []requests.AddSubscriptionRequest{invalidDigestInterval}, http.StatusBadRequest, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPost, constants.ApiSubscriptionRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddSubscription)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseWithIdResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestPatchSubscription(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	testReq := buildTestUpdateSubscriptionRequest()
+	model := dtos.ToSubscriptionModel(buildTestAddSubscriptionRequest().Subscription)
+
+	valid := testReq
+	dbClientMock.On("SubscriptionById", *valid.Subscription.Id).Return(model, nil)
+	dbClientMock.On("DeleteSubscriptionById", model.Id).Return(nil)
+	dbClientMock.On("AddSubscription", mock.Anything).Return(model, nil)
+	validWithNoReqID := testReq
+	validWithNoReqID.RequestId = ""
+	validWithNoId := buildTestUpdateSubscriptionRequest()
+	validWithNoId.Subscription.Id = nil
+	dbClientMock.On("SubscriptionBySlug", *validWithNoId.Subscription.Slug).Return(model, nil)
+	validWithNoSlug := buildTestUpdateSubscriptionRequest()
+	validWithNoSlug.Subscription.Slug = nil
+
+	invalidId := buildTestUpdateSubscriptionRequest()
+	invalidUUID := "invalidUUID"
+	invalidId.Subscription.Id = &invalidUUID
+
+	invalidNoIdAndSlug := buildTestUpdateSubscriptionRequest()
+	invalidNoIdAndSlug.Subscription.Id = nil
+	invalidNoIdAndSlug.Subscription.Slug = nil
+
+	invalidChannel := buildTestUpdateSubscriptionRequest()
+	invalidChannel.Subscription.Channels = []dtos.Channel{{Type: string(models.Rest)}}
+
+	invalidEmailSender := buildTestUpdateSubscriptionRequest()
+	emailSender := "a@example.com\n"
+	invalidEmailSender.Subscription.EmailSender = &emailSender
+
+	invalidTimeZone := buildTestUpdateSubscriptionRequest()
+	timeZone := "Mars/Olympus"
+	invalidTimeZone.Subscription.TimeZone = &timeZone
+
+	mismatchedSlug := buildTestUpdateSubscriptionRequest()
+	otherSlug := "otherSlug"
+	mismatchedSlug.Subscription.Slug = &otherSlug
+
+	invalidNotFoundId := buildTestUpdateSubscriptionRequest()
+	invalidNotFoundId.Subscription.Slug = nil
+	notFoundId := "12345678-1111-1234-5678-de9dac3fb9bc"
+	invalidNotFoundId.Subscription.Id = &notFoundId
+	notFoundIdError := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("%s doesn't exist in the database", notFoundId), nil)
+	dbClientMock.On("SubscriptionById", notFoundId).Return(model, notFoundIdError)
+
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+	controller := NewSubscriptionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.UpdateSubscriptionRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.UpdateSubscriptionRequest{valid}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no requestId", []requests.UpdateSubscriptionRequest{validWithNoReqID}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no id", []requests.UpdateSubscriptionRequest{validWithNoId}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no slug", []requests.UpdateSubscriptionRequest{validWithNoSlug}, http.StatusMultiStatus, http.StatusOK},
+		{"Invalid - invalid id", []requests.UpdateSubscriptionRequest{invalidId}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no id and slug", []requests.UpdateSubscriptionRequest{invalidNoIdAndSlug}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - REST channel without url", []requests.UpdateSubscriptionRequest{invalidChannel}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - email sender with CRLF", []requests.UpdateSubscriptionRequest{invalidEmailSender}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - time zone", []requests.UpdateSubscriptionRequest{invalidTimeZone}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - slug not match", []requests.UpdateSubscriptionRequest{mismatchedSlug}, http.StatusMultiStatus, http.StatusBadRequest},
+		{"Invalid - not found id", []requests.UpdateSubscriptionRequest{invalidNotFoundId}, http.StatusMultiStatus, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPatch, constants.ApiSubscriptionRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.PatchSubscription)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestSubscriptionById(t *testing.T) {
+	subscription := dtos.ToSubscriptionModel(buildTestAddSubscriptionRequest().Subscription)
+	notFoundId := "12345678-1111-1234-5678-de9dac3fb9bc"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("SubscriptionById", subscription.Id).Return(subscription, nil)
+	dbClientMock.On("SubscriptionById", notFoundId).Return(models.Subscription{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "subscription doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewSubscriptionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		id                 string
+		errorExpected      bool
+		expectedStatusCode int
+	}{
+		{"Valid - find subscription by id", subscription.Id, false, http.StatusOK},
+		{"Invalid - id parameter is empty", "", true, http.StatusBadRequest},
+		{"Invalid - subscription not found by id", notFoundId, true, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiSubscriptionByIdRoute, testCase.id)
+			req, err := http.NewRequest(http.MethodGet, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Id: testCase.id})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.SubscriptionById)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.SubscriptionResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.id, res.Subscription.Id, "Id not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestAllSubscriptions(t *testing.T) {
+	subscription := dtos.ToSubscriptionModel(buildTestAddSubscriptionRequest().Subscription)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllSubscriptions", 0, 20).Return([]models.Subscription{subscription, subscription}, nil)
+	dbClientMock.On("AllSubscriptions", 1, 1).Return([]models.Subscription{subscription}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewSubscriptionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		offset             string
+		limit              string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - get subscriptions without offset and limit", "0", "20", false, 2, http.StatusOK},
+		{"Valid - get subscriptions with offset and limit", "1", "1", false, 1, http.StatusOK},
+		{"Invalid - invalid offset format", "aaa", "1", true, 0, http.StatusBadRequest},
+		{"Invalid - invalid limit format", "1", "aaa", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, constants.ApiAllSubscriptionRoute, http.NoBody)
+			query := req.URL.Query()
+			query.Add(contractsV2.Offset, testCase.offset)
+			query.Add(contractsV2.Limit, testCase.limit)
+			req.URL.RawQuery = query.Encode()
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AllSubscriptions)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.MultiSubscriptionsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.expectedCount, len(res.Subscriptions), "Subscription count not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestDeleteSubscriptionBySlug(t *testing.T) {
+	notFoundSlug := "notFoundSlug"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("DeleteSubscriptionBySlug", TestSubscriptionSlug).Return(nil)
+	dbClientMock.On("DeleteSubscriptionBySlug", notFoundSlug).Return(errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "subscription doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2NotificationsContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewSubscriptionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		slug               string
+		expectedStatusCode int
+	}{
+		{"Valid - delete subscription by slug", TestSubscriptionSlug, http.StatusOK},
+		{"Invalid - slug parameter is empty", "", http.StatusBadRequest},
+		{"Invalid - subscription not found by slug", notFoundSlug, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiSubscriptionBySlugRoute, testCase.slug)
+			req, err := http.NewRequest(http.MethodDelete, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{constants.Slug: testCase.slug})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteSubscriptionBySlug)
+			handler.ServeHTTP(recorder, req)
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+		})
+	}
+}
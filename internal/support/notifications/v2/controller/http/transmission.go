@@ -0,0 +1,136 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/responses"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+type TransmissionController struct {
+	dic *di.Container
+}
+
+// NewTransmissionController creates and initializes an TransmissionController
+func NewTransmissionController(dic *di.Container) *TransmissionController {
+	return &TransmissionController{
+		dic: dic,
+	}
+}
+
+func (tc *TransmissionController) AllTransmissions(w http.ResponseWriter, r *http.Request) {
+	tc.transmissionsByPathParam(w, r, "", func(offset, limit int, _ string, dic *di.Container) ([]dtos.Transmission, errors.EdgeX) {
+		return application.AllTransmissions(offset, limit, dic)
+	})
+}
+
+func (tc *TransmissionController) TransmissionsByNotificationSlug(w http.ResponseWriter, r *http.Request) {
+	tc.transmissionsByPathParam(w, r, constants.Slug, application.TransmissionsByNotificationSlug)
+}
+
+func (tc *TransmissionController) TransmissionsByStatus(w http.ResponseWriter, r *http.Request) {
+	tc.transmissionsByPathParam(w, r, constants.Status, application.TransmissionsByStatus)
+}
+
+// transmissionsByPathParam queries transmissions by the value of the specified path parameter with offset and limit
+func (tc *TransmissionController) transmissionsByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	query func(offset, limit int, value string, dic *di.Container) ([]dtos.Transmission, errors.EdgeX)) {
+
+	lc := container.LoggingClientFrom(tc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := notificationsContainer.ConfigurationFrom(tc.dic.Get)
+
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		transmissions, err := query(offset, limit, value, tc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiTransmissionsResponse("", "", http.StatusOK, transmissions)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (tc *TransmissionController) TransmissionsByTimeRange(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(tc.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := notificationsContainer.ConfigurationFrom(tc.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse time range (start, end), offset, and limit from incoming request
+	start, end, offset, limit, err := utils.ParseTimeRangeOffsetLimit(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		transmissions, err := application.TransmissionsByTimeRange(start, end, offset, limit, tc.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiTransmissionsResponse("", "", http.StatusOK, transmissions)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (tc *TransmissionController) DeleteProcessedTransmissionsByAge(w http.ResponseWriter, r *http.Request) {
+	deleteByAge(w, r, tc.dic, application.DeleteProcessedTransmissionsByAge)
+}
@@ -0,0 +1,65 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Notification and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Notification
+type Notification struct {
+	common.Versionable `json:",inline"`
+	Id                 string   `json:"id,omitempty" validate:"omitempty,uuid"`
+	Created            int64    `json:"created,omitempty"`
+	Modified           int64    `json:"modified,omitempty"`
+	Slug               string   `json:"slug" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Sender             string   `json:"sender" validate:"required,edgex-dto-none-empty-string"`
+	Category           string   `json:"category" validate:"required,oneof='SECURITY' 'HW_HEALTH' 'SW_HEALTH'"`
+	Severity           string   `json:"severity" validate:"required,oneof='NORMAL' 'CRITICAL'"`
+	Content            string   `json:"content" validate:"required,edgex-dto-none-empty-string"`
+	ContentType        string   `json:"contentType,omitempty"`
+	Description        string   `json:"description,omitempty"`
+	Status             string   `json:"status,omitempty" validate:"omitempty,oneof='NEW' 'PROCESSED' 'ESCALATED'"`
+	Labels             []string `json:"labels,omitempty"`
+}
+
+// ToNotificationModel transforms the Notification DTO to the Notification Model
+func ToNotificationModel(dto Notification) models.Notification {
+	var n models.Notification
+	n.Id = dto.Id
+	n.Slug = dto.Slug
+	n.Sender = dto.Sender
+	n.Category = models.NotificationCategory(dto.Category)
+	n.Severity = models.NotificationSeverity(dto.Severity)
+	n.Content = dto.Content
+	n.ContentType = dto.ContentType
+	n.Description = dto.Description
+	n.Status = models.NotificationStatus(dto.Status)
+	n.Labels = dto.Labels
+	return n
+}
+
+// FromNotificationModelToDTO transforms the Notification Model to the Notification DTO
+func FromNotificationModelToDTO(n models.Notification) Notification {
+	return Notification{
+		Versionable: common.NewVersionable(),
+		Id:          n.Id,
+		Created:     n.Created,
+		Modified:    n.Modified,
+		Slug:        n.Slug,
+		Sender:      n.Sender,
+		Category:    string(n.Category),
+		Severity:    string(n.Severity),
+		Content:     n.Content,
+		ContentType: n.ContentType,
+		Description: n.Description,
+		Status:      string(n.Status),
+		Labels:      n.Labels,
+	}
+}
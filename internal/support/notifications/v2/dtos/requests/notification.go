@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddNotificationRequest defines the Request Content for POST Notification DTO.
+// This object and its properties correspond to the AddNotificationRequest object in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/AddNotificationRequest
+type AddNotificationRequest struct {
+	common.BaseRequest `json:",inline"`
+	Notification       dtos.Notification `json:"notification"`
+}
+
+// Validate satisfies the Validator interface
+func (n AddNotificationRequest) Validate() error {
+	err := v2.Validate(n)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the AddNotificationRequest type
+func (n *AddNotificationRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Notification dtos.Notification
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*n = AddNotificationRequest(alias)
+
+	// validate AddNotificationRequest DTO
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddNotificationReqToNotificationModels transforms the AddNotificationRequest DTO array to the Notification model array
+func AddNotificationReqToNotificationModels(addRequests []AddNotificationRequest) (notifications []models.Notification) {
+	for _, req := range addRequests {
+		n := dtos.ToNotificationModel(req.Notification)
+		notifications = append(notifications, n)
+	}
+	return notifications
+}
@@ -0,0 +1,183 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddSubscriptionRequest defines the Request Content for POST Subscription DTO.
+// This object and its properties correspond to the AddSubscriptionRequest object in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/AddSubscriptionRequest
+type AddSubscriptionRequest struct {
+	common.BaseRequest `json:",inline"`
+	Subscription       dtos.Subscription `json:"subscription"`
+}
+
+// Validate satisfies the Validator interface
+func (s AddSubscriptionRequest) Validate() error {
+	err := v2.Validate(s)
+	if err != nil {
+		return err
+	}
+	err = dtos.ValidateTimeZone(s.Subscription.TimeZone)
+	if err != nil {
+		return err
+	}
+	err = dtos.ValidateDuration("rateLimitPeriod", s.Subscription.RateLimitPeriod)
+	if err != nil {
+		return err
+	}
+	err = dtos.ValidateDuration("digestInterval", s.Subscription.DigestInterval)
+	if err != nil {
+		return err
+	}
+	return dtos.ValidateChannels(s.Subscription.Channels)
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the AddSubscriptionRequest type
+func (s *AddSubscriptionRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Subscription dtos.Subscription
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*s = AddSubscriptionRequest(alias)
+
+	// validate AddSubscriptionRequest DTO
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddSubscriptionReqToSubscriptionModels transforms the AddSubscriptionRequest DTO array to the Subscription model array
+func AddSubscriptionReqToSubscriptionModels(addRequests []AddSubscriptionRequest) (subscriptions []models.Subscription) {
+	for _, req := range addRequests {
+		s := dtos.ToSubscriptionModel(req.Subscription)
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions
+}
+
+// UpdateSubscriptionRequest defines the Request Content for PATCH Subscription DTO.
+// This object and its properties correspond to the UpdateSubscriptionRequest object in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/UpdateSubscriptionRequest
+type UpdateSubscriptionRequest struct {
+	common.BaseRequest `json:",inline"`
+	Subscription       dtos.UpdateSubscription `json:"subscription"`
+}
+
+// Validate satisfies the Validator interface
+func (s UpdateSubscriptionRequest) Validate() error {
+	err := v2.Validate(s)
+	if err != nil {
+		return err
+	}
+	if s.Subscription.TimeZone != nil {
+		err = dtos.ValidateTimeZone(*s.Subscription.TimeZone)
+		if err != nil {
+			return err
+		}
+	}
+	if s.Subscription.RateLimitPeriod != nil {
+		err = dtos.ValidateDuration("rateLimitPeriod", *s.Subscription.RateLimitPeriod)
+		if err != nil {
+			return err
+		}
+	}
+	if s.Subscription.DigestInterval != nil {
+		err = dtos.ValidateDuration("digestInterval", *s.Subscription.DigestInterval)
+		if err != nil {
+			return err
+		}
+	}
+	return dtos.ValidateChannels(s.Subscription.Channels)
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the UpdateSubscriptionRequest type
+func (s *UpdateSubscriptionRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Subscription dtos.UpdateSubscription
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*s = UpdateSubscriptionRequest(alias)
+
+	// validate UpdateSubscriptionRequest DTO
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceSubscriptionModelFieldsWithDTO replace existing Subscription's fields with DTO patch
+func ReplaceSubscriptionModelFieldsWithDTO(s *models.Subscription, patch dtos.UpdateSubscription) {
+	if patch.Receiver != nil {
+		s.Receiver = *patch.Receiver
+	}
+	if patch.Description != nil {
+		s.Description = *patch.Description
+	}
+	if patch.Channels != nil {
+		s.Channels = dtos.ToChannelModels(patch.Channels)
+	}
+	if patch.Categories != nil {
+		s.Categories = dtos.ToCategoryModels(patch.Categories)
+	}
+	if patch.Labels != nil {
+		s.Labels = patch.Labels
+	}
+	if patch.Template != nil {
+		s.Template = *patch.Template
+	}
+	if patch.EmailSender != nil {
+		s.EmailSender = *patch.EmailSender
+	}
+	if patch.EmailSubject != nil {
+		s.EmailSubject = *patch.EmailSubject
+	}
+	if patch.MinSeverity != nil {
+		s.MinSeverity = models.NotificationSeverity(*patch.MinSeverity)
+	}
+	if patch.TimeZone != nil {
+		s.TimeZone = *patch.TimeZone
+	}
+	if patch.DeliveryWindows != nil {
+		s.DeliveryWindows = dtos.ToDeliveryWindowModels(patch.DeliveryWindows)
+	}
+	if patch.QuietHours != nil {
+		s.QuietHours = *patch.QuietHours
+	}
+	if patch.RetryPolicy != nil {
+		s.RetryPolicy = *patch.RetryPolicy
+	}
+	if patch.EscalationPolicy != nil {
+		s.EscalationPolicy = *patch.EscalationPolicy
+	}
+	if patch.RateLimit != nil {
+		s.RateLimit = *patch.RateLimit
+	}
+	if patch.RateLimitPeriod != nil {
+		s.RateLimitPeriod = *patch.RateLimitPeriod
+	}
+	if patch.DigestInterval != nil {
+		s.DigestInterval = *patch.DigestInterval
+	}
+}
@@ -0,0 +1,42 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// SubscriptionResponse defines the Response Content for GET Subscription DTO.
+// This object and its properties correspond to the SubscriptionResponse object in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/SubscriptionResponse
+type SubscriptionResponse struct {
+	common.BaseResponse `json:",inline"`
+	Subscription        dtos.Subscription `json:"subscription"`
+}
+
+func NewSubscriptionResponse(requestId string, message string, statusCode int, subscription dtos.Subscription) SubscriptionResponse {
+	return SubscriptionResponse{
+		BaseResponse: common.NewBaseResponse(requestId, message, statusCode),
+		Subscription: subscription,
+	}
+}
+
+// MultiSubscriptionsResponse defines the Response Content for GET multiple Subscription DTOs.
+// This object and its properties correspond to the MultiSubscriptionsResponse object in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/MultiSubscriptionsResponse
+type MultiSubscriptionsResponse struct {
+	common.BaseResponse `json:",inline"`
+	Subscriptions       []dtos.Subscription `json:"subscriptions"`
+}
+
+func NewMultiSubscriptionsResponse(requestId string, message string, statusCode int, subscriptions []dtos.Subscription) MultiSubscriptionsResponse {
+	return MultiSubscriptionsResponse{
+		BaseResponse:  common.NewBaseResponse(requestId, message, statusCode),
+		Subscriptions: subscriptions,
+	}
+}
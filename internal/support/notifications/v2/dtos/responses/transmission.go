@@ -0,0 +1,27 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package responses
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// MultiTransmissionsResponse defines the Response Content for GET multiple Transmission DTOs.
+// This object and its properties correspond to the MultiTransmissionsResponse object in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/MultiTransmissionsResponse
+type MultiTransmissionsResponse struct {
+	common.BaseResponse `json:",inline"`
+	Transmissions       []dtos.Transmission `json:"transmissions"`
+}
+
+func NewMultiTransmissionsResponse(requestId string, message string, statusCode int, transmissions []dtos.Transmission) MultiTransmissionsResponse {
+	return MultiTransmissionsResponse{
+		BaseResponse:  common.NewBaseResponse(requestId, message, statusCode),
+		Transmissions: transmissions,
+	}
+}
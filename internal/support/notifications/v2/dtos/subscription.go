@@ -0,0 +1,280 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Subscription and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Subscription
+type Subscription struct {
+	common.Versionable `json:",inline"`
+	Id                 string           `json:"id,omitempty" validate:"omitempty,uuid"`
+	Created            int64            `json:"created,omitempty"`
+	Modified           int64            `json:"modified,omitempty"`
+	Slug               string           `json:"slug" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Receiver           string           `json:"receiver" validate:"required,edgex-dto-none-empty-string"`
+	Description        string           `json:"description,omitempty"`
+	Channels           []Channel        `json:"channels" validate:"required,gt=0,dive"`
+	Categories         []string         `json:"categories,omitempty" validate:"omitempty,dive,oneof='SECURITY' 'HW_HEALTH' 'SW_HEALTH'"`
+	Labels             []string         `json:"labels,omitempty"`
+	Template           string           `json:"template,omitempty"`
+	EmailSender        string           `json:"emailSender,omitempty" validate:"omitempty,excludesall=\r\n"`
+	EmailSubject       string           `json:"emailSubject,omitempty" validate:"omitempty,excludesall=\r\n"`
+	MinSeverity        string           `json:"minSeverity,omitempty" validate:"omitempty,oneof='NORMAL' 'CRITICAL'"`
+	TimeZone           string           `json:"timeZone,omitempty"`
+	DeliveryWindows    []DeliveryWindow `json:"deliveryWindows,omitempty" validate:"omitempty,dive"`
+	QuietHours         string           `json:"quietHours,omitempty" validate:"omitempty,oneof='delay' 'drop' 'critical'"`
+	RetryPolicy        string           `json:"retryPolicy,omitempty"`
+	EscalationPolicy   string           `json:"escalationPolicy,omitempty"`
+	RateLimit          int              `json:"rateLimit,omitempty" validate:"min=0"`
+	RateLimitPeriod    string           `json:"rateLimitPeriod,omitempty"`
+	DigestInterval     string           `json:"digestInterval,omitempty"`
+}
+
+// UpdateSubscription and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/UpdateSubscriptionRequest
+type UpdateSubscription struct {
+	Id               *string          `json:"id" validate:"required_without=Slug,edgex-dto-uuid"`
+	Slug             *string          `json:"slug" validate:"required_without=Id,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Receiver         *string          `json:"receiver" validate:"omitempty,edgex-dto-none-empty-string"`
+	Description      *string          `json:"description"`
+	Channels         []Channel        `json:"channels" validate:"omitempty,dive"`
+	Categories       []string         `json:"categories" validate:"omitempty,dive,oneof='SECURITY' 'HW_HEALTH' 'SW_HEALTH'"`
+	Labels           []string         `json:"labels"`
+	Template         *string          `json:"template"`
+	EmailSender      *string          `json:"emailSender" validate:"omitempty,excludesall=\r\n"`
+	EmailSubject     *string          `json:"emailSubject" validate:"omitempty,excludesall=\r\n"`
+	MinSeverity      *string          `json:"minSeverity" validate:"omitempty,oneof='NORMAL' 'CRITICAL'"`
+	TimeZone         *string          `json:"timeZone"`
+	DeliveryWindows  []DeliveryWindow `json:"deliveryWindows" validate:"omitempty,dive"`
+	QuietHours       *string          `json:"quietHours" validate:"omitempty,oneof='delay' 'drop' 'critical'"`
+	RetryPolicy      *string          `json:"retryPolicy"`
+	EscalationPolicy *string          `json:"escalationPolicy"`
+	RateLimit        *int             `json:"rateLimit" validate:"omitempty,min=0"`
+	RateLimitPeriod  *string          `json:"rateLimitPeriod"`
+	DigestInterval   *string          `json:"digestInterval"`
+}
+
+// DeliveryWindow and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/DeliveryWindow
+type DeliveryWindow struct {
+	Days  []string `json:"days,omitempty" validate:"omitempty,dive,oneof='Sun' 'Mon' 'Tue' 'Wed' 'Thu' 'Fri' 'Sat'"`
+	Start string   `json:"start,omitempty" validate:"omitempty,datetime=15:04"`
+	End   string   `json:"end,omitempty" validate:"omitempty,datetime=15:04"`
+}
+
+// Channel and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Channel
+type Channel struct {
+	Type           string   `json:"type" validate:"required,oneof='REST' 'EMAIL' 'MQTT' 'WEBHOOK' 'SMS'"`
+	EmailAddresses []string `json:"emailAddresses,omitempty" validate:"omitempty,dive,email"`
+	Url            string   `json:"url,omitempty" validate:"omitempty,uri"`
+	Webhook        string   `json:"webhook,omitempty" validate:"omitempty,edgex-dto-rfc3986-unreserved-chars"`
+	SmsGateway     string   `json:"smsGateway,omitempty" validate:"omitempty,edgex-dto-rfc3986-unreserved-chars"`
+	PhoneNumbers   []string `json:"phoneNumbers,omitempty" validate:"omitempty,dive,e164"`
+}
+
+// mqttSchemes are the url schemes of the brokers an MQTT channel publishes to
+var mqttSchemes = map[string]bool{"mqtt": true, "mqtts": true, "tcp": true, "ssl": true, "ws": true, "wss": true}
+
+// ValidateChannels checks that every channel carries the address its transport needs
+func ValidateChannels(channels []Channel) errors.EdgeX {
+	for _, c := range channels {
+		switch models.ChannelType(c.Type) {
+		case models.Rest:
+			if c.Url == "" {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, "url is required for REST channel", nil)
+			}
+		case models.Email:
+			if len(c.EmailAddresses) == 0 {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, "emailAddresses is required for EMAIL channel", nil)
+			}
+		case models.Mqtt:
+			u, err := url.Parse(c.Url)
+			if c.Url == "" || err != nil || !mqttSchemes[strings.ToLower(u.Scheme)] {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, "url with an mqtt, mqtts, tcp, ssl, ws or wss scheme is required for MQTT channel", nil)
+			}
+		case models.Webhook:
+			if c.Webhook == "" {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, "webhook is required for WEBHOOK channel", nil)
+			}
+		case models.Sms:
+			if c.SmsGateway == "" || len(c.PhoneNumbers) == 0 {
+				return errors.NewCommonEdgeX(errors.KindContractInvalid, "smsGateway and phoneNumbers are required for SMS channel", nil)
+			}
+		default:
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported channel type %s", c.Type), nil)
+		}
+	}
+	return nil
+}
+
+// ValidateTimeZone rejects the time zones unknown to the IANA time zone database
+func ValidateTimeZone(timeZone string) errors.EdgeX {
+	if timeZone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unknown time zone %s", timeZone), err)
+	}
+	return nil
+}
+
+// ValidateDuration rejects the durations which are not positive Go durations, such as 15m
+func ValidateDuration(field string, duration string) errors.EdgeX {
+	if duration == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(duration); err != nil || d <= 0 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid %s %s", field, duration), err)
+	}
+	return nil
+}
+
+// ToSubscriptionModel transforms the Subscription DTO to the Subscription Model
+func ToSubscriptionModel(dto Subscription) models.Subscription {
+	var s models.Subscription
+	s.Id = dto.Id
+	s.Slug = dto.Slug
+	s.Receiver = dto.Receiver
+	s.Description = dto.Description
+	s.Channels = ToChannelModels(dto.Channels)
+	s.Categories = ToCategoryModels(dto.Categories)
+	s.Labels = dto.Labels
+	s.Template = dto.Template
+	s.EmailSender = dto.EmailSender
+	s.EmailSubject = dto.EmailSubject
+	s.MinSeverity = models.NotificationSeverity(dto.MinSeverity)
+	s.TimeZone = dto.TimeZone
+	s.DeliveryWindows = ToDeliveryWindowModels(dto.DeliveryWindows)
+	s.QuietHours = dto.QuietHours
+	s.RetryPolicy = dto.RetryPolicy
+	s.EscalationPolicy = dto.EscalationPolicy
+	s.RateLimit = dto.RateLimit
+	s.RateLimitPeriod = dto.RateLimitPeriod
+	s.DigestInterval = dto.DigestInterval
+	return s
+}
+
+// FromSubscriptionModelToDTO transforms the Subscription Model to the Subscription DTO
+func FromSubscriptionModelToDTO(s models.Subscription) Subscription {
+	categories := make([]string, len(s.Categories))
+	for i, c := range s.Categories {
+		categories[i] = string(c)
+	}
+	return Subscription{
+		Versionable:      common.NewVersionable(),
+		Id:               s.Id,
+		Created:          s.Created,
+		Modified:         s.Modified,
+		Slug:             s.Slug,
+		Receiver:         s.Receiver,
+		Description:      s.Description,
+		Channels:         FromChannelModelsToDTOs(s.Channels),
+		Categories:       categories,
+		Labels:           s.Labels,
+		Template:         s.Template,
+		EmailSender:      s.EmailSender,
+		EmailSubject:     s.EmailSubject,
+		MinSeverity:      string(s.MinSeverity),
+		TimeZone:         s.TimeZone,
+		DeliveryWindows:  FromDeliveryWindowModelsToDTOs(s.DeliveryWindows),
+		QuietHours:       s.QuietHours,
+		RetryPolicy:      s.RetryPolicy,
+		EscalationPolicy: s.EscalationPolicy,
+		RateLimit:        s.RateLimit,
+		RateLimitPeriod:  s.RateLimitPeriod,
+		DigestInterval:   s.DigestInterval,
+	}
+}
+
+// ToChannelModels transforms the Channel DTOs to the Channel Models
+func ToChannelModels(dtos []Channel) []models.Channel {
+	channels := make([]models.Channel, len(dtos))
+	for i, c := range dtos {
+		channels[i] = ToChannelModel(c)
+	}
+	return channels
+}
+
+// ToChannelModel transforms the Channel DTO to the Channel Model
+func ToChannelModel(dto Channel) models.Channel {
+	return models.Channel{
+		Type:           models.ChannelType(dto.Type),
+		EmailAddresses: dto.EmailAddresses,
+		Url:            dto.Url,
+		Webhook:        dto.Webhook,
+		SmsGateway:     dto.SmsGateway,
+		PhoneNumbers:   dto.PhoneNumbers,
+	}
+}
+
+// FromChannelModelsToDTOs transforms the Channel Models to the Channel DTOs
+func FromChannelModelsToDTOs(channels []models.Channel) []Channel {
+	dtos := make([]Channel, len(channels))
+	for i, c := range channels {
+		dtos[i] = FromChannelModelToDTO(c)
+	}
+	return dtos
+}
+
+// FromChannelModelToDTO transforms the Channel Model to the Channel DTO
+func FromChannelModelToDTO(c models.Channel) Channel {
+	return Channel{
+		Type:           string(c.Type),
+		EmailAddresses: c.EmailAddresses,
+		Url:            c.Url,
+		Webhook:        c.Webhook,
+		SmsGateway:     c.SmsGateway,
+		PhoneNumbers:   c.PhoneNumbers,
+	}
+}
+
+// ToDeliveryWindowModels transforms the DeliveryWindow DTOs to the DeliveryWindow Models
+func ToDeliveryWindowModels(dtos []DeliveryWindow) []models.DeliveryWindow {
+	if dtos == nil {
+		return nil
+	}
+	windows := make([]models.DeliveryWindow, len(dtos))
+	for i, w := range dtos {
+		windows[i] = models.DeliveryWindow{Days: w.Days, Start: w.Start, End: w.End}
+	}
+	return windows
+}
+
+// FromDeliveryWindowModelsToDTOs transforms the DeliveryWindow Models to the DeliveryWindow DTOs
+func FromDeliveryWindowModelsToDTOs(windows []models.DeliveryWindow) []DeliveryWindow {
+	if windows == nil {
+		return nil
+	}
+	dtos := make([]DeliveryWindow, len(windows))
+	for i, w := range windows {
+		dtos[i] = DeliveryWindow{Days: w.Days, Start: w.Start, End: w.End}
+	}
+	return dtos
+}
+
+// ToCategoryModels transforms the category strings to the NotificationCategory Models
+func ToCategoryModels(categories []string) []models.NotificationCategory {
+	if categories == nil {
+		return nil
+	}
+	result := make([]models.NotificationCategory, len(categories))
+	for i, c := range categories {
+		result[i] = models.NotificationCategory(c)
+	}
+	return result
+}
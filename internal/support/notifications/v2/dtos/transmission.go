@@ -0,0 +1,59 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Transmission and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/Transmission
+type Transmission struct {
+	common.Versionable `json:",inline"`
+	Id                 string               `json:"id,omitempty"`
+	Created            int64                `json:"created,omitempty"`
+	Modified           int64                `json:"modified,omitempty"`
+	Notification       Notification         `json:"notification"`
+	Receiver           string               `json:"receiver"`
+	Channel            Channel              `json:"channel"`
+	Status             string               `json:"status"`
+	ResendCount        int                  `json:"resendCount"`
+	Records            []TransmissionRecord `json:"records,omitempty"`
+}
+
+// TransmissionRecord and its properties are defined in the APIv2 specification:
+// openapi/v2/support-notifications.yaml#/TransmissionRecord
+type TransmissionRecord struct {
+	Status   string `json:"status"`
+	Response string `json:"response,omitempty"`
+	Sent     int64  `json:"sent"`
+}
+
+// FromTransmissionModelToDTO transforms the Transmission Model to the Transmission DTO
+func FromTransmissionModelToDTO(t models.Transmission) Transmission {
+	records := make([]TransmissionRecord, len(t.Records))
+	for i, r := range t.Records {
+		records[i] = TransmissionRecord{
+			Status:   string(r.Status),
+			Response: r.Response,
+			Sent:     r.Sent,
+		}
+	}
+	return Transmission{
+		Versionable:  common.NewVersionable(),
+		Id:           t.Id,
+		Created:      t.Created,
+		Modified:     t.Modified,
+		Notification: FromNotificationModelToDTO(t.Notification),
+		Receiver:     t.Receiver,
+		Channel:      FromChannelModelToDTO(t.Channel),
+		Status:       string(t.Status),
+		ResendCount:  t.ResendCount,
+		Records:      records,
+	}
+}
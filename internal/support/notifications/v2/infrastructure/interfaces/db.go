@@ -0,0 +1,45 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interfaces
+
+import (
+	model "github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+type DBClient interface {
+	CloseSession()
+
+	AddNotification(n model.Notification) (model.Notification, errors.EdgeX)
+	NotificationBySlug(slug string) (model.Notification, errors.EdgeX)
+	NotificationsByCategory(offset int, limit int, category string) ([]model.Notification, errors.EdgeX)
+	NotificationsByLabel(offset int, limit int, label string) ([]model.Notification, errors.EdgeX)
+	NotificationsByStatus(offset int, limit int, status string) ([]model.Notification, errors.EdgeX)
+	NotificationsByTimeRange(start int, end int, offset int, limit int) ([]model.Notification, errors.EdgeX)
+	DeleteNotificationBySlug(slug string) errors.EdgeX
+	DeleteProcessedNotificationsByAge(age int64) errors.EdgeX
+	CleanupNotificationsByAge(age int64) errors.EdgeX
+
+	AddSubscription(s model.Subscription) (model.Subscription, errors.EdgeX)
+	SubscriptionById(id string) (model.Subscription, errors.EdgeX)
+	SubscriptionBySlug(slug string) (model.Subscription, errors.EdgeX)
+	AllSubscriptions(offset int, limit int) ([]model.Subscription, errors.EdgeX)
+	SubscriptionsByCategory(offset int, limit int, category string) ([]model.Subscription, errors.EdgeX)
+	SubscriptionsByLabel(offset int, limit int, label string) ([]model.Subscription, errors.EdgeX)
+	SubscriptionsByReceiver(offset int, limit int, receiver string) ([]model.Subscription, errors.EdgeX)
+	DeleteSubscriptionById(id string) errors.EdgeX
+	DeleteSubscriptionBySlug(slug string) errors.EdgeX
+
+	AddTransmission(t model.Transmission) (model.Transmission, errors.EdgeX)
+	TransmissionById(id string) (model.Transmission, errors.EdgeX)
+	UpdateTransmission(t model.Transmission) errors.EdgeX
+	AllTransmissions(offset int, limit int) ([]model.Transmission, errors.EdgeX)
+	TransmissionsByNotificationSlug(offset int, limit int, slug string) ([]model.Transmission, errors.EdgeX)
+	TransmissionsByStatus(offset int, limit int, status string) ([]model.Transmission, errors.EdgeX)
+	TransmissionsByTimeRange(start int, end int, offset int, limit int) ([]model.Transmission, errors.EdgeX)
+	DeleteProcessedTransmissionsByAge(age int64) errors.EdgeX
+}
@@ -0,0 +1,16 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interfaces
+
+import (
+	model "github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+)
+
+// NotificationDistributor sends stored notifications to the subscriptions of their category or labels.
+type NotificationDistributor interface {
+	// Distribute starts distributing the notification and returns without waiting for the deliveries.
+	Distribute(n model.Notification)
+}
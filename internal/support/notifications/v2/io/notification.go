@@ -0,0 +1,43 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package io
+
+import (
+	"encoding/json"
+	"io"
+
+	dtoRequest "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/dtos/requests"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// NotificationReader unmarshals a request body into an array of Notification type
+type NotificationReader interface {
+	ReadAddNotificationRequest(reader io.Reader) ([]dtoRequest.AddNotificationRequest, errors.EdgeX)
+}
+
+// NewNotificationRequestReader returns a BodyReader capable of processing the request body
+func NewNotificationRequestReader() NotificationReader {
+	return NewJsonNotificationReader()
+}
+
+// NewJsonNotificationReader creates a new instance of jsonNotificationReader
+func NewJsonNotificationReader() jsonNotificationReader {
+	return jsonNotificationReader{}
+}
+
+// jsonNotificationReader unmarshals the JSON request body payload
+type jsonNotificationReader struct{}
+
+// ReadAddNotificationRequest reads a request and then converts its JSON data into an array of AddNotificationRequest struct
+func (jsonNotificationReader) ReadAddNotificationRequest(reader io.Reader) ([]dtoRequest.AddNotificationRequest, errors.EdgeX) {
+	var addNotifications []dtoRequest.AddNotificationRequest
+	err := json.NewDecoder(reader).Decode(&addNotifications)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "notification json decoding failed", err)
+	}
+	return addNotifications, nil
+}
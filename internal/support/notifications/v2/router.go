@@ -0,0 +1,68 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/constants"
+	notificationsController "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/controller/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+)
+
+func LoadRestRoutes(r *mux.Router, dic *di.Container) {
+	// v2 API routes
+	// Common
+	cc := commonController.NewV2CommonController(dic)
+	r.HandleFunc(v2Constant.ApiPingRoute, cc.Ping).Methods(http.MethodGet)
+	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
+	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
+	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+
+	// Notification
+	nc := notificationsController.NewNotificationController(dic)
+	r.HandleFunc(constants.ApiNotificationRoute, nc.AddNotification).Methods(http.MethodPost)
+	r.HandleFunc(constants.ApiNotificationBySlugRoute, nc.NotificationBySlug).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiNotificationBySlugRoute, nc.DeleteNotificationBySlug).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiNotificationByCategoryRoute, nc.NotificationsByCategory).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiNotificationByLabelRoute, nc.NotificationsByLabel).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiNotificationByStatusRoute, nc.NotificationsByStatus).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiNotificationByTimeRangeRoute, nc.NotificationsByTimeRange).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiNotificationByAgeRoute, nc.DeleteProcessedNotificationsByAge).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiCleanupRoute, nc.CleanupNotifications).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiCleanupByAgeRoute, nc.CleanupNotificationsByAge).Methods(http.MethodDelete)
+
+	// Subscription
+	sc := notificationsController.NewSubscriptionController(dic)
+	r.HandleFunc(constants.ApiSubscriptionRoute, sc.AddSubscription).Methods(http.MethodPost)
+	r.HandleFunc(constants.ApiSubscriptionRoute, sc.PatchSubscription).Methods(http.MethodPatch)
+	r.HandleFunc(constants.ApiAllSubscriptionRoute, sc.AllSubscriptions).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiSubscriptionByIdRoute, sc.SubscriptionById).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiSubscriptionByIdRoute, sc.DeleteSubscriptionById).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiSubscriptionBySlugRoute, sc.SubscriptionBySlug).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiSubscriptionBySlugRoute, sc.DeleteSubscriptionBySlug).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiSubscriptionByCategoryRoute, sc.SubscriptionsByCategory).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiSubscriptionByLabelRoute, sc.SubscriptionsByLabel).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiSubscriptionByReceiverRoute, sc.SubscriptionsByReceiver).Methods(http.MethodGet)
+
+	// Transmission
+	tc := notificationsController.NewTransmissionController(dic)
+	r.HandleFunc(constants.ApiAllTransmissionRoute, tc.AllTransmissions).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiTransmissionBySlugRoute, tc.TransmissionsByNotificationSlug).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiTransmissionByStatusRoute, tc.TransmissionsByStatus).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiTransmissionByTimeRangeRoute, tc.TransmissionsByTimeRange).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiTransmissionByAgeRoute, tc.DeleteProcessedTransmissionsByAge).Methods(http.MethodDelete)
+
+	r.Use(correlation.ManageHeader)
+	r.Use(correlation.OnResponseComplete)
+	r.Use(correlation.OnRequestBegin)
+}
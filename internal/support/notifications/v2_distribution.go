@@ -0,0 +1,392 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	v2Models "github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	notificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces"
+	v2NotificationsContainer "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/bootstrap/container"
+	v2Interfaces "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// v2NotificationDistributor distributes the notifications added through the V2 API the same way as the V1 ones.
+type v2NotificationDistributor struct {
+	dic *di.Container
+}
+
+func (d v2NotificationDistributor) Distribute(n v2Models.Notification) {
+	go distribute(
+		toV1Notification(n),
+		notificationsContainer.ThrottleFrom(d.dic.Get),
+		bootstrapContainer.LoggingClientFrom(d.dic.Get),
+		distributionDBClientFrom(d.dic.Get),
+		*notificationsContainer.ConfigurationFrom(d.dic.Get))
+}
+
+// distributionDBClient is the database of the distribution. It resolves the subscriptions and notifications of the V2
+// API along with the V1 ones, converted to their V1 models.
+type distributionDBClient struct {
+	interfaces.DBClient
+	v2DBClient v2Interfaces.DBClient
+}
+
+// distributionDBClientFrom returns the database of the distribution, built from the V1 and V2 databases in the DIC.
+func distributionDBClientFrom(get di.Get) interfaces.DBClient {
+	return distributionDBClient{
+		DBClient:   container.DBClientFrom(get),
+		v2DBClient: v2NotificationsContainer.DBClientFrom(get),
+	}
+}
+
+func (c distributionDBClient) GetSubscriptionByCategoriesLabels(
+	categories []string,
+	labels []string) ([]models.Subscription, error) {
+
+	subscriptions, err := c.DBClient.GetSubscriptionByCategoriesLabels(categories, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, category := range categories {
+		v2Subscriptions, err := c.v2DBClient.SubscriptionsByCategory(0, -1, category)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = appendV2Subscriptions(subscriptions, v2Subscriptions, seen)
+	}
+	for _, label := range labels {
+		v2Subscriptions, err := c.v2DBClient.SubscriptionsByLabel(0, -1, label)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = appendV2Subscriptions(subscriptions, v2Subscriptions, seen)
+	}
+	return subscriptions, nil
+}
+
+func (c distributionDBClient) GetSubscriptionByReceiver(receiver string) ([]models.Subscription, error) {
+	subscriptions, err := c.DBClient.GetSubscriptionByReceiver(receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	v2Subscriptions, err := c.v2DBClient.SubscriptionsByReceiver(0, -1, receiver)
+	if err != nil {
+		return nil, err
+	}
+	return appendV2Subscriptions(subscriptions, v2Subscriptions, make(map[string]bool)), nil
+}
+
+func (c distributionDBClient) GetSubscriptionBySlug(slug string) (models.Subscription, error) {
+	s, err := c.DBClient.GetSubscriptionBySlug(slug)
+	if err != db.ErrNotFound {
+		return s, err
+	}
+
+	v2Subscription, v2Err := c.v2DBClient.SubscriptionBySlug(slug)
+	if v2Err != nil {
+		return s, v2NotFoundOr(v2Err, err)
+	}
+	return toV1Subscription(v2Subscription), nil
+}
+
+// GetSubscriptionSettings returns the settings stored with the V1 subscription, or the settings of the V2
+// subscription with the id.
+func (c distributionDBClient) GetSubscriptionSettings(id string) (notificationsModels.SubscriptionSettings, error) {
+	settings, err := c.DBClient.GetSubscriptionSettings(id)
+	if err != db.ErrNotFound {
+		return settings, err
+	}
+
+	v2Subscription, v2Err := c.v2DBClient.SubscriptionById(id)
+	if v2Err != nil {
+		return settings, v2NotFoundOr(v2Err, err)
+	}
+	return toSubscriptionSettings(v2Subscription), nil
+}
+
+func (c distributionDBClient) GetNotificationBySlug(slug string) (models.Notification, error) {
+	n, err := c.DBClient.GetNotificationBySlug(slug)
+	if err != db.ErrNotFound {
+		return n, err
+	}
+
+	v2Notification, v2Err := c.v2DBClient.NotificationBySlug(slug)
+	if v2Err != nil {
+		return n, v2NotFoundOr(v2Err, err)
+	}
+	return toV1Notification(v2Notification), nil
+}
+
+// AddTransmission stores the transmission of a V2 notification with the V2 notification, any other one in the V1
+// database.
+func (c distributionDBClient) AddTransmission(t models.Transmission) (string, error) {
+	v2Notification, isV2, err := c.v2Notification(t.Notification.Slug)
+	if err != nil {
+		return "", err
+	} else if !isV2 {
+		return c.DBClient.AddTransmission(t)
+	}
+
+	added, edgeXerr := c.v2DBClient.AddTransmission(toV2Transmission(t, v2Notification))
+	if edgeXerr != nil {
+		return "", edgeXerr
+	}
+	return added.Id, nil
+}
+
+func (c distributionDBClient) GetTransmissionById(id string) (models.Transmission, error) {
+	t, err := c.DBClient.GetTransmissionById(id)
+	if err != db.ErrNotFound {
+		return t, err
+	}
+
+	v2Transmission, v2Err := c.v2DBClient.TransmissionById(id)
+	if v2Err != nil {
+		return t, v2NotFoundOr(v2Err, err)
+	}
+	return toV1Transmission(v2Transmission), nil
+}
+
+func (c distributionDBClient) UpdateTransmission(t models.Transmission) error {
+	_, err := c.DBClient.GetTransmissionById(t.ID)
+	if err != db.ErrNotFound {
+		if err != nil {
+			return err
+		}
+		return c.DBClient.UpdateTransmission(t)
+	}
+
+	v2Transmission, v2Err := c.v2DBClient.TransmissionById(t.ID)
+	if v2Err != nil {
+		return v2NotFoundOr(v2Err, err)
+	}
+	if v2Err = c.v2DBClient.UpdateTransmission(toV2Transmission(t, v2Transmission.Notification)); v2Err != nil {
+		return v2Err
+	}
+	return nil
+}
+
+func (c distributionDBClient) GetTransmissionsByNotificationSlug(slug string, limit int) ([]models.Transmission, error) {
+	transmissions, err := c.DBClient.GetTransmissionsByNotificationSlug(slug, limit)
+	if err != nil && err != db.ErrNotFound {
+		return nil, err
+	} else if len(transmissions) > 0 {
+		return transmissions, nil
+	}
+
+	v2Transmissions, v2Err := c.v2DBClient.TransmissionsByNotificationSlug(0, limit, slug)
+	if v2Err != nil {
+		return nil, v2Err
+	}
+	for _, t := range v2Transmissions {
+		transmissions = append(transmissions, toV1Transmission(t))
+	}
+	return transmissions, nil
+}
+
+// v2Notification returns the V2 notification with the slug, reporting whether there is one. Notifications of the V1
+// database, and those of neither database, are no V2 notifications.
+func (c distributionDBClient) v2Notification(slug string) (v2Models.Notification, bool, error) {
+	_, err := c.DBClient.GetNotificationBySlug(slug)
+	if err != db.ErrNotFound {
+		return v2Models.Notification{}, false, err
+	}
+
+	n, v2Err := c.v2DBClient.NotificationBySlug(slug)
+	if v2Err != nil {
+		if errors.Kind(v2Err) == errors.KindEntityDoesNotExist {
+			return n, false, nil
+		}
+		return n, false, v2Err
+	}
+	return n, true, nil
+}
+
+// v2NotFoundOr returns notFound when the V2 error reports a missing entity, otherwise the V2 error.
+func v2NotFoundOr(v2Err errors.EdgeX, notFound error) error {
+	if errors.Kind(v2Err) == errors.KindEntityDoesNotExist {
+		return notFound
+	}
+	return v2Err
+}
+
+// appendV2Subscriptions appends the V2 subscriptions not seen before to the subscriptions.
+func appendV2Subscriptions(
+	subscriptions []models.Subscription,
+	v2Subscriptions []v2Models.Subscription,
+	seen map[string]bool) []models.Subscription {
+
+	for _, s := range v2Subscriptions {
+		if seen[s.Id] {
+			continue
+		}
+		seen[s.Id] = true
+		subscriptions = append(subscriptions, toV1Subscription(s))
+	}
+	return subscriptions
+}
+
+func toV1Notification(n v2Models.Notification) models.Notification {
+	return models.Notification{
+		Timestamps:  models.Timestamps{Created: n.Created, Modified: n.Modified},
+		ID:          n.Id,
+		Slug:        n.Slug,
+		Sender:      n.Sender,
+		Category:    models.NotificationsCategory(n.Category),
+		Severity:    models.NotificationsSeverity(n.Severity),
+		Content:     n.Content,
+		Description: n.Description,
+		Status:      models.NotificationsStatus(n.Status),
+		Labels:      n.Labels,
+		ContentType: n.ContentType,
+	}
+}
+
+func toV1Subscription(s v2Models.Subscription) models.Subscription {
+	categories := make([]models.NotificationsCategory, len(s.Categories))
+	for i, c := range s.Categories {
+		categories[i] = models.NotificationsCategory(c)
+	}
+	channels := make([]models.Channel, len(s.Channels))
+	for i, c := range s.Channels {
+		channels[i] = toV1Channel(c)
+	}
+	return models.Subscription{
+		Timestamps:           models.Timestamps{Created: s.Created, Modified: s.Modified},
+		ID:                   s.Id,
+		Slug:                 s.Slug,
+		Receiver:             s.Receiver,
+		Description:          s.Description,
+		SubscribedCategories: categories,
+		SubscribedLabels:     s.Labels,
+		Channels:             channels,
+	}
+}
+
+// toV1Channel converts the V2 channel to the V1 channel delivered by the same channel sender. MQTT, WEBHOOK and SMS
+// channels become REST channels with the URL scheme of their channel sender.
+func toV1Channel(c v2Models.Channel) models.Channel {
+	switch c.Type {
+	case v2Models.Email:
+		return models.Channel{Type: models.ChannelType(models.Email), MailAddresses: c.EmailAddresses}
+	case v2Models.Webhook:
+		return models.Channel{Type: models.ChannelType(models.Rest), Url: "webhook://" + c.Webhook}
+	case v2Models.Sms:
+		return models.Channel{
+			Type: models.ChannelType(models.Rest),
+			Url:  "sms://" + c.SmsGateway + "/" + strings.Join(c.PhoneNumbers, ","),
+		}
+	default:
+		return models.Channel{Type: models.ChannelType(models.Rest), Url: c.Url}
+	}
+}
+
+// toV2Channel converts the V1 channel back to the V2 channel it was converted from.
+func toV2Channel(c models.Channel) v2Models.Channel {
+	switch channelKind(c) {
+	case EmailChannel:
+		return v2Models.Channel{Type: v2Models.Email, EmailAddresses: c.MailAddresses}
+	case MqttChannel:
+		return v2Models.Channel{Type: v2Models.Mqtt, Url: c.Url}
+	case WebhookChannel:
+		if name, _, err := parseNamedChannelUrl(c.Url); err == nil {
+			return v2Models.Channel{Type: v2Models.Webhook, Webhook: name}
+		}
+	case SmsChannel:
+		if name, phoneNumbers, err := parseNamedChannelUrl(c.Url); err == nil {
+			return v2Models.Channel{Type: v2Models.Sms, SmsGateway: name, PhoneNumbers: phoneNumbers}
+		}
+	}
+	return v2Models.Channel{Type: v2Models.Rest, Url: c.Url}
+}
+
+func toV1Transmission(t v2Models.Transmission) models.Transmission {
+	records := make([]models.TransmissionRecord, len(t.Records))
+	for i, r := range t.Records {
+		records[i] = models.TransmissionRecord{
+			Status:   models.TransmissionStatus(r.Status),
+			Response: r.Response,
+			Sent:     r.Sent,
+		}
+	}
+	return models.Transmission{
+		Timestamps:   models.Timestamps{Created: t.Created, Modified: t.Modified},
+		ID:           t.Id,
+		Notification: toV1Notification(t.Notification),
+		Receiver:     t.Receiver,
+		Channel:      toV1Channel(t.Channel),
+		Status:       models.TransmissionStatus(t.Status),
+		ResendCount:  t.ResendCount,
+		Records:      records,
+	}
+}
+
+// toV2Transmission converts the V1 transmission of the V2 notification to the V2 transmission.
+func toV2Transmission(t models.Transmission, n v2Models.Notification) v2Models.Transmission {
+	records := make([]v2Models.TransmissionRecord, len(t.Records))
+	for i, r := range t.Records {
+		records[i] = v2Models.TransmissionRecord{
+			Status:   v2Models.TransmissionStatus(r.Status),
+			Response: r.Response,
+			Sent:     r.Sent,
+		}
+	}
+	v2Transmission := v2Models.Transmission{
+		Id:           t.ID,
+		Notification: n,
+		Receiver:     t.Receiver,
+		Channel:      toV2Channel(t.Channel),
+		Status:       v2Models.TransmissionStatus(t.Status),
+		ResendCount:  t.ResendCount,
+		Records:      records,
+	}
+	v2Transmission.Created, v2Transmission.Modified = t.Created, t.Modified
+	return v2Transmission
+}
+
+func toSubscriptionSettings(s v2Models.Subscription) notificationsModels.SubscriptionSettings {
+	var windows []notificationsModels.DeliveryWindow
+	for _, w := range s.DeliveryWindows {
+		windows = append(windows, notificationsModels.DeliveryWindow{Days: w.Days, Start: w.Start, End: w.End})
+	}
+	return notificationsModels.SubscriptionSettings{
+		Template:         s.Template,
+		EmailSender:      s.EmailSender,
+		EmailSubject:     s.EmailSubject,
+		MinSeverity:      string(s.MinSeverity),
+		TimeZone:         s.TimeZone,
+		DeliveryWindows:  windows,
+		QuietHours:       s.QuietHours,
+		RetryPolicy:      s.RetryPolicy,
+		EscalationPolicy: s.EscalationPolicy,
+		RateLimit:        s.RateLimit,
+		RateLimitPeriod:  s.RateLimitPeriod,
+		DigestInterval:   s.DigestInterval,
+	}
+}
@@ -0,0 +1,164 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ *******************************************************************************/
+
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/db"
+	notificationsModels "github.com/edgexfoundry/edgex-go/internal/pkg/notifications/models"
+	v2Models "github.com/edgexfoundry/edgex-go/internal/pkg/v2/notifications/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/notifications/interfaces/mocks"
+	v2Mocks "github.com/edgexfoundry/edgex-go/internal/support/notifications/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistributionDBClientResolvesV2Subscriptions(t *testing.T) {
+	v2Subscription := v2Models.Subscription{
+		Id:         "v2",
+		Slug:       "v2-sub",
+		Receiver:   "ops",
+		Categories: []v2Models.NotificationCategory{v2Models.Security},
+		Labels:     []string{"door"},
+		Channels: []v2Models.Channel{
+			{Type: v2Models.Email, EmailAddresses: []string{"ops@example.com"}},
+			{Type: v2Models.Mqtt, Url: "mqtt://broker:1883/alerts"},
+			{Type: v2Models.Webhook, Webhook: "pagerduty"},
+			{Type: v2Models.Sms, SmsGateway: "twilio", PhoneNumbers: []string{"+4915112345678", "+4915187654321"}},
+		},
+		MinSeverity: v2Models.Critical,
+		QuietHours:  "drop",
+		RetryPolicy: "aggressive",
+	}
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetSubscriptionByCategoriesLabels", []string{"SECURITY"}, []string{"door"}).
+		Return([]contract.Subscription{{ID: "v1", Slug: "v1-sub"}}, nil)
+	dbClient.On("GetSubscriptionBySlug", "v2-sub").Return(contract.Subscription{}, db.ErrNotFound)
+	dbClient.On("GetSubscriptionBySlug", "unknown").Return(contract.Subscription{}, db.ErrNotFound)
+	dbClient.On("GetSubscriptionSettings", "v2").Return(notificationsModels.SubscriptionSettings{}, db.ErrNotFound)
+	v2DBClient := &v2Mocks.DBClient{}
+	v2DBClient.On("SubscriptionsByCategory", 0, -1, "SECURITY").Return([]v2Models.Subscription{v2Subscription}, nil)
+	v2DBClient.On("SubscriptionsByLabel", 0, -1, "door").Return([]v2Models.Subscription{v2Subscription}, nil)
+	v2DBClient.On("SubscriptionBySlug", "v2-sub").Return(v2Subscription, nil)
+	v2DBClient.On("SubscriptionBySlug", "unknown").
+		Return(v2Models.Subscription{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+	v2DBClient.On("SubscriptionById", "v2").Return(v2Subscription, nil)
+	client := distributionDBClient{DBClient: dbClient, v2DBClient: v2DBClient}
+
+	subscriptions, err := client.GetSubscriptionByCategoriesLabels([]string{"SECURITY"}, []string{"door"})
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, "v1-sub", subscriptions[0].Slug)
+	assert.Equal(t, "v2-sub", subscriptions[1].Slug)
+	var kinds []string
+	for _, c := range subscriptions[1].Channels {
+		kinds = append(kinds, channelKind(c))
+	}
+	assert.Equal(t, []string{EmailChannel, MqttChannel, WebhookChannel, SmsChannel}, kinds)
+	assert.Equal(t, "sms://twilio/+4915112345678,+4915187654321", subscriptions[1].Channels[3].Url)
+
+	s, err := client.GetSubscriptionBySlug("v2-sub")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", s.ID)
+	_, err = client.GetSubscriptionBySlug("unknown")
+	assert.Equal(t, db.ErrNotFound, err)
+
+	settings, err := client.GetSubscriptionSettings("v2")
+	require.NoError(t, err)
+	assert.Equal(t, notificationsModels.SubscriptionSettings{MinSeverity: "CRITICAL", QuietHours: "drop", RetryPolicy: "aggressive"}, settings)
+}
+
+func TestDistributionDBClientStoresV2TransmissionsWithV2(t *testing.T) {
+	v2Notification := v2Models.Notification{Id: "n2", Slug: "v2-notification", Severity: v2Models.Critical}
+	notFound := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil)
+
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetNotificationBySlug", "v1-notification").Return(contract.Notification{Slug: "v1-notification"}, nil)
+	dbClient.On("GetNotificationBySlug", "v2-notification").Return(contract.Notification{}, db.ErrNotFound)
+	dbClient.On("AddTransmission", mock.Anything).Return("t1", nil)
+	dbClient.On("GetTransmissionById", "t2").Return(contract.Transmission{}, db.ErrNotFound)
+	dbClient.On("GetTransmissionById", "unknown").Return(contract.Transmission{}, db.ErrNotFound)
+	v2DBClient := &v2Mocks.DBClient{}
+	v2DBClient.On("NotificationBySlug", "v2-notification").Return(v2Notification, nil)
+	v2DBClient.On("AddTransmission", mock.Anything).Return(v2Models.Transmission{Id: "t2"}, nil)
+	v2DBClient.On("TransmissionById", "t2").
+		Return(v2Models.Transmission{Id: "t2", Notification: v2Notification, Status: v2Models.Failed}, nil)
+	v2DBClient.On("TransmissionById", "unknown").Return(v2Models.Transmission{}, notFound)
+	v2DBClient.On("UpdateTransmission", mock.Anything).Return(nil)
+	client := distributionDBClient{DBClient: dbClient, v2DBClient: v2DBClient}
+
+	id, err := client.AddTransmission(contract.Transmission{Notification: contract.Notification{Slug: "v1-notification"}})
+	require.NoError(t, err)
+	assert.Equal(t, "t1", id)
+	v2DBClient.AssertNotCalled(t, "AddTransmission", mock.Anything)
+
+	channel := contract.Channel{Type: contract.ChannelType(contract.Rest), Url: "sms://twilio/+4915112345678"}
+	id, err = client.AddTransmission(contract.Transmission{
+		Notification: contract.Notification{Slug: "v2-notification"},
+		Channel:      channel,
+		Status:       contract.Failed,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "t2", id)
+	v2DBClient.AssertCalled(t, "AddTransmission", mock.MatchedBy(func(t v2Models.Transmission) bool {
+		return t.Notification.Id == "n2" && t.Status == v2Models.Failed &&
+			t.Channel.Type == v2Models.Sms && t.Channel.SmsGateway == "twilio"
+	}))
+
+	transmission, err := client.GetTransmissionById("t2")
+	require.NoError(t, err)
+	assert.Equal(t, "v2-notification", transmission.Notification.Slug)
+	assert.Equal(t, contract.TransmissionStatus(contract.Failed), transmission.Status)
+	_, err = client.GetTransmissionById("unknown")
+	assert.Equal(t, db.ErrNotFound, err)
+
+	transmission.Status = contract.Sent
+	transmission.ResendCount = 1
+	require.NoError(t, client.UpdateTransmission(transmission))
+	v2DBClient.AssertCalled(t, "UpdateTransmission", mock.MatchedBy(func(t v2Models.Transmission) bool {
+		return t.Id == "t2" && t.Status == v2Models.Sent && t.ResendCount == 1 && t.Notification.Id == "n2"
+	}))
+	dbClient.AssertNotCalled(t, "UpdateTransmission", mock.Anything)
+}
+
+func TestGetNotificationLifecycleOfV2Notification(t *testing.T) {
+	dbClient := &mocks.DBClient{}
+	dbClient.On("GetNotificationBySlug", "n1").Return(contract.Notification{}, db.ErrNotFound)
+	dbClient.On("GetNotificationLifecycle", "n1").Return(notificationsModels.NotificationLifecycle{}, db.ErrNotFound)
+	v2DBClient := &v2Mocks.DBClient{}
+	v2DBClient.On("NotificationBySlug", "n1").Return(v2Models.Notification{Id: "n2", Slug: "n1"}, nil)
+	client := distributionDBClient{DBClient: dbClient, v2DBClient: v2DBClient}
+
+	rr := httptest.NewRecorder()
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, TestURI, nil), map[string]string{SLUG: "n1"})
+	restGetNotificationLifecycle(rr, req, logger.NewMockClient(), client)
+
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var l notificationsModels.NotificationLifecycle
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &l))
+	assert.Equal(t, notificationsModels.Open, l.State)
+}
@@ -30,14 +30,23 @@ type ConfigurationStruct struct {
 	Intervals       map[string]IntervalInfo
 	IntervalActions map[string]IntervalActionInfo
 	SecretStore     bootstrapConfig.SecretStoreInfo
+	Scheduler       SchedulerInfo
 }
 
 type WritableInfo struct {
+	// ScheduleIntervalTime is ignored, due interval actions are executed as soon as they are due.
+	// Deprecated: remove it from the configuration
 	ScheduleIntervalTime int
 	LogLevel             string
 	InsecureSecrets      bootstrapConfig.InsecureSecrets
 }
 
+// SchedulerInfo configures the execution of due interval actions.
+type SchedulerInfo struct {
+	// MaxWorkers is the number of interval actions which may be executed concurrently
+	MaxWorkers int
+}
+
 type IntervalInfo struct {
 	// Name of the schedule must be unique?
 	Name string
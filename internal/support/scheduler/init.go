@@ -19,10 +19,11 @@ import (
 	"context"
 	"fmt"
 	"sync"
-	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg/bootstrap/container"
 	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/application"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/startup"
@@ -46,9 +47,13 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 // BootstrapHandler fulfills the BootstrapHandler contract and performs initialization needed by the scheduler service.
 func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	loadRestRoutes(b.router, dic)
+	v2.LoadRestRoutes(b.router, dic)
 
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	configuration := schedulerContainer.ConfigurationFrom(dic.Get)
+	if configuration.Writable.ScheduleIntervalTime != 0 {
+		lc.Warn("Writable.ScheduleIntervalTime is deprecated and ignored, interval actions are executed as soon as they are due")
+	}
 
 	// add dependencies to bootstrapContainer
 	scClient := NewSchedulerQueueClient(lc)
@@ -63,17 +68,9 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _
 		lc.Error(fmt.Sprintf("Failed to load schedules and events %s", err.Error()))
 		return false
 	}
+	application.LoadIntervalsToQueue(dic)
 
-	ticker := time.NewTicker(time.Duration(configuration.Writable.ScheduleIntervalTime) * time.Millisecond)
-	StartTicker(ticker, lc, configuration)
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		<-ctx.Done()
-		StopTicker(ticker)
-	}()
+	scClient.StartScheduler(ctx, wg, configuration)
 
 	return true
 }
@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"container/heap"
+)
+
+// intervalQueue is a min-heap of interval contexts ordered by NextTime. It implements heap.Interface and keeps each
+// context's index up to date so that a context can be fixed or removed in place when its interval changes.
+type intervalQueue []*IntervalContext
+
+func (q intervalQueue) Len() int {
+	return len(q)
+}
+
+func (q intervalQueue) Less(i, j int) bool {
+	return q[i].NextTime.Before(q[j].NextTime)
+}
+
+func (q intervalQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *intervalQueue) Push(x interface{}) {
+	context := x.(*IntervalContext)
+	context.index = len(*q)
+	*q = append(*q, context)
+}
+
+func (q *intervalQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	context := old[n-1]
+	old[n-1] = nil
+	context.index = -1
+	*q = old[:n-1]
+	return context
+}
+
+// peek returns the context with the earliest NextTime without removing it, or nil if the queue is empty.
+func (q intervalQueue) peek() *IntervalContext {
+	if len(q) == 0 {
+		return nil
+	}
+	return q[0]
+}
+
+// add pushes the context onto the queue unless it is already queued.
+func (q *intervalQueue) add(context *IntervalContext) {
+	if context.queued(*q) {
+		return
+	}
+	heap.Push(q, context)
+}
+
+// fix re-establishes the heap ordering after the context's NextTime has changed.
+func (q *intervalQueue) fix(context *IntervalContext) {
+	if context.queued(*q) {
+		heap.Fix(q, context.index)
+	}
+}
+
+// remove takes the context out of the queue if it is queued.
+func (q *intervalQueue) remove(context *IntervalContext) {
+	if context.queued(*q) {
+		heap.Remove(q, context.index)
+	}
+}
+
+// clear empties the queue.
+func (q *intervalQueue) clear() {
+	for _, context := range *q {
+		context.index = -1
+	}
+	*q = intervalQueue{}
+}
@@ -29,12 +29,6 @@ func LoadScheduler(
 	scClient interfaces.SchedulerQueueClient,
 	configuration *config.ConfigurationStruct) error {
 
-	// ensure maps are clean
-	clearMaps()
-
-	// ensure queue is empty
-	clearQueue()
-
 	lc.Info("loading intervals, interval actions ...")
 
 	// load data from support-scheduler database
@@ -9,6 +9,8 @@ package scheduler
 
 import (
 	"bytes"
+	"container/heap"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -20,87 +22,153 @@ import (
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
 	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
-	queueV1 "gopkg.in/eapache/queue.v1"
 
 	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
 )
 
-// the interval specific shared variables
-var (
-	mutex                                   sync.Mutex
-	intervalQueue                           = queueV1.New()
-	intervalIdToContextMap                  = make(map[string]*IntervalContext)
-	intervalNameToContextMap                = make(map[string]*IntervalContext)
-	intervalNameToIdMap                     = make(map[string]string)
-	intervalActionIdToIntervalMap           = make(map[string]string)
-	intervalActionNameToIntervalMap         = make(map[string]string)
-	intervalActionNameToIntervalActionIdMap = make(map[string]string)
-)
+// defaultMaxWorkers is the size of the worker pool used when the configuration does not specify one.
+const defaultMaxWorkers = 16
 
-func StartTicker(ticker *time.Ticker, lc logger.LoggingClient, configuration *config.ConfigurationStruct) {
-	go func() {
-		for range ticker.C {
-			triggerInterval(lc, configuration)
-		}
-	}()
+// intervalActionJob is a single interval action which is due for execution by the worker pool.
+type intervalActionJob struct {
+	intervalId     string
+	intervalAction contract.IntervalAction
 }
 
-func StopTicker(ticker *time.Ticker) {
-	ticker.Stop()
-}
+// QueueClient holds the scheduler state: the interval contexts ordered by their next execution time and the lookup
+// maps between intervals and interval actions. Each QueueClient is independent of any other instance.
+type QueueClient struct {
+	loggingClient logger.LoggingClient
 
-// utility function
-func clearQueue() {
-	mutex.Lock()
-	defer mutex.Unlock()
+	mutex                                   sync.Mutex
+	intervalQueue                           intervalQueue
+	intervalIdToContextMap                  map[string]*IntervalContext // map : interval id -> interval context
+	intervalNameToContextMap                map[string]*IntervalContext // map : interval name -> interval context
+	intervalNameToIdMap                     map[string]string           // map : interval name -> interval id
+	intervalActionIdToIntervalMap           map[string]string           // map : interval action id -> interval id
+	intervalActionNameToIntervalMap         map[string]string           // map : interval action name -> interval id
+	intervalActionNameToIntervalActionIdMap map[string]string           // map : interval action name -> interval actionId
+
+	// wakeup is signalled whenever the queue changes so the scheduler loop can re-arm its timer.
+	wakeup chan struct{}
+}
 
-	for intervalQueue.Length() > 0 {
-		intervalQueue.Remove()
+// NewClient
+func NewSchedulerQueueClient(lc logger.LoggingClient) *QueueClient {
+	return &QueueClient{
+		loggingClient:                           lc,
+		intervalIdToContextMap:                  make(map[string]*IntervalContext),
+		intervalNameToContextMap:                make(map[string]*IntervalContext),
+		intervalNameToIdMap:                     make(map[string]string),
+		intervalActionIdToIntervalMap:           make(map[string]string),
+		intervalActionNameToIntervalMap:         make(map[string]string),
+		intervalActionNameToIntervalActionIdMap: make(map[string]string),
+		wakeup:                                  make(chan struct{}, 1),
 	}
 }
 
-func clearMaps() {
-	intervalIdToContextMap = make(map[string]*IntervalContext)        // map : interval id -> interval context
-	intervalNameToContextMap = make(map[string]*IntervalContext)      // map : interval name -> interval context
-	intervalNameToIdMap = make(map[string]string)                     // map : interval name -> interval id
-	intervalActionIdToIntervalMap = make(map[string]string)           // map : interval action id -> interval id
-	intervalActionNameToIntervalMap = make(map[string]string)         // map : interval action name -> interval id
-	intervalActionNameToIntervalActionIdMap = make(map[string]string) // map : interval action name -> interval actionId
+// StartScheduler starts the scheduler loop and its worker pool. The loop sleeps until the earliest interval in the
+// queue is due, hands each of its interval actions to the worker pool and re-queues the interval. Both the loop and
+// the workers stop once ctx is done.
+func (qc *QueueClient) StartScheduler(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	configuration *config.ConfigurationStruct) {
+
+	maxWorkers := configuration.Scheduler.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = defaultMaxWorkers
+	}
+
+	client := &http.Client{
+		Timeout: time.Duration(configuration.Service.Timeout) * time.Millisecond,
+	}
+
+	jobs := make(chan intervalActionJob, maxWorkers)
 
+	for i := 0; i < maxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				qc.execute(job, client)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		qc.run(ctx, jobs)
+	}()
 }
 
-func addIntervalOperation(interval contract.Interval, context *IntervalContext) {
-	intervalIdToContextMap[interval.ID] = context
-	intervalNameToContextMap[interval.Name] = context
-	intervalNameToIdMap[interval.Name] = interval.ID
-	intervalQueue.Add(context)
+// run is the scheduler loop; it arms a single timer for the earliest interval in the queue.
+func (qc *QueueClient) run(ctx context.Context, jobs chan<- intervalActionJob) {
+	for {
+		var timeout <-chan time.Time
+		var timer *time.Timer
+
+		qc.mutex.Lock()
+		if next := qc.intervalQueue.peek(); next != nil {
+			timer = time.NewTimer(time.Until(next.NextTime))
+			timeout = timer.C
+		}
+		qc.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-qc.wakeup:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-timeout:
+			for _, job := range qc.triggerIntervals(time.Now()) {
+				select {
+				case jobs <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
 }
 
-func deleteIntervalOperation(interval contract.Interval, intervalContext *IntervalContext) {
-	intervalContext.MarkedDeleted = true
-	intervalIdToContextMap[interval.ID] = intervalContext
-	intervalNameToContextMap[interval.Name] = intervalContext
-	delete(intervalIdToContextMap, interval.ID)
-	delete(intervalNameToContextMap, interval.Name)
+// notify wakes up the scheduler loop without blocking when a wakeup is already pending.
+func (qc *QueueClient) notify() {
+	select {
+	case qc.wakeup <- struct{}{}:
+	default:
+	}
 }
 
-func addIntervalActionOperation(interval contract.Interval, intervalAction contract.IntervalAction) {
-	intervalContext, _ := intervalIdToContextMap[interval.ID]
-	intervalContext.IntervalActionsMap[intervalAction.ID] = intervalAction
-	intervalActionIdToIntervalMap[intervalAction.ID] = interval.ID
-	intervalActionNameToIntervalMap[intervalAction.Name] = interval.ID
-	intervalActionNameToIntervalActionIdMap[intervalAction.Name] = intervalAction.ID
+func (qc *QueueClient) addIntervalOperation(interval contract.Interval, context *IntervalContext) {
+	qc.intervalIdToContextMap[interval.ID] = context
+	qc.intervalNameToContextMap[interval.Name] = context
+	qc.intervalNameToIdMap[interval.Name] = interval.ID
+	qc.intervalQueue.add(context)
+	qc.notify()
 }
 
-type QueueClient struct {
-	loggingClient logger.LoggingClient
+func (qc *QueueClient) deleteIntervalOperation(interval contract.Interval, intervalContext *IntervalContext) {
+	intervalContext.MarkedDeleted = true
+	qc.intervalQueue.remove(intervalContext)
+	delete(qc.intervalIdToContextMap, interval.ID)
+	delete(qc.intervalNameToContextMap, interval.Name)
+	qc.notify()
 }
 
-// NewClient
-func NewSchedulerQueueClient(lc logger.LoggingClient) *QueueClient {
-	return &QueueClient{
-		loggingClient: lc,
-	}
+func (qc *QueueClient) addIntervalActionOperation(interval contract.Interval, intervalAction contract.IntervalAction) {
+	intervalContext := qc.intervalIdToContextMap[interval.ID]
+	intervalContext.IntervalActionsMap[intervalAction.ID] = intervalAction
+	qc.intervalActionIdToIntervalMap[intervalAction.ID] = interval.ID
+	qc.intervalActionNameToIntervalMap[intervalAction.Name] = interval.ID
+	qc.intervalActionNameToIntervalActionIdMap[intervalAction.Name] = intervalAction.ID
 }
 
 func (qc *QueueClient) Connect() (string, error) {
@@ -108,10 +176,10 @@ func (qc *QueueClient) Connect() (string, error) {
 }
 func (qc *QueueClient) QueryIntervalByID(intervalId string) (contract.Interval, error) {
 
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
-	intervalContext, exists := intervalIdToContextMap[intervalId]
+	intervalContext, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		return contract.Interval{},
 			fmt.Errorf("scheduler could not find a interval context with interval id : %s", intervalId)
@@ -124,10 +192,10 @@ func (qc *QueueClient) QueryIntervalByID(intervalId string) (contract.Interval,
 
 func (qc *QueueClient) QueryIntervalByName(intervalName string) (contract.Interval, error) {
 
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
-	intervalContext, exists := intervalNameToContextMap[intervalName]
+	intervalContext, exists := qc.intervalNameToContextMap[intervalName]
 	if !exists {
 		return contract.Interval{},
 			fmt.Errorf("scheduler could not find interval with interval with name : %s", intervalName)
@@ -139,13 +207,13 @@ func (qc *QueueClient) QueryIntervalByName(intervalName string) (contract.Interv
 }
 
 func (qc *QueueClient) AddIntervalToQueue(interval contract.Interval) error {
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
 	intervalId := interval.ID
 	qc.loggingClient.Debug(fmt.Sprintf("adding the interval with id : %s at time %s", intervalId, interval.Start))
 
-	if _, exists := intervalIdToContextMap[intervalId]; exists {
+	if _, exists := qc.intervalIdToContextMap[intervalId]; exists {
 		qc.loggingClient.Debug(fmt.Sprintf("the interval context with id : %s already exists", intervalId))
 		return nil
 	}
@@ -158,7 +226,7 @@ func (qc *QueueClient) AddIntervalToQueue(interval contract.Interval) error {
 	qc.loggingClient.Debug(fmt.Sprintf("resetting the interval with id : %s", intervalId))
 	context.Reset(interval, qc.loggingClient)
 
-	addIntervalOperation(interval, &context)
+	qc.addIntervalOperation(interval, &context)
 
 	qc.loggingClient.Info(fmt.Sprintf("added the interval with id : %s into the scheduler queue", intervalId))
 
@@ -166,49 +234,57 @@ func (qc *QueueClient) AddIntervalToQueue(interval contract.Interval) error {
 }
 
 func (qc *QueueClient) UpdateIntervalInQueue(interval contract.Interval) error {
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
 	intervalId := interval.ID
-	context, exists := intervalIdToContextMap[intervalId]
+	context, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		return errors.New("the interval context with id " + intervalId + " does not exist ")
 	}
 
 	// remove the old map entry and create new one
-	_, exists = intervalNameToIdMap[context.Interval.Name]
+	_, exists = qc.intervalNameToIdMap[context.Interval.Name]
 	if exists {
-		delete(intervalNameToIdMap, context.Interval.Name)
+		delete(qc.intervalNameToIdMap, context.Interval.Name)
 	}
 
 	// add new map entry
-	intervalNameToIdMap[interval.Name] = interval.ID
+	qc.intervalNameToIdMap[interval.Name] = interval.ID
 
 	qc.loggingClient.Debug(fmt.Sprintf("resting the interval context with id: %s in the scheduler queue", intervalId))
 	context.Reset(interval, qc.loggingClient)
 
+	// the next time may have moved, so restore the queue ordering or re-queue a previously completed interval
+	if context.queued(qc.intervalQueue) {
+		qc.intervalQueue.fix(context)
+	} else if !context.IsComplete() {
+		qc.intervalQueue.add(context)
+	}
+	qc.notify()
+
 	qc.loggingClient.Info(fmt.Sprintf("updated the interval with id: %s in the scheduler queue", intervalId))
 
 	return nil
 }
 
 func (qc *QueueClient) RemoveIntervalInQueue(intervalId string) error {
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
 	qc.loggingClient.Debug(fmt.Sprintf("removing the interval with id: %s ", intervalId))
 
-	intervalContext, exists := intervalIdToContextMap[intervalId]
+	intervalContext, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		return fmt.Errorf("scheduler could not find interval context with interval id : %s", intervalId)
 	}
 
 	qc.loggingClient.Debug(fmt.Sprintf("removing all the mappings of interval action id to interval id: %s ", intervalId))
 	for eventId := range intervalContext.IntervalActionsMap {
-		delete(intervalActionIdToIntervalMap, eventId)
+		delete(qc.intervalActionIdToIntervalMap, eventId)
 	}
 
-	deleteIntervalOperation(intervalContext.Interval, intervalContext)
+	qc.deleteIntervalOperation(intervalContext.Interval, intervalContext)
 
 	qc.loggingClient.Info(fmt.Sprintf("removed the interval with id: %s from the scheduler queue", intervalId))
 
@@ -217,16 +293,16 @@ func (qc *QueueClient) RemoveIntervalInQueue(intervalId string) error {
 
 func (qc *QueueClient) QueryIntervalActionByID(intervalActionId string) (contract.IntervalAction, error) {
 
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
-	intervalId, exists := intervalActionIdToIntervalMap[intervalActionId]
+	intervalId, exists := qc.intervalActionIdToIntervalMap[intervalActionId]
 	if !exists {
 		return contract.IntervalAction{},
 			fmt.Errorf("scheduler could not find interval id with interval action id : %s", intervalActionId)
 	}
 
-	intervalContext, exists := intervalIdToContextMap[intervalId]
+	intervalContext, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		qc.loggingClient.Warn("scheduler could not find a interval context with interval id : " + intervalId)
 		return contract.IntervalAction{}, nil
@@ -243,16 +319,16 @@ func (qc *QueueClient) QueryIntervalActionByID(intervalActionId string) (contrac
 
 func (qc *QueueClient) QueryIntervalActionByName(intervalActionName string) (contract.IntervalAction, error) {
 
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
-	intervalId, exists := intervalActionNameToIntervalMap[intervalActionName]
+	intervalId, exists := qc.intervalActionNameToIntervalMap[intervalActionName]
 	if !exists {
 		return contract.IntervalAction{},
 			fmt.Errorf("scheduler could not find interval id with intervalAction name : %s", intervalActionName)
 	}
 
-	intervalActionId, exists := intervalActionNameToIntervalActionIdMap[intervalActionName]
+	intervalActionId, exists := qc.intervalActionNameToIntervalActionIdMap[intervalActionName]
 	if !exists {
 		return contract.IntervalAction{},
 			fmt.Errorf(
@@ -260,7 +336,7 @@ func (qc *QueueClient) QueryIntervalActionByName(intervalActionName string) (con
 				intervalActionName)
 	}
 
-	intervalContext, exists := intervalIdToContextMap[intervalId]
+	intervalContext, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		return contract.IntervalAction{},
 			fmt.Errorf(
@@ -281,8 +357,8 @@ func (qc *QueueClient) QueryIntervalActionByName(intervalActionName string) (con
 
 func (qc *QueueClient) AddIntervalActionToQueue(intervalAction contract.IntervalAction) error {
 
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
 	intervalActionId := intervalAction.ID
 	intervalName := intervalAction.Interval
@@ -293,25 +369,25 @@ func (qc *QueueClient) AddIntervalActionToQueue(intervalAction contract.Interval
 		intervalActionId,
 		intervalName))
 
-	if _, exists := intervalActionNameToIntervalMap[intervalActionName]; exists {
+	if _, exists := qc.intervalActionNameToIntervalMap[intervalActionName]; exists {
 		return fmt.Errorf("scheduler found existing intervalAction with same name: %s", intervalName)
 	}
 
 	// Ensure we have an existing Interval
-	intervalId, exists := intervalNameToIdMap[intervalName]
+	intervalId, exists := qc.intervalNameToIdMap[intervalName]
 	if !exists {
 		return fmt.Errorf("scheduler could not find a interval with interval name : %s", intervalName)
 	}
 
 	// Get the Schedule Context
-	intervalContext, exists := intervalIdToContextMap[intervalId]
+	intervalContext, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		return fmt.Errorf("scheduler could not find a interval with interval name : %s", intervalName)
 	}
 
 	interval := intervalContext.Interval
 
-	addIntervalActionOperation(interval, intervalAction)
+	qc.addIntervalActionOperation(interval, intervalAction)
 
 	qc.loggingClient.Info(fmt.Sprintf(
 		"added the intervalAction with id: %s to interal: %s into the queue",
@@ -323,21 +399,21 @@ func (qc *QueueClient) AddIntervalActionToQueue(intervalAction contract.Interval
 
 func (qc *QueueClient) UpdateIntervalActionQueue(intervalAction contract.IntervalAction) error {
 
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
 	intervalActionId := intervalAction.ID
 
 	qc.loggingClient.Debug(fmt.Sprintf("updating the intervalAction with id: %s ", intervalActionId))
 
-	oldIntervalId, exists := intervalActionIdToIntervalMap[intervalActionId]
+	oldIntervalId, exists := qc.intervalActionIdToIntervalMap[intervalActionId]
 	if !exists {
 		return fmt.Errorf(
 			"there is no mapping from interval action id : %s to interval",
 			intervalActionId)
 	}
 
-	intervalContext, exists := intervalNameToContextMap[intervalAction.Interval]
+	intervalContext, exists := qc.intervalNameToContextMap[intervalAction.Interval]
 	if !exists {
 		return fmt.Errorf(
 			"query the interval with name : %s  and did not exist.",
@@ -365,7 +441,7 @@ func (qc *QueueClient) UpdateIntervalActionQueue(intervalAction contract.Interva
 		// TODO: Not sure we want to just remove the interval from the interval context
 		if len(intervalContext.IntervalActionsMap) == 0 {
 			qc.loggingClient.Debug("there are no more events for the interval : " + oldIntervalId + ", remove it.")
-			deleteIntervalOperation(interval, intervalContext)
+			qc.deleteIntervalOperation(interval, intervalContext)
 		}
 
 		// add Interval Event
@@ -374,16 +450,16 @@ func (qc *QueueClient) UpdateIntervalActionQueue(intervalAction contract.Interva
 			intervalActionId,
 			newIntervalId))
 
-		if _, exists := intervalIdToContextMap[newIntervalId]; !exists {
+		if _, exists := qc.intervalIdToContextMap[newIntervalId]; !exists {
 			context := IntervalContext{
 				IntervalActionsMap: make(map[string]contract.IntervalAction),
 				MarkedDeleted:      false,
 			}
 			context.Reset(interval, qc.loggingClient)
 
-			addIntervalOperation(interval, &context)
+			qc.addIntervalOperation(interval, &context)
 		}
-		addIntervalActionOperation(interval, intervalAction)
+		qc.addIntervalActionOperation(interval, intervalAction)
 	} else { // if not, just update the interval action in place
 		intervalContext.IntervalActionsMap[intervalActionId] = intervalAction
 	}
@@ -397,24 +473,24 @@ func (qc *QueueClient) UpdateIntervalActionQueue(intervalAction contract.Interva
 }
 
 func (qc *QueueClient) RemoveIntervalActionQueue(intervalActionId string) error {
-	mutex.Lock()
-	defer mutex.Unlock()
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
 	qc.loggingClient.Debug(fmt.Sprintf("removing the intervalAction with id: %s", intervalActionId))
 
-	intervalId, exists := intervalActionIdToIntervalMap[intervalActionId]
+	intervalId, exists := qc.intervalActionIdToIntervalMap[intervalActionId]
 	if !exists {
 		return fmt.Errorf("could not find interval id with interval action id : %s", intervalActionId)
 	}
 
-	intervalContext, exists := intervalIdToContextMap[intervalId]
+	intervalContext, exists := qc.intervalIdToContextMap[intervalId]
 	if !exists {
 		return fmt.Errorf("can not find interval context with interval id : %s", intervalId)
 	}
 
 	action, exists := intervalContext.IntervalActionsMap[intervalActionId]
 	if exists {
-		delete(intervalActionNameToIntervalMap, action.Name)
+		delete(qc.intervalActionNameToIntervalMap, action.Name)
 	}
 
 	delete(intervalContext.IntervalActionsMap, intervalActionId)
@@ -424,109 +500,89 @@ func (qc *QueueClient) RemoveIntervalActionQueue(intervalActionId string) error
 	return nil
 }
 
-func triggerInterval(lc logger.LoggingClient, configuration *config.ConfigurationStruct) {
-	nowEpoch := time.Now().Unix()
-
-	defer func() {
-		if err := recover(); err != nil {
-			lc.Error("trigger interval error : " + err.(string))
-		}
-	}()
-
-	if intervalQueue.Length() == 0 {
-		return
-	}
+// triggerIntervals pops every interval which is due at now, advances it to its next execution time and re-queues it
+// unless it is complete. The interval actions of the due intervals are returned for execution by the worker pool.
+func (qc *QueueClient) triggerIntervals(now time.Time) []intervalActionJob {
+	qc.mutex.Lock()
+	defer qc.mutex.Unlock()
 
-	var wg sync.WaitGroup
+	var jobs []intervalActionJob
 
-	for i := 0; i < intervalQueue.Length(); i++ {
-		if intervalQueue.Peek().(*IntervalContext) != nil {
-			intervalContext := intervalQueue.Remove().(*IntervalContext)
-			intervalId := intervalContext.Interval.ID
-			if intervalContext.MarkedDeleted {
-				lc.Debug("the interval with id : " + intervalId + " be marked as deleted, removing it.")
-				continue // really delete from the queue
-			} else {
-				if intervalContext.NextTime.Unix() <= nowEpoch {
-					lc.Debug(
-						"executing interval, detail : {" + intervalContext.GetInfo() + "} ," +
-							" at : " + intervalContext.NextTime.String())
+	for {
+		intervalContext := qc.intervalQueue.peek()
+		if intervalContext == nil || intervalContext.NextTime.After(now) {
+			break
+		}
+		heap.Pop(&qc.intervalQueue)
 
-					wg.Add(1)
+		qc.loggingClient.Debug(
+			"executing interval, detail : {" + intervalContext.GetInfo() + "} ," +
+				" at : " + intervalContext.NextTime.String())
+		qc.loggingClient.Debug(fmt.Sprintf(
+			"%d interval action need to be executed.",
+			len(intervalContext.IntervalActionsMap)))
+
+		for _, intervalAction := range intervalContext.IntervalActionsMap {
+			jobs = append(jobs, intervalActionJob{
+				intervalId:     intervalContext.Interval.ID,
+				intervalAction: intervalAction,
+			})
+		}
 
-					// execute it in a individual go routine
-					go execute(intervalContext, &wg, lc, configuration)
-				} else {
-					intervalQueue.Add(intervalContext)
-				}
-			}
+		previousTime := intervalContext.NextTime
+		intervalContext.UpdateNextTime()
+		intervalContext.UpdateIterations()
+
+		if intervalContext.IsComplete() {
+			qc.loggingClient.Debug("completed interval, detail : " + intervalContext.GetInfo())
+		} else if !intervalContext.NextTime.After(previousTime) {
+			qc.loggingClient.Error("interval does not advance, detail : " + intervalContext.GetInfo() + ", removing it.")
+		} else {
+			qc.loggingClient.Debug("requeue interval, detail : " + intervalContext.GetInfo())
+			qc.intervalQueue.add(intervalContext)
 		}
 	}
 
-	wg.Wait()
+	return jobs
 }
 
-func execute(
-	context *IntervalContext,
-	wg *sync.WaitGroup,
-	lc logger.LoggingClient,
-	configuration *config.ConfigurationStruct) {
-
-	intervalActionMap := context.IntervalActionsMap
-
-	defer wg.Done()
+// execute sends the request of a single interval action; it is run by the workers of the pool.
+func (qc *QueueClient) execute(job intervalActionJob, client *http.Client) {
+	lc := qc.loggingClient
+	intervalAction := job.intervalAction
 
 	defer func() {
 		if err := recover(); err != nil {
-			lc.Error("interval execution error : " + err.(string))
+			lc.Error(fmt.Sprintf("interval action execution error : %v", err))
 		}
 	}()
 
-	lc.Debug(fmt.Sprintf("%d interval action need to be executed.", len(intervalActionMap)))
-
-	// execute interval action one by one
-	for eventId := range intervalActionMap {
-		lc.Debug(
-			"the event with id : " + eventId +
-				" belongs to interval : " + context.Interval.ID + " will be executing!")
-		intervalAction, _ := intervalActionMap[eventId]
-
-		executingUrl := getUrlStr(intervalAction)
-		lc.Debug("the event with id : " + eventId + " will request url : " + executingUrl)
-
-		httpMethod := intervalAction.HTTPMethod
-		if !validMethod(httpMethod) {
-			lc.Error(fmt.Sprintf("net/http: invalid method %q", httpMethod))
-			return
-		}
-
-		req, err := getHttpRequest(httpMethod, executingUrl, intervalAction, lc)
+	lc.Debug(
+		"the event with id : " + intervalAction.ID +
+			" belongs to interval : " + job.intervalId + " will be executing!")
 
-		if err != nil {
-			lc.Error("create new request occurs error : " + err.Error())
-		}
+	executingUrl := getUrlStr(intervalAction)
+	lc.Debug("the event with id : " + intervalAction.ID + " will request url : " + executingUrl)
 
-		client := &http.Client{
-			Timeout: time.Duration(configuration.Service.Timeout) * time.Millisecond,
-		}
-		responseBytes, statusCode, err := sendRequestAndGetResponse(client, req)
-		responseStr := string(responseBytes)
-
-		lc.Debug(fmt.Sprintf("execution returns status code : %d", statusCode))
-		lc.Debug("execution returns response content : " + responseStr)
+	httpMethod := intervalAction.HTTPMethod
+	if !validMethod(httpMethod) {
+		lc.Error(fmt.Sprintf("net/http: invalid method %q", httpMethod))
+		return
 	}
 
-	context.UpdateNextTime()
-	context.UpdateIterations()
+	req, err := getHttpRequest(httpMethod, executingUrl, intervalAction, lc)
+	if err != nil {
+		return
+	}
 
-	if context.IsComplete() {
-		lc.Debug("completed interval, detail : " + context.GetInfo())
-	} else {
-		lc.Debug("requeue interval, detail : " + context.GetInfo())
-		intervalQueue.Add(context)
+	responseBytes, statusCode, err := sendRequestAndGetResponse(client, req)
+	if err != nil {
+		lc.Error("execution of the event with id : " + intervalAction.ID + " failed : " + err.Error())
 	}
+	responseStr := string(responseBytes)
 
-	return
+	lc.Debug(fmt.Sprintf("execution returns status code : %d", statusCode))
+	lc.Debug("execution returns response content : " + responseStr)
 }
 
 // TODO xmlviking We may need to modify this for authorization type in the future
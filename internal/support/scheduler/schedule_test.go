@@ -0,0 +1,134 @@
+//
+// Copyright (c) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
+)
+
+func newTestInterval(id string, name string, start time.Time, frequency string) models.Interval {
+	return models.Interval{
+		ID:        id,
+		Name:      name,
+		Start:     start.UTC().Format(TIMELAYOUT),
+		Frequency: frequency,
+	}
+}
+
+func TestIntervalQueueOrdersByNextTime(t *testing.T) {
+	qc := NewSchedulerQueueClient(logger.NewMockClient())
+	now := time.Now()
+
+	require.NoError(t, qc.AddIntervalToQueue(newTestInterval("3", "late", now.Add(3*time.Hour), "24h")))
+	require.NoError(t, qc.AddIntervalToQueue(newTestInterval("1", "early", now.Add(1*time.Hour), "24h")))
+	require.NoError(t, qc.AddIntervalToQueue(newTestInterval("2", "middle", now.Add(2*time.Hour), "24h")))
+
+	assert.Equal(t, "1", qc.intervalQueue.peek().Interval.ID)
+
+	// moving the earliest interval to the back must reorder the queue
+	require.NoError(t, qc.UpdateIntervalInQueue(newTestInterval("1", "early", now.Add(4*time.Hour), "24h")))
+	assert.Equal(t, "2", qc.intervalQueue.peek().Interval.ID)
+
+	require.NoError(t, qc.RemoveIntervalInQueue("2"))
+	assert.Equal(t, "3", qc.intervalQueue.peek().Interval.ID)
+	assert.Equal(t, 2, qc.intervalQueue.Len())
+}
+
+func TestQueueClientsAreIsolated(t *testing.T) {
+	first := NewSchedulerQueueClient(logger.NewMockClient())
+	second := NewSchedulerQueueClient(logger.NewMockClient())
+
+	require.NoError(t, first.AddIntervalToQueue(newTestInterval("1", "midnight", time.Now().Add(time.Hour), "24h")))
+
+	_, err := first.QueryIntervalByName("midnight")
+	assert.NoError(t, err)
+	_, err = second.QueryIntervalByName("midnight")
+	assert.Error(t, err)
+	assert.Equal(t, 0, second.intervalQueue.Len())
+}
+
+func TestTriggerIntervals(t *testing.T) {
+	qc := NewSchedulerQueueClient(logger.NewMockClient())
+	now := time.Now()
+
+	require.NoError(t, qc.AddIntervalToQueue(newTestInterval("due", "due", now.Add(time.Minute), "1h")))
+	require.NoError(t, qc.AddIntervalToQueue(newTestInterval("later", "later", now.Add(time.Hour), "1h")))
+	require.NoError(t, qc.AddIntervalActionToQueue(models.IntervalAction{ID: "a1", Name: "a1", Interval: "due"}))
+	require.NoError(t, qc.AddIntervalActionToQueue(models.IntervalAction{ID: "a2", Name: "a2", Interval: "due"}))
+	require.NoError(t, qc.AddIntervalActionToQueue(models.IntervalAction{ID: "a3", Name: "a3", Interval: "later"}))
+
+	assert.Empty(t, qc.triggerIntervals(now))
+
+	jobs := qc.triggerIntervals(now.Add(2 * time.Minute))
+	require.Len(t, jobs, 2)
+	for _, job := range jobs {
+		assert.Equal(t, "due", job.intervalId)
+	}
+
+	// the executed interval is re-queued an hour later, behind the other one
+	assert.Equal(t, 2, qc.intervalQueue.Len())
+	assert.Equal(t, "later", qc.intervalQueue.peek().Interval.ID)
+}
+
+func TestStartSchedulerExecutesDueActions(t *testing.T) {
+	requests := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverUrl.Port())
+	require.NoError(t, err)
+
+	qc := NewSchedulerQueueClient(logger.NewMockClient())
+	configuration := &config.ConfigurationStruct{Scheduler: config.SchedulerInfo{MaxWorkers: 2}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	qc.StartScheduler(ctx, wg, configuration)
+
+	// adding an interval while the scheduler is idle must wake it up
+	interval := newTestInterval("once", "once", time.Now().Add(2*time.Second), "")
+	interval.RunOnce = true
+	require.NoError(t, qc.AddIntervalToQueue(interval))
+	require.NoError(t, qc.AddIntervalActionToQueue(models.IntervalAction{
+		ID:         "ping",
+		Name:       "ping",
+		Interval:   "once",
+		Protocol:   "http",
+		Address:    serverUrl.Hostname(),
+		Port:       port,
+		Path:       "/ping",
+		HTTPMethod: http.MethodGet,
+	}))
+
+	select {
+	case path := <-requests:
+		assert.Equal(t, "/ping", path)
+	case <-time.After(5 * time.Second):
+		t.Fatal("interval action was not executed")
+	}
+
+	cancel()
+	wg.Wait()
+}
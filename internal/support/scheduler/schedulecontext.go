@@ -24,6 +24,9 @@ type IntervalContext struct {
 	CurrentIterations  int64
 	MaxIterations      int64
 	MarkedDeleted      bool
+
+	// index is the position of the context within the scheduler's interval queue, maintained by the queue itself.
+	index int
 }
 
 func (sc *IntervalContext) Reset(interval models.Interval, lc logger.LoggingClient) {
@@ -110,3 +113,8 @@ func (sc *IntervalContext) isComplete(time time.Time) bool {
 		((sc.MaxIterations != 0) && (sc.CurrentIterations >= sc.MaxIterations))
 	return complete
 }
+
+// queued reports whether the context currently sits in the given interval queue.
+func (sc *IntervalContext) queued(q intervalQueue) bool {
+	return sc.index >= 0 && sc.index < len(q) && q[sc.index] == sc
+}
@@ -0,0 +1,173 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/requests"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// The AddInterval function accepts the new interval model from the controller function
+// and then invokes AddInterval function of infrastructure layer to add new interval.
+// The interval is then scheduled, and removed from the DB again if the scheduler queue rejects it.
+func AddInterval(interval models.Interval, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	queue := schedulerContainer.QueueFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	addedInterval, edgeXerr := dbClient.AddInterval(interval)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	err := queue.AddIntervalToQueue(toContractInterval(addedInterval))
+	if err != nil {
+		if edgeXerr = dbClient.DeleteIntervalById(addedInterval.Id); edgeXerr != nil {
+			lc.Errorf("failed to roll back the interval %s: %v", addedInterval.Name, edgeXerr)
+		}
+		return "", errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("fail to schedule the interval %s", addedInterval.Name), err)
+	}
+
+	lc.Debugf(
+		"Interval created on DB successfully. Interval ID: %s, Correlation-ID: %s ",
+		addedInterval.Id,
+		correlation.FromContext(ctx),
+	)
+
+	return addedInterval.Id, nil
+}
+
+// IntervalByName query the interval by name
+func IntervalByName(name string, dic *di.Container) (interval dtos.Interval, edgeXerr errors.EdgeX) {
+	if name == "" {
+		return interval, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	i, edgeXerr := dbClient.IntervalByName(name)
+	if edgeXerr != nil {
+		return interval, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return dtos.FromIntervalModelToDTO(i), nil
+}
+
+// AllIntervals query the intervals with offset and limit
+func AllIntervals(offset int, limit int, dic *di.Container) (intervals []dtos.Interval, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	intervalModels, edgeXerr := dbClient.AllIntervals(offset, limit)
+	if edgeXerr != nil {
+		return intervals, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	intervals = make([]dtos.Interval, len(intervalModels))
+	for i, interval := range intervalModels {
+		intervals[i] = dtos.FromIntervalModelToDTO(interval)
+	}
+	return intervals, nil
+}
+
+// PatchInterval executes the PATCH operation with the interval DTO to replace the old data
+func PatchInterval(dto dtos.UpdateInterval, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	queue := schedulerContainer.QueueFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	var interval models.Interval
+	var edgeXerr errors.EdgeX
+	if dto.Id != nil {
+		interval, edgeXerr = dbClient.IntervalById(*dto.Id)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	} else {
+		interval, edgeXerr = dbClient.IntervalByName(*dto.Name)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+	if dto.Name != nil && *dto.Name != interval.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("interval name '%s' not match the exsting '%s' ", *dto.Name, interval.Name), nil)
+	}
+
+	requests.ReplaceIntervalModelFieldsWithDTO(&interval, dto)
+	edgeXerr = dtos.ValidateInterval(interval.Start, interval.End, interval.Frequency, interval.RunOnce)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	edgeXerr = dbClient.UpdateInterval(interval)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	// the interval may be missing from the queue if it failed to load at startup
+	err := queue.UpdateIntervalInQueue(toContractInterval(interval))
+	if err != nil {
+		lc.Warnf("interval %s is not in the scheduler queue, adding it: %v", interval.Name, err)
+		if err = queue.AddIntervalToQueue(toContractInterval(interval)); err != nil {
+			return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("fail to reschedule the interval %s", interval.Name), err)
+		}
+	}
+
+	lc.Debugf(
+		"Interval patched on DB successfully. Correlation-ID: %s ",
+		correlation.FromContext(ctx),
+	)
+
+	return nil
+}
+
+// DeleteIntervalByName deletes the interval and its interval actions by name, and then removes them from the
+// scheduler queue
+func DeleteIntervalByName(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	queue := schedulerContainer.QueueFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	interval, edgeXerr := dbClient.IntervalByName(name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	actions, edgeXerr := dbClient.IntervalActionsByIntervalName(0, -1, name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	edgeXerr = dbClient.DeleteIntervalByName(name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	// the DB entries are already gone, so queue failures are only logged
+	for _, action := range actions {
+		if err := queue.RemoveIntervalActionQueue(action.Id); err != nil {
+			lc.Warnf("failed to remove the interval action %s from the scheduler queue: %v", action.Name, err)
+		}
+	}
+	if err := queue.RemoveIntervalInQueue(interval.Id); err != nil {
+		lc.Warnf("failed to remove the interval %s from the scheduler queue: %v", interval.Name, err)
+	}
+
+	lc.Debugf(
+		"Interval deleted on DB successfully. Interval name: %s, Correlation-ID: %s ",
+		name,
+		correlation.FromContext(ctx),
+	)
+
+	return nil
+}
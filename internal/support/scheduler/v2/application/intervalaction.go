@@ -0,0 +1,222 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/requests"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+// The AddIntervalAction function accepts the new interval action model from the controller function
+// and then invokes AddIntervalAction function of infrastructure layer to add new interval action.
+// The interval action is then scheduled, and removed from the DB again if the scheduler queue rejects it.
+func AddIntervalAction(action models.IntervalAction, ctx context.Context, dic *di.Container) (id string, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	queue := schedulerContainer.QueueFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	addedAction, edgeXerr := dbClient.AddIntervalAction(action)
+	if edgeXerr != nil {
+		return "", errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	err := queue.AddIntervalActionToQueue(toContractIntervalAction(addedAction))
+	if err != nil {
+		if edgeXerr = dbClient.DeleteIntervalActionById(addedAction.Id); edgeXerr != nil {
+			lc.Errorf("failed to roll back the interval action %s: %v", addedAction.Name, edgeXerr)
+		}
+		return "", errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("fail to schedule the interval action %s", addedAction.Name), err)
+	}
+
+	lc.Debugf(
+		"IntervalAction created on DB successfully. IntervalAction ID: %s, Correlation-ID: %s ",
+		addedAction.Id,
+		correlation.FromContext(ctx),
+	)
+
+	return addedAction.Id, nil
+}
+
+// IntervalActionByName query the interval action by name
+func IntervalActionByName(name string, dic *di.Container) (action dtos.IntervalAction, edgeXerr errors.EdgeX) {
+	if name == "" {
+		return action, errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	a, edgeXerr := dbClient.IntervalActionByName(name)
+	if edgeXerr != nil {
+		return action, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return dtos.FromIntervalActionModelToDTO(a), nil
+}
+
+// AllIntervalActions query the interval actions with offset and limit
+func AllIntervalActions(offset int, limit int, dic *di.Container) (actions []dtos.IntervalAction, edgeXerr errors.EdgeX) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	actionModels, edgeXerr := dbClient.AllIntervalActions(offset, limit)
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return fromIntervalActionModelsToDTOs(actionModels), nil
+}
+
+// IntervalActionsByTarget query the interval actions with offset, limit, and target
+func IntervalActionsByTarget(offset int, limit int, target string, dic *di.Container) (actions []dtos.IntervalAction, edgeXerr errors.EdgeX) {
+	if target == "" {
+		return actions, errors.NewCommonEdgeX(errors.KindContractInvalid, "target is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	actionModels, edgeXerr := dbClient.IntervalActionsByTarget(offset, limit, target)
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return fromIntervalActionModelsToDTOs(actionModels), nil
+}
+
+// IntervalActionsByIntervalName query the interval actions with offset, limit, and interval name
+func IntervalActionsByIntervalName(offset int, limit int, intervalName string, dic *di.Container) (actions []dtos.IntervalAction, edgeXerr errors.EdgeX) {
+	if intervalName == "" {
+		return actions, errors.NewCommonEdgeX(errors.KindContractInvalid, "interval name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	actionModels, edgeXerr := dbClient.IntervalActionsByIntervalName(offset, limit, intervalName)
+	if edgeXerr != nil {
+		return actions, errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	return fromIntervalActionModelsToDTOs(actionModels), nil
+}
+
+// PatchIntervalAction executes the PATCH operation with the interval action DTO to replace the old data
+func PatchIntervalAction(dto dtos.UpdateIntervalAction, ctx context.Context, dic *di.Container) errors.EdgeX {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	queue := schedulerContainer.QueueFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	var action models.IntervalAction
+	var edgeXerr errors.EdgeX
+	if dto.Id != nil {
+		action, edgeXerr = dbClient.IntervalActionById(*dto.Id)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	} else {
+		action, edgeXerr = dbClient.IntervalActionByName(*dto.Name)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+	if dto.Name != nil && *dto.Name != action.Name {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("interval action name '%s' not match the exsting '%s' ", *dto.Name, action.Name), nil)
+	}
+
+	requests.ReplaceIntervalActionModelFieldsWithDTO(&action, dto)
+
+	edgeXerr = dbClient.UpdateIntervalAction(action)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	// the action may have moved to another interval, so it is re-added rather than updated in place
+	if err := queue.RemoveIntervalActionQueue(action.Id); err != nil {
+		lc.Warnf("interval action %s is not in the scheduler queue: %v", action.Name, err)
+	}
+	if err := queue.AddIntervalActionToQueue(toContractIntervalAction(action)); err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("fail to reschedule the interval action %s", action.Name), err)
+	}
+
+	lc.Debugf(
+		"IntervalAction patched on DB successfully. Correlation-ID: %s ",
+		correlation.FromContext(ctx),
+	)
+
+	return nil
+}
+
+// DeleteIntervalActionByName deletes the interval action by name and removes it from the scheduler queue
+func DeleteIntervalActionByName(name string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if name == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "name is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+
+	action, edgeXerr := dbClient.IntervalActionByName(name)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	edgeXerr = deleteIntervalAction(action, dic)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+
+	container.LoggingClientFrom(dic.Get).Debugf(
+		"IntervalAction deleted on DB successfully. IntervalAction name: %s, Correlation-ID: %s ",
+		name,
+		correlation.FromContext(ctx),
+	)
+
+	return nil
+}
+
+// DeleteIntervalActionsByTarget deletes all the interval actions with the specified target and removes them from
+// the scheduler queue
+func DeleteIntervalActionsByTarget(target string, ctx context.Context, dic *di.Container) errors.EdgeX {
+	if target == "" {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "target is empty", nil)
+	}
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+
+	actions, edgeXerr := dbClient.IntervalActionsByTarget(0, -1, target)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	for _, action := range actions {
+		edgeXerr = deleteIntervalAction(action, dic)
+		if edgeXerr != nil {
+			return errors.NewCommonEdgeXWrapper(edgeXerr)
+		}
+	}
+
+	container.LoggingClientFrom(dic.Get).Debugf(
+		"%d IntervalActions deleted on DB successfully. Target: %s, Correlation-ID: %s ",
+		len(actions),
+		target,
+		correlation.FromContext(ctx),
+	)
+
+	return nil
+}
+
+// deleteIntervalAction deletes the interval action from the DB and then from the scheduler queue
+func deleteIntervalAction(action models.IntervalAction, dic *di.Container) errors.EdgeX {
+	edgeXerr := v2SchedulerContainer.DBClientFrom(dic.Get).DeleteIntervalActionById(action.Id)
+	if edgeXerr != nil {
+		return errors.NewCommonEdgeXWrapper(edgeXerr)
+	}
+	// the DB entry is already gone, so a queue failure is only logged
+	if err := schedulerContainer.QueueFrom(dic.Get).RemoveIntervalActionQueue(action.Id); err != nil {
+		container.LoggingClientFrom(dic.Get).Warnf("failed to remove the interval action %s from the scheduler queue: %v", action.Name, err)
+	}
+	return nil
+}
+
+func fromIntervalActionModelsToDTOs(actionModels []models.IntervalAction) []dtos.IntervalAction {
+	actions := make([]dtos.IntervalAction, len(actionModels))
+	for i, a := range actionModels {
+		actions[i] = dtos.FromIntervalActionModelToDTO(a)
+	}
+	return actions
+}
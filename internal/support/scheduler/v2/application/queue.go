@@ -0,0 +1,88 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	contract "github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+)
+
+// LoadIntervalsToQueue loads the intervals and interval actions stored through the v2 API into the scheduler queue.
+// Entries the queue rejects are logged and skipped so that a single bad entry doesn't prevent the service from starting.
+func LoadIntervalsToQueue(dic *di.Container) {
+	dbClient := v2SchedulerContainer.DBClientFrom(dic.Get)
+	queue := schedulerContainer.QueueFrom(dic.Get)
+	lc := container.LoggingClientFrom(dic.Get)
+
+	intervals, err := dbClient.AllIntervals(0, -1)
+	if err != nil {
+		lc.Errorf("failed to load the v2 intervals: %v", err)
+		return
+	}
+	for _, interval := range intervals {
+		if err := queue.AddIntervalToQueue(toContractInterval(interval)); err != nil {
+			lc.Errorf("failed to add the interval %s into the scheduler queue: %v", interval.Name, err)
+		}
+	}
+
+	actions, err := dbClient.AllIntervalActions(0, -1)
+	if err != nil {
+		lc.Errorf("failed to load the v2 interval actions: %v", err)
+		return
+	}
+	for _, action := range actions {
+		if err := queue.AddIntervalActionToQueue(toContractIntervalAction(action)); err != nil {
+			lc.Errorf("failed to add the interval action %s into the scheduler queue: %v", action.Name, err)
+		}
+	}
+
+	lc.Info(fmt.Sprintf("loaded %d v2 intervals and %d v2 interval actions into the scheduler queue", len(intervals), len(actions)))
+}
+
+// toContractInterval converts the v2 Interval model to the Interval model the scheduler queue works with
+func toContractInterval(i models.Interval) contract.Interval {
+	return contract.Interval{
+		Timestamps: contract.Timestamps{
+			Created:  i.Created,
+			Modified: i.Modified,
+		},
+		ID:        i.Id,
+		Name:      i.Name,
+		Start:     i.Start,
+		End:       i.End,
+		Frequency: i.Frequency,
+		RunOnce:   i.RunOnce,
+	}
+}
+
+// toContractIntervalAction converts the v2 IntervalAction model to the IntervalAction model the scheduler queue works with
+func toContractIntervalAction(a models.IntervalAction) contract.IntervalAction {
+	return contract.IntervalAction{
+		ID:         a.Id,
+		Created:    a.Created,
+		Modified:   a.Modified,
+		Name:       a.Name,
+		Interval:   a.IntervalName,
+		Parameters: a.Parameters,
+		Target:     a.Target,
+		Protocol:   a.Protocol,
+		HTTPMethod: a.HTTPMethod,
+		Address:    a.Host,
+		Port:       a.Port,
+		Path:       a.Path,
+		Publisher:  a.Publisher,
+		User:       a.User,
+		Password:   a.Password,
+		Topic:      a.Topic,
+	}
+}
@@ -0,0 +1,32 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+import (
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+)
+
+// Constants related to defined routes in the support-scheduler v2 service APIs
+const (
+	ApiIntervalRoute       = contractsV2.ApiBase + "/interval"
+	ApiAllIntervalRoute    = ApiIntervalRoute + "/" + contractsV2.All
+	ApiIntervalByNameRoute = ApiIntervalRoute + "/" + contractsV2.Name + "/{" + contractsV2.Name + "}"
+
+	ApiIntervalActionRoute           = contractsV2.ApiBase + "/intervalaction"
+	ApiAllIntervalActionRoute        = ApiIntervalActionRoute + "/" + contractsV2.All
+	ApiIntervalActionByNameRoute     = ApiIntervalActionRoute + "/" + contractsV2.Name + "/{" + contractsV2.Name + "}"
+	ApiIntervalActionByTargetRoute   = ApiIntervalActionRoute + "/" + Target + "/{" + Target + "}"
+	ApiIntervalActionByIntervalRoute = ApiIntervalActionRoute + "/" + Interval + "/{" + Interval + "}"
+)
+
+// Constants related to defined url path names and parameters in the support-scheduler v2 service APIs
+const (
+	Target   = "target"
+	Interval = "interval"
+)
+
+// TimeLayout is the layout of the interval start and end times, e.g. 20210101T000000
+const TimeLayout = "20060102T150405"
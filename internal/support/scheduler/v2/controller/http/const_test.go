@@ -0,0 +1,19 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+const (
+	ExampleUUID            = "82eb2e26-0f24-48aa-ae4c-de9dac3fb9bc"
+	TestIntervalName       = "TestInterval"
+	TestIntervalActionName = "TestIntervalAction"
+	TestStart              = "20210101T000000"
+	TestEnd                = "20220101T000000"
+	TestFrequency          = "30m"
+	TestTarget             = "TestTarget"
+	TestHost               = "localhost"
+	TestPort               = 48080
+	TestPath               = "/api/v2/ping"
+)
@@ -0,0 +1,233 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/application"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/io"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+type IntervalController struct {
+	reader io.IntervalReader
+	dic    *di.Container
+}
+
+// NewIntervalController creates and initializes an IntervalController
+func NewIntervalController(dic *di.Container) *IntervalController {
+	return &IntervalController{
+		reader: io.NewIntervalRequestReader(),
+		dic:    dic,
+	}
+}
+
+func (ic *IntervalController) AddInterval(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(ic.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	addIntervalDTOs, err := ic.reader.ReadAddIntervalRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		// Encode and send the resp body as JSON format
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+	intervals := requestDTO.AddIntervalReqToIntervalModels(addIntervalDTOs)
+
+	var addResponses []interface{}
+	for i, interval := range intervals {
+		newId, err := application.AddInterval(interval, ctx, ic.dic)
+		var addIntervalResponse interface{}
+		// get the requestID from addIntervalDTOs
+		reqId := addIntervalDTOs[i].RequestId
+
+		if err == nil {
+			addIntervalResponse = commonDTO.NewBaseWithIdResponse(
+				reqId,
+				"",
+				http.StatusCreated,
+				newId)
+		} else {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			addIntervalResponse = commonDTO.NewBaseResponse(
+				reqId,
+				err.Error(),
+				err.Code())
+		}
+		addResponses = append(addResponses, addIntervalResponse)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	// Encode and send the resp body as JSON format
+	pkg.Encode(addResponses, w, lc)
+}
+
+func (ic *IntervalController) PatchInterval(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(ic.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	updateIntervalDTOs, err := ic.reader.ReadUpdateIntervalRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+
+	var updateResponses []interface{}
+	for _, dto := range updateIntervalDTOs {
+		var response interface{}
+		reqId := dto.RequestId
+		err := application.PatchInterval(dto.Interval, ctx, ic.dic)
+		if err != nil {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(
+				reqId,
+				err.Message(),
+				err.Code())
+		} else {
+			response = commonDTO.NewBaseResponse(
+				reqId,
+				"",
+				http.StatusOK)
+		}
+		updateResponses = append(updateResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	pkg.Encode(updateResponses, w, lc)
+}
+
+func (ic *IntervalController) IntervalByName(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ic.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[contractsV2.Name]
+
+	var response interface{}
+	var statusCode int
+
+	interval, err := application.IntervalByName(name, ic.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = responseDTO.NewIntervalResponse("", "", http.StatusOK, interval)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (ic *IntervalController) AllIntervals(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ic.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := schedulerContainer.ConfigurationFrom(ic.dic.Get)
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		intervals, err := application.AllIntervals(offset, limit, ic.dic)
+		if err != nil {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiIntervalsResponse("", "", http.StatusOK, intervals)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (ic *IntervalController) DeleteIntervalByName(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ic.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[contractsV2.Name]
+
+	var response interface{}
+	var statusCode int
+
+	err := application.DeleteIntervalByName(name, ctx, ic.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
@@ -0,0 +1,504 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/config"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	queueMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/interfaces/mocks"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/requests"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/responses"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v2/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockDic() *di.Container {
+	return di.NewContainer(di.ServiceConstructorMap{
+		schedulerContainer.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{
+				Writable: config.WritableInfo{
+					LogLevel: "DEBUG",
+				},
+				Service: bootstrapConfig.ServiceInfo{
+					MaxResultCount: 30,
+				},
+			}
+		},
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+	})
+}
+
+func buildTestAddIntervalRequest() requests.AddIntervalRequest {
+	return requests.AddIntervalRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Interval: dtos.Interval{
+			Id:        ExampleUUID,
+			Name:      TestIntervalName,
+			Start:     TestStart,
+			End:       TestEnd,
+			Frequency: TestFrequency,
+		},
+	}
+}
+
+func buildTestUpdateIntervalRequest() requests.UpdateIntervalRequest {
+	testUUID := ExampleUUID
+	testName := TestIntervalName
+	testFrequency := "1h"
+	return requests.UpdateIntervalRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Interval: dtos.UpdateInterval{
+			Id:        &testUUID,
+			Name:      &testName,
+			Frequency: &testFrequency,
+		},
+	}
+}
+
+func TestAddInterval(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+
+	valid := buildTestAddIntervalRequest()
+	model := dtos.ToIntervalModel(valid.Interval)
+	dbClientMock.On("AddInterval", model).Return(model, nil).Twice()
+	noRequestId := buildTestAddIntervalRequest()
+	noRequestId.RequestId = ""
+	duplicated := buildTestAddIntervalRequest()
+	dbClientMock.On("AddInterval", model).Return(model, errors.NewCommonEdgeX(errors.KindDuplicateName, "interval name exists", nil))
+	queueClientMock.On("AddIntervalToQueue", mock.Anything).Return(nil)
+
+	noName := buildTestAddIntervalRequest()
+	noName.Interval.Name = ""
+	noFrequency := buildTestAddIntervalRequest()
+	noFrequency.Interval.Frequency = ""
+	invalidFrequency := buildTestAddIntervalRequest()
+	invalidFrequency.Interval.Frequency = "foo"
+	invalidStart := buildTestAddIntervalRequest()
+	invalidStart.Interval.Start = "2021-01-01"
+	endBeforeStart := buildTestAddIntervalRequest()
+	endBeforeStart.Interval.End = "20200101T000000"
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	controller := NewIntervalController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.AddIntervalRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.AddIntervalRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Valid - no requestId", []requests.AddIntervalRequest{noRequestId}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - duplicated name", []requests.AddIntervalRequest{duplicated}, http.StatusMultiStatus, http.StatusConflict},
+		{"Invalid - no name", []requests.AddIntervalRequest{noName}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no frequency", []requests.AddIntervalRequest{noFrequency}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid frequency", []requests.AddIntervalRequest{invalidFrequency}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid start", []requests.AddIntervalRequest{invalidStart}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - end before start", []requests.AddIntervalRequest{endBeforeStart}, http.StatusBadRequest, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPost, constants.ApiIntervalRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddInterval)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseWithIdResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestAddIntervalQueueRejected(t *testing.T) {
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+
+	request := buildTestAddIntervalRequest()
+	model := dtos.ToIntervalModel(request.Interval)
+	dbClientMock.On("AddInterval", model).Return(model, nil)
+	dbClientMock.On("DeleteIntervalById", model.Id).Return(nil)
+	queueClientMock.On("AddIntervalToQueue", mock.Anything).Return(fmt.Errorf("queue failure"))
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	controller := NewIntervalController(dic)
+
+	jsonData, err := json.Marshal([]requests.AddIntervalRequest{request})
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, constants.ApiIntervalRoute, bytes.NewReader(jsonData))
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	http.HandlerFunc(controller.AddInterval).ServeHTTP(recorder, req)
+
+	var res []common.BaseResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &res)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, int(res[0].StatusCode), "BaseResponse status code not as expected")
+	dbClientMock.AssertCalled(t, "DeleteIntervalById", model.Id)
+}
+
+func TestPatchInterval(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+	testReq := buildTestUpdateIntervalRequest()
+	model := dtos.ToIntervalModel(buildTestAddIntervalRequest().Interval)
+
+	valid := testReq
+	dbClientMock.On("IntervalById", *valid.Interval.Id).Return(model, nil)
+	dbClientMock.On("UpdateInterval", mock.Anything).Return(nil)
+	queueClientMock.On("UpdateIntervalInQueue", mock.Anything).Return(nil)
+	validWithNoReqID := testReq
+	validWithNoReqID.RequestId = ""
+	validWithNoId := buildTestUpdateIntervalRequest()
+	validWithNoId.Interval.Id = nil
+	dbClientMock.On("IntervalByName", *validWithNoId.Interval.Name).Return(model, nil)
+	validWithNoName := buildTestUpdateIntervalRequest()
+	validWithNoName.Interval.Name = nil
+
+	invalidId := buildTestUpdateIntervalRequest()
+	invalidUUID := "invalidUUID"
+	invalidId.Interval.Id = &invalidUUID
+
+	invalidNoIdAndName := buildTestUpdateIntervalRequest()
+	invalidNoIdAndName.Interval.Id = nil
+	invalidNoIdAndName.Interval.Name = nil
+
+	invalidFrequency := buildTestUpdateIntervalRequest()
+	invalidFrequencyValue := "foo"
+	invalidFrequency.Interval.Frequency = &invalidFrequencyValue
+
+	mismatchedName := buildTestUpdateIntervalRequest()
+	otherName := "otherName"
+	mismatchedName.Interval.Name = &otherName
+
+	invalidNotFoundId := buildTestUpdateIntervalRequest()
+	invalidNotFoundId.Interval.Name = nil
+	notFoundId := "12345678-1111-1234-5678-de9dac3fb9bc"
+	invalidNotFoundId.Interval.Id = &notFoundId
+	notFoundIdError := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("%s doesn't exist in the database", notFoundId), nil)
+	dbClientMock.On("IntervalById", notFoundId).Return(model, notFoundIdError)
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	controller := NewIntervalController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.UpdateIntervalRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.UpdateIntervalRequest{valid}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no requestId", []requests.UpdateIntervalRequest{validWithNoReqID}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no id", []requests.UpdateIntervalRequest{validWithNoId}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no name", []requests.UpdateIntervalRequest{validWithNoName}, http.StatusMultiStatus, http.StatusOK},
+		{"Invalid - invalid id", []requests.UpdateIntervalRequest{invalidId}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no id and name", []requests.UpdateIntervalRequest{invalidNoIdAndName}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid frequency", []requests.UpdateIntervalRequest{invalidFrequency}, http.StatusMultiStatus, http.StatusBadRequest},
+		{"Invalid - name not match", []requests.UpdateIntervalRequest{mismatchedName}, http.StatusMultiStatus, http.StatusBadRequest},
+		{"Invalid - not found id", []requests.UpdateIntervalRequest{invalidNotFoundId}, http.StatusMultiStatus, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPatch, constants.ApiIntervalRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.PatchInterval)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestIntervalByName(t *testing.T) {
+	interval := dtos.ToIntervalModel(buildTestAddIntervalRequest().Interval)
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("IntervalByName", interval.Name).Return(interval, nil)
+	dbClientMock.On("IntervalByName", notFoundName).Return(models.Interval{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "interval doesn't exist in the database", nil))
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewIntervalController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		intervalName       string
+		errorExpected      bool
+		expectedStatusCode int
+	}{
+		{"Valid - find interval by name", interval.Name, false, http.StatusOK},
+		{"Invalid - name parameter is empty", "", true, http.StatusBadRequest},
+		{"Invalid - interval not found by name", notFoundName, true, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiIntervalByNameRoute, testCase.intervalName)
+			req, err := http.NewRequest(http.MethodGet, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.intervalName})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.IntervalByName)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.IntervalResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.intervalName, res.Interval.Name, "Name not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestAllIntervals(t *testing.T) {
+	interval := dtos.ToIntervalModel(buildTestAddIntervalRequest().Interval)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("AllIntervals", 0, 20).Return([]models.Interval{interval, interval}, nil)
+	dbClientMock.On("AllIntervals", 1, 1).Return([]models.Interval{interval}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewIntervalController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		offset             string
+		limit              string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - get intervals without offset and limit", "0", "20", false, 2, http.StatusOK},
+		{"Valid - get intervals with offset and limit", "1", "1", false, 1, http.StatusOK},
+		{"Invalid - invalid offset format", "aaa", "1", true, 0, http.StatusBadRequest},
+		{"Invalid - invalid limit format", "1", "aaa", true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, constants.ApiAllIntervalRoute, http.NoBody)
+			query := req.URL.Query()
+			query.Add(contractsV2.Offset, testCase.offset)
+			query.Add(contractsV2.Limit, testCase.limit)
+			req.URL.RawQuery = query.Encode()
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AllIntervals)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.MultiIntervalsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.expectedCount, len(res.Intervals), "Interval count not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestDeleteIntervalByName(t *testing.T) {
+	interval := dtos.ToIntervalModel(buildTestAddIntervalRequest().Interval)
+	action := dtos.ToIntervalActionModel(buildTestAddIntervalActionRequest().Action)
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+	dbClientMock.On("IntervalByName", interval.Name).Return(interval, nil)
+	dbClientMock.On("IntervalActionsByIntervalName", 0, -1, interval.Name).Return([]models.IntervalAction{action}, nil)
+	dbClientMock.On("DeleteIntervalByName", interval.Name).Return(nil)
+	dbClientMock.On("IntervalByName", notFoundName).Return(models.Interval{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "interval doesn't exist in the database", nil))
+	queueClientMock.On("RemoveIntervalActionQueue", action.Id).Return(nil)
+	queueClientMock.On("RemoveIntervalInQueue", interval.Id).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+
+	controller := NewIntervalController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		intervalName       string
+		expectedStatusCode int
+	}{
+		{"Valid - delete interval by name", interval.Name, http.StatusOK},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest},
+		{"Invalid - interval not found by name", notFoundName, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiIntervalByNameRoute, testCase.intervalName)
+			req, err := http.NewRequest(http.MethodDelete, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.intervalName})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteIntervalByName)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			} else {
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+	queueClientMock.AssertCalled(t, "RemoveIntervalActionQueue", action.Id)
+	queueClientMock.AssertCalled(t, "RemoveIntervalInQueue", interval.Id)
+}
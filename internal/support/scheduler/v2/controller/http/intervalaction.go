@@ -0,0 +1,275 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"math"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/utils"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/application"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+	requestDTO "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/requests"
+	responseDTO "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/responses"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/io"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+)
+
+type IntervalActionController struct {
+	reader io.IntervalActionReader
+	dic    *di.Container
+}
+
+// NewIntervalActionController creates and initializes an IntervalActionController
+func NewIntervalActionController(dic *di.Container) *IntervalActionController {
+	return &IntervalActionController{
+		reader: io.NewIntervalActionRequestReader(),
+		dic:    dic,
+	}
+}
+
+func (ac *IntervalActionController) AddIntervalAction(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(ac.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	addActionDTOs, err := ac.reader.ReadAddIntervalActionRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		// Encode and send the resp body as JSON format
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+	actions := requestDTO.AddIntervalActionReqToIntervalActionModels(addActionDTOs)
+
+	var addResponses []interface{}
+	for i, action := range actions {
+		newId, err := application.AddIntervalAction(action, ctx, ac.dic)
+		var addActionResponse interface{}
+		// get the requestID from addActionDTOs
+		reqId := addActionDTOs[i].RequestId
+
+		if err == nil {
+			addActionResponse = commonDTO.NewBaseWithIdResponse(
+				reqId,
+				"",
+				http.StatusCreated,
+				newId)
+		} else {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			addActionResponse = commonDTO.NewBaseResponse(
+				reqId,
+				err.Error(),
+				err.Code())
+		}
+		addResponses = append(addResponses, addActionResponse)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	// Encode and send the resp body as JSON format
+	pkg.Encode(addResponses, w, lc)
+}
+
+func (ac *IntervalActionController) PatchIntervalAction(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		defer func() { _ = r.Body.Close() }()
+	}
+
+	lc := container.LoggingClientFrom(ac.dic.Get)
+
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	updateActionDTOs, err := ac.reader.ReadUpdateIntervalActionRequest(r.Body)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		errResponses := commonDTO.NewBaseResponse(
+			"",
+			err.Message(),
+			err.Code())
+		utils.WriteHttpHeader(w, ctx, err.Code())
+		pkg.Encode(errResponses, w, lc)
+		return
+	}
+
+	var updateResponses []interface{}
+	for _, dto := range updateActionDTOs {
+		var response interface{}
+		reqId := dto.RequestId
+		err := application.PatchIntervalAction(dto.Action, ctx, ac.dic)
+		if err != nil {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse(
+				reqId,
+				err.Message(),
+				err.Code())
+		} else {
+			response = commonDTO.NewBaseResponse(
+				reqId,
+				"",
+				http.StatusOK)
+		}
+		updateResponses = append(updateResponses, response)
+	}
+
+	utils.WriteHttpHeader(w, ctx, http.StatusMultiStatus)
+	pkg.Encode(updateResponses, w, lc)
+}
+
+func (ac *IntervalActionController) IntervalActionByName(w http.ResponseWriter, r *http.Request) {
+	lc := container.LoggingClientFrom(ac.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	name := vars[contractsV2.Name]
+
+	var response interface{}
+	var statusCode int
+
+	action, err := application.IntervalActionByName(name, ac.dic)
+	if err != nil {
+		if errors.Kind(err) != errors.KindEntityDoesNotExist {
+			lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		}
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = responseDTO.NewIntervalActionResponse("", "", http.StatusOK, action)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (ac *IntervalActionController) AllIntervalActions(w http.ResponseWriter, r *http.Request) {
+	ac.intervalActionsByPathParam(w, r, "", func(offset, limit int, _ string, dic *di.Container) ([]dtos.IntervalAction, errors.EdgeX) {
+		return application.AllIntervalActions(offset, limit, dic)
+	})
+}
+
+func (ac *IntervalActionController) IntervalActionsByTarget(w http.ResponseWriter, r *http.Request) {
+	ac.intervalActionsByPathParam(w, r, constants.Target, application.IntervalActionsByTarget)
+}
+
+func (ac *IntervalActionController) IntervalActionsByIntervalName(w http.ResponseWriter, r *http.Request) {
+	ac.intervalActionsByPathParam(w, r, constants.Interval, application.IntervalActionsByIntervalName)
+}
+
+// intervalActionsByPathParam queries interval actions by the value of the specified path parameter with offset and limit
+func (ac *IntervalActionController) intervalActionsByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	query func(offset, limit int, value string, dic *di.Container) ([]dtos.IntervalAction, errors.EdgeX)) {
+
+	lc := container.LoggingClientFrom(ac.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+	config := schedulerContainer.ConfigurationFrom(ac.dic.Get)
+
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	// parse URL query string for offset, limit
+	offset, limit, _, err := utils.ParseGetAllObjectsRequestQueryString(r, 0, math.MaxInt32, -1, config.Service.MaxResultCount)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		actions, err := query(offset, limit, value, ac.dic)
+		if err != nil {
+			if errors.Kind(err) != errors.KindEntityDoesNotExist {
+				lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+			}
+			lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+			response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+			statusCode = err.Code()
+		} else {
+			response = responseDTO.NewMultiIntervalActionsResponse("", "", http.StatusOK, actions)
+			statusCode = http.StatusOK
+		}
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
+
+func (ac *IntervalActionController) DeleteIntervalActionByName(w http.ResponseWriter, r *http.Request) {
+	ac.deleteIntervalActionByPathParam(w, r, contractsV2.Name, application.DeleteIntervalActionByName)
+}
+
+func (ac *IntervalActionController) DeleteIntervalActionsByTarget(w http.ResponseWriter, r *http.Request) {
+	ac.deleteIntervalActionByPathParam(w, r, constants.Target, application.DeleteIntervalActionsByTarget)
+}
+
+// deleteIntervalActionByPathParam deletes interval actions by the value of the specified path parameter
+func (ac *IntervalActionController) deleteIntervalActionByPathParam(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathKey string,
+	deletion func(value string, ctx context.Context, dic *di.Container) errors.EdgeX) {
+
+	lc := container.LoggingClientFrom(ac.dic.Get)
+	ctx := r.Context()
+	correlationId := correlation.FromContext(ctx)
+
+	// URL parameters
+	vars := mux.Vars(r)
+	value := vars[pathKey]
+
+	var response interface{}
+	var statusCode int
+
+	err := deletion(value, ctx, ac.dic)
+	if err != nil {
+		lc.Error(err.Error(), clients.CorrelationHeader, correlationId)
+		lc.Debug(err.DebugMessages(), clients.CorrelationHeader, correlationId)
+		response = commonDTO.NewBaseResponse("", err.Message(), err.Code())
+		statusCode = err.Code()
+	} else {
+		response = commonDTO.NewBaseResponse("", "", http.StatusOK)
+		statusCode = http.StatusOK
+	}
+
+	utils.WriteHttpHeader(w, ctx, statusCode)
+	pkg.Encode(response, w, lc)
+}
@@ -0,0 +1,375 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	schedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/container"
+	queueMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/interfaces/mocks"
+	v2SchedulerContainer "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/bootstrap/container"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/constants"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/requests"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos/responses"
+	dbMock "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/infrastructure/interfaces/mocks"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	contractsV2 "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func buildTestAddIntervalActionRequest() requests.AddIntervalActionRequest {
+	return requests.AddIntervalActionRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Action: dtos.IntervalAction{
+			Id:           ExampleUUID,
+			Name:         TestIntervalActionName,
+			IntervalName: TestIntervalName,
+			Target:       TestTarget,
+			Protocol:     "http",
+			Host:         TestHost,
+			Port:         TestPort,
+			Path:         TestPath,
+			HTTPMethod:   http.MethodGet,
+		},
+	}
+}
+
+func buildTestUpdateIntervalActionRequest() requests.UpdateIntervalActionRequest {
+	testUUID := ExampleUUID
+	testName := TestIntervalActionName
+	testPort := 48081
+	return requests.UpdateIntervalActionRequest{
+		BaseRequest: common.BaseRequest{
+			RequestId: ExampleUUID,
+		},
+		Action: dtos.UpdateIntervalAction{
+			Id:   &testUUID,
+			Name: &testName,
+			Port: &testPort,
+		},
+	}
+}
+
+func TestAddIntervalAction(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+
+	valid := buildTestAddIntervalActionRequest()
+	model := dtos.ToIntervalActionModel(valid.Action)
+	dbClientMock.On("AddIntervalAction", model).Return(model, nil).Twice()
+	noRequestId := buildTestAddIntervalActionRequest()
+	noRequestId.RequestId = ""
+	intervalNotFound := buildTestAddIntervalActionRequest()
+	dbClientMock.On("AddIntervalAction", model).Return(model, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "interval doesn't exist in the database", nil))
+	queueClientMock.On("AddIntervalActionToQueue", mock.Anything).Return(nil)
+
+	noName := buildTestAddIntervalActionRequest()
+	noName.Action.Name = ""
+	noIntervalName := buildTestAddIntervalActionRequest()
+	noIntervalName.Action.IntervalName = ""
+	invalidProtocol := buildTestAddIntervalActionRequest()
+	invalidProtocol.Action.Protocol = "foo"
+	invalidPort := buildTestAddIntervalActionRequest()
+	invalidPort.Action.Port = 70000
+	invalidHTTPMethod := buildTestAddIntervalActionRequest()
+	invalidHTTPMethod.Action.HTTPMethod = "foo"
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	controller := NewIntervalActionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.AddIntervalActionRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.AddIntervalActionRequest{valid}, http.StatusMultiStatus, http.StatusCreated},
+		{"Valid - no requestId", []requests.AddIntervalActionRequest{noRequestId}, http.StatusMultiStatus, http.StatusCreated},
+		{"Invalid - interval not found", []requests.AddIntervalActionRequest{intervalNotFound}, http.StatusMultiStatus, http.StatusNotFound},
+		{"Invalid - no name", []requests.AddIntervalActionRequest{noName}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - no interval name", []requests.AddIntervalActionRequest{noIntervalName}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid protocol", []requests.AddIntervalActionRequest{invalidProtocol}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid port", []requests.AddIntervalActionRequest{invalidPort}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid HTTP method", []requests.AddIntervalActionRequest{invalidHTTPMethod}, http.StatusBadRequest, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPost, constants.ApiIntervalActionRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.AddIntervalAction)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseWithIdResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestPatchIntervalAction(t *testing.T) {
+	expectedRequestId := ExampleUUID
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+	testReq := buildTestUpdateIntervalActionRequest()
+	model := dtos.ToIntervalActionModel(buildTestAddIntervalActionRequest().Action)
+
+	valid := testReq
+	dbClientMock.On("IntervalActionById", *valid.Action.Id).Return(model, nil)
+	dbClientMock.On("UpdateIntervalAction", mock.Anything).Return(nil)
+	queueClientMock.On("RemoveIntervalActionQueue", model.Id).Return(nil)
+	queueClientMock.On("AddIntervalActionToQueue", mock.Anything).Return(nil)
+	validWithNoReqID := testReq
+	validWithNoReqID.RequestId = ""
+	validWithNoId := buildTestUpdateIntervalActionRequest()
+	validWithNoId.Action.Id = nil
+	dbClientMock.On("IntervalActionByName", *validWithNoId.Action.Name).Return(model, nil)
+
+	invalidNoIdAndName := buildTestUpdateIntervalActionRequest()
+	invalidNoIdAndName.Action.Id = nil
+	invalidNoIdAndName.Action.Name = nil
+
+	invalidPort := buildTestUpdateIntervalActionRequest()
+	invalidPortValue := 0
+	invalidPort.Action.Port = &invalidPortValue
+
+	mismatchedName := buildTestUpdateIntervalActionRequest()
+	otherName := "otherName"
+	mismatchedName.Action.Name = &otherName
+
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+	controller := NewIntervalActionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name                 string
+		request              []requests.UpdateIntervalActionRequest
+		expectedStatusCode   int
+		expectedResponseCode int
+	}{
+		{"Valid", []requests.UpdateIntervalActionRequest{valid}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no requestId", []requests.UpdateIntervalActionRequest{validWithNoReqID}, http.StatusMultiStatus, http.StatusOK},
+		{"Valid - no id", []requests.UpdateIntervalActionRequest{validWithNoId}, http.StatusMultiStatus, http.StatusOK},
+		{"Invalid - no id and name", []requests.UpdateIntervalActionRequest{invalidNoIdAndName}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - invalid port", []requests.UpdateIntervalActionRequest{invalidPort}, http.StatusBadRequest, http.StatusBadRequest},
+		{"Invalid - name not match", []requests.UpdateIntervalActionRequest{mismatchedName}, http.StatusMultiStatus, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(jsonData)
+			req, err := http.NewRequest(http.MethodPatch, constants.ApiIntervalActionRoute, reader)
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.PatchIntervalAction)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode == http.StatusMultiStatus {
+				var res []common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res[0].ApiVersion, "API Version not as expected")
+				if res[0].RequestId != "" {
+					assert.Equal(t, expectedRequestId, res[0].RequestId, "RequestID not as expected")
+				}
+				assert.Equal(t, testCase.expectedResponseCode, int(res[0].StatusCode), "BaseResponse status code not as expected")
+			} else {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponseCode, int(res.StatusCode), "Response status code not as expected")
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+}
+
+func TestIntervalActionsByTarget(t *testing.T) {
+	action := dtos.ToIntervalActionModel(buildTestAddIntervalActionRequest().Action)
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	dbClientMock.On("IntervalActionsByTarget", 0, 20, TestTarget).Return([]models.IntervalAction{action, action}, nil)
+	dbClientMock.On("IntervalActionsByTarget", 1, 1, TestTarget).Return([]models.IntervalAction{action}, nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+	})
+
+	controller := NewIntervalActionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		offset             string
+		limit              string
+		target             string
+		errorExpected      bool
+		expectedCount      int
+		expectedStatusCode int
+	}{
+		{"Valid - get interval actions without offset and limit", "0", "20", TestTarget, false, 2, http.StatusOK},
+		{"Valid - get interval actions with offset and limit", "1", "1", TestTarget, false, 1, http.StatusOK},
+		{"Invalid - target is empty", "0", "20", "", true, 0, http.StatusBadRequest},
+		{"Invalid - invalid offset format", "aaa", "1", TestTarget, true, 0, http.StatusBadRequest},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiIntervalActionByTargetRoute, testCase.target)
+			req, err := http.NewRequest(http.MethodGet, reqPath, http.NoBody)
+			query := req.URL.Query()
+			query.Add(contractsV2.Offset, testCase.offset)
+			query.Add(contractsV2.Limit, testCase.limit)
+			req.URL.RawQuery = query.Encode()
+			req = mux.SetURLVars(req, map[string]string{constants.Target: testCase.target})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.IntervalActionsByTarget)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.errorExpected {
+				var res common.BaseResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			} else {
+				var res responses.MultiIntervalActionsResponse
+				err = json.Unmarshal(recorder.Body.Bytes(), &res)
+				require.NoError(t, err)
+				assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+				assert.Equal(t, testCase.expectedCount, len(res.Actions), "IntervalAction count not as expected")
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			}
+		})
+	}
+}
+
+func TestDeleteIntervalActionByName(t *testing.T) {
+	action := dtos.ToIntervalActionModel(buildTestAddIntervalActionRequest().Action)
+	notFoundName := "notFoundName"
+
+	dic := mockDic()
+	dbClientMock := &dbMock.DBClient{}
+	queueClientMock := &queueMock.SchedulerQueueClient{}
+	dbClientMock.On("IntervalActionByName", action.Name).Return(action, nil)
+	dbClientMock.On("DeleteIntervalActionById", action.Id).Return(nil)
+	dbClientMock.On("IntervalActionByName", notFoundName).Return(models.IntervalAction{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "interval action doesn't exist in the database", nil))
+	queueClientMock.On("RemoveIntervalActionQueue", action.Id).Return(nil)
+	dic.Update(di.ServiceConstructorMap{
+		v2SchedulerContainer.DBClientInterfaceName: func(get di.Get) interface{} {
+			return dbClientMock
+		},
+		schedulerContainer.QueueName: func(get di.Get) interface{} {
+			return queueClientMock
+		},
+	})
+
+	controller := NewIntervalActionController(dic)
+	require.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		actionName         string
+		expectedStatusCode int
+	}{
+		{"Valid - delete interval action by name", action.Name, http.StatusOK},
+		{"Invalid - name parameter is empty", "", http.StatusBadRequest},
+		{"Invalid - interval action not found by name", notFoundName, http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			reqPath := fmt.Sprintf("%s/%s", constants.ApiIntervalActionByNameRoute, testCase.actionName)
+			req, err := http.NewRequest(http.MethodDelete, reqPath, http.NoBody)
+			req = mux.SetURLVars(req, map[string]string{contractsV2.Name: testCase.actionName})
+			require.NoError(t, err)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			handler := http.HandlerFunc(controller.DeleteIntervalActionByName)
+			handler.ServeHTTP(recorder, req)
+
+			// Assert
+			var res common.BaseResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &res)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			assert.Equal(t, contractsV2.ApiVersion, res.ApiVersion, "API Version not as expected")
+			assert.Equal(t, testCase.expectedStatusCode, int(res.StatusCode), "Response status code not as expected")
+			if testCase.expectedStatusCode == http.StatusOK {
+				assert.Empty(t, res.Message, "Message should be empty when it is successful")
+			} else {
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+			}
+		})
+	}
+	queueClientMock.AssertCalled(t, "RemoveIntervalActionQueue", action.Id)
+}
@@ -0,0 +1,103 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/constants"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// Interval and its properties are defined in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/Interval
+type Interval struct {
+	common.Versionable `json:",inline"`
+	Id                 string `json:"id,omitempty" validate:"omitempty,uuid"`
+	Created            int64  `json:"created,omitempty"`
+	Modified           int64  `json:"modified,omitempty"`
+	Name               string `json:"name" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Start              string `json:"start,omitempty"`
+	End                string `json:"end,omitempty"`
+	Frequency          string `json:"frequency,omitempty" validate:"required_without=RunOnce"`
+	RunOnce            bool   `json:"runOnce,omitempty"`
+}
+
+// UpdateInterval and its properties are defined in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/UpdateInterval
+type UpdateInterval struct {
+	Id        *string `json:"id" validate:"required_without=Name,edgex-dto-uuid"`
+	Name      *string `json:"name" validate:"required_without=Id,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Start     *string `json:"start"`
+	End       *string `json:"end"`
+	Frequency *string `json:"frequency"`
+	RunOnce   *bool   `json:"runOnce"`
+}
+
+// ValidateInterval checks the start and end times against constants.TimeLayout, that the frequency is a positive
+// duration and that an interval which doesn't run once has a frequency
+func ValidateInterval(start string, end string, frequency string, runOnce bool) errors.EdgeX {
+	var startTime, endTime time.Time
+	var err error
+	if start != "" {
+		startTime, err = time.Parse(constants.TimeLayout, start)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("start %s does not match the time layout %s", start, constants.TimeLayout), err)
+		}
+	}
+	if end != "" {
+		endTime, err = time.Parse(constants.TimeLayout, end)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("end %s does not match the time layout %s", end, constants.TimeLayout), err)
+		}
+		if start != "" && !endTime.After(startTime) {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("end %s is not after start %s", end, start), nil)
+		}
+	}
+	if frequency != "" {
+		d, err := time.ParseDuration(frequency)
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("frequency %s is not a valid duration", frequency), err)
+		}
+		if d <= 0 {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("frequency %s is not positive", frequency), nil)
+		}
+	} else if !runOnce {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "frequency is required unless the interval runs once", nil)
+	}
+	return nil
+}
+
+// ToIntervalModel transforms the Interval DTO to the Interval Model
+func ToIntervalModel(dto Interval) models.Interval {
+	var i models.Interval
+	i.Id = dto.Id
+	i.Name = dto.Name
+	i.Start = dto.Start
+	i.End = dto.End
+	i.Frequency = dto.Frequency
+	i.RunOnce = dto.RunOnce
+	return i
+}
+
+// FromIntervalModelToDTO transforms the Interval Model to the Interval DTO
+func FromIntervalModelToDTO(i models.Interval) Interval {
+	return Interval{
+		Versionable: common.NewVersionable(),
+		Id:          i.Id,
+		Created:     i.Created,
+		Modified:    i.Modified,
+		Name:        i.Name,
+		Start:       i.Start,
+		End:         i.End,
+		Frequency:   i.Frequency,
+		RunOnce:     i.RunOnce,
+	}
+}
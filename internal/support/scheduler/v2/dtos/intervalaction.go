@@ -0,0 +1,96 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dtos
+
+import (
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// IntervalAction and its properties are defined in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/IntervalAction
+type IntervalAction struct {
+	common.Versionable `json:",inline"`
+	Id                 string `json:"id,omitempty" validate:"omitempty,uuid"`
+	Created            int64  `json:"created,omitempty"`
+	Modified           int64  `json:"modified,omitempty"`
+	Name               string `json:"name" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	IntervalName       string `json:"intervalName" validate:"required,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Target             string `json:"target" validate:"required,edgex-dto-none-empty-string"`
+	Protocol           string `json:"protocol" validate:"required,oneof='http' 'https'"`
+	Host               string `json:"host" validate:"required,edgex-dto-none-empty-string"`
+	Port               int    `json:"port" validate:"required,min=1,max=65535"`
+	Path               string `json:"path,omitempty"`
+	HTTPMethod         string `json:"httpMethod" validate:"required,oneof='GET' 'HEAD' 'POST' 'PUT' 'DELETE' 'TRACE' 'CONNECT'"`
+	Parameters         string `json:"parameters,omitempty"`
+	User               string `json:"user,omitempty"`
+	Password           string `json:"password,omitempty"`
+	Publisher          string `json:"publisher,omitempty"`
+	Topic              string `json:"topic,omitempty"`
+}
+
+// UpdateIntervalAction and its properties are defined in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/UpdateIntervalAction
+type UpdateIntervalAction struct {
+	Id           *string `json:"id" validate:"required_without=Name,edgex-dto-uuid"`
+	Name         *string `json:"name" validate:"required_without=Id,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	IntervalName *string `json:"intervalName" validate:"omitempty,edgex-dto-none-empty-string,edgex-dto-rfc3986-unreserved-chars"`
+	Target       *string `json:"target" validate:"omitempty,edgex-dto-none-empty-string"`
+	Protocol     *string `json:"protocol" validate:"omitempty,oneof='http' 'https'"`
+	Host         *string `json:"host" validate:"omitempty,edgex-dto-none-empty-string"`
+	Port         *int    `json:"port" validate:"omitempty,min=1,max=65535"`
+	Path         *string `json:"path"`
+	HTTPMethod   *string `json:"httpMethod" validate:"omitempty,oneof='GET' 'HEAD' 'POST' 'PUT' 'DELETE' 'TRACE' 'CONNECT'"`
+	Parameters   *string `json:"parameters"`
+	User         *string `json:"user"`
+	Password     *string `json:"password"`
+	Publisher    *string `json:"publisher"`
+	Topic        *string `json:"topic"`
+}
+
+// ToIntervalActionModel transforms the IntervalAction DTO to the IntervalAction Model
+func ToIntervalActionModel(dto IntervalAction) models.IntervalAction {
+	var a models.IntervalAction
+	a.Id = dto.Id
+	a.Name = dto.Name
+	a.IntervalName = dto.IntervalName
+	a.Target = dto.Target
+	a.Protocol = dto.Protocol
+	a.Host = dto.Host
+	a.Port = dto.Port
+	a.Path = dto.Path
+	a.HTTPMethod = dto.HTTPMethod
+	a.Parameters = dto.Parameters
+	a.User = dto.User
+	a.Password = dto.Password
+	a.Publisher = dto.Publisher
+	a.Topic = dto.Topic
+	return a
+}
+
+// FromIntervalActionModelToDTO transforms the IntervalAction Model to the IntervalAction DTO
+func FromIntervalActionModelToDTO(a models.IntervalAction) IntervalAction {
+	return IntervalAction{
+		Versionable:  common.NewVersionable(),
+		Id:           a.Id,
+		Created:      a.Created,
+		Modified:     a.Modified,
+		Name:         a.Name,
+		IntervalName: a.IntervalName,
+		Target:       a.Target,
+		Protocol:     a.Protocol,
+		Host:         a.Host,
+		Port:         a.Port,
+		Path:         a.Path,
+		HTTPMethod:   a.HTTPMethod,
+		Parameters:   a.Parameters,
+		User:         a.User,
+		Password:     a.Password,
+		Publisher:    a.Publisher,
+		Topic:        a.Topic,
+	}
+}
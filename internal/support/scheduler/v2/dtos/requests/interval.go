@@ -0,0 +1,111 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddIntervalRequest defines the Request Content for POST Interval DTO.
+// This object and its properties correspond to the AddIntervalRequest object in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/AddIntervalRequest
+type AddIntervalRequest struct {
+	common.BaseRequest `json:",inline"`
+	Interval           dtos.Interval `json:"interval"`
+}
+
+// Validate satisfies the Validator interface
+func (i AddIntervalRequest) Validate() error {
+	err := v2.Validate(i)
+	if err != nil {
+		return err
+	}
+	return dtos.ValidateInterval(i.Interval.Start, i.Interval.End, i.Interval.Frequency, i.Interval.RunOnce)
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the AddIntervalRequest type
+func (i *AddIntervalRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Interval dtos.Interval
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*i = AddIntervalRequest(alias)
+
+	// validate AddIntervalRequest DTO
+	if err := i.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddIntervalReqToIntervalModels transforms the AddIntervalRequest DTO array to the Interval model array
+func AddIntervalReqToIntervalModels(addRequests []AddIntervalRequest) (intervals []models.Interval) {
+	for _, req := range addRequests {
+		i := dtos.ToIntervalModel(req.Interval)
+		intervals = append(intervals, i)
+	}
+	return intervals
+}
+
+// UpdateIntervalRequest defines the Request Content for PATCH Interval DTO.
+// This object and its properties correspond to the UpdateIntervalRequest object in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/UpdateIntervalRequest
+type UpdateIntervalRequest struct {
+	common.BaseRequest `json:",inline"`
+	Interval           dtos.UpdateInterval `json:"interval"`
+}
+
+// Validate satisfies the Validator interface
+func (i UpdateIntervalRequest) Validate() error {
+	err := v2.Validate(i)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the UpdateIntervalRequest type
+func (i *UpdateIntervalRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Interval dtos.UpdateInterval
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*i = UpdateIntervalRequest(alias)
+
+	// validate UpdateIntervalRequest DTO
+	if err := i.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceIntervalModelFieldsWithDTO replace existing Interval's fields with DTO patch
+func ReplaceIntervalModelFieldsWithDTO(interval *models.Interval, patch dtos.UpdateInterval) {
+	if patch.Start != nil {
+		interval.Start = *patch.Start
+	}
+	if patch.End != nil {
+		interval.End = *patch.End
+	}
+	if patch.Frequency != nil {
+		interval.Frequency = *patch.Frequency
+	}
+	if patch.RunOnce != nil {
+		interval.RunOnce = *patch.RunOnce
+	}
+}
@@ -0,0 +1,132 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package requests
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/dtos"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/v2/dtos/common"
+)
+
+// AddIntervalActionRequest defines the Request Content for POST IntervalAction DTO.
+// This object and its properties correspond to the AddIntervalActionRequest object in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/AddIntervalActionRequest
+type AddIntervalActionRequest struct {
+	common.BaseRequest `json:",inline"`
+	Action             dtos.IntervalAction `json:"action"`
+}
+
+// Validate satisfies the Validator interface
+func (a AddIntervalActionRequest) Validate() error {
+	err := v2.Validate(a)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the AddIntervalActionRequest type
+func (a *AddIntervalActionRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Action dtos.IntervalAction
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*a = AddIntervalActionRequest(alias)
+
+	// validate AddIntervalActionRequest DTO
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddIntervalActionReqToIntervalActionModels transforms the AddIntervalActionRequest DTO array to the IntervalAction model array
+func AddIntervalActionReqToIntervalActionModels(addRequests []AddIntervalActionRequest) (actions []models.IntervalAction) {
+	for _, req := range addRequests {
+		a := dtos.ToIntervalActionModel(req.Action)
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// UpdateIntervalActionRequest defines the Request Content for PATCH IntervalAction DTO.
+// This object and its properties correspond to the UpdateIntervalActionRequest object in the APIv2 specification:
+// openapi/v2/support-scheduler.yaml#/UpdateIntervalActionRequest
+type UpdateIntervalActionRequest struct {
+	common.BaseRequest `json:",inline"`
+	Action             dtos.UpdateIntervalAction `json:"action"`
+}
+
+// Validate satisfies the Validator interface
+func (a UpdateIntervalActionRequest) Validate() error {
+	err := v2.Validate(a)
+	return err
+}
+
+// UnmarshalJSON implements the Unmarshaler interface for the UpdateIntervalActionRequest type
+func (a *UpdateIntervalActionRequest) UnmarshalJSON(b []byte) error {
+	var alias struct {
+		common.BaseRequest
+		Action dtos.UpdateIntervalAction
+	}
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "Failed to unmarshal request body as JSON.", err)
+	}
+
+	*a = UpdateIntervalActionRequest(alias)
+
+	// validate UpdateIntervalActionRequest DTO
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplaceIntervalActionModelFieldsWithDTO replace existing IntervalAction's fields with DTO patch
+func ReplaceIntervalActionModelFieldsWithDTO(action *models.IntervalAction, patch dtos.UpdateIntervalAction) {
+	if patch.IntervalName != nil {
+		action.IntervalName = *patch.IntervalName
+	}
+	if patch.Target != nil {
+		action.Target = *patch.Target
+	}
+	if patch.Protocol != nil {
+		action.Protocol = *patch.Protocol
+	}
+	if patch.Host != nil {
+		action.Host = *patch.Host
+	}
+	if patch.Port != nil {
+		action.Port = *patch.Port
+	}
+	if patch.Path != nil {
+		action.Path = *patch.Path
+	}
+	if patch.HTTPMethod != nil {
+		action.HTTPMethod = *patch.HTTPMethod
+	}
+	if patch.Parameters != nil {
+		action.Parameters = *patch.Parameters
+	}
+	if patch.User != nil {
+		action.User = *patch.User
+	}
+	if patch.Password != nil {
+		action.Password = *patch.Password
+	}
+	if patch.Publisher != nil {
+		action.Publisher = *patch.Publisher
+	}
+	if patch.Topic != nil {
+		action.Topic = *patch.Topic
+	}
+}
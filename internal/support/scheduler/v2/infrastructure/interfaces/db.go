@@ -0,0 +1,34 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interfaces
+
+import (
+	model "github.com/edgexfoundry/edgex-go/internal/pkg/v2/scheduler/models"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/errors"
+)
+
+type DBClient interface {
+	CloseSession()
+
+	AddInterval(i model.Interval) (model.Interval, errors.EdgeX)
+	IntervalById(id string) (model.Interval, errors.EdgeX)
+	IntervalByName(name string) (model.Interval, errors.EdgeX)
+	AllIntervals(offset int, limit int) ([]model.Interval, errors.EdgeX)
+	UpdateInterval(i model.Interval) errors.EdgeX
+	DeleteIntervalById(id string) errors.EdgeX
+	DeleteIntervalByName(name string) errors.EdgeX
+
+	AddIntervalAction(a model.IntervalAction) (model.IntervalAction, errors.EdgeX)
+	IntervalActionById(id string) (model.IntervalAction, errors.EdgeX)
+	IntervalActionByName(name string) (model.IntervalAction, errors.EdgeX)
+	AllIntervalActions(offset int, limit int) ([]model.IntervalAction, errors.EdgeX)
+	IntervalActionsByIntervalName(offset int, limit int, intervalName string) ([]model.IntervalAction, errors.EdgeX)
+	IntervalActionsByTarget(offset int, limit int, target string) ([]model.IntervalAction, errors.EdgeX)
+	UpdateIntervalAction(a model.IntervalAction) errors.EdgeX
+	DeleteIntervalActionById(id string) errors.EdgeX
+	DeleteIntervalActionByName(name string) errors.EdgeX
+}
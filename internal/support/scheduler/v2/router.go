@@ -0,0 +1,53 @@
+//
+// Copyright (C) 2021 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v2
+
+import (
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
+	commonController "github.com/edgexfoundry/edgex-go/internal/pkg/v2/controller/http"
+	"github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/constants"
+	schedulerController "github.com/edgexfoundry/edgex-go/internal/support/scheduler/v2/controller/http"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v2/di"
+	v2Constant "github.com/edgexfoundry/go-mod-core-contracts/v2/v2"
+
+	"github.com/gorilla/mux"
+)
+
+func LoadRestRoutes(r *mux.Router, dic *di.Container) {
+	// v2 API routes
+	// Common
+	cc := commonController.NewV2CommonController(dic)
+	r.HandleFunc(v2Constant.ApiPingRoute, cc.Ping).Methods(http.MethodGet)
+	r.HandleFunc(v2Constant.ApiVersionRoute, cc.Version).Methods(http.MethodGet)
+	r.HandleFunc(v2Constant.ApiConfigRoute, cc.Config).Methods(http.MethodGet)
+	r.HandleFunc(v2Constant.ApiMetricsRoute, cc.Metrics).Methods(http.MethodGet)
+
+	// Interval
+	ic := schedulerController.NewIntervalController(dic)
+	r.HandleFunc(constants.ApiIntervalRoute, ic.AddInterval).Methods(http.MethodPost)
+	r.HandleFunc(constants.ApiIntervalRoute, ic.PatchInterval).Methods(http.MethodPatch)
+	r.HandleFunc(constants.ApiAllIntervalRoute, ic.AllIntervals).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiIntervalByNameRoute, ic.IntervalByName).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiIntervalByNameRoute, ic.DeleteIntervalByName).Methods(http.MethodDelete)
+
+	// IntervalAction
+	ac := schedulerController.NewIntervalActionController(dic)
+	r.HandleFunc(constants.ApiIntervalActionRoute, ac.AddIntervalAction).Methods(http.MethodPost)
+	r.HandleFunc(constants.ApiIntervalActionRoute, ac.PatchIntervalAction).Methods(http.MethodPatch)
+	r.HandleFunc(constants.ApiAllIntervalActionRoute, ac.AllIntervalActions).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiIntervalActionByNameRoute, ac.IntervalActionByName).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiIntervalActionByNameRoute, ac.DeleteIntervalActionByName).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiIntervalActionByTargetRoute, ac.IntervalActionsByTarget).Methods(http.MethodGet)
+	r.HandleFunc(constants.ApiIntervalActionByTargetRoute, ac.DeleteIntervalActionsByTarget).Methods(http.MethodDelete)
+	r.HandleFunc(constants.ApiIntervalActionByIntervalRoute, ac.IntervalActionsByIntervalName).Methods(http.MethodGet)
+
+	r.Use(correlation.ManageHeader)
+	r.Use(correlation.OnResponseComplete)
+	r.Use(correlation.OnRequestBegin)
+}
@@ -29,6 +29,19 @@ type ConfigurationStruct struct {
 	Registry         bootstrapConfig.RegistryInfo
 	FormatSpecifier  string
 	SecretStore      bootstrapConfig.SecretStoreInfo
+	// Dependencies maps a service to the services it depends on; entries replace the built-in EdgeX defaults.
+	Dependencies   map[string][]string
+	HealthGate     HealthGateInfo
+	MetricsHistory MetricsHistoryInfo
+}
+
+// HealthGateInfo configures how long a dependency-ordered operation waits for each stage's services to report
+// healthy in the registry before operating on the services which depend on them.
+type HealthGateInfo struct {
+	Timeout  string
+	Interval string
+	// Ungated lists services which don't register with the registry; the built-in list is used when it is empty.
+	Ungated []string
 }
 
 type WritableInfo struct {
@@ -37,6 +50,17 @@ type WritableInfo struct {
 	InsecureSecrets bootstrapConfig.InsecureSecrets
 }
 
+// MetricsHistoryInfo configures the periodic sampling of service metrics retained for the metrics history endpoint.
+type MetricsHistoryInfo struct {
+	Enabled bool
+	// Interval is the time between samples.
+	Interval string
+	// Capacity is the number of samples retained per service; the oldest are discarded first.
+	Capacity int
+	// Services lists services to sample in addition to the default EdgeX services.
+	Services []string
+}
+
 // UpdateFromRaw converts configuration received from the registry to a service-specific configuration struct which is
 // then used to overwrite the service's existing configuration struct.
 func (c *ConfigurationStruct) UpdateFromRaw(rawConfig interface{}) bool {
@@ -0,0 +1,149 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package dependency
+
+import (
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+)
+
+const (
+	RedisServiceKey  = "edgex-redis"
+	ConsulServiceKey = "edgex-core-consul"
+	KuiperServiceKey = "edgex-kuiper"
+	RulesServiceKey  = "edgex-app-service-configurable-rules"
+)
+
+// DefaultDependencies returns the dependencies between the standard EdgeX services, keyed by service.
+func DefaultDependencies() map[string][]string {
+	return map[string][]string{
+		clients.CoreMetaDataServiceKey:         {RedisServiceKey, ConsulServiceKey},
+		clients.CoreDataServiceKey:             {clients.CoreMetaDataServiceKey, RedisServiceKey, ConsulServiceKey},
+		clients.CoreCommandServiceKey:          {clients.CoreMetaDataServiceKey, ConsulServiceKey},
+		clients.SupportNotificationsServiceKey: {RedisServiceKey, ConsulServiceKey},
+		clients.SupportSchedulerServiceKey:     {RedisServiceKey, ConsulServiceKey},
+		RulesServiceKey:                        {clients.CoreDataServiceKey, RedisServiceKey, ConsulServiceKey},
+		KuiperServiceKey:                       {RulesServiceKey, RedisServiceKey},
+		"edgex-device-virtual":                 {clients.CoreDataServiceKey, clients.CoreMetaDataServiceKey},
+		"edgex-device-rest":                    {clients.CoreDataServiceKey, clients.CoreMetaDataServiceKey},
+	}
+}
+
+// DefaultUngated returns the standard EdgeX services which don't register with the registry and so can't be
+// health-gated.
+func DefaultUngated() []string {
+	return []string{RedisServiceKey, ConsulServiceKey, KuiperServiceKey}
+}
+
+// Graph records which services each service depends on.
+type Graph struct {
+	dependencies map[string][]string
+}
+
+// NewGraph returns a Graph built from DefaultDependencies; each entry in overrides replaces the default
+// dependencies of that service.
+func NewGraph(overrides map[string][]string) Graph {
+	dependencies := DefaultDependencies()
+	for service, serviceDependencies := range overrides {
+		dependencies[service] = serviceDependencies
+	}
+	return Graph{dependencies: dependencies}
+}
+
+// DependsOn returns the requested services which service depends on, directly or through services which weren't
+// requested.
+func (g Graph) DependsOn(service string, requested []string) []string {
+	isRequested := make(map[string]bool, len(requested))
+	for _, name := range requested {
+		isRequested[name] = true
+	}
+
+	var result []string
+	visited := map[string]bool{service: true}
+	pending := append([]string{}, g.dependencies[service]...)
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		if isRequested[current] {
+			result = append(result, current)
+		}
+		pending = append(pending, g.dependencies[current]...)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Stages orders the requested services so every service appears in a later stage than the services it depends on;
+// services within a stage are independent of one another.  Services which are part of, or depend on, a dependency
+// cycle can't be ordered and are returned in unordered.
+func (g Graph) Stages(services []string) (stages [][]string, unordered []string) {
+	requested := unique(services)
+	remaining := make(map[string][]string, len(requested))
+	for _, service := range requested {
+		remaining[service] = g.DependsOn(service, requested)
+	}
+
+	placed := make(map[string]bool, len(requested))
+	for len(remaining) > 0 {
+		var stage []string
+		for service, serviceDependencies := range remaining {
+			if allPlaced(serviceDependencies, placed) {
+				stage = append(stage, service)
+			}
+		}
+		if len(stage) == 0 {
+			break
+		}
+		sort.Strings(stage)
+		for _, service := range stage {
+			placed[service] = true
+			delete(remaining, service)
+		}
+		stages = append(stages, stage)
+	}
+
+	for service := range remaining {
+		unordered = append(unordered, service)
+	}
+	sort.Strings(unordered)
+	return stages, unordered
+}
+
+func allPlaced(services []string, placed map[string]bool) bool {
+	for _, service := range services {
+		if !placed[service] {
+			return false
+		}
+	}
+	return true
+}
+
+// unique returns services without duplicates, preserving their order.
+func unique(services []string) []string {
+	seen := make(map[string]bool, len(services))
+	var result []string
+	for _, service := range services {
+		if !seen[service] {
+			seen[service] = true
+			result = append(result, service)
+		}
+	}
+	return result
+}
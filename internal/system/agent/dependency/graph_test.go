@@ -0,0 +1,93 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package dependency
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStages(t *testing.T) {
+	tests := []struct {
+		name              string
+		overrides         map[string][]string
+		services          []string
+		expectedStages    [][]string
+		expectedUnordered []string
+	}{
+		{
+			"independent services share a stage",
+			nil,
+			[]string{clients.SupportSchedulerServiceKey, clients.SupportNotificationsServiceKey},
+			[][]string{{clients.SupportNotificationsServiceKey, clients.SupportSchedulerServiceKey}},
+			nil,
+		},
+		{
+			"default EdgeX dependencies",
+			nil,
+			[]string{clients.CoreDataServiceKey, clients.CoreCommandServiceKey, clients.CoreMetaDataServiceKey, RedisServiceKey},
+			[][]string{
+				{RedisServiceKey},
+				{clients.CoreMetaDataServiceKey},
+				{clients.CoreCommandServiceKey, clients.CoreDataServiceKey},
+			},
+			nil,
+		},
+		{
+			"dependencies through services which weren't requested",
+			nil,
+			[]string{KuiperServiceKey, clients.CoreMetaDataServiceKey},
+			[][]string{{clients.CoreMetaDataServiceKey}, {KuiperServiceKey}},
+			nil,
+		},
+		{
+			"duplicates are ignored",
+			nil,
+			[]string{clients.CoreDataServiceKey, clients.CoreDataServiceKey},
+			[][]string{{clients.CoreDataServiceKey}},
+			nil,
+		},
+		{
+			"overrides replace defaults",
+			map[string][]string{clients.CoreMetaDataServiceKey: {clients.SupportNotificationsServiceKey}},
+			[]string{clients.CoreMetaDataServiceKey, clients.SupportNotificationsServiceKey, RedisServiceKey},
+			[][]string{
+				{RedisServiceKey},
+				{clients.SupportNotificationsServiceKey},
+				{clients.CoreMetaDataServiceKey},
+			},
+			nil,
+		},
+		{
+			"cycles are unordered",
+			map[string][]string{"a": {"b"}, "b": {"a"}, "c": {"a"}},
+			[]string{"a", "b", "c", "d"},
+			[][]string{{"d"}},
+			[]string{"a", "b", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stages, unordered := NewGraph(test.overrides).Stages(test.services)
+
+			assert.Equal(t, test.expectedStages, stages)
+			assert.Equal(t, test.expectedUnordered, unordered)
+		})
+	}
+}
@@ -14,10 +14,49 @@
 
 package executor
 
-import "os/exec"
+import (
+	"bufio"
+	"context"
+	"os/exec"
+)
+
+// maxLogEntrySize bounds a single line of executor output, which holds the whole result of a non-followed logs
+// request.
+const maxLogEntrySize = 16 * 1024 * 1024
 
 // CommandExecutor provides the common callout to the configuration-defined executor.
 func CommandExecutor(executorPath, serviceName, operation string) (string, error) {
 	bytes, err := exec.Command(executorPath, serviceName, operation).CombinedOutput()
 	return string(bytes), err
 }
+
+// StreamingCommandExecutor runs the configuration-defined executor, passing each line of its output to emit as it is
+// written.  The executor is killed when ctx is done or emit returns an error.
+func StreamingCommandExecutor(ctx context.Context, executorPath string, args []string, emit func(line []byte) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, executorPath, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxLogEntrySize)
+	for scanner.Scan() {
+		if err := emit(scanner.Bytes()); err != nil {
+			cancel()
+			_ = cmd.Wait()
+			return err
+		}
+	}
+	scanErr := scanner.Err()
+	if err := cmd.Wait(); err != nil {
+		return err
+	}
+	return scanErr
+}
@@ -0,0 +1,129 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/concurrent"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/response"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// logs contains references to dependencies required to retrieve service logs via executor use case.
+type logs struct {
+	executor      interfaces.StreamingCommandExecutor
+	loggingClient logger.LoggingClient
+	executorPath  string
+}
+
+// NewLogs is a factory function that returns an initialized logs receiver struct.
+func NewLogs(executor interfaces.StreamingCommandExecutor, lc logger.LoggingClient, executorPath string) *logs {
+	return &logs{
+		executor:      executor,
+		loggingClient: lc,
+		executorPath:  executorPath,
+	}
+}
+
+// executorArguments returns the executor command line requesting the service's log.
+func executorArguments(serviceName string, options interfaces.LogOptions, follow bool) []string {
+	args := []string{serviceName, system.Logs}
+	if options.Tail > 0 {
+		args = append(args, "-tail", strconv.Itoa(options.Tail))
+	}
+	if options.Since != "" {
+		args = append(args, "-since", options.Since)
+	}
+	if options.Until != "" {
+		args = append(args, "-until", options.Until)
+	}
+	if follow {
+		args = append(args, "-follow")
+	}
+	return args
+}
+
+// delegateToExecutor wraps executor execution and handles error response creation when necessary.
+func (e logs) delegateToExecutor(ctx context.Context, serviceName string, options interfaces.LogOptions) interface{} {
+	var output bytes.Buffer
+	err := e.executor(ctx, e.executorPath, executorArguments(serviceName, options, false), func(line []byte) error {
+		output.Write(line)
+		return nil
+	})
+	if err != nil {
+		return system.Failure(serviceName, system.Logs, UnknownExecutorType, err.Error())
+	}
+	return response.Process(output.String(), e.loggingClient)
+}
+
+// Get implements the Logs interface to obtain logs via executor for one or more services concurrently.
+func (e logs) Get(ctx context.Context, services []string, options interfaces.LogOptions) []interface{} {
+	var closures []concurrent.Closure
+	for index := range services {
+		closures = append(
+			closures,
+			func(serviceName string) concurrent.Closure {
+				return func() interface{} {
+					return e.delegateToExecutor(ctx, serviceName, options)
+				}
+			}(services[index]),
+		)
+	}
+	return concurrent.ExecuteAndAggregateResults(closures)
+}
+
+// Follow implements the Logs interface to follow the logs of one or more services concurrently.  Each entry the
+// executor writes -- a system.LogEntry per line, then a result once the log ends -- is passed to emit unchanged;
+// a result is synthesized when the executor itself fails.  Once emit returns an error every executor is stopped.
+func (e logs) Follow(ctx context.Context, services []string, options interfaces.LogOptions, emit func(entry []byte) error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mutex sync.Mutex
+	synchronizedEmit := func(entry []byte) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err := emit(entry); err != nil {
+			cancel()
+			return err
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for _, service := range services {
+		wg.Add(1)
+		go func(serviceName string) {
+			defer wg.Done()
+			err := e.executor(ctx, e.executorPath, executorArguments(serviceName, options, true), synchronizedEmit)
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+			e.loggingClient.Errorf("following %s log failed: %s", serviceName, err.Error())
+			if failure, err := json.Marshal(system.Failure(serviceName, system.Logs, UnknownExecutorType, err.Error())); err == nil {
+				_ = synchronizedEmit(failure)
+			}
+		}(service)
+	}
+	wg.Wait()
+}
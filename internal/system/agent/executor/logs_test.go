@@ -0,0 +1,124 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/response"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// streamingStub returns canned output lines for each service and records the arguments of each call.
+type streamingStub struct {
+	mutex        sync.Mutex
+	capturedArgs [][]string
+	lines        map[string][]string
+	errors       map[string]error
+}
+
+func (s *streamingStub) executor(_ context.Context, _ string, args []string, emit func(line []byte) error) error {
+	s.mutex.Lock()
+	s.capturedArgs = append(s.capturedArgs, args)
+	s.mutex.Unlock()
+
+	for _, line := range s.lines[args[0]] {
+		if err := emit([]byte(line)); err != nil {
+			return err
+		}
+	}
+	return s.errors[args[0]]
+}
+
+func TestExecutorArguments(t *testing.T) {
+	options := interfaces.LogOptions{Tail: 10, Since: "1h", Until: "2021-03-01T10:00:00Z"}
+
+	assert.Equal(t,
+		[]string{"service", system.Logs, "-tail", "10", "-since", "1h", "-until", "2021-03-01T10:00:00Z", "-follow"},
+		executorArguments("service", options, true))
+	assert.Equal(t, []string{"service", system.Logs}, executorArguments("service", interfaces.LogOptions{}, false))
+}
+
+func TestLogsGet(t *testing.T) {
+	const result = `{"operation":"logs","service":"service1","executor":"docker","Success":true,"lines":["one"]}`
+	lc := logger.NewMockClient()
+	expectedError := errors.New("expectedError")
+	stub := &streamingStub{
+		lines:  map[string][]string{"service1": {result}},
+		errors: map[string]error{"service2": expectedError},
+	}
+	sut := NewLogs(stub.executor, lc, "executorPath")
+
+	results := sut.Get(context.Background(), []string{"service1", "service2"}, interfaces.LogOptions{Tail: 1})
+
+	assertResultsAreEqualInAnyOrder(t,
+		[]interface{}{
+			response.Process(result, lc),
+			system.Failure("service2", system.Logs, UnknownExecutorType, expectedError.Error()),
+		},
+		results)
+}
+
+func TestLogsFollow(t *testing.T) {
+	stub := &streamingStub{
+		lines: map[string][]string{
+			"service1": {`{"service":"service1","line":"one"}`, `{"service":"service1","line":"two"}`},
+			"service2": {`{"service":"service2","line":"three"}`},
+		},
+		errors: map[string]error{"service2": errors.New("exit status 1")},
+	}
+	sut := NewLogs(stub.executor, logger.NewMockClient(), "executorPath")
+
+	var entries []string
+	sut.Follow(context.Background(), []string{"service1", "service2"}, interfaces.LogOptions{}, func(entry []byte) error {
+		entries = append(entries, string(entry))
+		return nil
+	})
+
+	sort.Strings(entries)
+	assert.Equal(t, []string{
+		`{"operation":"logs","service":"service2","executor":"unknown","Success":false,"errorMessage":"exit status 1"}`,
+		`{"service":"service1","line":"one"}`,
+		`{"service":"service1","line":"two"}`,
+		`{"service":"service2","line":"three"}`,
+	}, entries)
+	for _, args := range stub.capturedArgs {
+		assert.Equal(t, "-follow", args[len(args)-1])
+	}
+}
+
+func TestLogsFollowStopsWhenEmitFails(t *testing.T) {
+	stub := &streamingStub{
+		lines: map[string][]string{"service1": {`{"service":"service1","line":"one"}`, `{"service":"service1","line":"two"}`}},
+	}
+	sut := NewLogs(stub.executor, logger.NewMockClient(), "executorPath")
+
+	emitted := 0
+	sut.Follow(context.Background(), []string{"service1"}, interfaces.LogOptions{}, func([]byte) error {
+		emitted++
+		return errors.New("client disconnected")
+	})
+
+	assert.Equal(t, 1, emitted)
+}
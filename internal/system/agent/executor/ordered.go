@@ -0,0 +1,258 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/concurrent"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/dependency"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+const (
+	stopOperation = "stop"
+
+	defaultHealthGateTimeout  = time.Minute
+	defaultHealthGateInterval = time.Second
+
+	HealthGateHealthy = "healthy"
+	HealthGateUngated = "ungated"
+	HealthGateSkipped = "skipped"
+)
+
+// StageResult reports the outcome of one stage of a dependency-ordered operation.
+type StageResult struct {
+	Stage    int           `json:"stage"`
+	Services []string      `json:"services"`
+	Results  []interface{} `json:"results"`
+	// HealthGate holds, for each service started in the stage, "healthy", "ungated", "skipped" or the reason the
+	// service didn't become healthy.
+	HealthGate map[string]string `json:"healthGate,omitempty"`
+}
+
+// HealthGate determines how long an ordered operation waits for a stage's services to become healthy before moving
+// on to the services which depend on them.
+type HealthGate struct {
+	// Checker is nil when no registry is available, in which case no service is gated.
+	Checker  interfaces.HealthChecker
+	Timeout  time.Duration
+	Interval time.Duration
+	// Ungated lists services which don't register with the registry; they're considered healthy once started.
+	Ungated []string
+}
+
+// serviceResult pairs an executor result with the service it was requested for, since the executor's response isn't
+// guaranteed to name it.
+type serviceResult struct {
+	service string
+	result  interface{}
+}
+
+// orderedOperations applies operations to services in dependency order, one stage at a time.
+type orderedOperations struct {
+	operations
+	graph dependency.Graph
+	gate  HealthGate
+	sleep func(time.Duration)
+}
+
+// NewOrderedOperations is a factory function that returns an initialized orderedOperations receiver struct.
+func NewOrderedOperations(
+	executor interfaces.CommandExecutor,
+	lc logger.LoggingClient,
+	executorPath string,
+	graph dependency.Graph,
+	gate HealthGate) *orderedOperations {
+
+	if gate.Timeout <= 0 {
+		gate.Timeout = defaultHealthGateTimeout
+	}
+	if gate.Interval <= 0 {
+		gate.Interval = defaultHealthGateInterval
+	}
+	return &orderedOperations{
+		operations: *NewOperations(executor, lc, executorPath),
+		graph:      graph,
+		gate:       gate,
+		sleep:      time.Sleep,
+	}
+}
+
+// succeeded reports whether an executor result indicates success.
+func succeeded(result interface{}) bool {
+	switch r := result.(type) {
+	case map[string]interface{}:
+		success, _ := r["Success"].(bool)
+		return success
+	case *system.SuccessResult:
+		return true
+	default:
+		return false
+	}
+}
+
+// Do applies a start/stop/restart operation to services in stages.  Start and restart work from the services with no
+// dependencies outward, waiting for each stage to become healthy; stop works in reverse.  A service is skipped when
+// the operation failed on a service it must follow.  The result is a []interface{} of StageResult values.
+func (o orderedOperations) Do(services []string, operation string) []interface{} {
+	stages, unordered := o.graph.Stages(services)
+	forward := operation != stopOperation
+	if !forward {
+		for left, right := 0, len(stages)-1; left < right; left, right = left+1, right-1 {
+			stages[left], stages[right] = stages[right], stages[left]
+		}
+	}
+
+	var results []interface{}
+	failed := make(map[string]bool)
+	for index, stage := range stages {
+		stageResult := StageResult{Stage: index + 1, Services: stage}
+
+		var closures []concurrent.Closure
+		for _, service := range stage {
+			if blocker := o.blockedBy(service, services, forward, failed); blocker != "" {
+				failed[service] = true
+				stageResult.Results = append(
+					stageResult.Results,
+					system.Failure(
+						service,
+						operation,
+						UnknownExecutorType,
+						fmt.Sprintf("skipped: %s of %s failed", operation, blocker)))
+				continue
+			}
+			closures = append(
+				closures,
+				func(serviceName string) concurrent.Closure {
+					return func() interface{} {
+						return serviceResult{service: serviceName, result: o.delegateToExecutor(serviceName, operation)}
+					}
+				}(service),
+			)
+		}
+
+		var started []string
+		for _, aggregated := range concurrent.ExecuteAndAggregateResults(closures) {
+			r := aggregated.(serviceResult)
+			stageResult.Results = append(stageResult.Results, r.result)
+			if !succeeded(r.result) {
+				failed[r.service] = true
+				continue
+			}
+			started = append(started, r.service)
+		}
+
+		if forward && index < len(stages)-1 {
+			stageResult.HealthGate = o.awaitHealthy(started, failed)
+		}
+		results = append(results, stageResult)
+	}
+
+	if len(unordered) > 0 {
+		stageResult := StageResult{Stage: len(stages) + 1, Services: unordered}
+		for _, service := range unordered {
+			stageResult.Results = append(
+				stageResult.Results,
+				system.Failure(
+					service,
+					operation,
+					UnknownExecutorType,
+					"skipped: dependency cycle among "+strings.Join(unordered, ", ")))
+		}
+		results = append(results, stageResult)
+	}
+	return results
+}
+
+// blockedBy returns a requested service which must be handled before service and on which the operation failed, or
+// "" if there is none.  Going forward, a service follows its dependencies; in reverse, it follows its dependents.
+func (o orderedOperations) blockedBy(service string, requested []string, forward bool, failed map[string]bool) string {
+	if forward {
+		for _, name := range o.graph.DependsOn(service, requested) {
+			if failed[name] {
+				return name
+			}
+		}
+		return ""
+	}
+	for _, dependent := range requested {
+		if failed[dependent] {
+			for _, name := range o.graph.DependsOn(dependent, requested) {
+				if name == service {
+					return dependent
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// awaitHealthy polls the health checker until every service is healthy or the gate's timeout elapses, marking those
+// which never became healthy as failed.
+func (o orderedOperations) awaitHealthy(services []string, failed map[string]bool) map[string]string {
+	outcome := make(map[string]string, len(services))
+	ungated := make(map[string]bool, len(o.gate.Ungated))
+	for _, service := range o.gate.Ungated {
+		ungated[service] = true
+	}
+
+	var pending []string
+	for _, service := range services {
+		switch {
+		case o.gate.Checker == nil:
+			outcome[service] = HealthGateSkipped
+		case ungated[service]:
+			outcome[service] = HealthGateUngated
+		default:
+			pending = append(pending, service)
+		}
+	}
+
+	reasons := make(map[string]string)
+	for waited := time.Duration(0); len(pending) > 0; waited += o.gate.Interval {
+		var unhealthy []string
+		for _, service := range pending {
+			healthy, err := o.gate.Checker(service)
+			switch {
+			case healthy:
+				outcome[service] = HealthGateHealthy
+			case err != nil:
+				reasons[service] = err.Error()
+				unhealthy = append(unhealthy, service)
+			default:
+				reasons[service] = fmt.Sprintf("service %s is not available", service)
+				unhealthy = append(unhealthy, service)
+			}
+		}
+		pending = unhealthy
+		if len(pending) == 0 || waited >= o.gate.Timeout {
+			break
+		}
+		o.sleep(o.gate.Interval)
+	}
+
+	for _, service := range pending {
+		failed[service] = true
+		outcome[service] = fmt.Sprintf("not healthy after %s: %s", o.gate.Timeout, reasons[service])
+		o.loggingClient.Warn(fmt.Sprintf("health gate for %s failed: %s", service, outcome[service]))
+	}
+	return outcome
+}
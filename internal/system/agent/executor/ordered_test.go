@@ -0,0 +1,241 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/dependency"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/response"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	dbService   = "db"
+	metaService = "meta"
+	dataService = "data"
+)
+
+func testGraph() dependency.Graph {
+	return dependency.NewGraph(map[string][]string{
+		metaService: {dbService},
+		dataService: {metaService, dbService},
+	})
+}
+
+func successOutput(service, operation string) string {
+	return fmt.Sprintf(`{"operation":"%s","service":"%s","executor":"docker","Success":true}`, operation, service)
+}
+
+func failureOutput(service, operation string) string {
+	return fmt.Sprintf(`{"operation":"%s","service":"%s","executor":"docker","Success":false,"errorMessage":"failed"}`, operation, service)
+}
+
+func alwaysHealthy(string) (bool, error) {
+	return true, nil
+}
+
+func newTestOrderedOperations(executor *Stub, gate HealthGate) *orderedOperations {
+	sut := NewOrderedOperations(executor.CommandExecutor, logger.NewMockClient(), "executorPath", testGraph(), gate)
+	sut.sleep = func(time.Duration) {}
+	return sut
+}
+
+// capturedServices returns the services the executor was called for, in order.
+func capturedServices(executor *Stub) []string {
+	var services []string
+	for _, args := range executor.capturedArgs {
+		services = append(services, args[1])
+	}
+	return services
+}
+
+func TestOrderedStart(t *testing.T) {
+	lc := logger.NewMockClient()
+	executor := NewStub(map[string]stubCall{
+		dbService:   {nil, successOutput(dbService, "start"), nil},
+		metaService: {nil, successOutput(metaService, "start"), nil},
+		dataService: {nil, successOutput(dataService, "start"), nil},
+	})
+	sut := newTestOrderedOperations(&executor, HealthGate{Checker: alwaysHealthy, Ungated: []string{dbService}})
+
+	result := sut.Do([]string{dataService, metaService, dbService}, "start")
+
+	assert.Equal(t, []string{dbService, metaService, dataService}, capturedServices(&executor))
+	assert.Equal(t, []interface{}{
+		StageResult{
+			Stage:      1,
+			Services:   []string{dbService},
+			Results:    []interface{}{response.Process(successOutput(dbService, "start"), lc)},
+			HealthGate: map[string]string{dbService: HealthGateUngated},
+		},
+		StageResult{
+			Stage:      2,
+			Services:   []string{metaService},
+			Results:    []interface{}{response.Process(successOutput(metaService, "start"), lc)},
+			HealthGate: map[string]string{metaService: HealthGateHealthy},
+		},
+		StageResult{
+			Stage:    3,
+			Services: []string{dataService},
+			Results:  []interface{}{response.Process(successOutput(dataService, "start"), lc)},
+		},
+	}, result)
+}
+
+func TestOrderedStartSkipsDependentsOfFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		calls   map[string]stubCall
+		checker func(string) (bool, error)
+		blocker string
+	}{
+		{
+			"executor error",
+			map[string]stubCall{dbService: {nil, "", errors.New("expectedError")}},
+			alwaysHealthy,
+			dbService,
+		},
+		{
+			"executor reports failure",
+			map[string]stubCall{dbService: {nil, failureOutput(dbService, "restart"), nil}},
+			alwaysHealthy,
+			dbService,
+		},
+		{
+			"health gate times out",
+			map[string]stubCall{
+				dbService:   {nil, successOutput(dbService, "restart"), nil},
+				metaService: {nil, successOutput(metaService, "restart"), nil},
+			},
+			func(service string) (bool, error) { return service != metaService, nil },
+			metaService,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executor := NewStub(test.calls)
+			sut := newTestOrderedOperations(&executor, HealthGate{Checker: test.checker})
+
+			result := sut.Do([]string{dbService, metaService, dataService}, "restart")
+
+			require.Len(t, result, 3)
+			last := result[2].(StageResult)
+			assert.Equal(t, []interface{}{
+				system.Failure(dataService, "restart", UnknownExecutorType, "skipped: restart of "+test.blocker+" failed"),
+			}, last.Results)
+			assert.NotContains(t, capturedServices(&executor), dataService)
+		})
+	}
+}
+
+func TestOrderedHealthGateTimeout(t *testing.T) {
+	executor := NewStub(map[string]stubCall{dbService: {nil, successOutput(dbService, "start"), nil}})
+	checks := 0
+	sut := newTestOrderedOperations(&executor, HealthGate{
+		Checker: func(string) (bool, error) {
+			checks++
+			return false, errors.New("not registered")
+		},
+		Timeout:  5 * time.Second,
+		Interval: time.Second,
+	})
+
+	result := sut.Do([]string{dbService, metaService}, "start")
+
+	assert.Equal(t, 6, checks)
+	assert.Equal(t, map[string]string{dbService: "not healthy after 5s: not registered"}, result[0].(StageResult).HealthGate)
+}
+
+func TestOrderedStartWithoutRegistry(t *testing.T) {
+	executor := NewStub(map[string]stubCall{
+		dbService:   {nil, successOutput(dbService, "start"), nil},
+		metaService: {nil, successOutput(metaService, "start"), nil},
+	})
+	sut := newTestOrderedOperations(&executor, HealthGate{})
+
+	result := sut.Do([]string{dbService, metaService}, "start")
+
+	assert.Equal(t, map[string]string{dbService: HealthGateSkipped}, result[0].(StageResult).HealthGate)
+	assert.Equal(t, []string{dbService, metaService}, capturedServices(&executor))
+}
+
+func TestOrderedStop(t *testing.T) {
+	lc := logger.NewMockClient()
+	executor := NewStub(map[string]stubCall{
+		dataService: {nil, failureOutput(dataService, "stop"), nil},
+		metaService: {nil, successOutput(metaService, "stop"), nil},
+	})
+	sut := newTestOrderedOperations(&executor, HealthGate{
+		Checker: func(string) (bool, error) {
+			assert.Fail(t, "stop shouldn't be health-gated")
+			return false, nil
+		},
+	})
+
+	result := sut.Do([]string{dbService, metaService, dataService}, "stop")
+
+	assert.Equal(t, []string{dataService}, capturedServices(&executor))
+	assert.Equal(t, []interface{}{
+		StageResult{
+			Stage:    1,
+			Services: []string{dataService},
+			Results:  []interface{}{response.Process(failureOutput(dataService, "stop"), lc)},
+		},
+		StageResult{
+			Stage:    2,
+			Services: []string{metaService},
+			Results:  []interface{}{system.Failure(metaService, "stop", UnknownExecutorType, "skipped: stop of data failed")},
+		},
+		StageResult{
+			Stage:    3,
+			Services: []string{dbService},
+			Results:  []interface{}{system.Failure(dbService, "stop", UnknownExecutorType, "skipped: stop of meta failed")},
+		},
+	}, result)
+}
+
+func TestOrderedDependencyCycle(t *testing.T) {
+	executor := NewStub(map[string]stubCall{})
+	sut := NewOrderedOperations(
+		executor.CommandExecutor,
+		logger.NewMockClient(),
+		"executorPath",
+		dependency.NewGraph(map[string][]string{"a": {"b"}, "b": {"a"}}),
+		HealthGate{})
+
+	result := sut.Do([]string{"a", "b"}, "start")
+
+	assert.Equal(t, 0, executor.Called)
+	assert.Equal(t, []interface{}{
+		StageResult{
+			Stage:    1,
+			Services: []string{"a", "b"},
+			Results: []interface{}{
+				system.Failure("a", "start", UnknownExecutorType, "skipped: dependency cycle among a, b"),
+				system.Failure("b", "start", UnknownExecutorType, "skipped: dependency cycle among a, b"),
+			},
+		},
+	}, result)
+}
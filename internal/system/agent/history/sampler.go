@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+)
+
+// Sampler periodically records the metrics of a set of services in a Store.
+type Sampler struct {
+	metrics       interfaces.Metrics
+	store         Store
+	services      []string
+	interval      time.Duration
+	loggingClient logger.LoggingClient
+	now           func() time.Time
+}
+
+// NewSampler is a factory function that returns an initialized Sampler.
+func NewSampler(
+	metrics interfaces.Metrics,
+	store Store,
+	services []string,
+	interval time.Duration,
+	lc logger.LoggingClient) *Sampler {
+
+	return &Sampler{
+		metrics:       metrics,
+		store:         store,
+		services:      services,
+		interval:      interval,
+		loggingClient: lc,
+		now:           time.Now,
+	}
+}
+
+// Run samples every interval until ctx is done.
+func (s *Sampler) Run(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Sample(ctx)
+			}
+		}
+	}()
+}
+
+// Sample records the current metrics of every service; services whose metrics can't be retrieved are skipped.
+func (s *Sampler) Sample(ctx context.Context) {
+	timestamp := s.now()
+	for _, result := range s.metrics.Get(ctx, s.services) {
+		service, sample, ok := toSample(result)
+		if !ok {
+			s.loggingClient.Debugf("metrics sample skipped: %v", result)
+			continue
+		}
+		sample.Timestamp = timestamp
+		s.store.Add(service, sample)
+	}
+}
+
+// goroutines extracts the goroutine count from the telemetry.SystemUsage a service's metrics endpoint returns.
+func goroutines(raw []byte) int {
+	var usage struct {
+		Goroutines int
+	}
+	if json.Unmarshal(raw, &usage) != nil {
+		return 0
+	}
+	return usage.Goroutines
+}
+
+// toSample converts a successful metrics result -- a system.MetricsSuccessResult from the direct mechanism or the
+// executor's decoded JSON response -- into a Sample.
+func toSample(result interface{}) (string, Sample, bool) {
+	switch r := result.(type) {
+	case *system.MetricsSuccessResult:
+		return r.Service, Sample{
+			CpuUsedPercent: r.MetricsResultValue.CpuUsedPercent,
+			MemoryUsed:     r.MetricsResultValue.MemoryUsed,
+			Goroutines:     goroutines(r.MetricsResultValue.Raw),
+		}, true
+	case map[string]interface{}:
+		if success, _ := r["Success"].(bool); !success {
+			return "", Sample{}, false
+		}
+		service, _ := r["service"].(string)
+		values, ok := r["result"].(map[string]interface{})
+		if !ok || service == "" {
+			return "", Sample{}, false
+		}
+		cpu, _ := values["cpuUsedPercent"].(float64)
+		memory, _ := values["memoryUsed"].(float64)
+		raw, _ := json.Marshal(values["raw"])
+		return service, Sample{CpuUsedPercent: cpu, MemoryUsed: int64(memory), Goroutines: goroutines(raw)}, true
+	default:
+		return "", Sample{}, false
+	}
+}
@@ -0,0 +1,68 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// metricsStub returns canned results regardless of the services requested.
+type metricsStub struct {
+	results []interface{}
+}
+
+func (m metricsStub) Get(_ context.Context, _ []string) []interface{} {
+	return m.results
+}
+
+func TestSample(t *testing.T) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	store := NewRingStore(10)
+	sampler := NewSampler(
+		metricsStub{results: []interface{}{
+			system.MetricsSuccess("direct", "direct-service", 12.5, 1024, []byte(`{"Memory":{},"CpuBusyAvg":12.5,"Goroutines":17}`)),
+			map[string]interface{}{
+				"service": "executor",
+				"Success": true,
+				"result":  map[string]interface{}{"cpuUsedPercent": 3.0, "memoryUsed": 2048.0, "raw": map[string]interface{}{"pids": "4"}},
+			},
+			system.Failure("failed", system.Metrics, "docker", "container not found"),
+			map[string]interface{}{"service": "unsuccessful", "Success": false},
+		}},
+		store,
+		[]string{"direct", "executor", "failed", "unsuccessful"},
+		time.Second,
+		logger.NewMockClient())
+	sampler.now = func() time.Time { return now }
+
+	sampler.Sample(context.Background())
+
+	assert.Equal(t,
+		[]Sample{{Timestamp: now, CpuUsedPercent: 12.5, MemoryUsed: 1024, Goroutines: 17}},
+		store.Range("direct", time.Time{}, time.Time{}))
+	assert.Equal(t,
+		[]Sample{{Timestamp: now, CpuUsedPercent: 3.0, MemoryUsed: 2048}},
+		store.Range("executor", time.Time{}, time.Time{}))
+	assert.Empty(t, store.Range("failed", time.Time{}, time.Time{}))
+	assert.Empty(t, store.Range("unsuccessful", time.Time{}, time.Time{}))
+}
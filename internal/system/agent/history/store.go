@@ -0,0 +1,120 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Sample is a service's resource usage at a point in time.
+type Sample struct {
+	Timestamp      time.Time `json:"timestamp"`
+	CpuUsedPercent float64   `json:"cpuUsedPercent"`
+	MemoryUsed     int64     `json:"memoryUsed"`
+	// Goroutines is only reported by services which expose their own metrics endpoint.
+	Goroutines int `json:"goroutines,omitempty"`
+}
+
+// ServiceHistory is the response to a metrics history request for one service.
+type ServiceHistory struct {
+	Service string   `json:"service"`
+	Samples []Sample `json:"samples"`
+}
+
+// Store retains the samples taken for each service.
+type Store interface {
+	Add(service string, sample Sample)
+	// Range returns the samples of service taken within [from, to] in chronological order; a zero from or to
+	// leaves that end of the window open.
+	Range(service string, from time.Time, to time.Time) []Sample
+}
+
+// ring is a fixed-capacity circular buffer of samples which overwrites its oldest sample once full.
+type ring struct {
+	samples []Sample
+	next    int
+	full    bool
+}
+
+func (r *ring) add(sample Sample) {
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// ordered returns the retained samples, oldest first.
+func (r *ring) ordered() []Sample {
+	if !r.full {
+		return r.samples[:r.next]
+	}
+	return append(append([]Sample{}, r.samples[r.next:]...), r.samples[:r.next]...)
+}
+
+// ringStore is a Store which keeps the most recent samples of each service in memory.
+type ringStore struct {
+	mutex    sync.RWMutex
+	capacity int
+	rings    map[string]*ring
+}
+
+// NewRingStore returns a Store retaining at most capacity samples per service.
+func NewRingStore(capacity int) Store {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ringStore{
+		capacity: capacity,
+		rings:    make(map[string]*ring),
+	}
+}
+
+func (s *ringStore) Add(service string, sample Sample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r, ok := s.rings[service]
+	if !ok {
+		r = &ring{samples: make([]Sample, s.capacity)}
+		s.rings[service] = r
+	}
+	r.add(sample)
+}
+
+func (s *ringStore) Range(service string, from time.Time, to time.Time) []Sample {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := []Sample{}
+	r, ok := s.rings[service]
+	if !ok {
+		return result
+	}
+	samples := r.ordered()
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(samples), func(i int) bool { return !samples[i].Timestamp.Before(from) })
+	}
+	for _, sample := range samples[start:] {
+		if !to.IsZero() && sample.Timestamp.After(to) {
+			break
+		}
+		result = append(result, sample)
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func samplesAt(base time.Time, seconds ...int) []Sample {
+	samples := []Sample{}
+	for _, second := range seconds {
+		samples = append(samples, Sample{Timestamp: base.Add(time.Duration(second) * time.Second), MemoryUsed: int64(second)})
+	}
+	return samples
+}
+
+func TestRingStore(t *testing.T) {
+	base := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	store := NewRingStore(3)
+
+	assert.Equal(t, []Sample{}, store.Range("service", time.Time{}, time.Time{}))
+
+	for _, sample := range samplesAt(base, 1, 2) {
+		store.Add("service", sample)
+	}
+	assert.Equal(t, samplesAt(base, 1, 2), store.Range("service", time.Time{}, time.Time{}))
+
+	// the oldest samples are discarded once the store is full
+	for _, sample := range samplesAt(base, 3, 4, 5) {
+		store.Add("service", sample)
+	}
+	assert.Equal(t, samplesAt(base, 3, 4, 5), store.Range("service", time.Time{}, time.Time{}))
+	assert.Equal(t, samplesAt(base, 4, 5), store.Range("service", base.Add(4*time.Second), time.Time{}))
+	assert.Equal(t, samplesAt(base, 3, 4), store.Range("service", time.Time{}, base.Add(4*time.Second)))
+	assert.Equal(t, samplesAt(base, 4), store.Range("service", base.Add(3500*time.Millisecond), base.Add(4500*time.Millisecond)))
+	assert.Equal(t, []Sample{}, store.Range("other", time.Time{}, time.Time{}))
+}
@@ -16,15 +16,21 @@ package agent
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/urlclient/local"
 
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/clients"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/config"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/container"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/dependency"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/direct"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/executor"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/getconfig"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/history"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/setconfig"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -49,7 +55,7 @@ func NewBootstrap(router *mux.Router) *Bootstrap {
 }
 
 // BootstrapHandler fulfills the BootstrapHandler contract.  It implements agent-specific initialization.
-func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
+func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, _ startup.Timer, dic *di.Container) bool {
 	loadRestRoutes(b.router, dic)
 
 	configuration := container.ConfigurationFrom(dic.Get)
@@ -64,6 +70,13 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 		return false
 	}
 
+	healthGate, err := newHealthGate(configuration.HealthGate)
+	if err != nil {
+		lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+		lc.Error(err.Error())
+		return false
+	}
+
 	// add dependencies to container
 	dic.Update(di.ServiceConstructorMap{
 		container.GeneralClientsName: func(get di.Get) interface{} {
@@ -86,9 +99,20 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 			}
 		},
 		container.OperationsInterfaceName: func(get di.Get) interface{} {
-			return executor.NewOperations(
+			if registryClient := bootstrapContainer.RegistryFrom(get); registryClient != nil {
+				healthGate.Checker = registryClient.IsServiceAvailable
+			}
+			return executor.NewOrderedOperations(
 				executor.CommandExecutor,
 				bootstrapContainer.LoggingClientFrom(get),
+				configuration.ExecutorPath,
+				dependency.NewGraph(configuration.Dependencies),
+				healthGate)
+		},
+		container.LogsInterfaceName: func(get di.Get) interface{} {
+			return executor.NewLogs(
+				executor.StreamingCommandExecutor,
+				bootstrapContainer.LoggingClientFrom(get),
 				configuration.ExecutorPath)
 		},
 		container.GetConfigInterfaceName: func(get di.Get) interface{} {
@@ -115,9 +139,76 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, _ *sync.WaitGroup, _ s
 		)
 	}
 
+	if configuration.MetricsHistory.Enabled {
+		if !b.startMetricsHistory(ctx, wg, dic, configuration.MetricsHistory) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// startMetricsHistory adds the metrics history store to the DIC and starts sampling the default services and any
+// configured in addition.
+func (b *Bootstrap) startMetricsHistory(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	dic *di.Container,
+	info config.MetricsHistoryInfo) bool {
+
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	interval, err := time.ParseDuration(info.Interval)
+	if err != nil || interval <= 0 {
+		lc.Error(fmt.Sprintf("invalid MetricsHistory.Interval %s", info.Interval))
+		return false
+	}
+
+	unique := make(map[string]bool)
+	for serviceKey := range b.listDefaultServices() {
+		unique[serviceKey] = true
+	}
+	for _, service := range info.Services {
+		unique[service] = true
+	}
+	var services []string
+	for service := range unique {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	store := history.NewRingStore(info.Capacity)
+	dic.Update(di.ServiceConstructorMap{
+		container.MetricsHistoryName: func(get di.Get) interface{} {
+			return store
+		},
+	})
+	history.NewSampler(container.MetricsFrom(dic.Get), store, services, interval, lc).Run(ctx, wg)
+	lc.Info(fmt.Sprintf("sampling metrics of %d services every %s", len(services), interval))
 	return true
 }
 
+// newHealthGate converts the health gate configuration into an executor.HealthGate; its Checker is set once the
+// registry client is available.
+func newHealthGate(info config.HealthGateInfo) (executor.HealthGate, error) {
+	gate := executor.HealthGate{Ungated: info.Ungated}
+	if len(gate.Ungated) == 0 {
+		gate.Ungated = dependency.DefaultUngated()
+	}
+
+	var err error
+	if info.Timeout != "" {
+		if gate.Timeout, err = time.ParseDuration(info.Timeout); err != nil {
+			return gate, fmt.Errorf("invalid HealthGate.Timeout %s: %s", info.Timeout, err.Error())
+		}
+	}
+	if info.Interval != "" {
+		if gate.Interval, err = time.ParseDuration(info.Interval); err != nil {
+			return gate, fmt.Errorf("invalid HealthGate.Interval %s: %s", info.Interval, err.Error())
+		}
+	}
+	return gate, nil
+}
+
 func (Bootstrap) listDefaultServices() map[string]string {
 	return map[string]string{
 		contracts.SupportNotificationsServiceKey: "Notifications",
@@ -0,0 +1,36 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package interfaces
+
+import "context"
+
+// StreamingCommandExecutor runs the executor with args, passing each line of its output to emit as it is written.
+// The executor is stopped when ctx is done.
+type StreamingCommandExecutor func(ctx context.Context, executorPath string, args []string, emit func(line []byte) error) error
+
+// Logs defines a log retrieval abstraction.
+type Logs interface {
+	// Get returns the selected lines of each service's log.
+	Get(ctx context.Context, services []string, options LogOptions) []interface{}
+	// Follow passes each service's log entries to emit as they are written until ctx is done or every log ends.
+	Follow(ctx context.Context, services []string, options LogOptions, emit func(entry []byte) error)
+}
+
+// LogOptions selects the lines of a log; Since and Until are RFC3339 timestamps or durations before now.
+type LogOptions struct {
+	Tail  int
+	Since string
+	Until string
+}
@@ -16,13 +16,19 @@
 package agent
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal/pkg"
 	"github.com/edgexfoundry/edgex-go/internal/pkg/correlation"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/container"
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/history"
 	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v2/bootstrap/container"
@@ -38,6 +44,13 @@ import (
 	"github.com/gorilla/mux"
 )
 
+const (
+	defaultLogTail         = 100
+	contentTypeEventStream = "text/event-stream"
+	contentTypeNDJSON      = "application/x-ndjson"
+	lastEventIDHeader      = "Last-Event-ID"
+)
+
 func loadRestRoutes(r *mux.Router, dic *di.Container) {
 	b := r.PathPrefix("/api/v1").Subrouter()
 
@@ -65,6 +78,18 @@ func loadRestRoutes(r *mux.Router, dic *di.Container) {
 			metricsHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.MetricsFrom(dic.Get))
 		}).Methods(http.MethodGet)
 
+	b.HandleFunc(
+		"/metrics/{services}/history",
+		func(w http.ResponseWriter, r *http.Request) {
+			metricsHistoryHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.MetricsHistoryFrom(dic.Get))
+		}).Methods(http.MethodGet)
+
+	b.HandleFunc(
+		"/logs/{services}",
+		func(w http.ResponseWriter, r *http.Request) {
+			logsHandler(w, r, bootstrapContainer.LoggingClientFrom(dic.Get), container.LogsFrom(dic.Get))
+		}).Methods(http.MethodGet)
+
 	b.HandleFunc(
 		"/health/{services}",
 		func(w http.ResponseWriter, r *http.Request) {
@@ -97,6 +122,173 @@ func metricsHandler(
 	pkg.Encode(metricsImpl.Get(r.Context(), strings.Split(vars["services"], ",")), w, lc)
 }
 
+// parseTimeParameter accepts either an RFC3339 timestamp or a duration, which is interpreted as that long before now;
+// an empty value returns the zero time.
+func parseTimeParameter(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// metricsHistoryHandler implements a controller to execute a metrics history request.  The since and until query
+// parameters bound the time window; every retained sample is returned when they're omitted.
+func metricsHistoryHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	store history.Store) {
+
+	if store == nil {
+		const errorMessage = "metrics history is not enabled"
+		http.Error(w, errorMessage, http.StatusServiceUnavailable)
+		lc.Error(errorMessage)
+		return
+	}
+
+	now := time.Now()
+	query := r.URL.Query()
+	since, err := parseTimeParameter(query.Get("since"), now)
+	if err != nil {
+		http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+		lc.Error(err.Error())
+		return
+	}
+	until, err := parseTimeParameter(query.Get("until"), now)
+	if err != nil {
+		http.Error(w, "invalid until: "+err.Error(), http.StatusBadRequest)
+		lc.Error(err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	var result []history.ServiceHistory
+	for _, service := range strings.Split(vars["services"], ",") {
+		result = append(result, history.ServiceHistory{Service: service, Samples: store.Range(service, since, until)})
+	}
+	pkg.Encode(result, w, lc)
+}
+
+// logsHandler implements a controller to execute a logs request.  The tail, since and until query parameters select
+// the lines; the last defaultLogTail lines are returned when none is given.  With follow=true the response streams
+// log entries as they are written, as server-sent events when the client accepts text/event-stream and as
+// newline-delimited JSON otherwise.  A followed stream isn't bounded by the server's write timeout.  Each server-sent
+// event's id is the time it was sent; a client reconnecting with Last-Event-ID, as EventSource clients do
+// automatically, resumes from the start of that second, so entries written within it may be repeated.
+func logsHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	logsImpl interfaces.Logs) {
+
+	vars := mux.Vars(r)
+	services := strings.Split(vars["services"], ",")
+	query := r.URL.Query()
+
+	options := interfaces.LogOptions{
+		Since: query.Get("since"),
+		Until: query.Get("until"),
+	}
+	if tail := query.Get("tail"); tail != "" {
+		var err error
+		if options.Tail, err = strconv.Atoi(tail); err != nil || options.Tail < 0 {
+			http.Error(w, "tail must be a non-negative integer", http.StatusBadRequest)
+			lc.Errorf("invalid tail %s", tail)
+			return
+		}
+	} else if options.Since == "" {
+		options.Tail = defaultLogTail
+	}
+
+	follow, err := strconv.ParseBool(query.Get("follow"))
+	if err != nil || !follow {
+		pkg.Encode(logsImpl.Get(r.Context(), services, options), w, lc)
+		return
+	}
+
+	serverSentEvents := strings.Contains(r.Header.Get("Accept"), contentTypeEventStream)
+	if lastEventID := r.Header.Get(lastEventIDHeader); serverSentEvents && lastEventID != "" {
+		sent, err := time.Parse(time.RFC3339Nano, lastEventID)
+		if err != nil {
+			http.Error(w, "Last-Event-ID must be an RFC3339 timestamp", http.StatusBadRequest)
+			lc.Errorf("invalid Last-Event-ID %s", lastEventID)
+			return
+		}
+		options.Since = sent.UTC().Format(time.RFC3339)
+		options.Tail = 0
+	}
+
+	followLogs(w, r, lc, logsImpl, services, options, serverSentEvents)
+}
+
+// followLogs streams the services' log entries over the hijacked connection, which frees the stream from the
+// deadlines the server sets on each request.  The response is delimited by closing the connection; the stream ends
+// when every log ends or the client disconnects.
+func followLogs(
+	w http.ResponseWriter,
+	r *http.Request,
+	lc logger.LoggingClient,
+	logsImpl interfaces.Logs,
+	services []string,
+	options interfaces.LogOptions,
+	serverSentEvents bool) {
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		lc.Error("response writer does not support hijacking")
+		return
+	}
+	conn, buffered, err := hijacker.Hijack()
+	if err != nil {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		lc.Errorf("hijacking the connection failed: %s", err.Error())
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Time{})
+
+	// the hijacked request's context is no longer cancelled when the client goes away, so watch for that here
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		_, _ = io.Copy(ioutil.Discard, buffered.Reader)
+		cancel()
+	}()
+
+	header := http.Header{}
+	if serverSentEvents {
+		header.Set(clients.ContentType, contentTypeEventStream)
+		header.Set("Cache-Control", "no-cache")
+	} else {
+		header.Set(clients.ContentType, contentTypeNDJSON)
+	}
+	header.Set("Connection", "close")
+	_, _ = fmt.Fprintf(buffered, "HTTP/1.1 %d %s\r\n", http.StatusOK, http.StatusText(http.StatusOK))
+	_ = header.Write(buffered)
+	_, _ = buffered.WriteString("\r\n")
+	if err := buffered.Flush(); err != nil {
+		lc.Errorf("writing the logs response failed: %s", err.Error())
+		return
+	}
+
+	logsImpl.Follow(ctx, services, options, func(entry []byte) error {
+		var err error
+		if serverSentEvents {
+			_, err = fmt.Fprintf(buffered, "id: %s\ndata: %s\n\n", time.Now().UTC().Format(time.RFC3339Nano), entry)
+		} else {
+			_, err = fmt.Fprintf(buffered, "%s\n", entry)
+		}
+		if err != nil {
+			return err
+		}
+		return buffered.Flush()
+	})
+}
+
 // operationHandler implements a controller to execute a start/stop/restart operation request.
 func operationHandler(
 	w http.ResponseWriter,
@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package agent
+
+import (
+	"bufio"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system/agent/interfaces"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/logger"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// followStub emits its entries, pausing between them, and records the options of the last Follow call.
+type followStub struct {
+	entries []string
+	pause   time.Duration
+	options interfaces.LogOptions
+}
+
+func (s *followStub) Get(context.Context, []string, interfaces.LogOptions) []interface{} {
+	return nil
+}
+
+func (s *followStub) Follow(_ context.Context, _ []string, options interfaces.LogOptions, emit func(entry []byte) error) {
+	s.options = options
+	for _, entry := range s.entries {
+		time.Sleep(s.pause)
+		if err := emit([]byte(entry)); err != nil {
+			return
+		}
+	}
+}
+
+// newLogsServer returns a server for logsImpl whose write timeout is shorter than a followed stream.
+func newLogsServer(logsImpl interfaces.Logs) *httptest.Server {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/logs/{services}", func(w http.ResponseWriter, r *http.Request) {
+		logsHandler(w, r, logger.NewMockClient(), logsImpl)
+	})
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Start()
+	return server
+}
+
+func TestLogsHandlerFollowOutlivesWriteTimeout(t *testing.T) {
+	stub := &followStub{entries: []string{`{"line":"first"}`, `{"line":"second"}`}, pause: 100 * time.Millisecond}
+	server := newLogsServer(stub)
+	defer server.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/logs/core-data?follow=true", nil)
+	require.NoError(t, err)
+	request.Header.Set("Accept", contentTypeEventStream)
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer func() { _ = response.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, contentTypeEventStream, response.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	var ids, data []string
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	assert.Equal(t, stub.entries, data)
+	require.Len(t, ids, 2)
+	for _, id := range ids {
+		_, err := time.Parse(time.RFC3339Nano, id)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, interfaces.LogOptions{Tail: defaultLogTail}, stub.options)
+}
+
+func TestLogsHandlerFollowResumesFromLastEventID(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastEventID string
+		accept      string
+		status      int
+		expected    interfaces.LogOptions
+	}{
+		{"resume", "2021-03-01T10:00:05.123456789Z", contentTypeEventStream, http.StatusOK,
+			interfaces.LogOptions{Since: "2021-03-01T10:00:05Z"}},
+		{"ignored without event stream", "2021-03-01T10:00:05Z", contentTypeNDJSON, http.StatusOK,
+			interfaces.LogOptions{Tail: 5}},
+		{"invalid", "42", contentTypeEventStream, http.StatusBadRequest, interfaces.LogOptions{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &followStub{}
+			server := newLogsServer(stub)
+			defer server.Close()
+
+			request, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/logs/core-data?follow=true&tail=5", nil)
+			require.NoError(t, err)
+			request.Header.Set("Accept", tt.accept)
+			request.Header.Set(lastEventIDHeader, tt.lastEventID)
+			response, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			_, _ = ioutil.ReadAll(response.Body)
+			_ = response.Body.Close()
+
+			assert.Equal(t, tt.status, response.StatusCode)
+			assert.Equal(t, tt.expected, stub.options)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+)
+
+const (
+	// BackendEnvName names the environment variable which selects the executor back-end.  The docker back-end is
+	// used when it is not set.
+	BackendEnvName = "EDGEX_EXECUTOR_BACKEND"
+	// SystemdUnitEnvName names the environment variable holding the fmt template used to derive a systemd unit name
+	// from a service name, e.g. "snap.edgexfoundry.%s.service".
+	SystemdUnitEnvName = "EDGEX_EXECUTOR_SYSTEMD_UNIT"
+	// ProcessConfigEnvName names the environment variable holding the path of the process back-end's TOML file.
+	ProcessConfigEnvName = "EDGEX_EXECUTOR_PROCESS_CONFIG"
+	// DockerHostEnvName names the environment variable locating the Docker Engine used by the docker-api back-end;
+	// it follows the docker CLI's convention and defaults to unix:///var/run/docker.sock.
+	DockerHostEnvName = "DOCKER_HOST"
+
+	DockerType    = executorType
+	DockerAPIType = "docker-api"
+	SystemdType   = "systemd"
+	ProcessType   = "process"
+)
+
+// Backend is implemented by each mechanism the executor can use to manage services.  Every operation returns the
+// Result reported back to the System Management Agent.
+type Backend interface {
+	// Type returns the value reported in the executor field of each Result.
+	Type() string
+	Start(service string) system.Result
+	Restart(service string) system.Result
+	Stop(service string) system.Result
+	Metrics(service string) system.Result
+}
+
+// messageUnknownBackend returns a text error message and exists to support unit testing.
+func messageUnknownBackend(backendType string) string {
+	return fmt.Sprintf("unknown executor back-end %s", backendType)
+}
+
+// NewBackend returns the back-end of the specified type.  commandExecutor and streamingExecutor return executors
+// which run the named program; they are used by the back-ends that delegate to a CLI.
+func NewBackend(
+	backendType string,
+	commandExecutor func(name string) CommandExecutor,
+	streamingExecutor func(name string) StreamingCommandExecutor) (Backend, error) {
+
+	switch backendType {
+	case "", DockerType:
+		return NewDockerBackend(commandExecutor("docker"), streamingExecutor("docker")), nil
+	case DockerAPIType:
+		return NewDockerAPIBackend(os.Getenv(DockerHostEnvName))
+	case SystemdType:
+		return NewSystemdBackend(
+			commandExecutor("systemctl"),
+			streamingExecutor("journalctl"),
+			os.Getenv(SystemdUnitEnvName)), nil
+	case ProcessType:
+		config, err := LoadProcessConfig(os.Getenv(ProcessConfigEnvName))
+		if err != nil {
+			return nil, err
+		}
+		return NewProcessBackend(config), nil
+	default:
+		return nil, errors.New(messageUnknownBackend(backendType))
+	}
+}
+
+// ExecuteWith processes a request by dispatching the operation given on the command line to the back-end.  out
+// receives the lines of a followed log as they are read.
+func ExecuteWith(args []string, backend Backend, out io.Writer) (result system.Result) {
+	switch {
+	case len(args) > 2:
+		service := args[1]
+		operation := args[2]
+
+		switch operation {
+		case Start:
+			result = backend.Start(service)
+		case Restart:
+			result = backend.Restart(service)
+		case Stop:
+			result = backend.Stop(service)
+		case Metrics:
+			result = backend.Metrics(service)
+		case Logs:
+			result = executeLogs(service, args[3:], backend, out)
+		default:
+			result = system.Failure(service, operation, backend.Type(), messageExecutorOperationNotSupported())
+		}
+	default:
+		result = system.Failure("", "", backend.Type(), messageMissingArguments())
+	}
+	return
+}
@@ -15,7 +15,11 @@
 package executor
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/edgexfoundry/edgex-go/internal/system"
 )
@@ -47,27 +51,64 @@ func messageMissingArguments() string {
 	return fmt.Sprintf("missing <service> and <operation> command line arguments")
 }
 
-// Execute is called from main (which supplies an executor) to process a request.
-func Execute(args []string, executor CommandExecutor) (result system.Result) {
-	switch {
-	case len(args) > 2:
-		service := args[1]
-		operation := args[2]
-
-		switch operation {
-		case Start:
-			result = executeACommand(operation, service, executor, failedStartPrefix, true)
-		case Restart:
-			result = executeACommand(operation, service, executor, failedRestartPrefix, true)
-		case Stop:
-			result = executeACommand(operation, service, executor, failedStopPrefix, false)
-		case Metrics:
-			result = gatherMetrics(service, executor)
-		default:
-			result = system.Failure(service, operation, executorType, messageExecutorOperationNotSupported())
-		}
-	default:
-		result = system.Failure("", "", executorType, messageMissingArguments())
+// Execute is called from main (which supplies an executor) to process a request with the docker back-end.
+func Execute(args []string, executor CommandExecutor) system.Result {
+	return ExecuteWith(args, NewDockerBackend(executor, nil), os.Stdout)
+}
+
+// dockerBackend implements Backend and LogReader by delegating to the docker CLI.
+type dockerBackend struct {
+	executor CommandExecutor
+	streamer StreamingCommandExecutor
+}
+
+// NewDockerBackend returns a Backend which manages services as docker containers through the supplied executor.
+// streamer runs docker logs; logs are unavailable when it is nil.
+func NewDockerBackend(executor CommandExecutor, streamer StreamingCommandExecutor) Backend {
+	return dockerBackend{executor: executor, streamer: streamer}
+}
+
+func (b dockerBackend) Type() string {
+	return executorType
+}
+
+func (b dockerBackend) Start(service string) system.Result {
+	return executeACommand(Start, service, b.executor, failedStartPrefix, true)
+}
+
+func (b dockerBackend) Restart(service string) system.Result {
+	return executeACommand(Restart, service, b.executor, failedRestartPrefix, true)
+}
+
+func (b dockerBackend) Stop(service string) system.Result {
+	return executeACommand(Stop, service, b.executor, failedStopPrefix, false)
+}
+
+func (b dockerBackend) Metrics(service string) system.Result {
+	return gatherMetrics(service, b.executor)
+}
+
+func (b dockerBackend) Logs(service string, options LogOptions, emit func(line string) error) error {
+	if b.streamer == nil {
+		return errors.New(messageLogsNotSupported(executorType))
+	}
+	return b.streamer(emit, dockerLogsArguments(service, options)...)
+}
+
+// dockerLogsArguments returns the docker logs command line selecting the requested lines.
+func dockerLogsArguments(service string, options LogOptions) []string {
+	args := []string{Logs}
+	if options.Tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(options.Tail))
+	}
+	if !options.Since.IsZero() {
+		args = append(args, "--since", options.Since.UTC().Format(time.RFC3339))
+	}
+	if !options.Until.IsZero() {
+		args = append(args, "--until", options.Until.UTC().Format(time.RFC3339))
+	}
+	if options.Follow {
+		args = append(args, "--follow")
 	}
-	return
+	return append(args, service)
 }
@@ -0,0 +1,178 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+)
+
+// recentLogLines is the number of log lines included in each metrics result.
+const recentLogLines = 20
+
+// containerReport is the raw, executor-specific portion of a docker-api metrics result.
+type containerReport struct {
+	Name         string   `json:"name"`
+	Status       string   `json:"status"`
+	Running      bool     `json:"running"`
+	Restarting   bool     `json:"restarting"`
+	ExitCode     int      `json:"exit_code"`
+	StartedAt    string   `json:"started_at"`
+	FinishedAt   string   `json:"finished_at"`
+	Health       string   `json:"health,omitempty"`
+	RestartCount int      `json:"restart_count"`
+	CpuPerc      float64  `json:"cpu_perc"`
+	MemUsage     uint64   `json:"mem_usage"`
+	MemLimit     uint64   `json:"mem_limit"`
+	MemPerc      float64  `json:"mem_perc"`
+	NetRx        uint64   `json:"net_rx_bytes"`
+	NetTx        uint64   `json:"net_tx_bytes"`
+	BlockRead    uint64   `json:"block_read_bytes"`
+	BlockWrite   uint64   `json:"block_write_bytes"`
+	Pids         uint64   `json:"pids"`
+	RecentLogs   []string `json:"recent_logs"`
+	LogsError    string   `json:"logs_error,omitempty"`
+}
+
+// dockerAPIBackend implements Backend by calling the Docker Engine REST API directly rather than forking the
+// docker CLI and scraping its output.
+type dockerAPIBackend struct {
+	client *engineClient
+}
+
+// NewDockerAPIBackend returns a Backend which manages services as docker containers through the Docker Engine API
+// at host; see newEngineClient for the supported forms.
+func NewDockerAPIBackend(host string) (Backend, error) {
+	client, err := newEngineClient(host)
+	if err != nil {
+		return nil, err
+	}
+	return dockerAPIBackend{client: client}, nil
+}
+
+func (b dockerAPIBackend) Type() string {
+	return DockerAPIType
+}
+
+func (b dockerAPIBackend) Start(service string) system.Result {
+	return b.executeACommand(Start, service, failedStartPrefix, true)
+}
+
+func (b dockerAPIBackend) Restart(service string) system.Result {
+	return b.executeACommand(Restart, service, failedRestartPrefix, true)
+}
+
+func (b dockerAPIBackend) Stop(service string) system.Result {
+	return b.executeACommand(Stop, service, failedStopPrefix, false)
+}
+
+// engineErrorMessage maps a missing container to the message used by the docker back-end.
+func engineErrorMessage(service string, err error) string {
+	if isNotFound(err) {
+		return messageContainerNotFound(service)
+	}
+	return err.Error()
+}
+
+// executeACommand asks the engine to perform the operation and then verifies the container's state is as expected.
+func (b dockerAPIBackend) executeACommand(
+	operation string,
+	service string,
+	operationPrefix string,
+	shouldBeRunning bool) system.Result {
+
+	if err := b.client.containerAction(service, operation); err != nil {
+		return system.Failure(service, operation, DockerAPIType,
+			messageExecutorCommandFailed(operationPrefix, http.MethodPost+" "+containerPath(service, "/"+operation),
+				engineErrorMessage(service, err)))
+	}
+
+	container, err := b.client.inspect(service)
+	switch {
+	case err != nil:
+		return system.Failure(service, operation, DockerAPIType,
+			messageExecutorInspectFailed(operationPrefix, engineErrorMessage(service, err)))
+	case container.State.Running != shouldBeRunning:
+		if container.State.Running {
+			return system.Failure(service, operation, DockerAPIType, messageServiceIsRunningButShouldNotBe(operationPrefix))
+		}
+		return system.Failure(service, operation, DockerAPIType, messageServiceIsNotRunningButShouldBe(operationPrefix))
+	default:
+		return system.Success(service, operation, DockerAPIType)
+	}
+}
+
+// Metrics reports the container's state, resource usage and most recent log lines.  A failure to read the logs is
+// recorded in the report rather than failing the request.
+func (b dockerAPIBackend) Metrics(service string) system.Result {
+	container, err := b.client.inspect(service)
+	if err != nil {
+		return system.Failure(service, Metrics, DockerAPIType, engineErrorMessage(service, err))
+	}
+	stats, err := b.client.stats(service)
+	if err != nil {
+		return system.Failure(service, Metrics, DockerAPIType, engineErrorMessage(service, err))
+	}
+
+	report := containerReport{
+		Name:         container.Name,
+		Status:       container.State.Status,
+		Running:      container.State.Running,
+		Restarting:   container.State.Restarting,
+		ExitCode:     container.State.ExitCode,
+		StartedAt:    container.State.StartedAt,
+		FinishedAt:   container.State.FinishedAt,
+		RestartCount: container.RestartCount,
+		CpuPerc:      stats.cpuPercent(),
+		MemUsage:     stats.memoryUsage(),
+		MemLimit:     stats.MemoryStats.Limit,
+		Pids:         stats.PidsStats.Current,
+	}
+	if container.State.Health != nil {
+		report.Health = container.State.Health.Status
+	}
+	if report.MemLimit > 0 {
+		report.MemPerc = float64(report.MemUsage) / float64(report.MemLimit) * 100
+	}
+	report.NetRx, report.NetTx = stats.networkIO()
+	report.BlockRead, report.BlockWrite = stats.blockIO()
+	err = b.client.logs(service, LogOptions{Tail: recentLogLines}, container.Config.Tty, func(line string) error {
+		report.RecentLogs = append(report.RecentLogs, line)
+		return nil
+	})
+	if err != nil {
+		report.LogsError = err.Error()
+	}
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return system.Failure(service, Metrics, DockerAPIType, err.Error())
+	}
+	return system.MetricsSuccess(service, DockerAPIType, report.CpuPerc, int64(report.MemUsage), raw)
+}
+
+func (b dockerAPIBackend) Logs(service string, options LogOptions, emit func(line string) error) error {
+	container, err := b.client.inspect(service)
+	if err != nil {
+		return errors.New(engineErrorMessage(service, err))
+	}
+	if err := b.client.logs(service, options, container.Config.Tty, emit); err != nil {
+		return errors.New(engineErrorMessage(service, err))
+	}
+	return nil
+}
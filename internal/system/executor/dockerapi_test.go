@@ -0,0 +1,293 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	inspectRunning = `{"Name":"/edgex-core-data","RestartCount":3,"State":{"Status":"running","Running":true,` +
+		`"StartedAt":"2021-03-01T10:00:00Z","Health":{"Status":"healthy"}},"Config":{"Tty":false}}`
+	inspectExited = `{"Name":"/edgex-core-data","State":{"Status":"exited","Running":false,"ExitCode":137}}`
+	statsSample   = `{"cpu_stats":{"cpu_usage":{"total_usage":3000},"system_cpu_usage":20000,"online_cpus":2},` +
+		`"precpu_stats":{"cpu_usage":{"total_usage":1000},"system_cpu_usage":10000},` +
+		`"memory_stats":{"usage":3145728,"limit":16777216,"stats":{"inactive_file":1048576}},` +
+		`"networks":{"eth0":{"rx_bytes":100,"tx_bytes":200},"eth1":{"rx_bytes":1,"tx_bytes":2}},` +
+		`"blkio_stats":{"io_service_bytes_recursive":[{"op":"Read","value":4096},{"op":"Write","value":8192}]},` +
+		`"pids_stats":{"current":12}}`
+)
+
+// fakeEngine serves canned Docker Engine API responses over a unix socket.
+type fakeEngine struct {
+	host     string
+	mutex    sync.Mutex
+	requests []string
+	cleanup  func()
+}
+
+func (e *fakeEngine) received() []string {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.requests
+}
+
+func newFakeEngine(t *testing.T, routes map[string]func(w http.ResponseWriter)) *fakeEngine {
+	dir, err := ioutil.TempDir("", "docker")
+	require.NoError(t, err)
+	socket := filepath.Join(dir, "docker.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	engine := &fakeEngine{host: "unix://" + socket}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.Method + " " + r.URL.Path
+		engine.mutex.Lock()
+		engine.requests = append(engine.requests, route)
+		engine.mutex.Unlock()
+		handler, ok := routes[route]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No such container: edgex-core-data"}`))
+			return
+		}
+		handler(w)
+	})}
+	go func() { _ = server.Serve(listener) }()
+	engine.cleanup = func() {
+		_ = server.Close()
+		_ = os.RemoveAll(dir)
+	}
+	return engine
+}
+
+func respond(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// multiplexed frames each payload as the engine does for a non-TTY container's log stream.
+func multiplexed(payloads ...string) string {
+	var buffer bytes.Buffer
+	for index, payload := range payloads {
+		header := make([]byte, multiplexedHeaderSize)
+		header[0] = byte(1 + index%2)
+		binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+		buffer.Write(header)
+		buffer.WriteString(payload)
+	}
+	return buffer.String()
+}
+
+func TestDockerAPIExecute(t *testing.T) {
+	const containerPrefix = "/containers/" + serviceName
+	tests := []struct {
+		name             string
+		operation        string
+		routes           map[string]func(w http.ResponseWriter)
+		expectedResult   system.Result
+		expectedRequests []string
+	}{
+		{
+			"Start: container not found",
+			Start,
+			map[string]func(w http.ResponseWriter){},
+			system.Failure(serviceName, Start, DockerAPIType, messageExecutorCommandFailed(failedStartPrefix, "POST "+containerPrefix+"/start", messageContainerNotFound(serviceName))),
+			[]string{"POST " + containerPrefix + "/start"},
+		},
+		{
+			"Start: engine error",
+			Start,
+			map[string]func(w http.ResponseWriter){
+				"POST " + containerPrefix + "/start": respond(http.StatusInternalServerError, `{"message":"`+errorMessage+`"}`),
+			},
+			system.Failure(serviceName, Start, DockerAPIType, messageExecutorCommandFailed(failedStartPrefix, "POST "+containerPrefix+"/start", "docker engine returned 500: "+errorMessage)),
+			[]string{"POST " + containerPrefix + "/start"},
+		},
+		{
+			"Start: container is not running as expected",
+			Start,
+			map[string]func(w http.ResponseWriter){
+				"POST " + containerPrefix + "/start": respond(http.StatusNoContent, ""),
+				"GET " + containerPrefix + "/json":   respond(http.StatusOK, inspectExited),
+			},
+			system.Failure(serviceName, Start, DockerAPIType, messageServiceIsNotRunningButShouldBe(failedStartPrefix)),
+			[]string{"POST " + containerPrefix + "/start", "GET " + containerPrefix + "/json"},
+		},
+		{
+			"Start: already started",
+			Start,
+			map[string]func(w http.ResponseWriter){
+				"POST " + containerPrefix + "/start": respond(http.StatusNotModified, ""),
+				"GET " + containerPrefix + "/json":   respond(http.StatusOK, inspectRunning),
+			},
+			system.Success(serviceName, Start, DockerAPIType),
+			[]string{"POST " + containerPrefix + "/start", "GET " + containerPrefix + "/json"},
+		},
+		{
+			"Restart: Success",
+			Restart,
+			map[string]func(w http.ResponseWriter){
+				"POST " + containerPrefix + "/restart": respond(http.StatusNoContent, ""),
+				"GET " + containerPrefix + "/json":     respond(http.StatusOK, inspectRunning),
+			},
+			system.Success(serviceName, Restart, DockerAPIType),
+			[]string{"POST " + containerPrefix + "/restart", "GET " + containerPrefix + "/json"},
+		},
+		{
+			"Stop: container is running but shouldn't be",
+			Stop,
+			map[string]func(w http.ResponseWriter){
+				"POST " + containerPrefix + "/stop": respond(http.StatusNoContent, ""),
+				"GET " + containerPrefix + "/json":  respond(http.StatusOK, inspectRunning),
+			},
+			system.Failure(serviceName, Stop, DockerAPIType, messageServiceIsRunningButShouldNotBe(failedStopPrefix)),
+			[]string{"POST " + containerPrefix + "/stop", "GET " + containerPrefix + "/json"},
+		},
+		{
+			"Stop: Success",
+			Stop,
+			map[string]func(w http.ResponseWriter){
+				"POST " + containerPrefix + "/stop": respond(http.StatusNoContent, ""),
+				"GET " + containerPrefix + "/json":  respond(http.StatusOK, inspectExited),
+			},
+			system.Success(serviceName, Stop, DockerAPIType),
+			[]string{"POST " + containerPrefix + "/stop", "GET " + containerPrefix + "/json"},
+		},
+		{
+			"Metrics: container not found",
+			Metrics,
+			map[string]func(w http.ResponseWriter){},
+			system.Failure(serviceName, Metrics, DockerAPIType, messageContainerNotFound(serviceName)),
+			[]string{"GET " + containerPrefix + "/json"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine := newFakeEngine(t, test.routes)
+			defer engine.cleanup()
+			backend, err := NewDockerAPIBackend(engine.host)
+			require.NoError(t, err)
+
+			result := ExecuteWith(executeArguments(serviceName, test.operation), backend, ioutil.Discard)
+
+			assert.Equal(t, test.expectedResult, result)
+			assert.Equal(t, test.expectedRequests, engine.received())
+		})
+	}
+}
+
+func TestDockerAPIMetrics(t *testing.T) {
+	const containerPrefix = "/containers/" + serviceName
+	engine := newFakeEngine(t, map[string]func(w http.ResponseWriter){
+		"GET " + containerPrefix + "/json":  respond(http.StatusOK, inspectRunning),
+		"GET " + containerPrefix + "/stats": respond(http.StatusOK, statsSample),
+		"GET " + containerPrefix + "/logs":  respond(http.StatusOK, multiplexed("first line\nsecond ", "line\n", "third line\n")),
+	})
+	defer engine.cleanup()
+	backend, err := NewDockerAPIBackend(engine.host)
+	require.NoError(t, err)
+
+	result := backend.Metrics(serviceName)
+
+	require.IsType(t, &system.MetricsSuccessResult{}, result)
+	metrics := result.(*system.MetricsSuccessResult)
+	assert.Equal(t, DockerAPIType, metrics.Executor)
+	assert.Equal(t, 40.0, metrics.MetricsResultValue.CpuUsedPercent)
+	assert.Equal(t, int64(2097152), metrics.MetricsResultValue.MemoryUsed)
+
+	var report containerReport
+	require.NoError(t, json.Unmarshal(metrics.MetricsResultValue.Raw, &report))
+	assert.Equal(t, containerReport{
+		Name:         "/edgex-core-data",
+		Status:       "running",
+		Running:      true,
+		StartedAt:    "2021-03-01T10:00:00Z",
+		Health:       "healthy",
+		RestartCount: 3,
+		CpuPerc:      40.0,
+		MemUsage:     2097152,
+		MemLimit:     16777216,
+		MemPerc:      12.5,
+		NetRx:        101,
+		NetTx:        202,
+		BlockRead:    4096,
+		BlockWrite:   8192,
+		Pids:         12,
+		RecentLogs:   []string{"first line", "second line", "third line"},
+	}, report)
+}
+
+func TestDockerAPIMetricsLogsFailure(t *testing.T) {
+	const containerPrefix = "/containers/" + serviceName
+	engine := newFakeEngine(t, map[string]func(w http.ResponseWriter){
+		"GET " + containerPrefix + "/json":  respond(http.StatusOK, inspectRunning),
+		"GET " + containerPrefix + "/stats": respond(http.StatusOK, statsSample),
+		"GET " + containerPrefix + "/logs":  respond(http.StatusNotImplemented, `{"message":"configured logging driver does not support reading"}`),
+	})
+	defer engine.cleanup()
+	backend, err := NewDockerAPIBackend(engine.host)
+	require.NoError(t, err)
+
+	result := backend.Metrics(serviceName)
+
+	require.IsType(t, &system.MetricsSuccessResult{}, result)
+	var report containerReport
+	require.NoError(t, json.Unmarshal(result.(*system.MetricsSuccessResult).MetricsResultValue.Raw, &report))
+	assert.Nil(t, report.RecentLogs)
+	assert.Equal(t, "docker engine returned 501: configured logging driver does not support reading", report.LogsError)
+}
+
+func TestNewEngineClient(t *testing.T) {
+	tests := []struct {
+		host            string
+		expectedBaseURL string
+		expectError     bool
+	}{
+		{"", "http://" + engineHTTPHost, false},
+		{"unix:///run/docker.sock", "http://" + engineHTTPHost, false},
+		{"tcp://10.0.0.1:2375", "http://10.0.0.1:2375", false},
+		{"https://docker.example.com:2376/", "https://docker.example.com:2376", false},
+		{"ssh://docker.example.com", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.host, func(t *testing.T) {
+			client, err := newEngineClient(test.host)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBaseURL, client.baseURL)
+		})
+	}
+}
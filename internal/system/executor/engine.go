@@ -0,0 +1,359 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDockerHost = "unix:///var/run/docker.sock"
+	// engineHTTPHost is the placeholder host used in request URLs when the engine is reached over a unix socket.
+	engineHTTPHost = "docker"
+	engineTimeout  = 2 * time.Minute
+
+	// multiplexedHeaderSize is the size of the frame header the engine prefixes to each chunk of a non-TTY
+	// container's log stream.
+	multiplexedHeaderSize = 8
+)
+
+// engineError is returned when the Docker Engine answers a request with an error status.
+type engineError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e engineError) Error() string {
+	return fmt.Sprintf("docker engine returned %d: %s", e.StatusCode, e.Message)
+}
+
+// isNotFound reports whether err is the engine's response to a request naming a container which doesn't exist.
+func isNotFound(err error) bool {
+	var engineErr engineError
+	return errors.As(err, &engineErr) && engineErr.StatusCode == http.StatusNotFound
+}
+
+// containerInspect holds the subset of GET /containers/{name}/json used by the executor.
+type containerInspect struct {
+	Name         string
+	RestartCount int
+	State        struct {
+		Status     string
+		Running    bool
+		Restarting bool
+		ExitCode   int
+		StartedAt  string
+		FinishedAt string
+		Health     *struct {
+			Status string
+		}
+	}
+	Config struct {
+		Tty bool
+	}
+}
+
+// containerStats holds the subset of GET /containers/{name}/stats used by the executor.
+type containerStats struct {
+	CPUStats    cpuStats    `json:"cpu_stats"`
+	PreCPUStats cpuStats    `json:"precpu_stats"`
+	MemoryStats memoryStats `json:"memory_stats"`
+	Networks    map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IoServiceBytesRecursive []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+type cpuStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+type memoryStats struct {
+	Usage uint64            `json:"usage"`
+	Limit uint64            `json:"limit"`
+	Stats map[string]uint64 `json:"stats"`
+}
+
+// cpuPercent calculates CPU utilization the same way the docker CLI does: the container's share of the host's
+// CPU time between the two samples, scaled by the number of online CPUs.
+func (s containerStats) cpuPercent() float64 {
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(s.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// memoryUsage returns the container's memory usage excluding the page cache, as the docker CLI reports it; cgroup
+// v1 hosts report the cache as total_inactive_file and cgroup v2 hosts as inactive_file.
+func (s containerStats) memoryUsage() uint64 {
+	cache, ok := s.MemoryStats.Stats["total_inactive_file"]
+	if !ok {
+		cache = s.MemoryStats.Stats["inactive_file"]
+	}
+	if cache > s.MemoryStats.Usage {
+		return s.MemoryStats.Usage
+	}
+	return s.MemoryStats.Usage - cache
+}
+
+// networkIO returns the bytes received and transmitted summed across the container's networks.
+func (s containerStats) networkIO() (rx uint64, tx uint64) {
+	for _, network := range s.Networks {
+		rx += network.RxBytes
+		tx += network.TxBytes
+	}
+	return
+}
+
+// blockIO returns the bytes read and written by the container's block devices.
+func (s containerStats) blockIO() (read uint64, write uint64) {
+	for _, entry := range s.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			read += entry.Value
+		case "write":
+			write += entry.Value
+		}
+	}
+	return
+}
+
+// engineClient is a minimal client of the Docker Engine REST API.
+type engineClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// newEngineClient returns a client of the engine at host, which takes the same form as DOCKER_HOST:
+// unix:///var/run/docker.sock, tcp://127.0.0.1:2375 or an http(s) URL.  The default socket is used when host
+// is empty.
+func newEngineClient(host string) (*engineClient, error) {
+	if host == "" {
+		host = defaultDockerHost
+	}
+	hostURL, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %s: %s", host, err.Error())
+	}
+
+	transport := &http.Transport{}
+	var baseURL string
+	switch hostURL.Scheme {
+	case "unix":
+		socket := hostURL.Path
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+		baseURL = "http://" + engineHTTPHost
+	case "tcp":
+		baseURL = "http://" + hostURL.Host
+	case "http", "https":
+		baseURL = strings.TrimRight(host, "/")
+	default:
+		return nil, fmt.Errorf("unsupported docker host %s", host)
+	}
+
+	return &engineClient{
+		httpClient: &http.Client{Transport: transport},
+		baseURL:    baseURL,
+	}, nil
+}
+
+// do sends a request to the engine and returns the response when its status is successful.  The caller must close
+// the response body.
+func (c *engineClient) do(ctx context.Context, method string, path string, query url.Values) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusBadRequest {
+		defer func() { _ = response.Body.Close() }()
+		body, _ := ioutil.ReadAll(response.Body)
+		var message struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &message) != nil || message.Message == "" {
+			message.Message = strings.TrimSpace(string(body))
+		}
+		return nil, engineError{StatusCode: response.StatusCode, Message: message.Message}
+	}
+	return response, nil
+}
+
+// getJSON sends a GET request to the engine and decodes the JSON response into value.
+func (c *engineClient) getJSON(path string, query url.Values, value interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), engineTimeout)
+	defer cancel()
+
+	response, err := c.do(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	return json.NewDecoder(response.Body).Decode(value)
+}
+
+// containerPath returns the API path of the named container's endpoint.
+func containerPath(name string, endpoint string) string {
+	return "/containers/" + url.PathEscape(name) + endpoint
+}
+
+// containerAction starts, stops or restarts the named container.  The engine answers 304 when the container is
+// already in the requested state, which is treated as success.
+func (c *engineClient) containerAction(name string, action string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), engineTimeout)
+	defer cancel()
+
+	response, err := c.do(ctx, http.MethodPost, containerPath(name, "/"+action), nil)
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
+
+// inspect returns the named container's state.
+func (c *engineClient) inspect(name string) (containerInspect, error) {
+	var result containerInspect
+	err := c.getJSON(containerPath(name, "/json"), nil, &result)
+	return result, err
+}
+
+// stats returns a single sample of the named container's resource usage.
+func (c *engineClient) stats(name string) (containerStats, error) {
+	var result containerStats
+	err := c.getJSON(containerPath(name, "/stats"), url.Values{"stream": []string{"false"}}, &result)
+	return result, err
+}
+
+// logs passes the selected lines of the named container's stdout and stderr to emit.  tty reports whether the
+// container was created with a TTY, in which case the engine returns the raw stream rather than multiplexed frames.
+// A followed log has no deadline; it ends when the container stops or emit returns an error.
+func (c *engineClient) logs(name string, options LogOptions, tty bool, emit func(line string) error) error {
+	ctx := context.Background()
+	if !options.Follow {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, engineTimeout)
+		defer cancel()
+	}
+
+	query := url.Values{
+		"stdout": []string{"true"},
+		"stderr": []string{"true"},
+		"tail":   []string{"all"},
+	}
+	if options.Tail > 0 {
+		query.Set("tail", strconv.Itoa(options.Tail))
+	}
+	if !options.Since.IsZero() {
+		query.Set("since", strconv.FormatInt(options.Since.Unix(), 10))
+	}
+	if !options.Until.IsZero() {
+		query.Set("until", strconv.FormatInt(options.Until.Unix(), 10))
+	}
+	if options.Follow {
+		query.Set("follow", "true")
+	}
+	response, err := c.do(ctx, http.MethodGet, containerPath(name, "/logs"), query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	var stream io.Reader = response.Body
+	if !tty {
+		stream = newDemultiplexer(response.Body)
+	}
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		if err := emit(scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// demultiplexer strips the frame headers from a multiplexed stdout/stderr stream, yielding the interleaved payload.
+type demultiplexer struct {
+	source    io.Reader
+	remaining uint32
+}
+
+func newDemultiplexer(source io.Reader) io.Reader {
+	return &demultiplexer{source: source}
+}
+
+func (d *demultiplexer) Read(p []byte) (int, error) {
+	for d.remaining == 0 {
+		var header [multiplexedHeaderSize]byte
+		if _, err := io.ReadFull(d.source, header[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				return 0, fmt.Errorf("truncated log frame header")
+			}
+			return 0, err
+		}
+		d.remaining = binary.BigEndian.Uint32(header[4:])
+	}
+	if uint32(len(p)) > d.remaining {
+		p = p[:d.remaining]
+	}
+	n, err := d.source.Read(p)
+	d.remaining -= uint32(n)
+	if err == io.EOF && d.remaining > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
@@ -0,0 +1,152 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// messageNoLogFile returns a text error message and exists to support unit testing.
+func messageNoLogFile(service string) string {
+	return fmt.Sprintf("service %s has no LogFile configured", service)
+}
+
+func (b processBackend) Logs(service string, options LogOptions, emit func(line string) error) error {
+	serviceConfig, ok := b.config.Services[service]
+	switch {
+	case !ok:
+		return errors.New(messageServiceNotConfigured(service))
+	case serviceConfig.LogFile == "":
+		return errors.New(messageNoLogFile(service))
+	case !options.Since.IsZero() || !options.Until.IsZero():
+		// plain log files carry no reliable timestamps to filter on
+		return errors.New(messageTimeWindowNotSupported())
+	}
+	follower := logFileFollower{path: serviceConfig.LogFile, sleep: b.sleep}
+	return follower.run(options.Tail, options.Follow, emit)
+}
+
+// logFileFollower reads a log file, optionally continuing to read lines as they are appended.  It reopens the file
+// from the start when it is truncated or replaced by log rotation.
+type logFileFollower struct {
+	path   string
+	sleep  func(time.Duration)
+	file   *os.File
+	reader *bufio.Reader
+	offset int64
+}
+
+func (f *logFileFollower) open() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+	f.file = file
+	f.reader = bufio.NewReader(file)
+	f.offset = 0
+	return nil
+}
+
+// rotated reports whether the file at path has been truncated or replaced since it was opened.
+func (f *logFileFollower) rotated() bool {
+	current, err := os.Stat(f.path)
+	if err != nil {
+		return false
+	}
+	opened, err := f.file.Stat()
+	if err != nil {
+		return true
+	}
+	return !os.SameFile(current, opened) || current.Size() < f.offset
+}
+
+func (f *logFileFollower) run(tail int, follow bool, emit func(line string) error) error {
+	if err := f.open(); err != nil {
+		return err
+	}
+	defer func() { _ = f.file.Close() }()
+
+	// read the existing content, retaining only the last tail lines
+	var buffered []string
+	partial, err := f.readLines(func(line string) error {
+		buffered = append(buffered, line)
+		if tail > 0 && len(buffered) > tail {
+			buffered = buffered[1:]
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !follow && partial != "" {
+		buffered = append(buffered, partial)
+		if tail > 0 && len(buffered) > tail {
+			buffered = buffered[1:]
+		}
+	}
+	for _, line := range buffered {
+		if err := emit(line); err != nil {
+			return err
+		}
+	}
+	if !follow {
+		return nil
+	}
+
+	for {
+		f.sleep(pollInterval)
+		if f.rotated() {
+			if err := f.open(); err != nil {
+				return err
+			}
+			partial = ""
+		}
+		rest, err := f.readLines(func(line string) error {
+			err := emit(partial + line)
+			partial = ""
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		partial += rest
+	}
+}
+
+// readLines passes each complete line up to the end of the file to emit and returns any trailing partial line.
+func (f *logFileFollower) readLines(emit func(line string) error) (string, error) {
+	for {
+		line, err := f.reader.ReadString('\n')
+		f.offset += int64(len(line))
+		switch {
+		case err == io.EOF:
+			return line, nil
+		case err != nil:
+			return "", err
+		}
+		if err := emit(strings.TrimRight(line, "\r\n")); err != nil {
+			return "", err
+		}
+	}
+}
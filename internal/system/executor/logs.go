@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+)
+
+const Logs = system.Logs
+
+// StreamingCommandExecutor runs a command and passes each line of its combined output to emit as the line is
+// produced.  It exists so commands which run indefinitely, such as docker logs --follow, can be consumed
+// incrementally.
+type StreamingCommandExecutor func(emit func(line string) error, arg ...string) error
+
+// LogOptions selects the log lines returned by a LogReader.
+type LogOptions struct {
+	// Tail limits the result to the last Tail lines; 0 returns every line.
+	Tail int
+	// Since and Until bound the time window of the result; the zero value leaves that end open.
+	Since time.Time
+	Until time.Time
+	// Follow continues to return lines as they are written until the log ends or the executor is stopped.
+	Follow bool
+}
+
+// LogReader is implemented by back-ends which can retrieve a service's log.
+type LogReader interface {
+	// Logs passes each selected line of the service's log to emit.
+	Logs(service string, options LogOptions, emit func(line string) error) error
+}
+
+// messageLogsNotSupported returns a text error message and exists to support unit testing.
+func messageLogsNotSupported(backendType string) string {
+	return fmt.Sprintf("the %s executor does not support logs", backendType)
+}
+
+// messageTimeWindowNotSupported returns a text error message and exists to support unit testing.
+func messageTimeWindowNotSupported() string {
+	return "a time window is not supported for this service's log"
+}
+
+// parseLogTime accepts either an RFC3339 timestamp or a duration, which is interpreted as that long before now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if duration, err := time.ParseDuration(value); err == nil {
+		return now.Add(-duration), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %s: expected an RFC3339 timestamp or a duration", value)
+	}
+	return t, nil
+}
+
+// ParseLogOptions parses the arguments which follow the logs operation on the command line:
+// [-tail N] [-since T] [-until T] [-follow], where T is an RFC3339 timestamp or a duration before now.
+func ParseLogOptions(args []string, now time.Time) (LogOptions, error) {
+	var options LogOptions
+	var since, until string
+	flags := flag.NewFlagSet(Logs, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.IntVar(&options.Tail, "tail", 0, "")
+	flags.StringVar(&since, "since", "", "")
+	flags.StringVar(&until, "until", "", "")
+	flags.BoolVar(&options.Follow, "follow", false, "")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+	if options.Tail < 0 {
+		return options, errors.New("tail must not be negative")
+	}
+
+	var err error
+	if options.Since, err = parseLogTime(since, now); err != nil {
+		return options, err
+	}
+	if options.Until, err = parseLogTime(until, now); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+// executeLogs handles a logs request.  Without -follow the selected lines are returned in a LogsSuccessResult.
+// With -follow each line is written to out as a system.LogEntry JSON document as soon as it is read, and the
+// returned result marks the end of the stream.
+func executeLogs(service string, args []string, backend Backend, out io.Writer) system.Result {
+	reader, ok := backend.(LogReader)
+	if !ok {
+		return system.Failure(service, Logs, backend.Type(), messageLogsNotSupported(backend.Type()))
+	}
+	options, err := ParseLogOptions(args, time.Now())
+	if err != nil {
+		return system.Failure(service, Logs, backend.Type(), err.Error())
+	}
+
+	if options.Follow {
+		encoder := json.NewEncoder(out)
+		err = reader.Logs(service, options, func(line string) error {
+			return encoder.Encode(system.LogEntry{Service: service, Line: line})
+		})
+		if err != nil {
+			return system.Failure(service, Logs, backend.Type(), err.Error())
+		}
+		return system.Success(service, Logs, backend.Type())
+	}
+
+	lines := []string{}
+	err = reader.Logs(service, options, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return system.Failure(service, Logs, backend.Type(), err.Error())
+	}
+	return system.LogsSuccess(service, backend.Type(), lines)
+}
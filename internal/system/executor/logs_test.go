@@ -0,0 +1,226 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStopFollowing = errors.New("stop following")
+
+// streamerStub records the arguments of each call and emits its canned lines.
+type streamerStub struct {
+	capturedArgs []string
+	lines        []string
+	err          error
+}
+
+func (s *streamerStub) streamer(emit func(line string) error, arg ...string) error {
+	s.capturedArgs = arg
+	for _, line := range s.lines {
+		if err := emit(line); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+func TestParseLogOptions(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		args        []string
+		expected    LogOptions
+		expectError bool
+	}{
+		{"no options", nil, LogOptions{}, false},
+		{"tail and follow", []string{"-tail", "50", "-follow"}, LogOptions{Tail: 50, Follow: true}, false},
+		{"duration window", []string{"-since", "1h", "-until", "10m"}, LogOptions{Since: now.Add(-time.Hour), Until: now.Add(-10 * time.Minute)}, false},
+		{"timestamp window", []string{"-since", "2021-03-01T10:00:00Z"}, LogOptions{Since: time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)}, false},
+		{"invalid time", []string{"-since", "yesterday"}, LogOptions{}, true},
+		{"negative tail", []string{"-tail", "-1"}, LogOptions{}, true},
+		{"unknown flag", []string{"-grep", "error"}, LogOptions{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options, err := ParseLogOptions(test.args, now)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, options)
+		})
+	}
+}
+
+func TestExecuteLogs(t *testing.T) {
+	t.Run("lines are returned in the result", func(t *testing.T) {
+		stub := &streamerStub{lines: []string{"one", "two"}}
+		backend := NewDockerBackend(nil, stub.streamer)
+
+		result := ExecuteWith(append(executeArguments(serviceName, Logs), "-tail", "2"), backend, ioutil.Discard)
+
+		assert.Equal(t, system.LogsSuccess(serviceName, DockerType, []string{"one", "two"}), result)
+		assert.Equal(t, []string{Logs, "--tail", "2", serviceName}, stub.capturedArgs)
+	})
+
+	t.Run("followed lines are written as they are read", func(t *testing.T) {
+		stub := &streamerStub{lines: []string{"one", "two"}}
+		backend := NewDockerBackend(nil, stub.streamer)
+		var out bytes.Buffer
+
+		result := ExecuteWith(append(executeArguments(serviceName, Logs), "-follow"), backend, &out)
+
+		assert.Equal(t, system.Success(serviceName, Logs, DockerType), result)
+		assert.Equal(t,
+			`{"service":"`+serviceName+`","line":"one"}`+"\n"+`{"service":"`+serviceName+`","line":"two"}`+"\n",
+			out.String())
+	})
+
+	t.Run("streamer error", func(t *testing.T) {
+		stub := &streamerStub{err: errors.New(errorMessage)}
+		backend := NewDockerBackend(nil, stub.streamer)
+
+		result := ExecuteWith(executeArguments(serviceName, Logs), backend, ioutil.Discard)
+
+		assert.Equal(t, system.Failure(serviceName, Logs, DockerType, errorMessage), result)
+	})
+
+	t.Run("back-end without logs", func(t *testing.T) {
+		backend := NewDockerBackend(nil, nil)
+
+		result := ExecuteWith(executeArguments(serviceName, Logs), backend, ioutil.Discard)
+
+		assert.Equal(t, system.Failure(serviceName, Logs, DockerType, messageLogsNotSupported(DockerType)), result)
+	})
+}
+
+func TestLogsArguments(t *testing.T) {
+	since := time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+	options := LogOptions{Tail: 10, Since: since, Until: until, Follow: true}
+
+	assert.Equal(t,
+		[]string{Logs, "--tail", "10", "--since", "2021-03-01T10:00:00Z", "--until", "2021-03-01T11:00:00Z", "--follow", serviceName},
+		dockerLogsArguments(serviceName, options))
+	assert.Equal(t,
+		[]string{"--unit", unitName, "--output", "cat", "--no-pager", "--lines", "10", "--since", "@1614592800", "--until", "@1614596400", "--follow"},
+		journalctlArguments(unitName, options))
+	assert.Equal(t,
+		[]string{"--unit", unitName, "--output", "cat", "--no-pager", "--lines", "all"},
+		journalctlArguments(unitName, LogOptions{}))
+}
+
+func TestProcessLogs(t *testing.T) {
+	control := newFakeProcessControl()
+	backend, _, cleanup := newTestProcessBackend(t, control)
+	defer cleanup()
+	logFile := filepath.Join(backend.config.PidDir, "service.log")
+	backend.config.Services[serviceName] = ProcessService{Command: executableName, LogFile: logFile}
+	require.NoError(t, ioutil.WriteFile(logFile, []byte("one\ntwo\nthree\npartial"), 0644))
+
+	collect := func(options LogOptions) ([]string, error) {
+		var lines []string
+		err := backend.Logs(serviceName, options, func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+		return lines, err
+	}
+
+	lines, err := collect(LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three", "partial"}, lines)
+
+	lines, err = collect(LogOptions{Tail: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"three", "partial"}, lines)
+
+	_, err = collect(LogOptions{Since: time.Now()})
+	assert.EqualError(t, err, messageTimeWindowNotSupported())
+
+	err = backend.Logs("unknown", LogOptions{}, nil)
+	assert.EqualError(t, err, messageServiceNotConfigured("unknown"))
+}
+
+func TestProcessLogsFollow(t *testing.T) {
+	control := newFakeProcessControl()
+	backend, _, cleanup := newTestProcessBackend(t, control)
+	defer cleanup()
+	logFile := filepath.Join(backend.config.PidDir, "service.log")
+	backend.config.Services[serviceName] = ProcessService{Command: executableName, LogFile: logFile}
+	require.NoError(t, ioutil.WriteFile(logFile, []byte("one\ntwo\nthr"), 0644))
+
+	appendToLog := func(content string) {
+		file, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = file.WriteString(content)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+	// each poll for new lines simulates the service writing, and later rotating, its log
+	writes := []func(){
+		func() { appendToLog("ee\nfo") },
+		func() {},
+		func() { appendToLog("ur\n") },
+		func() { require.NoError(t, ioutil.WriteFile(logFile, []byte("rotated\n"), 0644)) },
+	}
+	backend.sleep = func(time.Duration) {
+		if len(writes) > 0 {
+			writes[0]()
+			writes = writes[1:]
+		}
+	}
+
+	var lines []string
+	err := backend.Logs(serviceName, LogOptions{Tail: 1, Follow: true}, func(line string) error {
+		lines = append(lines, line)
+		if line == "rotated" {
+			return errStopFollowing
+		}
+		return nil
+	})
+
+	assert.Equal(t, errStopFollowing, err)
+	assert.Equal(t, []string{"two", "three", "four", "rotated"}, lines)
+}
+
+func TestDockerAPILogs(t *testing.T) {
+	const containerPrefix = "/containers/" + serviceName
+	engine := newFakeEngine(t, map[string]func(w http.ResponseWriter){
+		"GET " + containerPrefix + "/json": respond(http.StatusOK, `{"Config":{"Tty":true}}`),
+		"GET " + containerPrefix + "/logs": respond(http.StatusOK, "one\ntwo\n"),
+	})
+	defer engine.cleanup()
+	backend, err := NewDockerAPIBackend(engine.host)
+	require.NoError(t, err)
+
+	result := ExecuteWith(append(executeArguments(serviceName, Logs), "-tail", "5"), backend, ioutil.Discard)
+
+	assert.Equal(t, system.LogsSuccess(serviceName, DockerAPIType, []string{"one", "two"}), result)
+}
@@ -0,0 +1,275 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	defaultPidDir           = "/var/run/edgex"
+	defaultStopTimeout      = 10 * time.Second
+	defaultStartGracePeriod = time.Second
+	pollInterval            = 100 * time.Millisecond
+)
+
+// ProcessConfig is the TOML configuration of the process back-end, e.g.
+//
+//	PidDir = "/var/run/edgex"
+//	StopTimeout = "10s"
+//
+//	[Services.edgex-core-data]
+//	Command = "/usr/local/bin/core-data"
+//	Args = ["--confdir", "/etc/edgex/core-data"]
+//	LogFile = "/var/log/edgex/core-data.log"
+type ProcessConfig struct {
+	// PidDir holds the PID file of every service which doesn't specify its own.
+	PidDir string
+	// StopTimeout is how long a service is given to exit after SIGTERM before it is sent SIGKILL.
+	StopTimeout string
+	// StartGracePeriod is how long a started service must stay up for the start to be considered successful.
+	StartGracePeriod string
+	Services         map[string]ProcessService
+}
+
+// ProcessService describes how the process back-end runs a single service.
+type ProcessService struct {
+	Command string
+	Args    []string
+	Env     []string
+	WorkDir string
+	// LogFile receives the service's stdout and stderr; they are discarded when it is empty.
+	LogFile string
+	// PidFile defaults to <PidDir>/<service>.pid.
+	PidFile string
+}
+
+// LoadProcessConfig reads the process back-end's configuration from the TOML file at path.
+func LoadProcessConfig(path string) (ProcessConfig, error) {
+	var config ProcessConfig
+	if path == "" {
+		return config, fmt.Errorf("%s must name the process executor configuration file", ProcessConfigEnvName)
+	}
+	if _, err := toml.DecodeFile(path, &config); err != nil {
+		return config, fmt.Errorf("failed to load the process executor configuration %s: %s", path, err.Error())
+	}
+	return config, nil
+}
+
+// processControl abstracts the operating system's process management to support unit testing.
+type processControl interface {
+	// spawn starts the service's command in its own session and returns its PID without waiting for it to exit.
+	spawn(service ProcessService) (int, error)
+	signal(pid int, sig syscall.Signal) error
+	alive(pid int) bool
+}
+
+// processBackend implements Backend by running each service as a native process tracked by a PID file.
+type processBackend struct {
+	config   ProcessConfig
+	control  processControl
+	procRoot string
+	sleep    func(time.Duration)
+}
+
+// NewProcessBackend returns a Backend which manages services as native processes described by config.
+func NewProcessBackend(config ProcessConfig) Backend {
+	return processBackend{
+		config:   config,
+		control:  newOSProcessControl(),
+		procRoot: defaultProcRoot,
+		sleep:    time.Sleep,
+	}
+}
+
+// messageServiceNotConfigured returns a text error message and exists to support unit testing.
+func messageServiceNotConfigured(service string) string {
+	return fmt.Sprintf("service %s is not configured for the process executor", service)
+}
+
+// messageServiceNotRunning returns a text error message and exists to support unit testing.
+func messageServiceNotRunning(service string) string {
+	return fmt.Sprintf("service %s is not running", service)
+}
+
+func (b processBackend) Type() string {
+	return ProcessType
+}
+
+func (b processBackend) Start(service string) system.Result {
+	if err := b.start(service, failedStartPrefix); err != nil {
+		return system.Failure(service, Start, ProcessType, err.Error())
+	}
+	return system.Success(service, Start, ProcessType)
+}
+
+func (b processBackend) Restart(service string) system.Result {
+	if err := b.stop(service, failedRestartPrefix); err != nil {
+		return system.Failure(service, Restart, ProcessType, err.Error())
+	}
+	if err := b.start(service, failedRestartPrefix); err != nil {
+		return system.Failure(service, Restart, ProcessType, err.Error())
+	}
+	return system.Success(service, Restart, ProcessType)
+}
+
+func (b processBackend) Stop(service string) system.Result {
+	if err := b.stop(service, failedStopPrefix); err != nil {
+		return system.Failure(service, Stop, ProcessType, err.Error())
+	}
+	return system.Success(service, Stop, ProcessType)
+}
+
+func (b processBackend) Metrics(service string) system.Result {
+	pid, err := b.runningPid(service)
+	if err != nil {
+		return system.Failure(service, Metrics, ProcessType, err.Error())
+	}
+	if pid == 0 {
+		return system.Failure(service, Metrics, ProcessType, messageServiceNotRunning(service))
+	}
+
+	stats, err := readProcessStats(b.procRoot, pid)
+	if err != nil {
+		return system.Failure(service, Metrics, ProcessType, err.Error())
+	}
+	raw, err := json.Marshal(stats)
+	if err != nil {
+		return system.Failure(service, Metrics, ProcessType, err.Error())
+	}
+	return system.MetricsSuccess(service, ProcessType, stats.cpuUsedPercent(), stats.MemoryRSS, raw)
+}
+
+// start spawns the service unless it is already running and records its PID once it has survived the grace period.
+func (b processBackend) start(service string, operationPrefix string) error {
+	pid, err := b.runningPid(service)
+	if err != nil {
+		return errors.New(messageExecutorInspectFailed(operationPrefix, err.Error()))
+	}
+	if pid != 0 {
+		return nil
+	}
+
+	serviceConfig := b.config.Services[service]
+	pid, err = b.control.spawn(serviceConfig)
+	if err != nil {
+		return errors.New(messageExecutorCommandFailed(operationPrefix, serviceConfig.Command, err.Error()))
+	}
+	pidFile := b.pidFile(service)
+	if err := os.MkdirAll(filepath.Dir(pidFile), 0755); err != nil {
+		return errors.New(messageExecutorInspectFailed(operationPrefix, err.Error()))
+	}
+	if err := ioutil.WriteFile(pidFile, []byte(strconv.Itoa(pid)+"\n"), 0644); err != nil {
+		return errors.New(messageExecutorInspectFailed(operationPrefix, err.Error()))
+	}
+
+	b.sleep(parseDuration(b.config.StartGracePeriod, defaultStartGracePeriod))
+	if !b.control.alive(pid) {
+		_ = os.Remove(pidFile)
+		return errors.New(messageServiceIsNotRunningButShouldBe(operationPrefix))
+	}
+	return nil
+}
+
+// stop sends SIGTERM to a running service, escalating to SIGKILL once the stop timeout elapses, and removes its PID
+// file.  Stopping a service which isn't running succeeds.
+func (b processBackend) stop(service string, operationPrefix string) error {
+	pid, err := b.runningPid(service)
+	if err != nil {
+		return errors.New(messageExecutorInspectFailed(operationPrefix, err.Error()))
+	}
+	if pid != 0 {
+		if err := b.control.signal(pid, syscall.SIGTERM); err != nil {
+			return errors.New(messageExecutorCommandFailed(operationPrefix, "SIGTERM", err.Error()))
+		}
+		if !b.waitForExit(pid, parseDuration(b.config.StopTimeout, defaultStopTimeout)) {
+			if err := b.control.signal(pid, syscall.SIGKILL); err != nil {
+				return errors.New(messageExecutorCommandFailed(operationPrefix, "SIGKILL", err.Error()))
+			}
+			if !b.waitForExit(pid, defaultStartGracePeriod) {
+				return errors.New(messageServiceIsRunningButShouldNotBe(operationPrefix))
+			}
+		}
+	}
+	if err := os.Remove(b.pidFile(service)); err != nil && !os.IsNotExist(err) {
+		return errors.New(messageExecutorInspectFailed(operationPrefix, err.Error()))
+	}
+	return nil
+}
+
+// waitForExit polls until the process exits or the timeout elapses and reports whether it exited.
+func (b processBackend) waitForExit(pid int, timeout time.Duration) bool {
+	for waited := time.Duration(0); waited < timeout; waited += pollInterval {
+		if !b.control.alive(pid) {
+			return true
+		}
+		b.sleep(pollInterval)
+	}
+	return !b.control.alive(pid)
+}
+
+// runningPid returns the PID recorded for a configured service, or zero when there is no PID file or the recorded
+// process has exited.
+func (b processBackend) runningPid(service string) (int, error) {
+	if _, ok := b.config.Services[service]; !ok {
+		return 0, errors.New(messageServiceNotConfigured(service))
+	}
+	contents, err := ioutil.ReadFile(b.pidFile(service))
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid PID file %s: %s", b.pidFile(service), err.Error())
+	}
+	if !b.control.alive(pid) {
+		return 0, nil
+	}
+	return pid, nil
+}
+
+func (b processBackend) pidFile(service string) string {
+	if pidFile := b.config.Services[service].PidFile; pidFile != "" {
+		return pidFile
+	}
+	pidDir := b.config.PidDir
+	if pidDir == "" {
+		pidDir = defaultPidDir
+	}
+	return filepath.Join(pidDir, service+".pid")
+}
+
+// parseDuration parses a configured duration, returning defaultValue when it is empty or invalid.
+func parseDuration(value string, defaultValue time.Duration) time.Duration {
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	return defaultValue
+}
@@ -0,0 +1,237 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spawnedPid = 4242
+
+// fakeProcessControl simulates processes without spawning or signalling anything.
+type fakeProcessControl struct {
+	alivePids   map[int]bool
+	spawnError  error
+	diesOnStart bool
+	ignoresTerm bool
+	signals     []syscall.Signal
+}
+
+func newFakeProcessControl() *fakeProcessControl {
+	return &fakeProcessControl{alivePids: map[int]bool{}}
+}
+
+func (c *fakeProcessControl) spawn(_ ProcessService) (int, error) {
+	if c.spawnError != nil {
+		return 0, c.spawnError
+	}
+	c.alivePids[spawnedPid] = !c.diesOnStart
+	return spawnedPid, nil
+}
+
+func (c *fakeProcessControl) signal(pid int, sig syscall.Signal) error {
+	c.signals = append(c.signals, sig)
+	if sig == syscall.SIGKILL || !c.ignoresTerm {
+		c.alivePids[pid] = false
+	}
+	return nil
+}
+
+func (c *fakeProcessControl) alive(pid int) bool {
+	return c.alivePids[pid]
+}
+
+func newTestProcessBackend(t *testing.T, control *fakeProcessControl) (processBackend, string, func()) {
+	pidDir, err := ioutil.TempDir("", "pids")
+	require.NoError(t, err)
+	backend := processBackend{
+		config: ProcessConfig{
+			PidDir:   pidDir,
+			Services: map[string]ProcessService{serviceName: {Command: executableName}},
+		},
+		control:  control,
+		procRoot: defaultProcRoot,
+		sleep:    func(time.Duration) {},
+	}
+	return backend, filepath.Join(pidDir, serviceName+".pid"), func() { _ = os.RemoveAll(pidDir) }
+}
+
+func writePidFile(t *testing.T, pidFile string, pid int) {
+	require.NoError(t, ioutil.WriteFile(pidFile, []byte(strconv.Itoa(pid)+"\n"), 0644))
+}
+
+func TestProcessStart(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		control := newFakeProcessControl()
+		backend, pidFile, cleanup := newTestProcessBackend(t, control)
+		defer cleanup()
+
+		assert.Equal(t, system.Success(serviceName, Start, ProcessType), backend.Start(serviceName))
+		contents, err := ioutil.ReadFile(pidFile)
+		require.NoError(t, err)
+		assert.Equal(t, "4242\n", string(contents))
+	})
+
+	t.Run("already running", func(t *testing.T) {
+		control := newFakeProcessControl()
+		control.spawnError = errors.New(errorMessage)
+		control.alivePids[1234] = true
+		backend, pidFile, cleanup := newTestProcessBackend(t, control)
+		defer cleanup()
+		writePidFile(t, pidFile, 1234)
+
+		assert.Equal(t, system.Success(serviceName, Start, ProcessType), backend.Start(serviceName))
+	})
+
+	t.Run("spawn fails", func(t *testing.T) {
+		control := newFakeProcessControl()
+		control.spawnError = errors.New(errorMessage)
+		backend, _, cleanup := newTestProcessBackend(t, control)
+		defer cleanup()
+
+		assert.Equal(t,
+			system.Failure(serviceName, Start, ProcessType, messageExecutorCommandFailed(failedStartPrefix, executableName, errorMessage)),
+			backend.Start(serviceName))
+	})
+
+	t.Run("dies during grace period", func(t *testing.T) {
+		control := newFakeProcessControl()
+		control.diesOnStart = true
+		backend, pidFile, cleanup := newTestProcessBackend(t, control)
+		defer cleanup()
+
+		assert.Equal(t,
+			system.Failure(serviceName, Start, ProcessType, messageServiceIsNotRunningButShouldBe(failedStartPrefix)),
+			backend.Start(serviceName))
+		_, err := os.Stat(pidFile)
+		assert.True(t, os.IsNotExist(err), "expected the PID file to be removed")
+	})
+
+	t.Run("service not configured", func(t *testing.T) {
+		backend, _, cleanup := newTestProcessBackend(t, newFakeProcessControl())
+		defer cleanup()
+
+		assert.Equal(t,
+			system.Failure("unknown", Start, ProcessType, messageExecutorInspectFailed(failedStartPrefix, messageServiceNotConfigured("unknown"))),
+			backend.Start("unknown"))
+	})
+}
+
+func TestProcessStop(t *testing.T) {
+	tests := []struct {
+		name            string
+		running         bool
+		ignoresTerm     bool
+		expectedSignals []syscall.Signal
+	}{
+		{"not running", false, false, nil},
+		{"exits on SIGTERM", true, false, []syscall.Signal{syscall.SIGTERM}},
+		{"escalates to SIGKILL", true, true, []syscall.Signal{syscall.SIGTERM, syscall.SIGKILL}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			control := newFakeProcessControl()
+			control.ignoresTerm = test.ignoresTerm
+			control.alivePids[1234] = test.running
+			backend, pidFile, cleanup := newTestProcessBackend(t, control)
+			defer cleanup()
+			writePidFile(t, pidFile, 1234)
+
+			assert.Equal(t, system.Success(serviceName, Stop, ProcessType), backend.Stop(serviceName))
+			assert.Equal(t, test.expectedSignals, control.signals)
+			_, err := os.Stat(pidFile)
+			assert.True(t, os.IsNotExist(err), "expected the PID file to be removed")
+		})
+	}
+}
+
+func TestProcessRestart(t *testing.T) {
+	control := newFakeProcessControl()
+	control.alivePids[1234] = true
+	backend, pidFile, cleanup := newTestProcessBackend(t, control)
+	defer cleanup()
+	writePidFile(t, pidFile, 1234)
+
+	assert.Equal(t, system.Success(serviceName, Restart, ProcessType), backend.Restart(serviceName))
+	assert.False(t, control.alive(1234))
+	assert.True(t, control.alive(spawnedPid))
+}
+
+func TestProcessMetrics(t *testing.T) {
+	procRoot, err := ioutil.TempDir("", "proc")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(procRoot) }()
+	writeFakeProcess(t, procRoot, 1234, 5000, 10000, 300, 2048)
+
+	control := newFakeProcessControl()
+	backend, pidFile, cleanup := newTestProcessBackend(t, control)
+	defer cleanup()
+	backend.procRoot = procRoot
+
+	assert.Equal(t,
+		system.Failure(serviceName, Metrics, ProcessType, messageServiceNotRunning(serviceName)),
+		backend.Metrics(serviceName))
+
+	control.alivePids[1234] = true
+	writePidFile(t, pidFile, 1234)
+	result := backend.Metrics(serviceName)
+
+	require.IsType(t, &system.MetricsSuccessResult{}, result)
+	metrics := result.(*system.MetricsSuccessResult)
+	assert.Equal(t, 25.0, metrics.MetricsResultValue.CpuUsedPercent)
+	assert.Equal(t, int64(2048*1024), metrics.MetricsResultValue.MemoryUsed)
+}
+
+func TestLoadProcessConfig(t *testing.T) {
+	file, err := ioutil.TempFile("", "process-executor.toml")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(file.Name()) }()
+	_, err = file.WriteString(`
+PidDir = "/tmp/edgex"
+StopTimeout = "5s"
+
+[Services.edgex-core-data]
+Command = "/usr/local/bin/core-data"
+Args = ["--confdir", "/etc/edgex/core-data"]
+`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	config, err := LoadProcessConfig(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/edgex", config.PidDir)
+	assert.Equal(t, "5s", config.StopTimeout)
+	assert.Equal(t, []string{"--confdir", "/etc/edgex/core-data"}, config.Services["edgex-core-data"].Args)
+
+	_, err = LoadProcessConfig("")
+	assert.Error(t, err)
+}
+
+func TestNewBackendUnknownType(t *testing.T) {
+	_, err := NewBackend("kubernetes", nil, nil)
+	assert.Error(t, err)
+}
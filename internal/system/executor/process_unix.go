@@ -0,0 +1,63 @@
+// +build !windows
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// osProcessControl implements processControl with the operating system's process APIs.
+type osProcessControl struct{}
+
+func newOSProcessControl() processControl {
+	return osProcessControl{}
+}
+
+func (osProcessControl) spawn(service ProcessService) (int, error) {
+	cmd := exec.Command(service.Command, service.Args...)
+	cmd.Dir = service.WorkDir
+	cmd.Env = append(os.Environ(), service.Env...)
+	// detach from the executor's session so the service outlives the executor
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if service.LogFile != "" {
+		log, err := os.OpenFile(service.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return 0, err
+		}
+		defer func() { _ = log.Close() }()
+		cmd.Stdout = log
+		cmd.Stderr = log
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, err
+	}
+	// reap the child if it exits while the executor is still running so it isn't reported alive as a zombie
+	go func() { _ = cmd.Wait() }()
+	return cmd.Process.Pid, nil
+}
+
+func (osProcessControl) signal(pid int, sig syscall.Signal) error {
+	return syscall.Kill(pid, sig)
+}
+
+func (osProcessControl) alive(pid int) bool {
+	// signal 0 performs the existence and permission checks without sending a signal
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
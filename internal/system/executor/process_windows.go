@@ -0,0 +1,43 @@
+// +build windows
+
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"errors"
+	"syscall"
+)
+
+var errProcessControlUnsupported = errors.New("the process executor is not supported on windows")
+
+// unsupportedProcessControl fails every operation; PID file based process management relies on POSIX signals.
+type unsupportedProcessControl struct{}
+
+func newOSProcessControl() processControl {
+	return unsupportedProcessControl{}
+}
+
+func (unsupportedProcessControl) spawn(ProcessService) (int, error) {
+	return 0, errProcessControlUnsupported
+}
+
+func (unsupportedProcessControl) signal(int, syscall.Signal) error {
+	return errProcessControlUnsupported
+}
+
+func (unsupportedProcessControl) alive(int) bool {
+	return false
+}
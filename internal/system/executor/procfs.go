@@ -0,0 +1,117 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultProcRoot = "/proc"
+	// clockTicks is USER_HZ, the unit of the times reported in /proc/<pid>/stat; it is 100 on every Linux platform
+	// EdgeX supports.
+	clockTicks = 100
+)
+
+// processStats holds the values read from procfs for a single process.
+type processStats struct {
+	Pid        int     `json:"pid"`
+	State      string  `json:"state"`
+	Threads    int     `json:"threads"`
+	CpuSeconds float64 `json:"cpu_seconds"`
+	Uptime     float64 `json:"uptime_seconds"`
+	MemoryRSS  int64   `json:"mem_rss"`
+}
+
+// cpuUsedPercent returns the average CPU utilization over the lifetime of the process, as reported by ps.
+func (s processStats) cpuUsedPercent() float64 {
+	if s.Uptime <= 0 {
+		return 0
+	}
+	return s.CpuSeconds / s.Uptime * 100
+}
+
+// readProcessStats reads the stat and status files of the process from the procfs mounted at procRoot.
+func readProcessStats(procRoot string, pid int) (processStats, error) {
+	stats := processStats{Pid: pid}
+	processDir := filepath.Join(procRoot, strconv.Itoa(pid))
+
+	stat, err := ioutil.ReadFile(filepath.Join(processDir, "stat"))
+	if err != nil {
+		return stats, err
+	}
+	// the command name is enclosed in parentheses and may itself contain spaces and parentheses
+	end := strings.LastIndexByte(string(stat), ')')
+	if end < 0 {
+		return stats, fmt.Errorf("malformed stat for process %d", pid)
+	}
+	// fields[0] is the third field of the stat file, so field n is at index n-3
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return stats, fmt.Errorf("malformed stat for process %d", pid)
+	}
+	stats.State = fields[0]
+	utime, _ := strconv.ParseUint(fields[11], 10, 64)
+	stime, _ := strconv.ParseUint(fields[12], 10, 64)
+	stats.Threads, _ = strconv.Atoi(fields[17])
+	startTime, _ := strconv.ParseUint(fields[19], 10, 64)
+	stats.CpuSeconds = float64(utime+stime) / clockTicks
+
+	uptime, err := ioutil.ReadFile(filepath.Join(procRoot, "uptime"))
+	if err != nil {
+		return stats, err
+	}
+	var systemUptime float64
+	if _, err := fmt.Sscanf(string(uptime), "%f", &systemUptime); err != nil {
+		return stats, err
+	}
+	stats.Uptime = systemUptime - float64(startTime)/clockTicks
+
+	stats.MemoryRSS, err = readMemoryRSS(filepath.Join(processDir, "status"))
+	return stats, err
+}
+
+// readMemoryRSS returns the resident set size in bytes from the VmRSS line of a /proc/<pid>/status file.
+func readMemoryRSS(path string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return -1, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "VmRSS:") {
+			continue
+		}
+		var kb int64
+		if _, err := fmt.Sscanf(strings.TrimPrefix(line, "VmRSS:"), "%d", &kb); err != nil {
+			return -1, err
+		}
+		return kb * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return -1, err
+	}
+	// kernel threads and zombies have no VmRSS line
+	return 0, nil
+}
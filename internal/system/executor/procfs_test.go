@@ -0,0 +1,62 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeProcess creates the procfs entries read for a process under procRoot.  The process has used cpuTicks of
+// CPU time since it started at startTicks, and the system has been up for uptime seconds.
+func writeFakeProcess(t *testing.T, procRoot string, pid int, cpuTicks int, startTicks int, uptime float64, rssKB int) {
+	processDir := filepath.Join(procRoot, strconv.Itoa(pid))
+	require.NoError(t, os.MkdirAll(processDir, 0755))
+	stat := fmt.Sprintf("%d (core data) S 1 %d %d 0 -1 4194560 1000 0 0 0 %d %d 0 0 20 0 7 0 %d 800000000 5000",
+		pid, pid, pid, cpuTicks/2, cpuTicks-cpuTicks/2, startTicks)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(processDir, "stat"), []byte(stat), 0644))
+	status := fmt.Sprintf("Name:\tcore-data\nState:\tS (sleeping)\nVmRSS:\t%8d kB\nThreads:\t7\n", rssKB)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(processDir, "status"), []byte(status), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(procRoot, "uptime"), []byte(fmt.Sprintf("%.2f 1000.00\n", uptime)), 0644))
+}
+
+func TestReadProcessStats(t *testing.T) {
+	procRoot, err := ioutil.TempDir("", "proc")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(procRoot) }()
+
+	// started 100s after boot, up for 200s and used 50s of CPU time
+	writeFakeProcess(t, procRoot, 42, 5000, 10000, 300, 2048)
+
+	stats, err := readProcessStats(procRoot, 42)
+	require.NoError(t, err)
+	assert.Equal(t, 42, stats.Pid)
+	assert.Equal(t, "S", stats.State)
+	assert.Equal(t, 7, stats.Threads)
+	assert.Equal(t, 50.0, stats.CpuSeconds)
+	assert.Equal(t, 200.0, stats.Uptime)
+	assert.Equal(t, int64(2048*1024), stats.MemoryRSS)
+	assert.Equal(t, 25.0, stats.cpuUsedPercent())
+
+	_, err = readProcessStats(procRoot, 43)
+	assert.Error(t, err, "expected an error for a process which doesn't exist")
+}
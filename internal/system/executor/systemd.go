@@ -0,0 +1,207 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+)
+
+const (
+	systemctlShow       = "show"
+	defaultUnitTemplate = "%s.service"
+
+	loadState     = "LoadState"
+	activeState   = "ActiveState"
+	mainPid       = "MainPID"
+	memoryCurrent = "MemoryCurrent"
+	cpuUsageNSec  = "CPUUsageNSec"
+)
+
+// systemdBackend implements Backend by delegating to systemctl, and LogReader by delegating to journalctl; each
+// service is managed as a systemd unit.
+type systemdBackend struct {
+	executor     CommandExecutor
+	journal      StreamingCommandExecutor
+	unitTemplate string
+	procRoot     string
+}
+
+// NewSystemdBackend returns a Backend which manages services as systemd units through the supplied systemctl
+// executor and reads their logs through the supplied journalctl executor, which may be nil.  unitTemplate is a
+// fmt template deriving the unit name from the service name; "%s.service" is used when it is empty.
+func NewSystemdBackend(executor CommandExecutor, journal StreamingCommandExecutor, unitTemplate string) Backend {
+	if unitTemplate == "" {
+		unitTemplate = defaultUnitTemplate
+	}
+	return systemdBackend{
+		executor:     executor,
+		journal:      journal,
+		unitTemplate: unitTemplate,
+		procRoot:     defaultProcRoot,
+	}
+}
+
+// messageUnitNotFound returns a text error message and exists to support unit testing.
+func messageUnitNotFound(unit string) string {
+	return fmt.Sprintf("unit %s not found", unit)
+}
+
+func (b systemdBackend) Type() string {
+	return SystemdType
+}
+
+func (b systemdBackend) unit(service string) string {
+	return fmt.Sprintf(b.unitTemplate, service)
+}
+
+func (b systemdBackend) Start(service string) system.Result {
+	return b.executeACommand(Start, service, failedStartPrefix, true)
+}
+
+func (b systemdBackend) Restart(service string) system.Result {
+	return b.executeACommand(Restart, service, failedRestartPrefix, true)
+}
+
+func (b systemdBackend) Stop(service string) system.Result {
+	return b.executeACommand(Stop, service, failedStopPrefix, false)
+}
+
+// executeACommand runs the systemctl operation against the service's unit and then verifies the unit's state is
+// as expected.
+func (b systemdBackend) executeACommand(
+	operation string,
+	service string,
+	operationPrefix string,
+	shouldBeRunning bool) system.Result {
+
+	unit := b.unit(service)
+	if output, err := b.executor(operation, unit); err != nil {
+		return system.Failure(service, operation, SystemdType, messageExecutorCommandFailed(operationPrefix, string(output), err.Error()))
+	}
+
+	properties, err := b.show(unit, activeState)
+	if err != nil {
+		return system.Failure(service, operation, SystemdType, messageExecutorInspectFailed(operationPrefix, err.Error()))
+	}
+	isRunning := isUnitRunning(properties[activeState])
+	switch {
+	case isRunning != shouldBeRunning:
+		if isRunning {
+			return system.Failure(service, operation, SystemdType, messageServiceIsRunningButShouldNotBe(operationPrefix))
+		}
+		return system.Failure(service, operation, SystemdType, messageServiceIsNotRunningButShouldBe(operationPrefix))
+	default:
+		return system.Success(service, operation, SystemdType)
+	}
+}
+
+// Metrics reads the unit's main process from procfs.  The memory accounted to the unit's cgroup is preferred over
+// the main process' resident set size when systemd tracks it, since it includes any child processes.
+func (b systemdBackend) Metrics(service string) system.Result {
+	unit := b.unit(service)
+	properties, err := b.show(unit, activeState, mainPid, memoryCurrent, cpuUsageNSec)
+	if err != nil {
+		return system.Failure(service, Metrics, SystemdType, err.Error())
+	}
+	pid, _ := strconv.Atoi(properties[mainPid])
+	if pid == 0 {
+		return system.Failure(service, Metrics, SystemdType, fmt.Sprintf("unit %s has no main process", unit))
+	}
+
+	stats, err := readProcessStats(b.procRoot, pid)
+	if err != nil {
+		return system.Failure(service, Metrics, SystemdType, err.Error())
+	}
+	memoryUsed := stats.MemoryRSS
+	if memory, err := strconv.ParseInt(properties[memoryCurrent], 10, 64); err == nil {
+		memoryUsed = memory
+	}
+
+	raw, err := json.Marshal(struct {
+		Unit          string `json:"unit"`
+		ActiveState   string `json:"active_state"`
+		MemoryCurrent string `json:"memory_current"`
+		CpuUsageNSec  string `json:"cpu_usage_nsec"`
+		processStats
+	}{
+		Unit:          unit,
+		ActiveState:   properties[activeState],
+		MemoryCurrent: properties[memoryCurrent],
+		CpuUsageNSec:  properties[cpuUsageNSec],
+		processStats:  stats,
+	})
+	if err != nil {
+		return system.Failure(service, Metrics, SystemdType, err.Error())
+	}
+	return system.MetricsSuccess(service, SystemdType, stats.cpuUsedPercent(), memoryUsed, raw)
+}
+
+// show returns the requested properties of the unit as reported by "systemctl show".
+func (b systemdBackend) show(unit string, names ...string) (map[string]string, error) {
+	names = append([]string{loadState}, names...)
+	output, err := b.executor(systemctlShow, unit, "--property="+strings.Join(names, ","))
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		if i := strings.IndexByte(line, '='); i > 0 {
+			properties[line[:i]] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	// systemctl doesn't fail for unknown units, it reports them as not-found
+	if properties[loadState] == "not-found" {
+		return nil, errors.New(messageUnitNotFound(unit))
+	}
+	return properties, nil
+}
+
+// isUnitRunning interprets the unit's ActiveState; a unit which is reloading still has a running process.
+func isUnitRunning(state string) bool {
+	return state == "active" || state == "reloading"
+}
+
+func (b systemdBackend) Logs(service string, options LogOptions, emit func(line string) error) error {
+	if b.journal == nil {
+		return errors.New(messageLogsNotSupported(SystemdType))
+	}
+	return b.journal(emit, journalctlArguments(b.unit(service), options)...)
+}
+
+// journalctlArguments returns the journalctl command line selecting the requested lines of the unit's log.
+func journalctlArguments(unit string, options LogOptions) []string {
+	args := []string{"--unit", unit, "--output", "cat", "--no-pager"}
+	if options.Tail > 0 {
+		args = append(args, "--lines", strconv.Itoa(options.Tail))
+	} else {
+		args = append(args, "--lines", "all")
+	}
+	if !options.Since.IsZero() {
+		args = append(args, "--since", "@"+strconv.FormatInt(options.Since.Unix(), 10))
+	}
+	if !options.Until.IsZero() {
+		args = append(args, "--until", "@"+strconv.FormatInt(options.Until.Unix(), 10))
+	}
+	if options.Follow {
+		args = append(args, "--follow")
+	}
+	return args
+}
@@ -0,0 +1,182 @@
+/*******************************************************************************
+ * Copyright 2021 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/edgexfoundry/edgex-go/internal/system"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unitName = serviceName + ".service"
+
+func showStateArgs() []string {
+	return []string{systemctlShow, unitName, "--property=LoadState,ActiveState"}
+}
+
+func showMetricsArgs() []string {
+	return []string{systemctlShow, unitName, "--property=LoadState,ActiveState,MainPID,MemoryCurrent,CPUUsageNSec"}
+}
+
+func systemdCommandSucceeds(operation string, show string) []executorStubCall {
+	return []executorStubCall{
+		{[]string{operation, unitName}, []byte(nil), nil},
+		{showStateArgs(), []byte(show), nil},
+	}
+}
+
+func TestSystemdExecute(t *testing.T) {
+	tests := []struct {
+		name           string
+		operation      string
+		expectedResult system.Result
+		executorCalls  []executorStubCall
+	}{
+		{
+			"Start: systemctl fails",
+			Start,
+			system.Failure(serviceName, Start, SystemdType, messageExecutorCommandFailed(failedStartPrefix, "denied", errorMessage)),
+			[]executorStubCall{{[]string{Start, unitName}, []byte("denied"), errors.New(errorMessage)}},
+		},
+		{
+			"Start: show fails",
+			Start,
+			system.Failure(serviceName, Start, SystemdType, messageExecutorInspectFailed(failedStartPrefix, errorMessage)),
+			[]executorStubCall{
+				{[]string{Start, unitName}, []byte(nil), nil},
+				{showStateArgs(), []byte(nil), errors.New(errorMessage)},
+			},
+		},
+		{
+			"Start: unit not found",
+			Start,
+			system.Failure(serviceName, Start, SystemdType, messageExecutorInspectFailed(failedStartPrefix, messageUnitNotFound(unitName))),
+			systemdCommandSucceeds(Start, "LoadState=not-found\nActiveState=inactive\n"),
+		},
+		{
+			"Start: unit is not running as expected",
+			Start,
+			system.Failure(serviceName, Start, SystemdType, messageServiceIsNotRunningButShouldBe(failedStartPrefix)),
+			systemdCommandSucceeds(Start, "LoadState=loaded\nActiveState=failed\n"),
+		},
+		{
+			"Start: Success",
+			Start,
+			system.Success(serviceName, Start, SystemdType),
+			systemdCommandSucceeds(Start, "LoadState=loaded\nActiveState=active\n"),
+		},
+		{
+			"Restart: Success",
+			Restart,
+			system.Success(serviceName, Restart, SystemdType),
+			systemdCommandSucceeds(Restart, "LoadState=loaded\nActiveState=active\n"),
+		},
+		{
+			"Stop: unit is running but shouldn't be",
+			Stop,
+			system.Failure(serviceName, Stop, SystemdType, messageServiceIsRunningButShouldNotBe(failedStopPrefix)),
+			systemdCommandSucceeds(Stop, "LoadState=loaded\nActiveState=active\n"),
+		},
+		{
+			"Stop: Success",
+			Stop,
+			system.Success(serviceName, Stop, SystemdType),
+			systemdCommandSucceeds(Stop, "LoadState=loaded\nActiveState=inactive\n"),
+		},
+		{
+			"Metrics: unit has no main process",
+			Metrics,
+			system.Failure(serviceName, Metrics, SystemdType, "unit "+unitName+" has no main process"),
+			[]executorStubCall{{showMetricsArgs(), []byte("LoadState=loaded\nActiveState=inactive\nMainPID=0\n"), nil}},
+		},
+		{
+			"operation not supported by executor",
+			invalidOperation,
+			system.Failure(serviceName, invalidOperation, SystemdType, messageExecutorOperationNotSupported()),
+			[]executorStubCall{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executor := newExecutor(test.executorCalls)
+
+			result := ExecuteWith(executeArguments(serviceName, test.operation), NewSystemdBackend(executor.commandExecutor, nil, ""), ioutil.Discard)
+
+			if assert.Equal(t, len(test.executorCalls), executor.Called) {
+				for key, executorCall := range test.executorCalls {
+					assertArgsAreEqual(t, executorCall.expectedArgs, executor.capturedArgs[key])
+				}
+			}
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestSystemdUnitTemplate(t *testing.T) {
+	executor := newExecutor([]executorStubCall{
+		{[]string{Stop, "snap.edgexfoundry." + serviceName + ".service"}, []byte(nil), nil},
+		{[]string{systemctlShow, "snap.edgexfoundry." + serviceName + ".service", "--property=LoadState,ActiveState"}, []byte("ActiveState=inactive"), nil},
+	})
+
+	result := NewSystemdBackend(executor.commandExecutor, nil, "snap.edgexfoundry.%s.service").Stop(serviceName)
+
+	assert.Equal(t, system.Success(serviceName, Stop, SystemdType), result)
+	for key, executorCall := range executor.perCallResults {
+		assertArgsAreEqual(t, executorCall.expectedArgs, executor.capturedArgs[key])
+	}
+}
+
+func TestSystemdMetrics(t *testing.T) {
+	procRoot, err := ioutil.TempDir("", "proc")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(procRoot) }()
+	writeFakeProcess(t, procRoot, 42, 5000, 10000, 300, 2048)
+
+	tests := []struct {
+		name           string
+		show           string
+		expectedMemory int64
+	}{
+		{"memory accounted by systemd", "ActiveState=active\nMainPID=42\nMemoryCurrent=8388608\nCPUUsageNSec=50000000000\n", 8388608},
+		{"memory accounting disabled", "ActiveState=active\nMainPID=42\nMemoryCurrent=[not set]\nCPUUsageNSec=[not set]\n", 2048 * 1024},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			executor := newExecutor([]executorStubCall{{showMetricsArgs(), []byte(test.show), nil}})
+			backend := NewSystemdBackend(executor.commandExecutor, nil, "").(systemdBackend)
+			backend.procRoot = procRoot
+
+			result := backend.Metrics(serviceName)
+
+			require.IsType(t, &system.MetricsSuccessResult{}, result)
+			metrics := result.(*system.MetricsSuccessResult)
+			assert.Equal(t, SystemdType, metrics.Executor)
+			assert.Equal(t, 25.0, metrics.MetricsResultValue.CpuUsedPercent)
+			assert.Equal(t, test.expectedMemory, metrics.MetricsResultValue.MemoryUsed)
+			var raw map[string]interface{}
+			require.NoError(t, json.Unmarshal(metrics.MetricsResultValue.Raw, &raw))
+			assert.Equal(t, unitName, raw["unit"])
+			assert.Equal(t, 42.0, raw["pid"])
+		})
+	}
+}
@@ -16,7 +16,10 @@ package system
 
 import "encoding/json"
 
-const Metrics = "metrics"
+const (
+	Metrics = "metrics"
+	Logs    = "logs"
+)
 
 // Result provides a generic interface implemented by receivers intended to return their struct as a request result.
 type Result interface {
@@ -56,6 +59,21 @@ type MetricsSuccessResult struct {
 // isResult method is not called; its only purpose is to include MetricsSuccessResult in the Result abstraction.
 func (r MetricsSuccessResult) isResult() {}
 
+// LogsSuccessResult contains the fields to be returned for a successful logs request.
+type LogsSuccessResult struct {
+	CommonResultValue
+	Lines []string `json:"lines"`
+}
+
+// isResult method is not called; its only purpose is to include LogsSuccessResult in the Result abstraction.
+func (r LogsSuccessResult) isResult() {}
+
+// LogEntry is written by the executor for each log line while following a service's log.
+type LogEntry struct {
+	Service string `json:"service"`
+	Line    string `json:"line"`
+}
+
 // FailureResult contains the fields to e returned for a failed request.
 type FailureResult struct {
 	CommonResultValue
@@ -106,3 +124,16 @@ func MetricsSuccess(serviceName, executor string, cpuUsedPercent float64, memory
 		},
 	}
 }
+
+// LogsSuccess function returns a LogsSuccessResult as a Result abstraction.
+func LogsSuccess(serviceName, executor string, lines []string) Result {
+	return &LogsSuccessResult{
+		CommonResultValue: CommonResultValue{
+			Operation: Logs,
+			Service:   serviceName,
+			Executor:  executor,
+			Success:   true,
+		},
+		Lines: lines,
+	}
+}
@@ -26,6 +26,7 @@ func TestIsResultForCoverage(t *testing.T) {
 	}{
 		{"SuccessResult", SuccessResult{}},
 		{"MetricsSuccessResult", MetricsSuccessResult{}},
+		{"LogsSuccessResult", LogsSuccessResult{}},
 		{"FailureResult", FailureResult{}},
 	}
 